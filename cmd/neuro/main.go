@@ -42,6 +42,9 @@ var (
 	confirmRC bool
 	// Command execution flag
 	commandString string
+	// HTTP cassette flags
+	cassettePath string
+	cassetteMode string
 	// Global shell instance for prompt updates
 	globalShell *ishell.Shell
 )
@@ -128,6 +131,10 @@ func init() {
 	// Add command execution flag
 	rootCmd.PersistentFlags().StringVarP(&commandString, "command", "c", "", "Execute command(s) and exit (use \\n for multiple commands)")
 
+	// Add HTTP cassette flags
+	rootCmd.PersistentFlags().StringVar(&cassettePath, "cassette", "", "Record LLM HTTP traffic to, or replay it from, this cassette file")
+	rootCmd.PersistentFlags().StringVar(&cassetteMode, "cassette-mode", string(services.CassetteModeReplay), "Cassette mode (record|replay)")
+
 	// Add version command flags
 	versionCmd.Flags().Bool("detailed", false, "Show detailed version information")
 
@@ -175,12 +182,36 @@ func initConfig() {
 		os.Exit(1)
 	}
 
+	// Configure the HTTP cassette before services are initialized
+	if err := configureCassette(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error configuring cassette: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Configure lipgloss color output based on CLI flags, environment, and test mode
 	if noColor || testMode || os.Getenv("NO_COLOR") != "" {
 		lipgloss.SetColorProfile(termenv.Ascii)
 	}
 }
 
+// configureCassette hands the --cassette flags to the debug transport, which every LLM client uses.
+func configureCassette() error {
+	mode, err := services.ParseCassetteMode(cassetteMode)
+	if err != nil {
+		return err
+	}
+	if cassettePath == "" {
+		return nil
+	}
+
+	debugTransportService, err := services.GetGlobalDebugTransportService()
+	if err != nil {
+		return err
+	}
+	debugTransportService.SetCassette(cassettePath, mode)
+	return nil
+}
+
 // createCustomReadlineConfig creates a readline configuration with custom key bindings.
 func createCustomReadlineConfig() *readline.Config {
 	cfg := &readline.Config{
@@ -453,6 +484,9 @@ func runShell(_ *cobra.Command, _ []string) {
 		// Don't exit - just log the error and continue with shell startup
 	}
 
+	// Load saved sessions after .neurorc, which is where _session_autoload is usually set
+	autoloadSessions()
+
 	// Create shell with custom readline configuration
 	cfg := createCustomReadlineConfig()
 	sh := ishell.NewWithConfig(cfg)
@@ -628,6 +662,25 @@ func executeBatchScript(scriptPath string, ctx *context.NeuroContext) error {
 	return sm.Execute(commandInput)
 }
 
+// autoloadSessions loads the sessions saved in the config directory when _session_autoload
+// is true or lazy. Failures are logged and never stop the shell from starting.
+func autoloadSessions() {
+	chatService, err := services.GetGlobalChatSessionService()
+	if err != nil {
+		logger.Debug("Chat session service not available for session autoload", "error", err)
+		return
+	}
+
+	loaded, err := chatService.AutoloadSessions()
+	if err != nil {
+		logger.Error("Failed to load saved sessions", "error", err)
+		return
+	}
+	if len(loaded) > 0 {
+		logger.Info("Loaded saved sessions", "count", len(loaded))
+	}
+}
+
 // executeNeuroRC looks for and executes .neurorc startup scripts.
 //
 // Priority Order for Configuration:
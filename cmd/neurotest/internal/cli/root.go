@@ -36,6 +36,8 @@ verify expected behavior. It can record, run, and verify test cases.`,
 	rootCmd.PersistentFlags().StringVar(&app.Config.TestDir, "test-dir", shared.DefaultTestDir, "Test directory")
 	rootCmd.PersistentFlags().StringVar(&app.Config.NeuroCmd, "neuro-cmd", shared.DefaultNeuroCmd, "Neuro command to test (will try ./bin/neuro, then PATH)")
 	rootCmd.PersistentFlags().IntVar(&app.Config.TestTimeout, "timeout", shared.DefaultTestTimeout, "Test timeout in seconds")
+	rootCmd.PersistentFlags().StringVar(&app.Config.Cassette, "cassette", "", "Cassette file, or directory of <testname>.cassette.json files, passed to neuro")
+	rootCmd.PersistentFlags().StringVar(&app.Config.CassetteMode, "cassette-mode", shared.DefaultCassetteMode, "Cassette mode passed to neuro (record|replay)")
 
 	// Add all subcommands
 	app.addGoldenFileCommands(rootCmd)
@@ -39,7 +39,7 @@ func (d *Differ) ShowDiff(testName string) error {
 	}
 
 	// Get actual output
-	output, err := shared.RunNeuroScript(scriptPath, d.config.NeuroCmd, d.config.TestTimeout)
+	output, err := shared.RunNeuroScript(scriptPath, d.config.NeuroCmd, d.config.TestTimeout, d.config.CassetteArgs(testName)...)
 	if err != nil && d.config.Verbose {
 		fmt.Printf("Command failed with error: %v\nOutput: %s\n", err, output)
 	}
@@ -73,7 +73,7 @@ func (d *Differ) ShowCFlagDiff(testName string) error {
 	}
 
 	// Get actual output using -c flag
-	output, err := shared.RunNeuroCFlag(scriptPath, d.config.NeuroCmd, d.config.TestTimeout)
+	output, err := shared.RunNeuroCFlag(scriptPath, d.config.NeuroCmd, d.config.TestTimeout, d.config.CassetteArgs(testName)...)
 	if err != nil && d.config.Verbose {
 		fmt.Printf("Command failed with error: %v\nOutput: %s\n", err, output)
 	}
@@ -39,7 +39,7 @@ func (r *Recorder) RecordTest(testName string) error {
 		return fmt.Errorf("test script not found: %s", scriptPath)
 	}
 
-	output, err := shared.RunNeuroScript(scriptPath, r.config.NeuroCmd, r.config.TestTimeout)
+	output, err := shared.RunNeuroScript(scriptPath, r.config.NeuroCmd, r.config.TestTimeout, r.config.CassetteArgs(testName)...)
 	if err != nil {
 		if r.config.Verbose {
 			fmt.Printf("Command failed with error: %v\nOutput: %s\n", err, output)
@@ -80,7 +80,7 @@ func (r *Recorder) RecordCFlagTest(testName string) error {
 		return fmt.Errorf("test script not found: %s", scriptPath)
 	}
 
-	output, err := shared.RunNeuroCFlag(scriptPath, r.config.NeuroCmd, r.config.TestTimeout)
+	output, err := shared.RunNeuroCFlag(scriptPath, r.config.NeuroCmd, r.config.TestTimeout, r.config.CassetteArgs(testName)...)
 	if err != nil {
 		if r.config.Verbose {
 			fmt.Printf("Command failed with error: %v\nOutput: %s\n", err, output)
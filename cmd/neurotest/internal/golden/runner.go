@@ -40,7 +40,7 @@ func (r *Runner) RunTest(testName string) error {
 		return fmt.Errorf("test script not found: %s", scriptPath)
 	}
 
-	output, err := shared.RunNeuroScript(scriptPath, r.config.NeuroCmd, r.config.TestTimeout)
+	output, err := shared.RunNeuroScript(scriptPath, r.config.NeuroCmd, r.config.TestTimeout, r.config.CassetteArgs(testName)...)
 	if err != nil {
 		if r.config.Verbose {
 			fmt.Printf("Command failed with error: %v\nOutput: %s\n", err, output)
@@ -117,7 +117,7 @@ func (r *Runner) RunCFlagTest(testName string) error {
 		return fmt.Errorf("test script not found: %s", scriptPath)
 	}
 
-	output, err := shared.RunNeuroCFlag(scriptPath, r.config.NeuroCmd, r.config.TestTimeout)
+	output, err := shared.RunNeuroCFlag(scriptPath, r.config.NeuroCmd, r.config.TestTimeout, r.config.CassetteArgs(testName)...)
 	if err != nil {
 		if r.config.Verbose {
 			fmt.Printf("Command failed with error: %v\nOutput: %s\n", err, output)
@@ -1,12 +1,20 @@
 // Package shared provides common configuration and utilities for neurotest.
 package shared
 
+import (
+	"os"
+	"path/filepath"
+)
+
 // Config holds the global configuration for neurotest
 type Config struct {
 	TestDir     string
 	NeuroCmd    string
 	Verbose     bool
 	TestTimeout int
+	// Cassette is a cassette file, or a directory holding <testname>.cassette.json files
+	Cassette     string
+	CassetteMode string
 }
 
 // Default configuration values
@@ -14,14 +22,36 @@ const (
 	DefaultTestDir     = "test/golden"
 	DefaultNeuroCmd    = "neuro"
 	DefaultTestTimeout = 30
+	// DefaultCassetteMode replays cassettes so golden tests never reach the network
+	DefaultCassetteMode = "replay"
+	// CassetteSuffix names the cassette a golden test replays automatically
+	CassetteSuffix = ".cassette.json"
 )
 
 // NewConfig creates a new configuration with default values
 func NewConfig() *Config {
 	return &Config{
-		TestDir:     DefaultTestDir,
-		NeuroCmd:    DefaultNeuroCmd,
-		Verbose:     false,
-		TestTimeout: DefaultTestTimeout,
+		TestDir:      DefaultTestDir,
+		NeuroCmd:     DefaultNeuroCmd,
+		Verbose:      false,
+		TestTimeout:  DefaultTestTimeout,
+		CassetteMode: DefaultCassetteMode,
+	}
+}
+
+// CassetteArgs returns the neuro flags selecting the cassette for a test.
+// Without --cassette, a <testname>.cassette.json next to the test script is replayed if present.
+func (c *Config) CassetteArgs(testName string) []string {
+	path := c.Cassette
+	mode := c.CassetteMode
+	if path == "" {
+		path = filepath.Join(c.TestDir, testName+CassetteSuffix)
+		if _, err := os.Stat(path); err != nil {
+			return nil
+		}
+		mode = DefaultCassetteMode
+	} else if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, testName+CassetteSuffix)
 	}
+	return []string{"--cassette", path, "--cassette-mode", mode}
 }
@@ -65,8 +65,9 @@ func CheckNeuroCommand(neuroCmd string) error {
 	return fmt.Errorf("neuro command not found. Tried: %v", candidates)
 }
 
-// RunNeuroScript executes a neuro script and returns its output
-func RunNeuroScript(scriptPath, neuroCmd string, _ int) (string, error) {
+// RunNeuroScript executes a neuro script and returns its output.
+// extraArgs are passed to neuro as global flags (e.g. cassette selection).
+func RunNeuroScript(scriptPath, neuroCmd string, _ int, extraArgs ...string) (string, error) {
 	if err := CheckNeuroCommand(neuroCmd); err != nil {
 		return "", err
 	}
@@ -83,15 +84,17 @@ func RunNeuroScript(scriptPath, neuroCmd string, _ int) (string, error) {
 	}
 
 	// Always use --log-level error to suppress INFO messages in test output
-	cmd := exec.Command(actualCmd, "--test-mode", "--log-level", "error", "batch", scriptPath)
+	args := append([]string{"--test-mode", "--log-level", "error"}, extraArgs...)
+	cmd := exec.Command(actualCmd, append(args, "batch", scriptPath)...)
 	cmd.Env = os.Environ()
 
 	output, err := cmd.CombinedOutput()
 	return string(output), err
 }
 
-// RunNeuroCFlag executes a neuro script using the -c flag and returns its output
-func RunNeuroCFlag(scriptPath, neuroCmd string, _ int) (string, error) {
+// RunNeuroCFlag executes a neuro script using the -c flag and returns its output.
+// extraArgs are passed to neuro as global flags (e.g. cassette selection).
+func RunNeuroCFlag(scriptPath, neuroCmd string, _ int, extraArgs ...string) (string, error) {
 	if err := CheckNeuroCommand(neuroCmd); err != nil {
 		return "", err
 	}
@@ -115,7 +118,8 @@ func RunNeuroCFlag(scriptPath, neuroCmd string, _ int) (string, error) {
 
 	// Execute with -c flag and test mode, passing the script content directly
 	// The -c flag will handle creating a temporary file and using batch processing
-	cmd := exec.Command(actualCmd, "--test-mode", "--log-level", "error", "-c", string(scriptContent))
+	args := append([]string{"--test-mode", "--log-level", "error"}, extraArgs...)
+	cmd := exec.Command(actualCmd, append(args, "-c", string(scriptContent))...)
 	cmd.Env = os.Environ()
 
 	output, err := cmd.CombinedOutput()
@@ -0,0 +1,111 @@
+package builtin
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/data/embedded"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// DefCommand implements the \def command for defining script commands inline.
+// A \def name … \enddef block in a script, .neurorc or the shell is compiled into a \def command
+// that carries the body; running it registers \name for the rest of the session.
+type DefCommand struct{}
+
+// Name returns the command name "def" for registration and lookup.
+func (c *DefCommand) Name() string {
+	return "def"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *DefCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the def command does.
+func (c *DefCommand) Description() string {
+	return "Define a command from the script lines up to \\enddef"
+}
+
+// Usage returns the syntax and usage examples for the def command.
+func (c *DefCommand) Usage() string {
+	return "\\def name\n  %% Description shown by \\help\n  %% Usage: \\name[option=value] message\n  command lines\n\\enddef"
+}
+
+// HelpInfo returns structured help information for the def command.
+func (c *DefCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       c.Usage(),
+		ParseMode:   c.ParseMode(),
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\def review\n  %% Ask the model to review a file\n  %% Usage: \\review path/to/file\n  \\bash cat ${_1}\n  \\send Review this file: ${_output}\n\\enddef",
+				Description: "Define \\review, then run it as \\review main.go",
+			},
+			{
+				Command:     "\\def greet\n  \\echo Hello ${name}!\n\\enddef",
+				Description: "Named options become variables: \\greet[name=Ada]",
+			},
+		},
+		Notes: []string{
+			"The first %% comment of the body is the command's description in \\help",
+			"A '%% Usage:' comment sets its usage line; further leading comments are shown as notes",
+			"The body runs like a script: ${_1} is the message and named options become variables",
+			"Variables in the body are expanded when the command runs, not when it is defined",
+			"Builtin and stdlib commands cannot be redefined; defining a name again replaces it",
+			"Put definitions in .neurorc to have the commands in every shell",
+		},
+	}
+}
+
+// Execute registers the command.
+// The input is the command name followed by its body as a BLOCK_BODY entry produced by the block compiler.
+func (c *DefCommand) Execute(_ map[string]string, input string) error {
+	name, body, _ := strings.Cut(strings.TrimSpace(input), " ")
+	if name == "" {
+		return fmt.Errorf("command name is required\n\nUsage: %s", c.Usage())
+	}
+	encoded, found := strings.CutPrefix(strings.TrimSpace(body), "BLOCK_BODY:")
+	if !found {
+		return fmt.Errorf("\\def %s must be followed by the command's lines and closed with \\enddef", name)
+	}
+
+	var lines []string
+	if err := json.Unmarshal([]byte(encoded), &lines); err != nil {
+		return fmt.Errorf("invalid body for \\def %s: %w", name, err)
+	}
+
+	if _, exists := commands.GetGlobalRegistry().Get(name); exists {
+		return fmt.Errorf("cannot redefine builtin command \\%s", name)
+	}
+	if embedded.NewStdlibLoader().ScriptExists(name) {
+		return fmt.Errorf("cannot redefine stdlib command \\%s", name)
+	}
+
+	definitionService, err := services.GetGlobalDefinitionService()
+	if err != nil {
+		return fmt.Errorf("definition service not available: %w", err)
+	}
+
+	if _, err := definitionService.Define(name, lines); err != nil {
+		return err
+	}
+	return nil
+}
+
+// IsReadOnly returns false as the def command modifies system state.
+func (c *DefCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&DefCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register def command: %v", err))
+	}
+}
@@ -0,0 +1,90 @@
+package builtin
+
+import (
+	"testing"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/context"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupDefTestRegistry(t *testing.T) *services.DefinitionService {
+	oldRegistry := services.GetGlobalRegistry()
+	oldCommands := commands.GetGlobalRegistry()
+	services.SetGlobalRegistry(services.NewRegistry())
+	commands.SetGlobalRegistry(commands.NewRegistry())
+	context.SetGlobalContext(context.New())
+
+	definitionService := services.NewDefinitionService()
+	require.NoError(t, services.GetGlobalRegistry().RegisterService(definitionService))
+	require.NoError(t, services.GetGlobalRegistry().InitializeAll())
+	require.NoError(t, commands.GetGlobalRegistry().Register(&EchoCommand{}))
+
+	t.Cleanup(func() {
+		services.SetGlobalRegistry(oldRegistry)
+		commands.SetGlobalRegistry(oldCommands)
+		context.ResetGlobalContext()
+	})
+	return definitionService
+}
+
+func TestDefCommand_BasicProperties(t *testing.T) {
+	cmd := &DefCommand{}
+
+	assert.Equal(t, "def", cmd.Name())
+	assert.Equal(t, neurotypes.ParseModeKeyValue, cmd.ParseMode())
+	assert.NotEmpty(t, cmd.Description())
+	assert.Contains(t, cmd.Usage(), "\\enddef")
+	assert.False(t, cmd.IsReadOnly())
+
+	help := cmd.HelpInfo()
+	assert.Equal(t, "def", help.Command)
+	assert.NotEmpty(t, help.Examples)
+	assert.NotEmpty(t, help.Notes)
+}
+
+func TestDefCommand_Execute(t *testing.T) {
+	definitionService := setupDefTestRegistry(t)
+	cmd := &DefCommand{}
+
+	err := cmd.Execute(map[string]string{}, `greet BLOCK_BODY:["%% Say hello","\\echo Hello ${_1}"]`)
+	require.NoError(t, err)
+
+	definition, exists := definitionService.Get("greet")
+	require.True(t, exists)
+	assert.Equal(t, "Say hello", definition.Help.Description)
+	assert.Equal(t, "%% Say hello\n\\echo Hello ${_1}", definition.Script)
+}
+
+func TestDefCommand_Execute_Errors(t *testing.T) {
+	setupDefTestRegistry(t)
+	cmd := &DefCommand{}
+
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{"missing name", "", "command name is required"},
+		{"missing body", "greet", "must be followed by the command's lines"},
+		{"invalid body", "greet BLOCK_BODY:[oops", "invalid body for \\def greet"},
+		{"builtin name", `echo BLOCK_BODY:[]`, "cannot redefine builtin command \\echo"},
+		{"stdlib name", `test-script BLOCK_BODY:[]`, "cannot redefine stdlib command \\test-script"},
+		{"invalid name", `_hidden BLOCK_BODY:[]`, "invalid command name '_hidden'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := cmd.Execute(map[string]string{}, tt.input)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+// Interface compliance check
+var _ neurotypes.Command = (*DefCommand)(nil)
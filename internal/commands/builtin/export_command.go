@@ -0,0 +1,137 @@
+package builtin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/output"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// ExportCommand implements the \export command for passing script variables to the caller.
+// The value is set in the caller's scope: the caller's local variable of that name if it has one,
+// otherwise the global variable.
+type ExportCommand struct{}
+
+// Name returns the command name "export" for registration and lookup.
+func (c *ExportCommand) Name() string {
+	return "export"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *ExportCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the export command does.
+func (c *ExportCommand) Description() string {
+	return "Pass a variable of the running script to its caller"
+}
+
+// Usage returns the syntax and usage examples for the export command.
+func (c *ExportCommand) Usage() string {
+	return "\\export[var] or \\export[var=value] or \\export var ..."
+}
+
+// HelpInfo returns structured help information for the export command.
+func (c *ExportCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       c.Usage(),
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "var",
+				Description: "Variable to pass to the caller; without a value its current value is passed",
+				Required:    true,
+				Type:        "string",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\export[summary]",
+				Description: "Pass the script's 'summary' variable to the caller",
+			},
+			{
+				Command:     "\\export[result=${_output}]",
+				Description: "Set 'result' in the caller's scope",
+			},
+			{
+				Command:     "\\export summary verdict",
+				Description: "Pass several variables",
+			},
+		},
+		Notes: []string{
+			"Only available inside scripts, including \\def commands and library scripts",
+			"Sets the caller's local variable if it has one with that name, otherwise the global variable",
+			"The script keeps its own value until it returns",
+		},
+	}
+}
+
+// Execute sets the variables in the scope of the running script's caller.
+func (c *ExportCommand) Execute(args map[string]string, input string) error {
+	// Get variable service
+	variableService, err := services.GetGlobalVariableService()
+	if err != nil {
+		return fmt.Errorf("variable service not available: %w", err)
+	}
+
+	// Collect variables: \export[var] passes the current value, \export[var=value] the given one
+	exports := make(map[string]string)
+	for key, value := range args {
+		if value == "" {
+			if value, err = variableService.Get(key); err != nil {
+				return fmt.Errorf("failed to get variable %s: %w", key, err)
+			}
+		}
+		exports[key] = value
+	}
+	for _, key := range strings.Fields(input) {
+		value, err := variableService.Get(key)
+		if err != nil {
+			return fmt.Errorf("failed to get variable %s: %w", key, err)
+		}
+		exports[key] = value
+	}
+	if len(exports) == 0 {
+		return fmt.Errorf("Usage: %s", c.Usage())
+	}
+
+	// Create output printer with optional style injection
+	var styleProvider output.StyleProvider
+	if themeService, err := services.GetGlobalThemeService(); err == nil {
+		styleProvider = themeService
+	}
+	printer := output.NewPrinter(output.WithStyles(styleProvider))
+
+	// Sort keys to ensure deterministic output order
+	keys := make([]string, 0, len(exports))
+	for key := range exports {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := variableService.Export(key, exports[key]); err != nil {
+			return fmt.Errorf("failed to export variable %s: %w", key, err)
+		}
+		printer.Info(fmt.Sprintf("Exporting %s = %s", key, exports[key]))
+	}
+	return nil
+}
+
+// IsReadOnly returns false as the export command modifies system state.
+func (c *ExportCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&ExportCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register export command: %v", err))
+	}
+}
@@ -0,0 +1,61 @@
+package builtin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/internal/context"
+	"neuroshell/pkg/neurotypes"
+)
+
+func TestExportCommand_BasicProperties(t *testing.T) {
+	cmd := &ExportCommand{}
+
+	assert.Equal(t, "export", cmd.Name())
+	assert.Equal(t, neurotypes.ParseModeKeyValue, cmd.ParseMode())
+	assert.Equal(t, "Pass a variable of the running script to its caller", cmd.Description())
+	assert.Contains(t, cmd.Usage(), "\\export[var]")
+	assert.False(t, cmd.IsReadOnly())
+
+	help := cmd.HelpInfo()
+	assert.Equal(t, "export", help.Command)
+	assert.NotEmpty(t, help.Examples)
+	assert.NotEmpty(t, help.Notes)
+}
+
+func TestExportCommand_Execute(t *testing.T) {
+	ctx := context.New()
+	setupSetTestRegistry(t, ctx)
+	cmd := &ExportCommand{}
+
+	// Outside a script there is no caller to export to
+	err := cmd.Execute(map[string]string{"summary": "x"}, "")
+	assert.ErrorContains(t, err, "only be exported from inside a script")
+
+	outer := ctx.PushScriptFrame("outer", nil, map[string]string{"verdict": ""})
+	inner := ctx.PushScriptFrame("inner", nil, map[string]string{"summary": "short", "verdict": "ok"})
+
+	// \export[var] passes the current value, \export[var=value] the given one, \export var … several
+	require.NoError(t, cmd.Execute(map[string]string{"summary": ""}, ""))
+	require.NoError(t, cmd.Execute(map[string]string{"score": "9"}, ""))
+	require.NoError(t, cmd.Execute(map[string]string{}, "verdict"))
+
+	err = cmd.Execute(map[string]string{}, "")
+	assert.ErrorContains(t, err, "Usage:")
+	err = cmd.Execute(map[string]string{"@user": "x"}, "")
+	assert.ErrorContains(t, err, "cannot be local")
+
+	require.True(t, ctx.PopScriptFrame(inner))
+	value, _ := ctx.GetVariable("verdict")
+	assert.Equal(t, "ok", value, "the caller's local variable receives the value")
+
+	require.True(t, ctx.PopScriptFrame(outer))
+	value, _ = ctx.GetVariable("verdict")
+	assert.Empty(t, value)
+	value, _ = ctx.GetVariable("summary")
+	assert.Equal(t, "short", value)
+	value, _ = ctx.GetVariable("score")
+	assert.Equal(t, "9", value)
+}
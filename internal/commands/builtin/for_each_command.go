@@ -0,0 +1,204 @@
+package builtin
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/context"
+	"neuroshell/pkg/neurotypes"
+)
+
+// ForEachCommand implements the \for-each command for iterating over a list of items.
+// It runs a command once per item with the item stored in a variable.
+type ForEachCommand struct{}
+
+// Name returns the command name "for-each" for registration and lookup.
+func (c *ForEachCommand) Name() string {
+	return "for-each"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *ForEachCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the for-each command does.
+func (c *ForEachCommand) Description() string {
+	return "Run a command once for each item of a list, lines of text or matching files"
+}
+
+// Usage returns the syntax and usage examples for the for-each command.
+func (c *ForEachCommand) Usage() string {
+	return "\\for-each[var=item, in=\"a,b,c\"|lines=text|glob=pattern, max=1000] command_to_execute"
+}
+
+// HelpInfo returns structured help information for the for-each command.
+func (c *ForEachCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       c.Usage(),
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "var",
+				Description: "Variable set to the current item on each pass",
+				Required:    false,
+				Type:        "string",
+				Default:     "item",
+			},
+			{
+				Name:        "in",
+				Description: "Comma-separated items; blank items are skipped",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "lines",
+				Description: "Text whose non-blank lines are the items",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "glob",
+				Description: "File pattern whose matches, in sorted order, are the items",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "max",
+				Description: "Safety cap on the number of items",
+				Required:    false,
+				Type:        "int",
+				Default:     "1000",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\for-each[var=name, in=\"alice,bob\"] \\echo Hello ${name}",
+				Description: "Greet each name in turn",
+			},
+			{
+				Command:     "\\for-each[var=file, lines=${_output}] \\send Summarize ${file}",
+				Description: "Run a command for each line of the previous command's output",
+			},
+			{
+				Command:     "\\for-each[var=doc, glob=\"docs/*.md\"] \\cat ${doc}",
+				Description: "Run a command for each matching file",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "#loop_index",
+				Description: "Number of the current pass, starting at 1",
+				Type:        "system_metadata",
+				Example:     "2",
+			},
+			{
+				Name:        "#loop_count",
+				Description: "Number of passes the loop ran, set when it ends",
+				Type:        "system_metadata",
+				Example:     "3",
+			},
+		},
+		Notes: []string{
+			"Exactly one of in, lines or glob must be given",
+			"The command is interpolated on each pass, so ${var} holds the current item",
+			"A list longer than max is rejected before any pass runs",
+			"An error in the command stops the loop; wrap the command in \\try to keep going",
+			"Loops can be nested; #loop_index belongs to the innermost loop",
+		},
+	}
+}
+
+// Execute collects the items and starts the loop.
+// Options:
+//   - var: loop variable (optional, default: item)
+//   - in, lines, glob: item source (exactly one required)
+//   - max: safety cap on the number of items (optional, default: 1000)
+func (c *ForEachCommand) Execute(args map[string]string, input string) error {
+	variable := "item"
+	if value, exists := args["var"]; exists {
+		variable = strings.TrimSpace(value)
+	}
+	if err := validateLoopVariable(variable); err != nil {
+		return err
+	}
+	limit, err := parseLoopMax(args)
+	if err != nil {
+		return err
+	}
+	items, err := c.collectItems(args)
+	if err != nil {
+		return err
+	}
+	if len(items) > limit {
+		return fmt.Errorf("for-each has %d items, more than max=%d", len(items), limit)
+	}
+
+	body := strings.TrimSpace(input)
+	if body == "" {
+		// Empty for-each command - nothing to run
+		return nil
+	}
+
+	return startLoop(context.LoopBlockContext{
+		Kind:     c.Name(),
+		Variable: variable,
+		Items:    items,
+		Body:     body,
+		Max:      limit,
+	})
+}
+
+// collectItems returns the items of the single item source given in args.
+func (c *ForEachCommand) collectItems(args map[string]string) ([]string, error) {
+	sources := 0
+	for _, key := range []string{"in", "lines", "glob"} {
+		if _, exists := args[key]; exists {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return nil, fmt.Errorf("exactly one of in, lines or glob is required")
+	}
+
+	var items []string
+	switch {
+	case args["glob"] != "":
+		matches, err := filepath.Glob(args["glob"])
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob pattern '%s': %w", args["glob"], err)
+		}
+		sort.Strings(matches)
+		items = matches
+	case args["lines"] != "":
+		for _, line := range strings.Split(args["lines"], "\n") {
+			line = strings.TrimRight(line, "\r")
+			if strings.TrimSpace(line) != "" {
+				items = append(items, line)
+			}
+		}
+	default:
+		for _, item := range strings.Split(args["in"], ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items, nil
+}
+
+// IsReadOnly returns false as the for-each command modifies system state.
+func (c *ForEachCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&ForEachCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register for-each command: %v", err))
+	}
+}
@@ -0,0 +1,141 @@
+package builtin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/internal/context"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+func TestForEachCommand_BasicProperties(t *testing.T) {
+	cmd := &ForEachCommand{}
+	assert.Equal(t, "for-each", cmd.Name())
+	assert.Equal(t, neurotypes.ParseModeKeyValue, cmd.ParseMode())
+	assert.NotEmpty(t, cmd.Description())
+	assert.Contains(t, cmd.Usage(), "\\for-each")
+	assert.False(t, cmd.IsReadOnly())
+
+	help := cmd.HelpInfo()
+	assert.Equal(t, "for-each", help.Command)
+	assert.NotEmpty(t, help.Options)
+	assert.NotEmpty(t, help.Examples)
+	assert.NotEmpty(t, help.StoredVariables)
+}
+
+func TestForEachCommand_Execute_Sources(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.md", "a.md", "c.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("x"), 0644))
+	}
+
+	tests := []struct {
+		name     string
+		args     map[string]string
+		expected []string
+	}{
+		{
+			name:     "comma-separated items",
+			args:     map[string]string{"in": " a, b,,c "},
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:     "non-blank lines",
+			args:     map[string]string{"lines": "one\r\n\n  \ntwo words\n"},
+			expected: []string{"one", "two words"},
+		},
+		{
+			name:     "sorted glob matches",
+			args:     map[string]string{"glob": filepath.Join(dir, "*.md")},
+			expected: []string{filepath.Join(dir, "a.md"), filepath.Join(dir, "b.md")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupLoopTestRegistry(t)
+
+			err := (&ForEachCommand{}).Execute(tt.args, "\\echo ${item}")
+			require.NoError(t, err)
+
+			stackService, err := services.GetGlobalStackService()
+			require.NoError(t, err)
+			marker, ok := stackService.PopCommand()
+			require.True(t, ok)
+			assert.Equal(t, "LOOP_BOUNDARY_START:"+stackService.GetCurrentLoopID(), marker)
+
+			loop, ok := stackService.NextLoopPass(stackService.GetCurrentLoopID())
+			require.True(t, ok)
+			assert.Equal(t, "for-each", loop.Kind)
+			assert.Equal(t, "item", loop.Variable)
+			assert.Equal(t, "\\echo ${item}", loop.Body)
+			assert.Equal(t, defaultLoopMax, loop.Max)
+			assert.Equal(t, tt.expected, loop.Items)
+		})
+	}
+}
+
+func TestForEachCommand_Execute_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]string
+		err  string
+	}{
+		{"no source", map[string]string{"var": "x"}, "exactly one of in, lines or glob"},
+		{"two sources", map[string]string{"in": "a", "lines": "b"}, "exactly one of in, lines or glob"},
+		{"system variable", map[string]string{"var": "#x", "in": "a"}, "invalid loop variable"},
+		{"invalid max", map[string]string{"in": "a", "max": "0"}, "invalid max"},
+		{"too many items", map[string]string{"in": "a,b,c", "max": "2"}, "more than max=2"},
+		{"bad glob", map[string]string{"glob": "["}, "invalid glob pattern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupLoopTestRegistry(t)
+
+			err := (&ForEachCommand{}).Execute(tt.args, "\\echo ${item}")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+
+			stackService, err := services.GetGlobalStackService()
+			require.NoError(t, err)
+			assert.True(t, stackService.IsEmpty())
+			assert.False(t, stackService.IsInLoopBlock())
+		})
+	}
+}
+
+func TestForEachCommand_Execute_EmptyBody(t *testing.T) {
+	setupLoopTestRegistry(t)
+
+	err := (&ForEachCommand{}).Execute(map[string]string{"in": "a,b"}, "  ")
+	require.NoError(t, err)
+
+	stackService, err := services.GetGlobalStackService()
+	require.NoError(t, err)
+	assert.True(t, stackService.IsEmpty())
+	assert.False(t, stackService.IsInLoopBlock())
+}
+
+// setupLoopTestRegistry registers the stack service used by the loop commands.
+func setupLoopTestRegistry(t *testing.T) {
+	oldRegistry := services.GetGlobalRegistry()
+	services.SetGlobalRegistry(services.NewRegistry())
+	context.SetGlobalContext(context.New())
+
+	require.NoError(t, services.GetGlobalRegistry().RegisterService(services.NewStackService()))
+	require.NoError(t, services.GetGlobalRegistry().InitializeAll())
+
+	t.Cleanup(func() {
+		services.SetGlobalRegistry(oldRegistry)
+		context.ResetGlobalContext()
+	})
+}
+
+// Interface compliance check
+var _ neurotypes.Command = (*ForEachCommand)(nil)
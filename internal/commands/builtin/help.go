@@ -114,21 +114,18 @@ func (c *HelpCommand) Execute(args map[string]string, input string) error {
 // showCommandHelpNew displays detailed help information for a specific command using HelpInfo
 func (c *HelpCommand) showCommandHelpNew(commandName string, helpService *services.HelpService) error {
 	// Get the command directly from the help service
-	_, err := helpService.GetCommand(commandName)
+	storedInfo, err := helpService.GetCommand(commandName)
 	if err != nil {
 		return fmt.Errorf("command '%s' not found. Use \\help to see all available commands", commandName)
 	}
 
-	// Get the actual command to access its HelpInfo method
-	command, exists := commands.GlobalRegistry.Get(commandName)
-	if !exists {
-		// Fallback to simple output if we can't get the command instance
-		return fmt.Errorf("command '%s' not found in registry", commandName)
+	// Get structured help info from the command itself; commands defined with \def
+	// have no instance and use the stored help info
+	helpInfo := *storedInfo
+	if command, exists := commands.GlobalRegistry.Get(commandName); exists {
+		helpInfo = command.HelpInfo()
 	}
 
-	// Get structured help info
-	helpInfo := command.HelpInfo()
-
 	// Create output printer with optional style injection
 	var styleProvider output.StyleProvider
 	if themeService, err := services.GetGlobalThemeService(); err == nil {
@@ -169,23 +166,31 @@ func (c *HelpCommand) categorizeCommands(allCommands []*neurotypes.HelpInfo) []C
 		{Name: "Shell & Prompt", Commands: []*neurotypes.HelpInfo{}},
 		{Name: "System & Tools", Commands: []*neurotypes.HelpInfo{}},
 		{Name: "Testing & Debugging", Commands: []*neurotypes.HelpInfo{}},
+		{Name: "User Commands", Commands: []*neurotypes.HelpInfo{}},
 	}
 
+	// Commands defined with \def and library scripts are listed on their own
+	definitionService, _ := services.GetGlobalDefinitionService()
+	libraryService, _ := services.GetGlobalScriptLibraryService()
+
 	// Define command categories
 	coreCommands := map[string]bool{
 		"bash": true, "echo": true, "exit": true, "get": true, "get-env": true,
 		"help": true, "run": true, "send": true, "set": true, "set-env": true,
 		"silent": true, "try": true, "vars": true,
+		"for-each": true, "repeat": true, "while": true, "def": true, "which": true, "local": true, "export": true,
 	}
 
 	systemCommands := map[string]bool{
 		"check": true, "clip": true,
 
 		"editor": true, "render": true, "version": true, "license": true, "change-log-show": true,
+		"tool-define": true, "tool-list": true, "tool-remove": true, "tool-call": true, "tool-result": true,
+		"cc-init": true, "cc-send": true, "cc-status": true, "cc-wait": true, "cc-get": true, "cc-stream": true, "cc-jobs": true,
 	}
 
 	modelCommands := map[string]bool{
-		"model-catalog": true, "model-new": true, "model-status": true,
+		"model-catalog": true, "model-new": true, "model-status": true, "usage": true, "budget": true,
 	}
 
 	shellCommands := map[string]bool{
@@ -198,9 +203,10 @@ func (c *HelpCommand) categorizeCommands(allCommands []*neurotypes.HelpInfo) []C
 		"session-edit-msg": true, "session-delete-msg": true,
 		"session-edit-with-editor": true,
 		"session-rename":           true, "session-edit-system": true,
-		"session-export": true, "session-import": true, "session-save": true,
+		"session-export": true, "session-import": true, "session-save": true, "session-load": true, "session-purge": true,
 		"session-json-export": true, "session-json-import": true, "session-list": true,
-		"session-new": true, "session-show": true,
+		"session-markdown-export": true, "session-html-export": true, "session-jsonl-export": true, "session-chat-import": true,
+		"session-new": true, "session-show": true, "session-search": true,
 	}
 
 	testingCommands := map[string]bool{
@@ -210,6 +216,9 @@ func (c *HelpCommand) categorizeCommands(allCommands []*neurotypes.HelpInfo) []C
 	// Categorize commands
 	for _, cmdInfo := range allCommands {
 		switch {
+		case definitionService != nil && definitionService.Exists(cmdInfo.Command),
+			libraryService != nil && libraryService.IsLibraryCommand(cmdInfo.Command):
+			categories[6].Commands = append(categories[6].Commands, cmdInfo) // User Commands
 		case coreCommands[cmdInfo.Command]:
 			categories[0].Commands = append(categories[0].Commands, cmdInfo) // Core Commands
 		case sessionCommands[cmdInfo.Command]:
@@ -251,11 +260,13 @@ func (c *HelpCommand) displaySessionCommandsGrouped(sessionCommands []*neurotype
 	// Categorize session commands
 	for _, cmdInfo := range sessionCommands {
 		switch cmdInfo.Command {
-		case "session-new", "session-list", "session-activate", "session-copy", "session-delete", "session-show":
+		case "session-new", "session-list", "session-activate", "session-copy", "session-delete", "session-show", "session-search":
 			sessionGroups["Basic Management"] = append(sessionGroups["Basic Management"], cmdInfo)
 		case "session-add-usermsg", "session-add-assistantmsg", "session-edit-msg", "session-delete-msg":
 			sessionGroups["Conversation"] = append(sessionGroups["Conversation"], cmdInfo)
-		case "session-export", "session-import", "session-json-export", "session-json-import", "session-save":
+		case "session-export", "session-import", "session-json-export", "session-json-import", "session-save",
+			"session-markdown-export", "session-html-export", "session-jsonl-export", "session-chat-import",
+			"session-load", "session-purge":
 			sessionGroups["Import/Export"] = append(sessionGroups["Import/Export"], cmdInfo)
 		default:
 			sessionGroups["Basic Management"] = append(sessionGroups["Basic Management"], cmdInfo)
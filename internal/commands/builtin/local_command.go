@@ -0,0 +1,135 @@
+package builtin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/output"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// LocalCommand implements the \local command for setting variables local to a script.
+// Local variables hide global variables of the same name until the script returns.
+type LocalCommand struct{}
+
+// Name returns the command name "local" for registration and lookup.
+func (c *LocalCommand) Name() string {
+	return "local"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *LocalCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the local command does.
+func (c *LocalCommand) Description() string {
+	return "Set a variable local to the running script"
+}
+
+// Usage returns the syntax and usage examples for the local command.
+func (c *LocalCommand) Usage() string {
+	return "\\local[var=value] or \\local var value"
+}
+
+// HelpInfo returns structured help information for the local command.
+func (c *LocalCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       c.Usage(),
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "var",
+				Description: "Variable name to set in the script's frame",
+				Required:    true,
+				Type:        "string",
+			},
+			{
+				Name:        "value",
+				Description: "Value to assign to the variable",
+				Required:    false,
+				Type:        "string",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\local[file=]",
+				Description: "Make 'file' local so a loop over files does not change the caller's 'file'",
+			},
+			{
+				Command:     "\\local[tries=0, reply=\"\"]",
+				Description: "Set several local variables",
+			},
+		},
+		Notes: []string{
+			"Only available inside scripts, including \\def commands and library scripts",
+			"Named options of a script call are local variables of the script",
+			"\\set changes a local variable if the script has one with that name, otherwise the global one",
+			"Local variables vanish when the script returns; use \\export to pass a value to the caller",
+			"Scripts called from this one do not see its local variables",
+		},
+	}
+}
+
+// Execute sets variables in the frame of the running script.
+func (c *LocalCommand) Execute(args map[string]string, input string) error {
+	if len(args) == 0 && strings.TrimSpace(input) == "" {
+		return fmt.Errorf("Usage: %s", c.Usage())
+	}
+
+	// Get variable service
+	variableService, err := services.GetGlobalVariableService()
+	if err != nil {
+		return fmt.Errorf("variable service not available: %w", err)
+	}
+
+	// Create output printer with optional style injection
+	var styleProvider output.StyleProvider
+	if themeService, err := services.GetGlobalThemeService(); err == nil {
+		styleProvider = themeService
+	}
+	printer := output.NewPrinter(output.WithStyles(styleProvider))
+
+	// Handle bracket syntax: \local[var=value]
+	if len(args) > 0 {
+		// Sort keys to ensure deterministic output order
+		keys := make([]string, 0, len(args))
+		for key := range args {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if err := variableService.SetLocal(key, args[key]); err != nil {
+				return fmt.Errorf("failed to set local variable %s: %w", key, err)
+			}
+			printer.Info(fmt.Sprintf("Setting local %s = %s", key, args[key]))
+		}
+		return nil
+	}
+
+	// Handle space syntax: \local var value
+	key, value, _ := strings.Cut(strings.TrimLeft(input, " \t"), " ")
+	value = strings.TrimLeft(value, " \t")
+	if err := variableService.SetLocal(key, value); err != nil {
+		return fmt.Errorf("failed to set local variable %s: %w", key, err)
+	}
+	printer.Info(fmt.Sprintf("Setting local %s = %s", key, value))
+	return nil
+}
+
+// IsReadOnly returns false as the local command modifies system state.
+func (c *LocalCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&LocalCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register local command: %v", err))
+	}
+}
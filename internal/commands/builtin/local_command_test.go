@@ -0,0 +1,59 @@
+package builtin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/internal/context"
+	"neuroshell/pkg/neurotypes"
+)
+
+func TestLocalCommand_BasicProperties(t *testing.T) {
+	cmd := &LocalCommand{}
+
+	assert.Equal(t, "local", cmd.Name())
+	assert.Equal(t, neurotypes.ParseModeKeyValue, cmd.ParseMode())
+	assert.Equal(t, "Set a variable local to the running script", cmd.Description())
+	assert.Equal(t, "\\local[var=value] or \\local var value", cmd.Usage())
+	assert.False(t, cmd.IsReadOnly())
+
+	help := cmd.HelpInfo()
+	assert.Equal(t, "local", help.Command)
+	assert.NotEmpty(t, help.Examples)
+	assert.NotEmpty(t, help.Notes)
+}
+
+func TestLocalCommand_Execute(t *testing.T) {
+	ctx := context.New()
+	setupSetTestRegistry(t, ctx)
+	cmd := &LocalCommand{}
+	require.NoError(t, ctx.SetVariable("file", "global.txt"))
+
+	// Outside a script there is no frame to set variables in
+	err := cmd.Execute(map[string]string{"file": "x"}, "")
+	assert.ErrorContains(t, err, "only be set inside a script")
+
+	frameID := ctx.PushScriptFrame("review", nil, nil)
+	require.NoError(t, cmd.Execute(map[string]string{"file": "local.txt", "count": "0"}, ""))
+	require.NoError(t, cmd.Execute(map[string]string{}, "reply  hello there"))
+
+	value, _ := ctx.GetVariable("file")
+	assert.Equal(t, "local.txt", value)
+	value, _ = ctx.GetVariable("count")
+	assert.Equal(t, "0", value)
+	value, _ = ctx.GetVariable("reply")
+	assert.Equal(t, "hello there", value)
+
+	err = cmd.Execute(map[string]string{"_style": "dark"}, "")
+	assert.ErrorContains(t, err, "cannot be local")
+	err = cmd.Execute(map[string]string{}, "")
+	assert.ErrorContains(t, err, "Usage:")
+
+	require.True(t, ctx.PopScriptFrame(frameID))
+	value, _ = ctx.GetVariable("file")
+	assert.Equal(t, "global.txt", value)
+	value, _ = ctx.GetVariable("reply")
+	assert.Empty(t, value)
+}
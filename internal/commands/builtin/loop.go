@@ -0,0 +1,55 @@
+package builtin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"neuroshell/internal/context"
+	"neuroshell/internal/services"
+)
+
+// Global counter for generating unique loop IDs
+var loopCounter int64
+
+// defaultLoopMax is the default safety cap on the number of passes of a loop.
+const defaultLoopMax = 1000
+
+// parseLoopMax reads the max option shared by the loop commands.
+func parseLoopMax(args map[string]string) (int, error) {
+	value, exists := args["max"]
+	if !exists || strings.TrimSpace(value) == "" {
+		return defaultLoopMax, nil
+	}
+	limit, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || limit < 1 {
+		return 0, fmt.Errorf("invalid max '%s': must be a positive integer", value)
+	}
+	return limit, nil
+}
+
+// validateLoopVariable checks that a loop variable can be set by the user.
+func validateLoopVariable(name string) error {
+	if name == "" {
+		return nil
+	}
+	if err := context.ValidateVariableName(name); err != nil {
+		return fmt.Errorf("invalid loop variable '%s': %w", name, err)
+	}
+	return nil
+}
+
+// startLoop registers a loop and pushes its first start marker.
+// The stack machine runs the passes; see statemachine.LoopHandler.
+func startLoop(loop context.LoopBlockContext) error {
+	stackService, err := services.GetGlobalStackService()
+	if err != nil {
+		return fmt.Errorf("stack service not available: %w", err)
+	}
+
+	loop.ID = fmt.Sprintf("loop_id_%d", atomic.AddInt64(&loopCounter, 1))
+	stackService.PushLoopBoundary(loop)
+	stackService.PushCommand("LOOP_BOUNDARY_START:" + loop.ID)
+	return nil
+}
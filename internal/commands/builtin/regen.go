@@ -0,0 +1,289 @@
+package builtin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/commands/printing"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+	"neuroshell/pkg/stringprocessing"
+)
+
+// RegenCommand implements the \regen command for regenerating the last assistant response.
+// Regeneration is delegated to the _regen neuro script; picking and listing alternatives is done here.
+type RegenCommand struct{}
+
+// Name returns the command name "regen" for registration and lookup.
+func (c *RegenCommand) Name() string {
+	return "regen"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *RegenCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the regen command does.
+func (c *RegenCommand) Description() string {
+	return "Regenerate the last response and keep every version"
+}
+
+// Usage returns the syntax and usage examples for the regen command.
+func (c *RegenCommand) Usage() string {
+	return "\\regen[keep=false, model_id=model, client_id=client, temperature=0.9, session=session_id, pick=N, list=false]"
+}
+
+// HelpInfo returns structured help information for the regen command.
+func (c *RegenCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       c.Usage(),
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "keep",
+				Description: "Keep the current response in the conversation and only store the new one",
+				Required:    false,
+				Type:        "boolean",
+				Default:     "false",
+			},
+			{
+				Name:        "model_id",
+				Description: "Model to regenerate with",
+				Required:    false,
+				Type:        "string",
+				Default:     "active model",
+			},
+			{
+				Name:        "client_id",
+				Description: "Client to call (needed when model_id uses another provider)",
+				Required:    false,
+				Type:        "string",
+				Default:     "active client",
+			},
+			{
+				Name:        "temperature",
+				Description: "Temperature for this regeneration only",
+				Required:    false,
+				Type:        "number",
+				Default:     "model temperature",
+			},
+			{
+				Name:        "session",
+				Description: "Session name or ID",
+				Required:    false,
+				Type:        "string",
+				Default:     "active session",
+			},
+			{
+				Name:        "pick",
+				Description: "Choose which alternative stays in the conversation instead of regenerating",
+				Required:    false,
+				Type:        "integer",
+			},
+			{
+				Name:        "list",
+				Description: "List the alternatives of the last response instead of regenerating",
+				Required:    false,
+				Type:        "boolean",
+				Default:     "false",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\regen",
+				Description: "Replace the last response with a new one; the old one is kept as alternative 1",
+			},
+			{
+				Command:     "\\regen[temperature=1.2]",
+				Description: "Regenerate with a higher temperature for this call only",
+			},
+			{
+				Command:     "\\regen[keep=true, model_id=claude]",
+				Description: "Ask another model but keep the current response in the conversation",
+			},
+			{
+				Command:     "\\regen[list=true]",
+				Description: "Show every alternative of the last response",
+			},
+			{
+				Command:     "\\regen[pick=2]",
+				Description: "Put the second alternative back into the conversation",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "#message_alternatives",
+				Description: "Number of alternatives of the last response",
+				Type:        "system_metadata",
+				Example:     "3",
+			},
+			{
+				Name:        "#message_selected",
+				Description: "Alternative that is in the conversation",
+				Type:        "system_metadata",
+				Example:     "2",
+			},
+			{
+				Name:        "#llm_text_content",
+				Description: "The regenerated response (set by \\llm-call)",
+				Type:        "system_metadata",
+				Example:     "Here is another take...",
+			},
+		},
+		Notes: []string{
+			"The last message of the session must be an assistant response",
+			"The model answers the conversation up to the last user message again",
+			"Every response is stored as an alternative of the last message; the first is the original",
+			"Use \\session-branch to explore a different question instead of a different answer",
+			"Options are not combined: pick and list do not call the model",
+		},
+	}
+}
+
+// Execute regenerates, lists or picks alternatives of the last assistant response.
+func (c *RegenCommand) Execute(args map[string]string, _ string) error {
+	chatService, err := services.GetGlobalChatSessionService()
+	if err != nil {
+		return fmt.Errorf("chat session service not available: %w", err)
+	}
+
+	variableService, err := services.GetGlobalVariableService()
+	if err != nil {
+		return fmt.Errorf("variable service not available: %w", err)
+	}
+
+	var session *neurotypes.ChatSession
+	if sessionID := strings.TrimSpace(args["session"]); sessionID != "" {
+		session, err = chatService.GetSessionByNameOrID(sessionID)
+	} else {
+		session, err = chatService.GetActiveSession()
+	}
+	if err != nil {
+		return fmt.Errorf("no session to regenerate: %w", err)
+	}
+
+	if len(session.Messages) == 0 || session.Messages[len(session.Messages)-1].Role != "assistant" {
+		return fmt.Errorf("the last message of session '%s' is not an assistant response", session.Name)
+	}
+
+	if pickValue := strings.TrimSpace(args["pick"]); pickValue != "" {
+		return c.pick(chatService, variableService, session, pickValue)
+	}
+	if stringprocessing.IsTruthy(args["list"]) {
+		return c.list(variableService, session)
+	}
+
+	if temperature := strings.TrimSpace(args["temperature"]); temperature != "" {
+		if value, err := strconv.ParseFloat(temperature, 64); err != nil || value < 0 {
+			return fmt.Errorf("invalid temperature value: %s", temperature)
+		}
+	}
+
+	clientID := strings.TrimSpace(args["client_id"])
+	if clientID == "" {
+		clientID, _ = variableService.Get("#active_client_id")
+	}
+	if clientID == "" {
+		clientID, _ = variableService.Get("_client_id")
+	}
+
+	stackService, err := services.GetGlobalStackService()
+	if err != nil {
+		return fmt.Errorf("stack service not available: %w", err)
+	}
+
+	// Every option is passed so values from an earlier \regen never leak into this one
+	stackService.PushCommand(fmt.Sprintf("\\_regen[regen_session=%s, regen_model=%s, regen_client=%s, regen_temperature=%s, regen_keep=%t]",
+		session.ID, strings.TrimSpace(args["model_id"]), clientID, strings.TrimSpace(args["temperature"]), stringprocessing.IsTruthy(args["keep"])))
+
+	return nil
+}
+
+// pick makes the chosen alternative the content of the last response.
+func (c *RegenCommand) pick(chatService *services.ChatSessionService, variableService *services.VariableService, session *neurotypes.ChatSession, value string) error {
+	alternative, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("invalid pick value '%s': must be an alternative number", value)
+	}
+
+	if err := chatService.SelectAlternative(session.ID, alternative); err != nil {
+		return err
+	}
+	c.storeAlternativeVariables(variableService, session)
+
+	outputMsg := fmt.Sprintf("Picked alternative %d of %d for the last response in session '%s'",
+		alternative, alternativeCount(session), session.Name)
+	if err := variableService.SetSystemVariable("_output", outputMsg); err != nil {
+		return fmt.Errorf("failed to store result: %w", err)
+	}
+
+	printer := printing.NewDefaultPrinter()
+	printer.Success(outputMsg)
+
+	chatService.TriggerAutoSave(session.ID)
+	return nil
+}
+
+// list prints every alternative of the last response, marking the selected one.
+func (c *RegenCommand) list(variableService *services.VariableService, session *neurotypes.ChatSession) error {
+	message := session.Messages[len(session.Messages)-1]
+	alternatives := message.Alternatives
+	selected := message.SelectedAlternative
+	if len(alternatives) == 0 {
+		alternatives = []string{message.Content}
+		selected = 1
+	}
+
+	lines := make([]string, 0, len(alternatives))
+	for i, alternative := range alternatives {
+		marker := " "
+		if i+1 == selected {
+			marker = "*"
+		}
+		lines = append(lines, fmt.Sprintf("%s [%d] %s", marker, i+1, alternative))
+	}
+	c.storeAlternativeVariables(variableService, session)
+
+	output := strings.Join(lines, "\n")
+	if err := variableService.SetSystemVariable("_output", output); err != nil {
+		return fmt.Errorf("failed to store result: %w", err)
+	}
+
+	printer := printing.NewDefaultPrinter()
+	printer.Println(output)
+	return nil
+}
+
+// storeAlternativeVariables reports the alternatives of the last response in variables.
+func (c *RegenCommand) storeAlternativeVariables(variableService *services.VariableService, session *neurotypes.ChatSession) {
+	selected := session.Messages[len(session.Messages)-1].SelectedAlternative
+	if selected == 0 {
+		selected = 1
+	}
+	_ = variableService.SetSystemVariable("#message_alternatives", strconv.Itoa(alternativeCount(session)))
+	_ = variableService.SetSystemVariable("#message_selected", strconv.Itoa(selected))
+}
+
+// alternativeCount returns how many versions of the last response exist; an unregenerated response has one.
+func alternativeCount(session *neurotypes.ChatSession) int {
+	if count := len(session.Messages[len(session.Messages)-1].Alternatives); count > 0 {
+		return count
+	}
+	return 1
+}
+
+// IsReadOnly returns false as the regen command modifies system state.
+func (c *RegenCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&RegenCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register regen command: %v", err))
+	}
+}
@@ -0,0 +1,83 @@
+package builtin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/internal/context"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// setupRegenTest creates an active session whose last message is an assistant response.
+func setupRegenTest(t *testing.T) (neurotypes.Context, *services.ChatSessionService) {
+	ctx := context.New()
+	ctx.SetTestMode(true)
+	setupSendTestRegistry(t, ctx)
+	for _, service := range []neurotypes.Service{services.NewVariableService(), services.NewChatSessionService()} {
+		require.NoError(t, services.GetGlobalRegistry().RegisterService(service))
+		require.NoError(t, service.Initialize())
+	}
+
+	chatService, err := services.GetGlobalChatSessionService()
+	require.NoError(t, err)
+	_, err = chatService.CreateSession("regen", "", "Question")
+	require.NoError(t, err)
+	require.NoError(t, chatService.AddMessage("regen", "assistant", "First answer"))
+	return ctx, chatService
+}
+
+func TestRegenCommand_Metadata(t *testing.T) {
+	cmd := &RegenCommand{}
+	assert.Equal(t, "regen", cmd.Name())
+	assert.Equal(t, neurotypes.ParseModeKeyValue, cmd.ParseMode())
+	assert.False(t, cmd.IsReadOnly())
+
+	helpInfo := cmd.HelpInfo()
+	assert.Equal(t, cmd.Name(), helpInfo.Command)
+	assert.Len(t, helpInfo.Options, 7)
+	assert.NotEmpty(t, helpInfo.Examples)
+}
+
+func TestRegenCommand_Execute_PushesScript(t *testing.T) {
+	setupRegenTest(t)
+	variableService, err := services.GetGlobalVariableService()
+	require.NoError(t, err)
+	require.NoError(t, variableService.SetSystemVariable("_client_id", "MOCK:empty***"))
+
+	require.NoError(t, (&RegenCommand{}).Execute(map[string]string{"temperature": "1.2", "keep": "true"}, ""))
+
+	stackService, err := services.GetGlobalStackService()
+	require.NoError(t, err)
+	command, ok := stackService.PopCommand()
+	require.True(t, ok)
+	assert.Equal(t, "\\_regen[regen_session=00000001-0000-4000-8000-000000000001, regen_model=, regen_client=MOCK:empty***, regen_temperature=1.2, regen_keep=true]", command)
+
+	assert.ErrorContains(t, (&RegenCommand{}).Execute(map[string]string{"temperature": "warm"}, ""), "invalid temperature")
+}
+
+func TestRegenCommand_Execute_PickAndList(t *testing.T) {
+	ctx, chatService := setupRegenTest(t)
+
+	_, err := chatService.AddAlternative("regen", "Second answer", false, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, (&RegenCommand{}).Execute(map[string]string{"list": "true"}, ""))
+	output, _ := ctx.GetVariable("_output")
+	assert.Equal(t, "  [1] First answer\n* [2] Second answer", output)
+
+	require.NoError(t, (&RegenCommand{}).Execute(map[string]string{"pick": "1"}, ""))
+	session, err := chatService.GetSessionByName("regen")
+	require.NoError(t, err)
+	assert.Equal(t, "First answer", session.Messages[1].Content)
+	selected, _ := ctx.GetVariable("#message_selected")
+	assert.Equal(t, "1", selected)
+
+	assert.ErrorContains(t, (&RegenCommand{}).Execute(map[string]string{"pick": "3"}, ""), "out of bounds")
+	assert.ErrorContains(t, (&RegenCommand{}).Execute(map[string]string{"pick": "last"}, ""), "invalid pick value")
+
+	require.NoError(t, chatService.AddMessage("regen", "user", "Follow-up"))
+	assert.ErrorContains(t, (&RegenCommand{}).Execute(map[string]string{}, ""), "not an assistant response")
+}
@@ -0,0 +1,146 @@
+package builtin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/context"
+	"neuroshell/pkg/neurotypes"
+)
+
+// RepeatCommand implements the \repeat command for running a command a fixed number of times.
+type RepeatCommand struct{}
+
+// Name returns the command name "repeat" for registration and lookup.
+func (c *RepeatCommand) Name() string {
+	return "repeat"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *RepeatCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the repeat command does.
+func (c *RepeatCommand) Description() string {
+	return "Run a command a fixed number of times"
+}
+
+// Usage returns the syntax and usage examples for the repeat command.
+func (c *RepeatCommand) Usage() string {
+	return "\\repeat[times=N, var=name, max=1000] command_to_execute"
+}
+
+// HelpInfo returns structured help information for the repeat command.
+func (c *RepeatCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       c.Usage(),
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "times",
+				Description: "Number of passes",
+				Required:    true,
+				Type:        "int",
+			},
+			{
+				Name:        "var",
+				Description: "Variable set to the pass number, starting at 1",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "max",
+				Description: "Safety cap on the number of passes",
+				Required:    false,
+				Type:        "int",
+				Default:     "1000",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\repeat[times=3] \\send Give me another idea",
+				Description: "Send the same message three times",
+			},
+			{
+				Command:     "\\repeat[times=5, var=n] \\echo Attempt ${n}",
+				Description: "Use the pass number in the command",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "#loop_index",
+				Description: "Number of the current pass, starting at 1",
+				Type:        "system_metadata",
+				Example:     "2",
+			},
+			{
+				Name:        "#loop_count",
+				Description: "Number of passes the loop ran, set when it ends",
+				Type:        "system_metadata",
+				Example:     "5",
+			},
+		},
+		Notes: []string{
+			"The command is interpolated on each pass",
+			"times=0 runs no passes; times above max is rejected",
+			"An error in the command stops the loop; wrap the command in \\try to keep going",
+		},
+	}
+}
+
+// Execute starts the loop.
+// Options:
+//   - times: number of passes (required)
+//   - var: variable set to the pass number (optional)
+//   - max: safety cap on the number of passes (optional, default: 1000)
+func (c *RepeatCommand) Execute(args map[string]string, input string) error {
+	value, exists := args["times"]
+	if !exists {
+		return fmt.Errorf("times parameter is required")
+	}
+	times, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || times < 0 {
+		return fmt.Errorf("invalid times '%s': must be a non-negative integer", value)
+	}
+	variable := strings.TrimSpace(args["var"])
+	if err := validateLoopVariable(variable); err != nil {
+		return err
+	}
+	limit, err := parseLoopMax(args)
+	if err != nil {
+		return err
+	}
+	if times > limit {
+		return fmt.Errorf("repeat times=%d is more than max=%d", times, limit)
+	}
+
+	body := strings.TrimSpace(input)
+	if body == "" {
+		// Empty repeat command - nothing to run
+		return nil
+	}
+
+	return startLoop(context.LoopBlockContext{
+		Kind:     c.Name(),
+		Variable: variable,
+		Times:    times,
+		Body:     body,
+		Max:      limit,
+	})
+}
+
+// IsReadOnly returns false as the repeat command modifies system state.
+func (c *RepeatCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&RepeatCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register repeat command: %v", err))
+	}
+}
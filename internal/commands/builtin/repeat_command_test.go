@@ -0,0 +1,65 @@
+package builtin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+func TestRepeatCommand_BasicProperties(t *testing.T) {
+	cmd := &RepeatCommand{}
+	assert.Equal(t, "repeat", cmd.Name())
+	assert.Equal(t, neurotypes.ParseModeKeyValue, cmd.ParseMode())
+	assert.NotEmpty(t, cmd.Description())
+	assert.Contains(t, cmd.Usage(), "\\repeat")
+	assert.False(t, cmd.IsReadOnly())
+	assert.Equal(t, "repeat", cmd.HelpInfo().Command)
+}
+
+func TestRepeatCommand_Execute(t *testing.T) {
+	setupLoopTestRegistry(t)
+
+	err := (&RepeatCommand{}).Execute(map[string]string{"times": "3", "var": "n", "max": "5"}, "\\echo ${n}")
+	require.NoError(t, err)
+
+	stackService, err := services.GetGlobalStackService()
+	require.NoError(t, err)
+	loop, ok := stackService.NextLoopPass(stackService.GetCurrentLoopID())
+	require.True(t, ok)
+	assert.Equal(t, "repeat", loop.Kind)
+	assert.Equal(t, 3, loop.Times)
+	assert.Equal(t, "n", loop.Variable)
+	assert.Equal(t, 5, loop.Max)
+	assert.Equal(t, "\\echo ${n}", loop.Body)
+}
+
+func TestRepeatCommand_Execute_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]string
+		err  string
+	}{
+		{"missing times", map[string]string{}, "times parameter is required"},
+		{"negative times", map[string]string{"times": "-1"}, "non-negative integer"},
+		{"not a number", map[string]string{"times": "many"}, "non-negative integer"},
+		{"above max", map[string]string{"times": "1001"}, "more than max=1000"},
+		{"reserved variable", map[string]string{"times": "2", "var": "@n"}, "invalid loop variable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupLoopTestRegistry(t)
+
+			err := (&RepeatCommand{}).Execute(tt.args, "\\echo hi")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+// Interface compliance check
+var _ neurotypes.Command = (*RepeatCommand)(nil)
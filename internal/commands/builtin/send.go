@@ -29,7 +29,7 @@ func (c *SendCommand) Description() string {
 
 // Usage returns the syntax and usage examples for the send command.
 func (c *SendCommand) Usage() string {
-	return "\\send[include_thinking=false] message"
+	return "\\send[include_thinking=false, schema=path/to/schema.json] message"
 }
 
 // HelpInfo returns comprehensive help information for the send command.
@@ -47,6 +47,12 @@ func (c *SendCommand) HelpInfo() neurotypes.HelpInfo {
 				Type:        "boolean",
 				Default:     "false",
 			},
+			{
+				Name:        "schema",
+				Description: "JSON schema file the response must conform to",
+				Required:    false,
+				Type:        "string",
+			},
 		},
 		Examples: []neurotypes.HelpExample{
 			{
@@ -57,6 +63,10 @@ func (c *SendCommand) HelpInfo() neurotypes.HelpInfo {
 				Command:     "\\send[include_thinking=true] Explain quantum computing",
 				Description: "Send message and include thinking blocks in session history",
 			},
+			{
+				Command:     "\\send[schema=person.json] Describe Ada Lovelace",
+				Description: "Send message and require a JSON answer matching person.json",
+			},
 			{
 				Command:     "\\send Analyze this data: ${data_variable}",
 				Description: "Send message with variable interpolation",
@@ -70,12 +80,12 @@ func (c *SendCommand) HelpInfo() neurotypes.HelpInfo {
 				Description: "Send multi-line message with embedded content",
 			},
 			{
-				Command:     "\\set[_reply_way=stream]\n\\send Tell me a story",
-				Description: "Send message with streaming response mode",
+				Command:     "\\set[_stream=true]\n\\send Tell me a story",
+				Description: "Send message and render the response as it is generated",
 			},
 			{
-				Command:     "\\set[_reply_way=sync]\n\\send What is 2+2?",
-				Description: "Send message with synchronous response mode",
+				Command:     "\\set[_stream=false]\n\\send What is 2+2?",
+				Description: "Send message and render the complete response at once",
 			},
 		},
 		StoredVariables: []neurotypes.HelpStoredVariable{
@@ -120,9 +130,11 @@ func (c *SendCommand) HelpInfo() neurotypes.HelpInfo {
 			"Message history access patterns:",
 			"  • ${1}, ${2}, ${3}: Reverse order (most recent first)",
 			"  • ${.1}, ${.2}, ${.3}: Chronological order (first message first)",
-			"Set _reply_way variable to control response mode:",
-			"  • _reply_way=sync: Complete response at once (default)",
-			"  • _reply_way=stream: Real-time streaming response",
+			"Set _stream variable to control response mode:",
+			"  • _stream=false: Complete response rendered as markdown at once (default)",
+			"  • _stream=true: Response rendered live as it is generated",
+			"schema option requests structured output; top-level fields are stored in ${#llm_json.<field>}",
+			"Responses that do not match the schema fail with error type schema_violation",
 			"Requires API key: OPENAI_API_KEY, ANTHROPIC_API_KEY, etc.",
 			"Multi-line messages supported with \\n escape sequences",
 			"Error messages preserved on stderr for debugging",
@@ -56,8 +56,8 @@ func TestSendCommand_HelpInfo(t *testing.T) {
 		"\\send Analyze this data: ${data_variable}",
 		"\\send ${_output}",
 		"\\send Please review this code:\\n${code_content}",
-		"\\set[_reply_way=stream]\n\\send Tell me a story",
-		"\\set[_reply_way=sync]\n\\send What is 2+2?",
+		"\\set[_stream=true]\n\\send Tell me a story",
+		"\\set[_stream=false]\n\\send What is 2+2?",
 	}
 
 	for _, expectedCmd := range expectedCommands {
@@ -75,7 +75,7 @@ func TestSendCommand_HelpInfo(t *testing.T) {
 	notesText := strings.Join(helpInfo.Notes, " ")
 	assert.Contains(t, notesText, "session")
 	assert.Contains(t, notesText, "model")
-	assert.Contains(t, notesText, "_reply_way")
+	assert.Contains(t, notesText, "_stream")
 	assert.Contains(t, notesText, "API key")
 }
 
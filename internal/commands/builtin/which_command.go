@@ -0,0 +1,213 @@
+package builtin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/data/embedded"
+	"neuroshell/internal/output"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// WhichCommand implements the \which command for showing where a command resolves from.
+// It follows the same order as command resolution: builtin, stdlib, \def, library scripts in the
+// script command search path, then .neuro files.
+type WhichCommand struct{}
+
+// whichMatch is a place a command name resolves from.
+type whichMatch struct {
+	kind   string // builtin, stdlib, def, library or file
+	label  string // Human readable kind
+	path   string // Script path, empty for builtin and \def commands
+	source string // Search path source of library scripts
+}
+
+// Name returns the command name "which" for registration and lookup.
+func (c *WhichCommand) Name() string {
+	return "which"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *WhichCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the which command does.
+func (c *WhichCommand) Description() string {
+	return "Show where a command resolves from"
+}
+
+// Usage returns the syntax and usage examples for the which command.
+func (c *WhichCommand) Usage() string {
+	return "\\which[all=true] command"
+}
+
+// HelpInfo returns structured help information for the which command.
+func (c *WhichCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       c.Usage(),
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "all",
+				Description: "Also list the commands and scripts shadowed by the one that runs",
+				Required:    false,
+				Type:        "bool",
+				Default:     "false",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\which review-pr",
+				Description: "Show which review-pr.neuro runs for \\review-pr",
+			},
+			{
+				Command:     "\\which[all=true] review-pr",
+				Description: "Also list review-pr.neuro scripts later in the search path",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "#which_type",
+				Description: "Kind of command that runs: builtin, stdlib, def, library or file",
+				Type:        "system_metadata",
+				Example:     "#which_type = \"library\"",
+			},
+			{
+				Name:        "#which_path",
+				Description: "Path of the script that runs, empty for builtin and \\def commands",
+				Type:        "system_metadata",
+				Example:     "#which_path = \"/home/user/.config/neuroshell/lib/review-pr.neuro\"",
+			},
+			{
+				Name:        "_output",
+				Description: "Script path, or the kind of command when it has no path",
+				Type:        "command_output",
+				Example:     "_output = \"/home/user/.config/neuroshell/lib/review-pr.neuro\"",
+			},
+		},
+		Notes: []string{
+			"Commands resolve in order: builtin, stdlib, \\def, library scripts, then .neuro files",
+			"Library scripts are searched in $NEURO_PATH, then ~/.config/neuroshell/lib, then ./.neuro/lib",
+			"The leading backslash of the command name is optional",
+		},
+	}
+}
+
+// Execute shows where the command resolves from and stores it in #which_type and #which_path.
+func (c *WhichCommand) Execute(args map[string]string, input string) error {
+	name := strings.TrimPrefix(strings.TrimSpace(input), "\\")
+	if name == "" {
+		return fmt.Errorf("command name is required\n\nUsage: %s", c.Usage())
+	}
+
+	showAll := false
+	if value, exists := args["all"]; exists {
+		showAll = strings.ToLower(value) == "true"
+	}
+
+	variableService, err := services.GetGlobalVariableService()
+	if err != nil {
+		return fmt.Errorf("variable service not available: %w", err)
+	}
+
+	matches := c.findMatches(name)
+	if len(matches) == 0 {
+		return fmt.Errorf("command not found: \\%s", name)
+	}
+
+	var styleProvider output.StyleProvider
+	if themeService, err := services.GetGlobalThemeService(); err == nil {
+		styleProvider = themeService
+	}
+	printer := output.NewPrinter(output.WithStyles(styleProvider))
+
+	printer.Info(fmt.Sprintf("\\%s is %s", name, formatWhichMatch(matches[0])))
+	if showAll {
+		for _, match := range matches[1:] {
+			printer.Info(fmt.Sprintf("  shadowed: %s", formatWhichMatch(match)))
+		}
+	}
+
+	result := matches[0].path
+	if result == "" {
+		result = matches[0].kind
+	}
+	if err := variableService.SetSystemVariable("#which_type", matches[0].kind); err != nil {
+		return fmt.Errorf("failed to set #which_type: %w", err)
+	}
+	if err := variableService.SetSystemVariable("#which_path", matches[0].path); err != nil {
+		return fmt.Errorf("failed to set #which_path: %w", err)
+	}
+	if err := variableService.SetSystemVariable("_output", result); err != nil {
+		return fmt.Errorf("failed to set _output: %w", err)
+	}
+	return nil
+}
+
+// findMatches returns every place the name resolves from, the one that runs first.
+func (c *WhichCommand) findMatches(name string) []whichMatch {
+	var matches []whichMatch
+
+	if _, exists := commands.GetGlobalRegistry().Get(name); exists {
+		matches = append(matches, whichMatch{kind: "builtin", label: "a builtin command"})
+	}
+
+	stdlibLoader := embedded.NewStdlibLoader()
+	if stdlibLoader.ScriptExists(name) {
+		matches = append(matches, whichMatch{kind: "stdlib", label: "a stdlib script", path: stdlibLoader.GetScriptPath(name)})
+	}
+
+	if definitionService, err := services.GetGlobalDefinitionService(); err == nil && definitionService.Exists(name) {
+		matches = append(matches, whichMatch{kind: "def", label: "defined with \\def"})
+	}
+
+	if libraryService, err := services.GetGlobalScriptLibraryService(); err == nil {
+		sources := make(map[string]string)
+		for _, entry := range libraryService.SearchPath() {
+			sources[entry.Path] = entry.Source
+		}
+		for _, path := range libraryService.FindAll(name) {
+			matches = append(matches, whichMatch{kind: "library", label: "a library script", path: path, source: sources[filepath.Dir(path)]})
+		}
+	}
+
+	if strings.HasSuffix(name, ".neuro") || strings.HasSuffix(name, ".neurorc") {
+		if path, err := filepath.Abs(name); err == nil && !strings.Contains(name, "..") {
+			if _, err := os.Stat(path); err == nil {
+				matches = append(matches, whichMatch{kind: "file", label: "a script file", path: path})
+			}
+		}
+	}
+
+	return matches
+}
+
+// formatWhichMatch describes a match, as in "a library script /path/name.neuro (user)".
+func formatWhichMatch(match whichMatch) string {
+	text := match.label
+	if match.path != "" {
+		text += " " + match.path
+	}
+	if match.source != "" {
+		text += fmt.Sprintf(" (%s)", match.source)
+	}
+	return text
+}
+
+// IsReadOnly returns true as the which command doesn't modify system state.
+func (c *WhichCommand) IsReadOnly() bool {
+	return true
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&WhichCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register which command: %v", err))
+	}
+}
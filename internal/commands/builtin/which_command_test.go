@@ -0,0 +1,117 @@
+package builtin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/context"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupWhichTestRegistry registers the services \which looks in, with NEURO_PATH set to two
+// temporary directories.
+func setupWhichTestRegistry(t *testing.T) (string, string) {
+	oldRegistry := services.GetGlobalRegistry()
+	oldCommands := commands.GetGlobalRegistry()
+	services.SetGlobalRegistry(services.NewRegistry())
+	commands.SetGlobalRegistry(commands.NewRegistry())
+
+	first, second := t.TempDir(), t.TempDir()
+	ctx := context.New()
+	ctx.SetTestMode(true)
+	ctx.SetTestEnvOverride(services.NeuroPathEnv, first+string(os.PathListSeparator)+second)
+	context.SetGlobalContext(ctx)
+
+	require.NoError(t, services.GetGlobalRegistry().RegisterService(services.NewVariableService()))
+	require.NoError(t, services.GetGlobalRegistry().RegisterService(services.NewDefinitionService()))
+	require.NoError(t, services.GetGlobalRegistry().RegisterService(services.NewScriptLibraryService()))
+	require.NoError(t, services.GetGlobalRegistry().InitializeAll())
+	require.NoError(t, commands.GetGlobalRegistry().Register(&EchoCommand{}))
+
+	t.Cleanup(func() {
+		services.SetGlobalRegistry(oldRegistry)
+		commands.SetGlobalRegistry(oldCommands)
+		context.ResetGlobalContext()
+	})
+	return first, second
+}
+
+func TestWhichCommand_BasicProperties(t *testing.T) {
+	cmd := &WhichCommand{}
+
+	assert.Equal(t, "which", cmd.Name())
+	assert.Equal(t, neurotypes.ParseModeKeyValue, cmd.ParseMode())
+	assert.NotEmpty(t, cmd.Description())
+	assert.Contains(t, cmd.Usage(), "\\which")
+	assert.True(t, cmd.IsReadOnly())
+
+	help := cmd.HelpInfo()
+	assert.Equal(t, "which", help.Command)
+	assert.Len(t, help.Options, 1)
+	assert.NotEmpty(t, help.StoredVariables)
+}
+
+func TestWhichCommand_Execute(t *testing.T) {
+	first, second := setupWhichTestRegistry(t)
+	cmd := &WhichCommand{}
+
+	firstPath := filepath.Join(first, "review-pr.neuro")
+	secondPath := filepath.Join(second, "review-pr.neuro")
+	require.NoError(t, os.WriteFile(firstPath, []byte("\\echo first"), 0644))
+	require.NoError(t, os.WriteFile(secondPath, []byte("\\echo second"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(first, "echo.neuro"), []byte("\\echo shadowed"), 0644))
+
+	definitionService, err := services.GetGlobalDefinitionService()
+	require.NoError(t, err)
+	_, err = definitionService.Define("greet", []string{"\\echo hi"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		input        string
+		expectedType string
+		expectedPath string
+	}{
+		{"library script", "review-pr", "library", firstPath},
+		{"leading backslash", "\\review-pr", "library", firstPath},
+		{"builtin before library", "echo", "builtin", ""},
+		{"defined command", "greet", "def", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, cmd.Execute(map[string]string{"all": "true"}, tt.input))
+
+			whichType, _ := context.GetGlobalContext().GetVariable("#which_type")
+			assert.Equal(t, tt.expectedType, whichType)
+			whichPath, _ := context.GetGlobalContext().GetVariable("#which_path")
+			assert.Equal(t, tt.expectedPath, whichPath)
+		})
+	}
+
+	matches := cmd.findMatches("review-pr")
+	require.Len(t, matches, 2)
+	assert.Equal(t, secondPath, matches[1].path)
+	assert.Equal(t, services.ScriptLibrarySourceEnv, matches[1].source)
+	assert.Equal(t, "a library script "+secondPath+" (NEURO_PATH)", formatWhichMatch(matches[1]))
+
+	output, _ := context.GetGlobalContext().GetVariable("_output")
+	assert.Equal(t, "def", output)
+}
+
+func TestWhichCommand_Errors(t *testing.T) {
+	setupWhichTestRegistry(t)
+	cmd := &WhichCommand{}
+
+	err := cmd.Execute(map[string]string{}, "")
+	assert.ErrorContains(t, err, "command name is required")
+
+	err = cmd.Execute(map[string]string{}, "missing")
+	assert.ErrorContains(t, err, "command not found: \\missing")
+}
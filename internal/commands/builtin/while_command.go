@@ -0,0 +1,128 @@
+package builtin
+
+import (
+	"fmt"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/context"
+	"neuroshell/pkg/neurotypes"
+)
+
+// WhileCommand implements the \while command for running a command as long as a condition holds.
+// The condition is interpolated and evaluated again before every pass.
+type WhileCommand struct{}
+
+// Name returns the command name "while" for registration and lookup.
+func (c *WhileCommand) Name() string {
+	return "while"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *WhileCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the while command does.
+func (c *WhileCommand) Description() string {
+	return "Run a command repeatedly while a condition is truthy"
+}
+
+// Usage returns the syntax and usage examples for the while command.
+func (c *WhileCommand) Usage() string {
+	return "\\while[condition=boolean_expression, max=1000] command_to_execute"
+}
+
+// HelpInfo returns structured help information for the while command.
+func (c *WhileCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       c.Usage(),
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "condition",
+				Description: "Expression evaluated for truthiness before each pass (same rules as \\if)",
+				Required:    true,
+				Type:        "string",
+			},
+			{
+				Name:        "max",
+				Description: "Safety cap on the number of passes",
+				Required:    false,
+				Type:        "int",
+				Default:     "1000",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\while[condition=${pending}] \\run process-next",
+				Description: "Run a script until it clears the pending variable",
+			},
+			{
+				Command:     "\\while[condition=${more}, max=20] \\run fetch-page",
+				Description: "Stop after 20 passes even if the script keeps setting more",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "#loop_index",
+				Description: "Number of the current pass, starting at 1",
+				Type:        "system_metadata",
+				Example:     "2",
+			},
+			{
+				Name:        "#loop_count",
+				Description: "Number of passes the loop ran, set when it ends",
+				Type:        "system_metadata",
+				Example:     "4",
+			},
+		},
+		Notes: []string{
+			"Variables in the condition are expanded before each pass, not once",
+			"The command must eventually make the condition falsy",
+			"Reaching max while the condition is still truthy is an error",
+			"An error in the command stops the loop; wrap the command in \\try to keep going",
+		},
+	}
+}
+
+// Execute starts the loop.
+// Options:
+//   - condition: uninterpolated expression evaluated before each pass (required)
+//   - max: safety cap on the number of passes (optional, default: 1000)
+func (c *WhileCommand) Execute(args map[string]string, input string) error {
+	condition, exists := args["condition"]
+	if !exists {
+		return fmt.Errorf("condition parameter is required")
+	}
+	limit, err := parseLoopMax(args)
+	if err != nil {
+		return err
+	}
+
+	body := strings.TrimSpace(input)
+	if body == "" {
+		// Empty while command - nothing to run
+		return nil
+	}
+
+	return startLoop(context.LoopBlockContext{
+		Kind:      c.Name(),
+		Condition: condition,
+		Body:      body,
+		Max:       limit,
+	})
+}
+
+// IsReadOnly returns false as the while command modifies system state.
+func (c *WhileCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&WhileCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register while command: %v", err))
+	}
+}
@@ -0,0 +1,51 @@
+package builtin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+func TestWhileCommand_BasicProperties(t *testing.T) {
+	cmd := &WhileCommand{}
+	assert.Equal(t, "while", cmd.Name())
+	assert.Equal(t, neurotypes.ParseModeKeyValue, cmd.ParseMode())
+	assert.NotEmpty(t, cmd.Description())
+	assert.Contains(t, cmd.Usage(), "condition")
+	assert.False(t, cmd.IsReadOnly())
+	assert.Equal(t, "while", cmd.HelpInfo().Command)
+}
+
+func TestWhileCommand_Execute_KeepsConditionUninterpolated(t *testing.T) {
+	setupLoopTestRegistry(t)
+
+	err := (&WhileCommand{}).Execute(map[string]string{"condition": "${more}"}, "\\run next")
+	require.NoError(t, err)
+
+	stackService, err := services.GetGlobalStackService()
+	require.NoError(t, err)
+	loop, ok := stackService.NextLoopPass(stackService.GetCurrentLoopID())
+	require.True(t, ok)
+	assert.Equal(t, "while", loop.Kind)
+	assert.Equal(t, "${more}", loop.Condition)
+	assert.Equal(t, defaultLoopMax, loop.Max)
+}
+
+func TestWhileCommand_Execute_Errors(t *testing.T) {
+	setupLoopTestRegistry(t)
+
+	err := (&WhileCommand{}).Execute(map[string]string{}, "\\echo hi")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "condition parameter is required")
+
+	err = (&WhileCommand{}).Execute(map[string]string{"condition": "true", "max": "lots"}, "\\echo hi")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid max")
+}
+
+// Interface compliance check
+var _ neurotypes.Command = (*WhileCommand)(nil)
@@ -0,0 +1,142 @@
+package claudecode
+
+import (
+	"fmt"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/commands/printing"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// GetCommand implements the \cc-get command for reading a Claude Code job's output without waiting.
+type GetCommand struct{}
+
+// Name returns the command name "cc-get" for registration and lookup.
+func (c *GetCommand) Name() string {
+	return "cc-get"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *GetCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the cc-get command does.
+func (c *GetCommand) Description() string {
+	return "Get the output of a Claude Code job without waiting"
+}
+
+// Usage returns the syntax and usage examples for the cc-get command.
+func (c *GetCommand) Usage() string {
+	return `\cc-get[job=id]
+
+Examples:
+  \cc-get                             %% Output of the last submitted job
+  \cc-get[job=${review}]              %% Output of a specific job
+
+Options:
+  job - Job ID (default: ${#cc_job_id}, the last submitted job)
+
+Note: While the job runs, the output so far is stored in ${_cc_partial} and ${_cc_output}
+      is left alone. Once it has finished, its response is stored in ${_cc_output}.`
+}
+
+// HelpInfo returns structured help information for the cc-get command.
+func (c *GetCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       "\\cc-get[job=id]",
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "job",
+				Description: "Job ID, the last submitted job by default",
+				Required:    false,
+				Type:        "string",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\cc-get",
+				Description: "Output of the last submitted job",
+			},
+		},
+		StoredVariables: append([]neurotypes.HelpStoredVariable{
+			{
+				Name:        "_cc_status",
+				Description: "Job status",
+				Type:        "command_output",
+				Example:     "running",
+			},
+			{
+				Name:        "_cc_partial",
+				Description: "Output so far while the job runs, empty once it has finished",
+				Type:        "command_output",
+				Example:     "I'll look at the parser first.",
+			},
+		}, jobResultVariableHelp()...),
+		Notes: []string{
+			"The _cc_output, _cc_error, _cc_tools_used and _cc_files_modified variables are set once the job has finished",
+			"Unlike \\cc-wait, a failed job is not reported as an error",
+		},
+	}
+}
+
+// Execute stores the current output of a job.
+// Options:
+//   - job: job ID (optional, default: ${#cc_job_id})
+func (c *GetCommand) Execute(args map[string]string, _ string) error {
+	service, err := services.GetGlobalClaudeCodeService()
+	if err != nil {
+		return fmt.Errorf("claude code is not running, start it with \\cc-init: %w", err)
+	}
+
+	jobID, err := resolveJobID(args)
+	if err != nil {
+		return err
+	}
+	info, err := service.GetJobInfo(jobID)
+	if err != nil {
+		return err
+	}
+
+	printer := printing.NewDefaultPrinter()
+	if !info.Status.IsFinished() {
+		if err := setVariables(map[string]string{
+			"_cc_status":  string(info.Status),
+			"_cc_partial": info.Output,
+		}); err != nil {
+			return err
+		}
+		printer.Info(fmt.Sprintf("Job %s is %s", info.ID, info.Status))
+		if info.Output != "" {
+			printer.Println(info.Output)
+		}
+		return nil
+	}
+
+	if err := setVariables(map[string]string{
+		"_cc_status":  string(info.Status),
+		"_cc_partial": "",
+	}); err != nil {
+		return err
+	}
+	if err := storeJobResult(info, true); err != nil {
+		// The failure is in _cc_error; reading it is not an error
+		printer.Warning(err.Error())
+	}
+	return nil
+}
+
+// IsReadOnly returns false as the cc-get command modifies system state.
+func (c *GetCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&GetCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register cc-get command: %v", err))
+	}
+}
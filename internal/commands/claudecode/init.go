@@ -0,0 +1,245 @@
+package claudecode
+
+import (
+	"fmt"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/commands/printing"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+	"neuroshell/pkg/stringprocessing"
+)
+
+// InitCommand implements the \cc-init command for starting a Claude Code session.
+type InitCommand struct{}
+
+// Name returns the command name "cc-init" for registration and lookup.
+func (c *InitCommand) Name() string {
+	return "cc-init"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *InitCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the cc-init command does.
+func (c *InitCommand) Description() string {
+	return "Start a Claude Code session"
+}
+
+// Usage returns the syntax and usage examples for the cc-init command.
+func (c *InitCommand) Usage() string {
+	return `\cc-init[model=name, verbose=false, dirs=dir1,dir2, permission_mode=mode]
+
+Examples:
+  \cc-init                                  %% Session with the CLI defaults
+  \cc-init[model=opus]                      %% Use a specific model
+  \cc-init[dirs="./src,./tests"]            %% Give Claude Code access to more directories
+  \cc-init[permission_mode=acceptEdits]     %% Let Claude Code edit files without asking
+
+Options:
+  model           - Model alias or name passed to the CLI (default: CLI default)
+  verbose         - Include tool calls in the job output (default: false)
+  dirs            - Comma-separated directories to allow in addition to the current one
+  permission_mode - default, acceptEdits, plan or bypassPermissions (default: CLI default)
+
+Note: Each \cc-init starts a new session and makes it the active one. \cc-send starts a
+      session with the defaults when there is none. Messages of a session continue the
+      same Claude Code conversation.`
+}
+
+// HelpInfo returns structured help information for the cc-init command.
+func (c *InitCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       "\\cc-init[model=name, verbose=false, dirs=dir1,dir2, permission_mode=mode]",
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "model",
+				Description: "Model alias or name passed to the CLI",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "verbose",
+				Description: "Include tool calls in the job output",
+				Required:    false,
+				Type:        "bool",
+				Default:     "false",
+			},
+			{
+				Name:        "dirs",
+				Description: "Comma-separated directories to allow in addition to the current one",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "permission_mode",
+				Description: "default, acceptEdits, plan or bypassPermissions",
+				Required:    false,
+				Type:        "string",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\cc-init",
+				Description: "Start a session with the CLI defaults",
+			},
+			{
+				Command:     "\\cc-init[model=opus, permission_mode=plan]",
+				Description: "Plan-only session with a specific model",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "#cc_session_id",
+				Description: "Active Claude Code session ID",
+				Type:        "system_metadata",
+				Example:     "cc-session-1",
+			},
+			{
+				Name:        "#cc_status",
+				Description: "Service status",
+				Type:        "system_metadata",
+				Example:     "ready",
+			},
+			{
+				Name:        "#cc_ready",
+				Description: "Whether Claude Code can take messages",
+				Type:        "system_metadata",
+				Example:     "true",
+			},
+			{
+				Name:        "#cc_model",
+				Description: "Model of the active session, empty for the CLI default",
+				Type:        "system_metadata",
+				Example:     "opus",
+			},
+		},
+		Notes: []string{
+			"Requires the claude CLI in PATH",
+			"Each \\cc-init starts a new session and makes it the active one",
+			"\\cc-send starts a default session when there is none",
+		},
+	}
+}
+
+// Execute starts a Claude Code session and makes it the active one.
+// Options:
+//   - model: model passed to the CLI (optional)
+//   - verbose: include tool calls in the output (optional, default: false)
+//   - dirs: comma-separated additional directories (optional)
+//   - permission_mode: CLI permission mode (optional)
+func (c *InitCommand) Execute(args map[string]string, _ string) error {
+	service, err := claudeCodeService()
+	if err != nil {
+		return err
+	}
+
+	opts := services.InitOptions{
+		Model:          strings.TrimSpace(args["model"]),
+		Verbose:        stringprocessing.IsTruthy(args["verbose"]),
+		PermissionMode: strings.TrimSpace(args["permission_mode"]),
+	}
+	for _, dir := range strings.Split(args["dirs"], ",") {
+		if dir = strings.TrimSpace(dir); dir != "" {
+			opts.Directories = append(opts.Directories, dir)
+		}
+	}
+
+	session, err := startSession(service, opts)
+	if err != nil {
+		return err
+	}
+
+	version, err := service.Version()
+	if err != nil {
+		version = "unknown version"
+	}
+	printer := printing.NewDefaultPrinter()
+	printer.Success(fmt.Sprintf("Claude Code session %s ready (%s)", session.ID, version))
+	return nil
+}
+
+// IsReadOnly returns false as the cc-init command modifies system state.
+func (c *InitCommand) IsReadOnly() bool {
+	return false
+}
+
+// claudeCodeService returns the Claude Code service, registering it on first use so that
+// NeuroShell starts normally on machines without the claude CLI.
+func claudeCodeService() (*services.ClaudeCodeService, error) {
+	if service, err := services.GetGlobalClaudeCodeService(); err == nil {
+		return service, nil
+	}
+
+	service := services.NewClaudeCodeService()
+	if err := service.Initialize(); err != nil {
+		return nil, err
+	}
+	if err := services.GetGlobalRegistry().RegisterService(service); err != nil {
+		return nil, fmt.Errorf("failed to register claude code service: %w", err)
+	}
+	return service, nil
+}
+
+// startSession creates a session, makes it the active one and publishes it in #cc_* variables.
+func startSession(service *services.ClaudeCodeService, opts services.InitOptions) (*services.ClaudeCodeSession, error) {
+	session, err := service.CreateSession(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start claude code session: %w", err)
+	}
+	if err := service.SetActiveSession(session.ID); err != nil {
+		return nil, err
+	}
+
+	if err := setVariables(map[string]string{
+		"#cc_session_id": session.ID,
+		"#cc_status":     "ready",
+		"#cc_ready":      "true",
+		"#cc_model":      session.Model,
+	}); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// activeSession returns the active Claude Code session, starting one with the defaults if needed.
+func activeSession() (*services.ClaudeCodeService, *services.ClaudeCodeSession, error) {
+	service, err := claudeCodeService()
+	if err != nil {
+		return nil, nil, err
+	}
+	if session, err := service.GetActiveSession(); err == nil {
+		return service, session, nil
+	}
+	session, err := startSession(service, services.InitOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	return service, session, nil
+}
+
+// setVariables stores command results as system variables.
+func setVariables(variables map[string]string) error {
+	variableService, err := services.GetGlobalVariableService()
+	if err != nil {
+		return fmt.Errorf("variable service not available: %w", err)
+	}
+	for name, value := range variables {
+		if err := variableService.SetSystemVariable(name, value); err != nil {
+			return fmt.Errorf("failed to set variable %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&InitCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register cc-init command: %v", err))
+	}
+}
@@ -0,0 +1,99 @@
+package claudecode
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/internal/context"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// setupClaudeCodeTest gives each test a fresh registry and puts the fake claude CLI from
+// test/fixtures/claudecode first in PATH.
+func setupClaudeCodeTest(t *testing.T) {
+	ctx := context.NewTestContext()
+
+	oldRegistry := services.GetGlobalRegistry()
+	services.SetGlobalRegistry(services.NewRegistry())
+	context.SetGlobalContext(ctx)
+
+	require.NoError(t, services.GetGlobalRegistry().RegisterService(services.NewVariableService()))
+	require.NoError(t, services.GetGlobalRegistry().InitializeAll())
+
+	fixtures, err := filepath.Abs(filepath.Join("..", "..", "..", "test", "fixtures", "claudecode"))
+	require.NoError(t, err)
+	t.Setenv("PATH", fixtures+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	t.Cleanup(func() {
+		if service, err := services.GetGlobalClaudeCodeService(); err == nil {
+			_ = service.Cleanup()
+		}
+		services.SetGlobalRegistry(oldRegistry)
+		context.ResetGlobalContext()
+	})
+}
+
+// variable reads a variable, failing the test if it cannot be read.
+func variable(t *testing.T, name string) string {
+	variableService, err := services.GetGlobalVariableService()
+	require.NoError(t, err)
+	value, err := variableService.Get(name)
+	require.NoError(t, err, name)
+	return value
+}
+
+func TestInitCommand_BasicProperties(t *testing.T) {
+	cmd := &InitCommand{}
+	assert.Equal(t, "cc-init", cmd.Name())
+	assert.Equal(t, neurotypes.ParseModeKeyValue, cmd.ParseMode())
+	assert.NotEmpty(t, cmd.Description())
+	assert.Contains(t, cmd.Usage(), "\\cc-init")
+	assert.Equal(t, cmd.Name(), cmd.HelpInfo().Command)
+	assert.False(t, cmd.IsReadOnly())
+}
+
+func TestInitCommand_Execute(t *testing.T) {
+	setupClaudeCodeTest(t)
+	cmd := &InitCommand{}
+
+	require.NoError(t, cmd.Execute(map[string]string{
+		"model":           "opus",
+		"verbose":         "true",
+		"dirs":            "./src, ./tests",
+		"permission_mode": "plan",
+	}, ""))
+	assert.Equal(t, "cc-session-1", variable(t, "#cc_session_id"))
+	assert.Equal(t, "ready", variable(t, "#cc_status"))
+	assert.Equal(t, "true", variable(t, "#cc_ready"))
+	assert.Equal(t, "opus", variable(t, "#cc_model"))
+
+	service, err := services.GetGlobalClaudeCodeService()
+	require.NoError(t, err)
+	session, err := service.GetActiveSession()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"./src", "./tests"}, session.Directories)
+	assert.Equal(t, "plan", session.PermissionMode)
+	assert.True(t, session.Verbose)
+
+	// Every \cc-init starts a new session and activates it
+	require.NoError(t, cmd.Execute(map[string]string{}, ""))
+	assert.Equal(t, "cc-session-2", variable(t, "#cc_session_id"))
+	assert.Equal(t, "", variable(t, "#cc_model"))
+
+	assert.ErrorContains(t, cmd.Execute(map[string]string{"permission_mode": "auto"}, ""), "invalid permission mode")
+}
+
+func TestInitCommand_ClaudeNotInstalled(t *testing.T) {
+	setupClaudeCodeTest(t)
+	t.Setenv("PATH", t.TempDir())
+
+	err := (&InitCommand{}).Execute(map[string]string{}, "")
+	assert.ErrorContains(t, err, "claude code CLI not found")
+	_, err = services.GetGlobalClaudeCodeService()
+	assert.Error(t, err, "the service is only registered once the CLI is found")
+}
@@ -0,0 +1,169 @@
+package claudecode
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/commands/printing"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// jobInputPreview is how many characters of a job's message \cc-jobs shows.
+const jobInputPreview = 50
+
+// JobsCommand implements the \cc-jobs command for listing Claude Code jobs.
+type JobsCommand struct{}
+
+// Name returns the command name "cc-jobs" for registration and lookup.
+func (c *JobsCommand) Name() string {
+	return "cc-jobs"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *JobsCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the cc-jobs command does.
+func (c *JobsCommand) Description() string {
+	return "List Claude Code jobs"
+}
+
+// Usage returns the syntax and usage examples for the cc-jobs command.
+func (c *JobsCommand) Usage() string {
+	return `\cc-jobs[status=status, session=id]
+
+Examples:
+  \cc-jobs                                  %% All jobs, oldest first
+  \cc-jobs[status=running]                  %% Only jobs still running
+  \cc-jobs[session=${#cc_session_id}]       %% Jobs of the active session
+
+Options:
+  status  - pending, running, completed, failed, cancelled or timeout (default: all)
+  session - Claude Code session ID (default: all sessions)
+
+Note: The listed jobs are stored in ${_cc_active_jobs} as a JSON array, and the number of
+      jobs still pending or running in ${#cc_active_jobs}.`
+}
+
+// HelpInfo returns structured help information for the cc-jobs command.
+func (c *JobsCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       "\\cc-jobs[status=status, session=id]",
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "status",
+				Description: "Only list jobs with this status",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "session",
+				Description: "Only list jobs of this Claude Code session",
+				Required:    false,
+				Type:        "string",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\cc-jobs",
+				Description: "List all jobs",
+			},
+			{
+				Command:     "\\cc-jobs[status=running]",
+				Description: "List the jobs still running",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "_cc_active_jobs",
+				Description: "JSON array of the listed jobs",
+				Type:        "command_output",
+				Example:     `[{"id":"cc-job-1","session_id":"cc-session-1","status":"running",...}]`,
+			},
+			{
+				Name:        "#cc_active_jobs",
+				Description: "Number of jobs still pending or running",
+				Type:        "system_metadata",
+				Example:     "1",
+			},
+		},
+		Notes: []string{
+			"Jobs are listed in the order they were submitted",
+		},
+	}
+}
+
+// Execute lists the jobs matching the filters.
+// Options:
+//   - status: job status filter (optional)
+//   - session: session ID filter (optional)
+func (c *JobsCommand) Execute(args map[string]string, _ string) error {
+	status := services.ClaudeCodeJobStatus(strings.TrimSpace(args["status"]))
+	switch status {
+	case "", services.JobStatusPending, services.JobStatusRunning, services.JobStatusCompleted,
+		services.JobStatusFailed, services.JobStatusCancelled, services.JobStatusTimeout:
+	default:
+		return fmt.Errorf("invalid status '%s': must be pending, running, completed, failed, cancelled or timeout", status)
+	}
+	sessionID := strings.TrimSpace(args["session"])
+
+	var jobs []*services.ClaudeCodeJobInfo
+	if service, err := services.GetGlobalClaudeCodeService(); err == nil {
+		jobs = service.ListJobs()
+	}
+
+	active := 0
+	listed := make([]*services.ClaudeCodeJobInfo, 0, len(jobs))
+	for _, job := range jobs {
+		if !job.Status.IsFinished() {
+			active++
+		}
+		if (status == "" || job.Status == status) && (sessionID == "" || job.SessionID == sessionID) {
+			listed = append(listed, job)
+		}
+	}
+
+	data, err := json.Marshal(listed)
+	if err != nil {
+		return fmt.Errorf("failed to encode jobs: %w", err)
+	}
+	if err := setVariables(map[string]string{
+		"_cc_active_jobs": string(data),
+		"#cc_active_jobs": strconv.Itoa(active),
+	}); err != nil {
+		return err
+	}
+
+	printer := printing.NewDefaultPrinter()
+	if len(listed) == 0 {
+		printer.Info("No Claude Code jobs")
+		return nil
+	}
+	for _, job := range listed {
+		input := strings.Join(strings.Fields(job.Input), " ")
+		if len(input) > jobInputPreview {
+			input = input[:jobInputPreview-3] + "..."
+		}
+		printer.Println(fmt.Sprintf("%-10s %-10s %-14s %s", job.ID, job.Status, job.SessionID, input))
+	}
+	return nil
+}
+
+// IsReadOnly returns false as the cc-jobs command modifies system state.
+func (c *JobsCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&JobsCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register cc-jobs command: %v", err))
+	}
+}
@@ -0,0 +1,90 @@
+package claudecode
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/internal/services"
+)
+
+func TestJobCommands_RunningJob(t *testing.T) {
+	setupClaudeCodeTest(t)
+
+	require.NoError(t, (&SendCommand{}).Execute(map[string]string{}, "slow task"))
+	service, err := services.GetGlobalClaudeCodeService()
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		output, _ := service.GetJobOutput("cc-job-1")
+		return output != ""
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, (&StatusCommand{}).Execute(map[string]string{}, ""))
+	assert.Equal(t, "running", variable(t, "_cc_job_status"))
+	assert.Equal(t, "0", variable(t, "_cc_job_progress"))
+	assert.Equal(t, "running", variable(t, "#cc_job_status"))
+
+	require.NoError(t, (&GetCommand{}).Execute(map[string]string{}, ""))
+	assert.Equal(t, "running", variable(t, "_cc_status"))
+	assert.Equal(t, "Working on it...", variable(t, "_cc_partial"))
+
+	require.NoError(t, (&StreamCommand{}).Execute(map[string]string{"follow": "false"}, ""))
+	assert.Equal(t, "Working on it...", variable(t, "_cc_stream"))
+
+	require.NoError(t, (&JobsCommand{}).Execute(map[string]string{"status": "running"}, ""))
+	assert.Equal(t, "1", variable(t, "#cc_active_jobs"))
+	var jobs []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(variable(t, "_cc_active_jobs")), &jobs))
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "cc-job-1", jobs[0]["id"])
+	assert.Equal(t, "slow task", jobs[0]["input"])
+
+	// Following the job prints the rest of its output
+	require.NoError(t, (&StreamCommand{}).Execute(map[string]string{"timeout": "5"}, ""))
+	assert.Equal(t, "Working on it...\n\nDone: slow task", variable(t, "_cc_stream"))
+	assert.Equal(t, "completed", variable(t, "#cc_job_status"))
+
+	require.NoError(t, (&GetCommand{}).Execute(map[string]string{"job": "cc-job-1"}, ""))
+	assert.Equal(t, "completed", variable(t, "_cc_status"))
+	assert.Equal(t, "", variable(t, "_cc_partial"))
+	assert.Equal(t, "Done: slow task", variable(t, "_cc_output"))
+
+	require.NoError(t, (&StatusCommand{}).Execute(map[string]string{}, ""))
+	assert.Equal(t, "100", variable(t, "_cc_job_progress"))
+}
+
+func TestJobsCommand_Filters(t *testing.T) {
+	setupClaudeCodeTest(t)
+	cmd := &JobsCommand{}
+
+	require.NoError(t, cmd.Execute(map[string]string{}, ""))
+	assert.Equal(t, "[]", variable(t, "_cc_active_jobs"))
+	assert.Equal(t, "0", variable(t, "#cc_active_jobs"))
+
+	send := &SendCommand{}
+	require.NoError(t, send.Execute(map[string]string{"wait": "true"}, "first"))
+	require.NoError(t, (&InitCommand{}).Execute(map[string]string{}, ""))
+	require.Error(t, send.Execute(map[string]string{"wait": "true"}, "fail in the second session"))
+
+	require.NoError(t, cmd.Execute(map[string]string{}, ""))
+	var jobs []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(variable(t, "_cc_active_jobs")), &jobs))
+	require.Len(t, jobs, 2)
+	assert.Equal(t, "cc-job-1", jobs[0]["id"])
+	assert.Equal(t, "Something went wrong", jobs[1]["error"])
+
+	require.NoError(t, cmd.Execute(map[string]string{"session": "cc-session-1"}, ""))
+	require.NoError(t, json.Unmarshal([]byte(variable(t, "_cc_active_jobs")), &jobs))
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "completed", jobs[0]["status"])
+
+	require.NoError(t, cmd.Execute(map[string]string{"status": "failed"}, ""))
+	require.NoError(t, json.Unmarshal([]byte(variable(t, "_cc_active_jobs")), &jobs))
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "cc-session-2", jobs[0]["session_id"])
+
+	assert.ErrorContains(t, cmd.Execute(map[string]string{"status": "done"}, ""), "invalid status")
+}
@@ -0,0 +1,172 @@
+// Package claudecode provides commands that delegate coding tasks to the Claude Code CLI.
+// Every message runs as a background job; scripts wait for it or poll it with the other cc-* commands.
+package claudecode
+
+import (
+	"fmt"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/commands/printing"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+	"neuroshell/pkg/stringprocessing"
+)
+
+// SendCommand implements the \cc-send command for giving Claude Code a task.
+type SendCommand struct{}
+
+// Name returns the command name "cc-send" for registration and lookup.
+func (c *SendCommand) Name() string {
+	return "cc-send"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *SendCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the cc-send command does.
+func (c *SendCommand) Description() string {
+	return "Send a message to Claude Code as a background job"
+}
+
+// Usage returns the syntax and usage examples for the cc-send command.
+func (c *SendCommand) Usage() string {
+	return `\cc-send[wait=false, timeout=300, stream=false] message
+
+Examples:
+  \cc-send Add tests for the config parser          %% Start a job, continue right away
+  \cc-wait                                          %% ...and collect its response later
+  \cc-send[wait=true] Explain the auth flow         %% Block until the response is in ${_cc_output}
+  \cc-send[stream=true] Refactor the HTTP client    %% Show the response as it arrives
+
+Options:
+  wait    - Block until the job finishes (default: false)
+  timeout - Seconds to wait when blocking (default: 300)
+  stream  - Block and print messages as they arrive (default: false)
+
+Note: Messages continue the active session's Claude Code conversation; a session with the
+      default options is started when there is none. The job ID is stored in ${#cc_job_id}.`
+}
+
+// HelpInfo returns structured help information for the cc-send command.
+func (c *SendCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       "\\cc-send[wait=false, timeout=300, stream=false] message",
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "wait",
+				Description: "Block until the job finishes",
+				Required:    false,
+				Type:        "bool",
+				Default:     "false",
+			},
+			{
+				Name:        "timeout",
+				Description: "Seconds to wait when blocking",
+				Required:    false,
+				Type:        "int",
+				Default:     "300",
+			},
+			{
+				Name:        "stream",
+				Description: "Block and print messages as they arrive",
+				Required:    false,
+				Type:        "bool",
+				Default:     "false",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\cc-send Add tests for the config parser",
+				Description: "Start a job in the background",
+			},
+			{
+				Command:     "\\cc-send[wait=true] What files implement authentication?",
+				Description: "Block until the response is in ${_cc_output}",
+			},
+		},
+		StoredVariables: append([]neurotypes.HelpStoredVariable{
+			{
+				Name:        "#cc_job_id",
+				Description: "ID of the submitted job",
+				Type:        "system_metadata",
+				Example:     "cc-job-1",
+			},
+		}, jobResultVariableHelp()...),
+		Notes: []string{
+			"Without wait or stream the response variables are set by \\cc-wait or \\cc-get",
+			"Messages of a session continue the same Claude Code conversation",
+		},
+	}
+}
+
+// Execute submits the input as a message to the active Claude Code session.
+// Options:
+//   - wait: block until the job finishes (optional, default: false)
+//   - timeout: seconds to wait when blocking (optional, default: 300)
+//   - stream: block and print messages as they arrive (optional, default: false)
+func (c *SendCommand) Execute(args map[string]string, input string) error {
+	message := strings.TrimSpace(input)
+	if message == "" {
+		return fmt.Errorf("message is required. Usage: %s", c.HelpInfo().Usage)
+	}
+	timeout, err := parseTimeout(args["timeout"])
+	if err != nil {
+		return err
+	}
+
+	service, session, err := activeSession()
+	if err != nil {
+		return err
+	}
+
+	stream := stringprocessing.IsTruthy(args["stream"])
+	job, err := service.SubmitJob(session.ID, "message", message, services.JobOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to send message to claude code: %w", err)
+	}
+	if err := setVariables(map[string]string{
+		"#cc_job_id":     job.ID,
+		"#cc_job_status": string(services.JobStatusPending),
+	}); err != nil {
+		return err
+	}
+
+	switch {
+	case stream:
+		info, err := followJob(service, job.ID, true, timeout)
+		if err != nil {
+			return err
+		}
+		return storeJobResult(info, false)
+	case stringprocessing.IsTruthy(args["wait"]):
+		if err := service.WaitForJob(job.ID, timeout); err != nil {
+			return fmt.Errorf("%w; it keeps running, wait for it with \\cc-wait", err)
+		}
+		info, err := service.GetJobInfo(job.ID)
+		if err != nil {
+			return err
+		}
+		return storeJobResult(info, true)
+	}
+
+	printer := printing.NewDefaultPrinter()
+	printer.Info(fmt.Sprintf("Started Claude Code job %s", job.ID))
+	return nil
+}
+
+// IsReadOnly returns false as the cc-send command modifies system state.
+func (c *SendCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&SendCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register cc-send command: %v", err))
+	}
+}
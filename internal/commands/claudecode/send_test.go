@@ -0,0 +1,77 @@
+package claudecode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+func TestSendCommand_BasicProperties(t *testing.T) {
+	cmd := &SendCommand{}
+	assert.Equal(t, "cc-send", cmd.Name())
+	assert.Equal(t, neurotypes.ParseModeKeyValue, cmd.ParseMode())
+	assert.NotEmpty(t, cmd.Description())
+	assert.Contains(t, cmd.Usage(), "\\cc-send")
+	assert.Equal(t, cmd.Name(), cmd.HelpInfo().Command)
+}
+
+func TestSendCommand_AsyncThenWait(t *testing.T) {
+	setupClaudeCodeTest(t)
+
+	// Sending without \cc-init starts a default session
+	require.NoError(t, (&SendCommand{}).Execute(map[string]string{}, "edit the app"))
+	assert.Equal(t, "cc-session-1", variable(t, "#cc_session_id"))
+	assert.Equal(t, "cc-job-1", variable(t, "#cc_job_id"))
+
+	require.NoError(t, (&WaitCommand{}).Execute(map[string]string{"timeout": "5"}, ""))
+	assert.Equal(t, "Updated src/app.go.", variable(t, "_cc_output"))
+	assert.Equal(t, `["Read","Edit"]`, variable(t, "_cc_tools_used"))
+	assert.Equal(t, `["src/app.go"]`, variable(t, "_cc_files_modified"))
+	assert.Equal(t, "", variable(t, "_cc_error"))
+	assert.Equal(t, "completed", variable(t, "#cc_job_status"))
+}
+
+func TestSendCommand_Wait(t *testing.T) {
+	setupClaudeCodeTest(t)
+	cmd := &SendCommand{}
+
+	require.NoError(t, cmd.Execute(map[string]string{"wait": "true"}, "hello there"))
+	assert.Equal(t, "Echo: hello there", variable(t, "_cc_output"))
+
+	// The next message continues the same Claude Code conversation
+	require.NoError(t, cmd.Execute(map[string]string{"stream": "true"}, "and again"))
+	assert.Equal(t, "Echo: and again (resumed)", variable(t, "_cc_output"))
+	assert.Equal(t, "Echo: and again (resumed)", variable(t, "_cc_stream"))
+	assert.Equal(t, "cc-job-2", variable(t, "#cc_job_id"))
+
+	err := cmd.Execute(map[string]string{"wait": "true"}, "fail please")
+	assert.ErrorContains(t, err, "claude code job cc-job-3 failed: Something went wrong")
+	assert.Equal(t, "Something went wrong", variable(t, "_cc_error"))
+	assert.Equal(t, "failed", variable(t, "#cc_job_status"))
+
+	assert.ErrorContains(t, cmd.Execute(map[string]string{}, "  "), "message is required")
+	assert.ErrorContains(t, cmd.Execute(map[string]string{"timeout": "soon"}, "hi"), "invalid timeout")
+}
+
+func TestWaitCommand_Errors(t *testing.T) {
+	setupClaudeCodeTest(t)
+
+	assert.ErrorContains(t, (&WaitCommand{}).Execute(map[string]string{}, ""), "start it with \\cc-init")
+
+	require.NoError(t, (&InitCommand{}).Execute(map[string]string{}, ""))
+	assert.ErrorContains(t, (&WaitCommand{}).Execute(map[string]string{}, ""), "no claude code job submitted yet")
+	assert.ErrorContains(t, (&WaitCommand{}).Execute(map[string]string{"job": "cc-job-9"}, ""), "not found")
+
+	require.NoError(t, (&SendCommand{}).Execute(map[string]string{}, "slow task"))
+	err := (&WaitCommand{}).Execute(map[string]string{"timeout": "1"}, "")
+	assert.ErrorContains(t, err, "it keeps running")
+
+	service, err := services.GetGlobalClaudeCodeService()
+	require.NoError(t, err)
+	require.NoError(t, service.CancelJob(variable(t, "#cc_job_id")))
+	assert.ErrorContains(t, (&WaitCommand{}).Execute(map[string]string{}, ""), "cancelled")
+}
@@ -0,0 +1,144 @@
+package claudecode
+
+import (
+	"fmt"
+	"strconv"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/commands/printing"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// StatusCommand implements the \cc-status command for checking on a Claude Code job.
+type StatusCommand struct{}
+
+// Name returns the command name "cc-status" for registration and lookup.
+func (c *StatusCommand) Name() string {
+	return "cc-status"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *StatusCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the cc-status command does.
+func (c *StatusCommand) Description() string {
+	return "Show the status of a Claude Code job"
+}
+
+// Usage returns the syntax and usage examples for the cc-status command.
+func (c *StatusCommand) Usage() string {
+	return `\cc-status[job=id]
+
+Examples:
+  \cc-status                          %% Status of the last submitted job
+  \cc-status[job=${review}]           %% Status of a specific job
+
+Options:
+  job - Job ID (default: ${#cc_job_id}, the last submitted job)
+
+Note: Statuses are pending, running, completed, failed, cancelled and timeout. Progress is
+      100 once the job has completed.`
+}
+
+// HelpInfo returns structured help information for the cc-status command.
+func (c *StatusCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       "\\cc-status[job=id]",
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "job",
+				Description: "Job ID, the last submitted job by default",
+				Required:    false,
+				Type:        "string",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\cc-status",
+				Description: "Status of the last submitted job",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "_cc_job_status",
+				Description: "pending, running, completed, failed, cancelled or timeout",
+				Type:        "command_output",
+				Example:     "running",
+			},
+			{
+				Name:        "_cc_job_progress",
+				Description: "Progress percentage",
+				Type:        "command_output",
+				Example:     "100",
+			},
+			{
+				Name:        "#cc_job_status",
+				Description: "Same as ${_cc_job_status}",
+				Type:        "system_metadata",
+				Example:     "running",
+			},
+			{
+				Name:        "#cc_job_progress",
+				Description: "Same as ${_cc_job_progress}",
+				Type:        "system_metadata",
+				Example:     "100",
+			},
+		},
+		Notes: []string{
+			"Checking a job never blocks; use \\cc-wait to block",
+		},
+	}
+}
+
+// Execute reports the status of a job.
+// Options:
+//   - job: job ID (optional, default: ${#cc_job_id})
+func (c *StatusCommand) Execute(args map[string]string, _ string) error {
+	service, err := services.GetGlobalClaudeCodeService()
+	if err != nil {
+		return fmt.Errorf("claude code is not running, start it with \\cc-init: %w", err)
+	}
+
+	jobID, err := resolveJobID(args)
+	if err != nil {
+		return err
+	}
+	info, err := service.GetJobInfo(jobID)
+	if err != nil {
+		return err
+	}
+
+	progress := strconv.FormatFloat(info.Progress, 'f', -1, 64)
+	if err := setVariables(map[string]string{
+		"_cc_job_status":   string(info.Status),
+		"_cc_job_progress": progress,
+		"#cc_job_status":   string(info.Status),
+		"#cc_job_progress": progress,
+	}); err != nil {
+		return err
+	}
+
+	printer := printing.NewDefaultPrinter()
+	printer.Pair(info.ID, fmt.Sprintf("%s (%s%%)", info.Status, progress))
+	if info.Error != "" {
+		printer.Error(info.Error)
+	}
+	return nil
+}
+
+// IsReadOnly returns false as the cc-status command modifies system state.
+func (c *StatusCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&StatusCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register cc-status command: %v", err))
+	}
+}
@@ -0,0 +1,186 @@
+package claudecode
+
+import (
+	"fmt"
+	"time"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/commands/printing"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+	"neuroshell/pkg/stringprocessing"
+)
+
+// streamPollInterval is how often a followed job is checked for new output.
+const streamPollInterval = 50 * time.Millisecond
+
+// StreamCommand implements the \cc-stream command for watching a Claude Code job's output.
+type StreamCommand struct{}
+
+// Name returns the command name "cc-stream" for registration and lookup.
+func (c *StreamCommand) Name() string {
+	return "cc-stream"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *StreamCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the cc-stream command does.
+func (c *StreamCommand) Description() string {
+	return "Show the output of a Claude Code job as it arrives"
+}
+
+// Usage returns the syntax and usage examples for the cc-stream command.
+func (c *StreamCommand) Usage() string {
+	return `\cc-stream[job=id, follow=true, timeout=300]
+
+Examples:
+  \cc-stream                          %% Follow the last submitted job until it finishes
+  \cc-stream[follow=false]            %% Show what the job has produced so far
+  \cc-stream[job=${review}]           %% Follow a specific job
+
+Options:
+  job     - Job ID (default: ${#cc_job_id}, the last submitted job)
+  follow  - Keep printing new output until the job finishes (default: true)
+  timeout - Seconds to follow before giving up (default: 300)
+
+Note: The output shows each message of the job; with \cc-init[verbose=true] it also shows
+      the tools Claude Code calls. Use \cc-wait for the final response.`
+}
+
+// HelpInfo returns structured help information for the cc-stream command.
+func (c *StreamCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       "\\cc-stream[job=id, follow=true, timeout=300]",
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "job",
+				Description: "Job ID, the last submitted job by default",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "follow",
+				Description: "Keep printing new output until the job finishes",
+				Required:    false,
+				Type:        "bool",
+				Default:     "true",
+			},
+			{
+				Name:        "timeout",
+				Description: "Seconds to follow before giving up",
+				Required:    false,
+				Type:        "int",
+				Default:     "300",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\cc-stream",
+				Description: "Follow the last submitted job",
+			},
+			{
+				Command:     "\\cc-stream[follow=false]",
+				Description: "Show the output so far",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "_cc_stream",
+				Description: "Output of the job shown so far",
+				Type:        "command_output",
+				Example:     "I'll look at the parser first.",
+			},
+			{
+				Name:        "#cc_job_status",
+				Description: "Job status when the command returned",
+				Type:        "system_metadata",
+				Example:     "completed",
+			},
+		},
+		Notes: []string{
+			"Stopping to follow does not stop the job",
+		},
+	}
+}
+
+// Execute prints a job's output, following it until it finishes unless follow=false.
+// Options:
+//   - job: job ID (optional, default: ${#cc_job_id})
+//   - follow: keep printing until the job finishes (optional, default: true)
+//   - timeout: seconds to follow (optional, default: 300)
+func (c *StreamCommand) Execute(args map[string]string, _ string) error {
+	service, err := services.GetGlobalClaudeCodeService()
+	if err != nil {
+		return fmt.Errorf("claude code is not running, start it with \\cc-init: %w", err)
+	}
+
+	jobID, err := resolveJobID(args)
+	if err != nil {
+		return err
+	}
+	timeout, err := parseTimeout(args["timeout"])
+	if err != nil {
+		return err
+	}
+	follow := true
+	if value, exists := args["follow"]; exists {
+		follow = stringprocessing.IsTruthy(value)
+	}
+
+	_, err = followJob(service, jobID, follow, timeout)
+	return err
+}
+
+// IsReadOnly returns false as the cc-stream command modifies system state.
+func (c *StreamCommand) IsReadOnly() bool {
+	return false
+}
+
+// followJob prints a job's output and, when follow is set, keeps printing what arrives until
+// the job finishes. It stores the printed output in _cc_stream and returns the job's last state.
+func followJob(service *services.ClaudeCodeService, jobID string, follow bool, timeout time.Duration) (*services.ClaudeCodeJobInfo, error) {
+	printer := printing.NewDefaultPrinter()
+	deadline := time.Now().Add(timeout)
+	printed := 0
+
+	for {
+		info, err := service.GetJobInfo(jobID)
+		if err != nil {
+			return nil, err
+		}
+		if len(info.Output) > printed {
+			printer.Print(info.Output[printed:])
+			printed = len(info.Output)
+		}
+
+		finished := info.Status.IsFinished()
+		if finished || !follow || time.Now().After(deadline) {
+			if printed > 0 {
+				printer.Println("")
+			}
+			if err := setVariables(map[string]string{
+				"_cc_stream":     info.Output,
+				"#cc_job_status": string(info.Status),
+			}); err != nil {
+				return nil, err
+			}
+			if follow && !finished {
+				return nil, fmt.Errorf("job %s still running after %v; it keeps running, check it with \\cc-status", jobID, timeout)
+			}
+			return info, nil
+		}
+		time.Sleep(streamPollInterval)
+	}
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&StreamCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register cc-stream command: %v", err))
+	}
+}
@@ -0,0 +1,226 @@
+package claudecode
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/commands/printing"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// defaultWaitTimeout is how long \cc-wait and \cc-send[wait=true] wait for a job, in seconds.
+const defaultWaitTimeout = 300
+
+// WaitCommand implements the \cc-wait command for blocking until a Claude Code job finishes.
+type WaitCommand struct{}
+
+// Name returns the command name "cc-wait" for registration and lookup.
+func (c *WaitCommand) Name() string {
+	return "cc-wait"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *WaitCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the cc-wait command does.
+func (c *WaitCommand) Description() string {
+	return "Wait for a Claude Code job and store its response"
+}
+
+// Usage returns the syntax and usage examples for the cc-wait command.
+func (c *WaitCommand) Usage() string {
+	return `\cc-wait[job=id, timeout=300]
+
+Examples:
+  \cc-wait                                 %% Wait for the last submitted job
+  \cc-wait[job=${review}, timeout=60]      %% Wait at most a minute for a specific job
+
+Options:
+  job     - Job ID (default: ${#cc_job_id}, the last submitted job)
+  timeout - Seconds to wait before giving up (default: 300)
+
+Note: A job that is still running when the timeout expires keeps running; wait again or
+      check it with \cc-status. Failed jobs are reported as errors, with the message in
+      ${_cc_error}.`
+}
+
+// HelpInfo returns structured help information for the cc-wait command.
+func (c *WaitCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       "\\cc-wait[job=id, timeout=300]",
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "job",
+				Description: "Job ID, the last submitted job by default",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "timeout",
+				Description: "Seconds to wait before giving up",
+				Required:    false,
+				Type:        "int",
+				Default:     "300",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\cc-wait",
+				Description: "Wait for the last submitted job",
+			},
+			{
+				Command:     "\\cc-wait[job=cc-job-2, timeout=60]",
+				Description: "Wait at most a minute for a specific job",
+			},
+		},
+		StoredVariables: jobResultVariableHelp(),
+		Notes: []string{
+			"A job still running at the timeout keeps running",
+			"Failed, cancelled and timed out jobs are reported as errors",
+		},
+	}
+}
+
+// Execute waits for a job and stores its response.
+// Options:
+//   - job: job ID (optional, default: ${#cc_job_id})
+//   - timeout: seconds to wait (optional, default: 300)
+func (c *WaitCommand) Execute(args map[string]string, _ string) error {
+	service, err := services.GetGlobalClaudeCodeService()
+	if err != nil {
+		return fmt.Errorf("claude code is not running, start it with \\cc-init: %w", err)
+	}
+
+	jobID, err := resolveJobID(args)
+	if err != nil {
+		return err
+	}
+	timeout, err := parseTimeout(args["timeout"])
+	if err != nil {
+		return err
+	}
+
+	if err := service.WaitForJob(jobID, timeout); err != nil {
+		return fmt.Errorf("%w; it keeps running, check it with \\cc-status", err)
+	}
+	info, err := service.GetJobInfo(jobID)
+	if err != nil {
+		return err
+	}
+	return storeJobResult(info, true)
+}
+
+// IsReadOnly returns false as the cc-wait command modifies system state.
+func (c *WaitCommand) IsReadOnly() bool {
+	return false
+}
+
+// resolveJobID returns the job option, or the last submitted job.
+func resolveJobID(args map[string]string) (string, error) {
+	if jobID := strings.TrimSpace(args["job"]); jobID != "" {
+		return jobID, nil
+	}
+	variableService, err := services.GetGlobalVariableService()
+	if err != nil {
+		return "", fmt.Errorf("variable service not available: %w", err)
+	}
+	if jobID, err := variableService.Get("#cc_job_id"); err == nil && jobID != "" {
+		return jobID, nil
+	}
+	return "", fmt.Errorf("no claude code job submitted yet, send one with \\cc-send")
+}
+
+// parseTimeout parses a timeout in seconds, defaulting to defaultWaitTimeout.
+func parseTimeout(value string) (time.Duration, error) {
+	if value = strings.TrimSpace(value); value == "" {
+		return defaultWaitTimeout * time.Second, nil
+	}
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 1 {
+		return 0, fmt.Errorf("invalid timeout '%s': must be a positive number of seconds", value)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// storeJobResult stores the response of a finished job in the _cc_* variables and prints it
+// when show is set. Jobs that did not complete are returned as errors.
+func storeJobResult(info *services.ClaudeCodeJobInfo, show bool) error {
+	toolsUsed, err := json.Marshal(info.ToolsUsed)
+	if err != nil {
+		return err
+	}
+	filesModified, err := json.Marshal(info.FilesModified)
+	if err != nil {
+		return err
+	}
+
+	if err := setVariables(map[string]string{
+		"#cc_job_status":     string(info.Status),
+		"_cc_output":         info.Response(),
+		"_cc_error":          info.Error,
+		"_cc_tools_used":     string(toolsUsed),
+		"_cc_files_modified": string(filesModified),
+	}); err != nil {
+		return err
+	}
+
+	if info.Status != services.JobStatusCompleted {
+		return fmt.Errorf("claude code job %s %s: %s", info.ID, info.Status, info.Error)
+	}
+	if show {
+		printing.NewDefaultPrinter().Println(info.Response())
+	}
+	return nil
+}
+
+// jobResultVariableHelp documents the variables set by storeJobResult.
+func jobResultVariableHelp() []neurotypes.HelpStoredVariable {
+	return []neurotypes.HelpStoredVariable{
+		{
+			Name:        "_cc_output",
+			Description: "Final response of the job",
+			Type:        "command_output",
+			Example:     "Added error handling to parseConfig.",
+		},
+		{
+			Name:        "_cc_error",
+			Description: "Error message when the job did not complete",
+			Type:        "command_output",
+			Example:     "Something went wrong",
+		},
+		{
+			Name:        "_cc_tools_used",
+			Description: "JSON array of the tools Claude Code used",
+			Type:        "command_output",
+			Example:     `["Read","Edit"]`,
+		},
+		{
+			Name:        "_cc_files_modified",
+			Description: "JSON array of the files edited or written",
+			Type:        "command_output",
+			Example:     `["src/config.go"]`,
+		},
+		{
+			Name:        "#cc_job_status",
+			Description: "Final job status",
+			Type:        "system_metadata",
+			Example:     "completed",
+		},
+	}
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&WaitCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register cc-wait command: %v", err))
+	}
+}
@@ -0,0 +1,187 @@
+package llm
+
+import (
+	"fmt"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// BudgetCommand implements the \budget command for setting and showing spending caps.
+// Caps are persisted in the user config directory and checked before every LLM request.
+type BudgetCommand struct{}
+
+// Name returns the command name "budget" for registration and lookup.
+func (c *BudgetCommand) Name() string {
+	return "budget"
+}
+
+// ParseMode returns ParseModeKeyValue for bracket parameter parsing.
+func (c *BudgetCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the budget command does.
+func (c *BudgetCommand) Description() string {
+	return "Set or show the session and daily spending caps for LLM calls"
+}
+
+// Usage returns the syntax and usage examples for the budget command.
+func (c *BudgetCommand) Usage() string {
+	return `\budget[session=amount, daily=amount]
+
+Examples:
+  \budget                         %% Show the caps and today's spend
+  \budget[session=0.50]           %% Cap each chat session at $0.50
+  \budget[daily=$5]               %% Cap the spend of all shells per day at $5
+  \budget[session=none]           %% Remove the session cap
+
+Options:
+  session - Cap in USD on the cost of one chat session ("none" or 0 removes it)
+  daily   - Cap in USD on the spend of all shells today ("none" or 0 removes it)
+
+Notes:
+  - Caps are saved in budget_caps.json in the user config directory, next to the
+    daily ledger budget.json, and apply to every shell and batch run
+  - Calls that would exceed a cap fail with error type budget_exceeded before any request is sent
+  - The check assumes the whole output limit is used: the model's max_tokens, else the
+    catalog max_output_tokens, else 4096 tokens
+  - While a cap is set, models without catalog pricing are blocked unless served locally`
+}
+
+// HelpInfo returns structured help information for the budget command.
+func (c *BudgetCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       `\budget[session=amount, daily=amount]`,
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "session",
+				Description: "Cap in USD on the cost of one chat session (\"none\" or 0 removes it)",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "daily",
+				Description: "Cap in USD on the spend of all shells today (\"none\" or 0 removes it)",
+				Required:    false,
+				Type:        "string",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     `\budget`,
+				Description: "Show the caps and today's spend",
+			},
+			{
+				Command:     `\budget[session=0.50, daily=5]`,
+				Description: "Cap each session at $0.50 and each day at $5",
+			},
+			{
+				Command:     `\budget[daily=none]`,
+				Description: "Remove the daily cap",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "#budget_session_usd",
+				Description: "Session cap in USD (0 when unset)",
+				Type:        "system_metadata",
+				Example:     "0.500000",
+			},
+			{
+				Name:        "#budget_daily_usd",
+				Description: "Daily cap in USD (0 when unset)",
+				Type:        "system_metadata",
+				Example:     "5.000000",
+			},
+			{
+				Name:        "#budget_spent_today_usd",
+				Description: "Spend of all shells today in USD",
+				Type:        "system_metadata",
+				Example:     "0.004210",
+			},
+			{
+				Name:        "_output",
+				Description: "Formatted budget report",
+				Type:        "command_output",
+				Example:     "Budget: session cap $0.500000, daily cap $5.000000, spent today $0.004210",
+			},
+		},
+		Notes: []string{
+			"Caps are saved in budget_caps.json in the user config directory and apply to every shell",
+			"Calls that would exceed a cap fail with error type budget_exceeded",
+			"The check assumes the model's max_tokens, else the catalog max_output_tokens, else 4096 output tokens",
+			"While a cap is set, models without catalog pricing are blocked unless served locally",
+		},
+	}
+}
+
+// Execute updates the caps given as options and reports the current caps and today's spend.
+func (c *BudgetCommand) Execute(args map[string]string, _ string) error {
+	budgetService, err := services.GetGlobalBudgetService()
+	if err != nil {
+		return fmt.Errorf("budget service not available: %w", err)
+	}
+
+	variableService, err := services.GetGlobalVariableService()
+	if err != nil {
+		return fmt.Errorf("variable service not available: %w", err)
+	}
+
+	caps, err := budgetService.GetCaps()
+	if err != nil {
+		return err
+	}
+
+	sessionValue, hasSession := args["session"]
+	dailyValue, hasDaily := args["daily"]
+	if hasSession || hasDaily {
+		if hasSession {
+			if caps.SessionUSD, err = services.ParseBudgetAmount(sessionValue); err != nil {
+				return fmt.Errorf("invalid session cap: %w", err)
+			}
+		}
+		if hasDaily {
+			if caps.DailyUSD, err = services.ParseBudgetAmount(dailyValue); err != nil {
+				return fmt.Errorf("invalid daily cap: %w", err)
+			}
+		}
+		if err := budgetService.SetCaps(caps); err != nil {
+			return err
+		}
+	}
+
+	spent := budgetService.GetDailySpend()
+	_ = variableService.SetSystemVariable("#budget_session_usd", fmt.Sprintf("%.6f", caps.SessionUSD))
+	_ = variableService.SetSystemVariable("#budget_daily_usd", fmt.Sprintf("%.6f", caps.DailyUSD))
+	_ = variableService.SetSystemVariable("#budget_spent_today_usd", fmt.Sprintf("%.6f", spent))
+
+	output := fmt.Sprintf("Budget: session cap %s, daily cap %s, spent today $%.6f",
+		formatBudgetCap(caps.SessionUSD), formatBudgetCap(caps.DailyUSD), spent)
+	_ = variableService.SetSystemVariable("_output", output)
+	fmt.Println(output)
+	return nil
+}
+
+// formatBudgetCap renders a cap in USD, or "none" when unset.
+func formatBudgetCap(amount float64) string {
+	if amount == 0 {
+		return "none"
+	}
+	return fmt.Sprintf("$%.6f", amount)
+}
+
+// IsReadOnly returns false as the budget command modifies the persisted caps.
+func (c *BudgetCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&BudgetCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register budget command: %v", err))
+	}
+}
@@ -0,0 +1,77 @@
+package llm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/internal/context"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// setupBudgetTestRegistry creates a registry with the services the budget command reads and writes.
+func setupBudgetTestRegistry(t *testing.T) *services.BudgetService {
+	ctx := context.New()
+	ctx.SetTestMode(true)
+
+	budgetService := services.NewBudgetService()
+	registry := services.NewRegistry()
+	require.NoError(t, registry.RegisterService(services.NewVariableService()))
+	require.NoError(t, registry.RegisterService(budgetService))
+	require.NoError(t, registry.InitializeAll())
+
+	oldRegistry := services.GetGlobalRegistry()
+	services.SetGlobalRegistry(registry)
+	oldCtx := context.GetGlobalContext()
+	context.SetGlobalContext(ctx)
+
+	t.Cleanup(func() {
+		services.SetGlobalRegistry(oldRegistry)
+		context.SetGlobalContext(oldCtx)
+	})
+	return budgetService
+}
+
+func TestBudgetCommand_BasicProperties(t *testing.T) {
+	cmd := &BudgetCommand{}
+	assert.Equal(t, "budget", cmd.Name())
+	assert.Equal(t, neurotypes.ParseModeKeyValue, cmd.ParseMode())
+	assert.NotEmpty(t, cmd.Description())
+	assert.Contains(t, cmd.Usage(), "\\budget")
+	assert.False(t, cmd.IsReadOnly())
+	assert.Equal(t, cmd.Name(), cmd.HelpInfo().Command)
+}
+
+func TestBudgetCommand_Execute(t *testing.T) {
+	budgetService := setupBudgetTestRegistry(t)
+	variableService, _ := services.GetGlobalVariableService()
+	cmd := &BudgetCommand{}
+
+	require.NoError(t, cmd.Execute(map[string]string{}, ""))
+	output, _ := variableService.Get("_output")
+	assert.Equal(t, "Budget: session cap none, daily cap none, spent today $0.000000", output)
+
+	require.NoError(t, cmd.Execute(map[string]string{"session": "0.5", "daily": "$5"}, ""))
+	caps, err := budgetService.GetCaps()
+	require.NoError(t, err)
+	assert.Equal(t, services.BudgetCaps{SessionUSD: 0.5, DailyUSD: 5}, caps)
+	sessionCap, _ := variableService.Get("#budget_session_usd")
+	assert.Equal(t, "0.500000", sessionCap)
+
+	// Options that are not given keep their cap
+	require.NoError(t, budgetService.RecordSpend(0.25))
+	require.NoError(t, cmd.Execute(map[string]string{"session": "none"}, ""))
+	caps, _ = budgetService.GetCaps()
+	assert.Equal(t, services.BudgetCaps{DailyUSD: 5}, caps)
+	spent, _ := variableService.Get("#budget_spent_today_usd")
+	assert.Equal(t, "0.250000", spent)
+
+	// Invalid amounts leave the caps unchanged
+	err = cmd.Execute(map[string]string{"daily": "lots"}, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid daily cap")
+	caps, _ = budgetService.GetCaps()
+	assert.Equal(t, services.BudgetCaps{DailyUSD: 5}, caps)
+}
@@ -2,18 +2,25 @@
 package llm
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"neuroshell/internal/commands"
 	"neuroshell/internal/services"
 	"neuroshell/pkg/neurotypes"
+	"neuroshell/pkg/stringprocessing"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/muesli/termenv"
 )
 
+// defaultToolMaxRounds is the default limit on tool calling rounds within a single \llm-call.
+const defaultToolMaxRounds = 10
+
 // CallCommand implements the \llm-call command for orchestrating LLM API calls.
 // It provides pure service orchestration without message manipulation.
 type CallCommand struct{}
@@ -35,19 +42,29 @@ func (c *CallCommand) Description() string {
 
 // Usage returns the syntax and usage examples for the llm-call command.
 func (c *CallCommand) Usage() string {
-	return `\llm-call[client_id=client_id, model_id=model_id, session_id=session_id, dry_run=false]
+	return `\llm-call[client_id=client_id, model_id=model_id, session_id=session_id, stream=false, tools=true, dry_run=false]
 
 Examples:
   \llm-call                                                %% Use defaults (active model, active session, cached client)
   \llm-call[client_id=${_client_id}, model_id=my-gpt4]     %% Explicit client and model
   \llm-call[session_id=work-session]                       %% Use specific session
   \llm-call[dry_run=true]                                  %% Show what would be sent without API call
+  \llm-call[stream=true]                                   %% Render the response as it is generated
+  \llm-call[tools=false]                                   %% Do not offer defined tools to the model
+  \llm-call[schema=person.json]                            %% Answer with JSON matching a schema
+  \llm-call[regenerate=true, temperature=1.0]              %% Answer the last user turn again
   \llm-call[client_id=OAR:a1b2c3d4, model_id=creative-gpt4, session_id=creative-work]
 
 Options:
   client_id     - LLM client ID (defaults to ${_client_id})
   model_id      - Model configuration ID (defaults to active model)
   session_id    - Session ID (defaults to active session)
+  stream        - Render response deltas live (defaults to ${_stream}, false if unset)
+  tools         - Offer tools defined with \tool-define to the model (default: true)
+  tool_loop     - Continue a tool loop (set internally when the model requests tools)
+  schema        - JSON schema file the response must conform to (structured output)
+  regenerate    - Leave the session's last assistant response out of the request (default: false)
+  temperature   - Temperature for this call only (overrides the model's temperature)
   dry_run       - Show API payload without making call (default: false)
 
 Notes:
@@ -56,7 +73,30 @@ Notes:
   - Response stored in ${_output} and ${#llm_response} variables
   - Network debug data always available in ${_debug_network}
   - Use \session-add-assistantmsg to add response to session
-  - Only HTTP/HTTPS communication is supported (streaming has been removed)`
+  - Streamed calls still fill ${#llm_text_content} and ${#llm_thinking_blocks_rendered}
+  - ${#llm_call_streamed} is "true" when the response was already rendered live
+  - With tools defined, requested tool scripts run via \tool-call and the model is called again
+    until it answers (at most ${_tool_max_rounds} rounds, default 10); tool rounds are not streamed,
+    so stream=true or ${_stream} fails while tools are offered (use tools=false to stream)
+  - Token usage is stored in ${#llm_input_tokens}, ${#llm_output_tokens} and ${#llm_cost_usd};
+    running totals per session and model are shown by \usage
+  - Calls that would exceed a spending cap set with \budget fail with
+    error type budget_exceeded before any request is sent
+  - Rate limit and server errors are retried with exponential backoff, honoring Retry-After;
+    set the count per model with max_retries or globally with ${_llm_retry} (default 2, "off" disables).
+    ${#llm_retry_count} and ${#llm_retry_wait} report the retries made and the total wait
+  - With schema, the provider's native structured output is used (no tools, no streaming) and the
+    response is validated locally; top-level fields are stored in ${#llm_json.<field>}, the document
+    in ${#llm_json} and the field names in ${#llm_json_fields}. Responses that do not match fail with
+    error type schema_violation
+  - Sessions that outgrow the model's context window (catalog context_window, or the model's
+    context_window parameter, minus room for output) are handled by the context policy: the session's,
+    else the model's context_policy, else ${_context_policy}. Policies are error, truncate-oldest,
+    keep-system-and-last-N and summarize-oldest (which asks the model to summarize early turns).
+    Only the request is changed; ${#llm_context_tokens}, ${#llm_context_sent_tokens},
+    ${#llm_context_dropped}, ${#llm_context_summarized} and ${#llm_context_summary} report the outcome
+  - ${#llm_model}, ${#llm_provider}, ${#llm_stop_reason}, ${#llm_request_id} and ${#llm_latency_ms}
+    describe the response; \session-add-assistantmsg stores them with the message it adds`
 }
 
 // HelpInfo returns structured help information for the llm-call command.
@@ -64,7 +104,7 @@ func (c *CallCommand) HelpInfo() neurotypes.HelpInfo {
 	return neurotypes.HelpInfo{
 		Command:     c.Name(),
 		Description: c.Description(),
-		Usage:       `\llm-call[client_id=client_id, model_id=model_id, session_id=session_id, dry_run=false]`,
+		Usage:       `\llm-call[client_id=client_id, model_id=model_id, session_id=session_id, stream=false, tools=true, dry_run=false]`,
 		ParseMode:   c.ParseMode(),
 		Options: []neurotypes.HelpOption{
 			{
@@ -88,6 +128,46 @@ func (c *CallCommand) HelpInfo() neurotypes.HelpInfo {
 				Type:        "string",
 				Default:     "active session",
 			},
+			{
+				Name:        "stream",
+				Description: "Render text and thinking deltas as they arrive",
+				Required:    false,
+				Type:        "boolean",
+				Default:     "${_stream}",
+			},
+			{
+				Name:        "tools",
+				Description: "Offer tools defined with \\tool-define to the model",
+				Required:    false,
+				Type:        "boolean",
+				Default:     "true",
+			},
+			{
+				Name:        "tool_loop",
+				Description: "Tool loop to continue (set internally while tools run)",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "schema",
+				Description: "JSON schema file the response must conform to",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "regenerate",
+				Description: "Leave the session's last assistant response out of the request",
+				Required:    false,
+				Type:        "boolean",
+				Default:     "false",
+			},
+			{
+				Name:        "temperature",
+				Description: "Temperature for this call only",
+				Required:    false,
+				Type:        "number",
+				Default:     "model temperature",
+			},
 			{
 				Name:        "dry_run",
 				Description: "Show API payload without making actual call",
@@ -109,6 +189,14 @@ func (c *CallCommand) HelpInfo() neurotypes.HelpInfo {
 				Command:     `\llm-call[dry_run=true]`,
 				Description: "Preview API payload without making call",
 			},
+			{
+				Command:     `\llm-call[schema=person.json]`,
+				Description: "Answer with a JSON document validated against person.json",
+			},
+			{
+				Command:     "\\set[_stream=true]\n\\llm-call",
+				Description: "Stream the response to the terminal as it is generated",
+			},
 		},
 		Notes: []string{
 			"Pure service orchestration - does not modify sessions",
@@ -118,7 +206,20 @@ func (c *CallCommand) HelpInfo() neurotypes.HelpInfo {
 			"Response stored in ${_output} for use with \\session-add-assistantmsg",
 			"Network debug data always available in ${_debug_network}",
 			"All parameters support variable interpolation",
-			"Only HTTP/HTTPS communication is supported (streaming removed)",
+			"Streaming renders deltas live and still stores the complete response in variables",
+			"Streaming cannot be combined with tool calling; pass tools=false to stream while tools are defined",
+			"Providers without streaming support fall back to a single blocking call",
+			"Defined tools are offered to models whose catalog entry allows function calling",
+			"Tool loops stop after ${_tool_max_rounds} rounds (default 10); ${#llm_tool_calls} counts the calls made",
+			"Token usage and cost (from catalog pricing) are added to the running totals shown by \\usage",
+			"Calls that would exceed a spending cap set with \\budget fail with error type budget_exceeded",
+			"Rate limit and server errors are retried with backoff (model max_retries, else ${_llm_retry}, default 2)",
+			"schema requests structured output; fields of the validated JSON are stored in ${#llm_json.<field>}",
+			"Responses that do not match the schema fail with error type schema_violation",
+			"Long sessions are fitted to the context window by the session's, model's or ${_context_policy} context policy",
+			"Sessions that cannot be fitted fail with error code context_window_exceeded; ${#llm_context_*} report what was dropped or summarized",
+			"regenerate=true answers the last user turn again; \\regen uses it to collect alternative responses",
+			"The model, provider, usage, stop reason, request ID, latency and thinking blocks are attached to the next assistant message added to the session",
 		},
 	}
 }
@@ -211,6 +312,18 @@ func (c *CallCommand) Execute(args map[string]string, input string) error {
 		return fmt.Errorf("failed to get session '%s': %w", sessionID, err)
 	}
 
+	// Per-call overrides work on copies; the stored session and model are not changed
+	if stringprocessing.IsTruthy(args["regenerate"]) {
+		if session, err = c.withoutLastResponse(session); err != nil {
+			return err
+		}
+	}
+	if temperature := strings.TrimSpace(args["temperature"]); temperature != "" {
+		if model, err = c.withTemperature(model, temperature); err != nil {
+			return err
+		}
+	}
+
 	// Validate session has messages before making LLM call
 	if len(session.Messages) == 0 {
 		// For dry run, show warnings but continue to display debug info
@@ -226,11 +339,436 @@ func (c *CallCommand) Execute(args map[string]string, input string) error {
 		return c.handleDryRun(client, model, session, variableService)
 	}
 
+	// A tool loop continuation sends the loop's tool exchange after the session's messages
+	if toolLoopID := args["tool_loop"]; toolLoopID != "" {
+		toolService, err := services.GetGlobalToolService()
+		if err != nil {
+			return fmt.Errorf("tool service not available: %w", err)
+		}
+		if session, err = toolService.BuildLoopSession(session, toolLoopID); err != nil {
+			return err
+		}
+	}
+
+	// Keep the request within the model's context window; the stored session is not changed
+	session, err = c.applyContextPolicy(llmService, client, session, model, variableService)
+	if err != nil {
+		return err
+	}
+
+	// Structured output is a single blocking request without tools
+	if schemaPath := strings.TrimSpace(args["schema"]); schemaPath != "" {
+		return c.handleSchemaCall(llmService, client, session, model, variableService, schemaPath)
+	}
+
+	// Continue a tool loop, or advertise defined tools to models that support function calling
+	if toolLoopID := args["tool_loop"]; toolLoopID != "" || c.shouldUseTools(args, model) {
+		// Tool rounds are blocking requests; refuse rather than silently dropping the stream request
+		if c.isStreamRequested(args, variableService) {
+			return fmt.Errorf("streaming is not supported with tool calling; use tools=false to stream this call or stream=false to let the model call tools")
+		}
+		return c.handleToolCall(llmService, client, session, model, variableService, clientID, toolLoopID, c.overrideOptions(args))
+	}
+
 	// Make LLM call (pure service orchestration)
-	// Note: _stream variable is ignored - only HTTP mode is supported
+	if c.isStreamRequested(args, variableService) {
+		return c.handleStreamCall(llmService, client, session, model, variableService)
+	}
 	return c.handleSyncCall(llmService, client, session, model, variableService)
 }
 
+// applyContextPolicy fits the session into the model's context window according to its context policy
+// and reports the outcome in ${#llm_context_*} variables. The returned session is what should be sent.
+// A session that cannot be fitted fails the call like other rejected requests.
+func (c *CallCommand) applyContextPolicy(llmService neurotypes.LLMService, client neurotypes.LLMClient, session *neurotypes.ChatSession, model *neurotypes.ModelConfig, variableService *services.VariableService) (*neurotypes.ChatSession, error) {
+	contextWindowService, err := services.GetGlobalContextWindowService()
+	if err != nil {
+		return session, nil
+	}
+
+	summarize := func(previousSummary string, messages []neurotypes.Message) (string, error) {
+		summaryResponse := llmService.SendStructuredCompletion(client, services.NewContextSummarySession(session, previousSummary, messages), model)
+		c.recordUsage(summaryResponse, session, model, variableService)
+		if summaryResponse.Error != nil {
+			return "", fmt.Errorf("%s", summaryResponse.Error.Message)
+		}
+		return strings.TrimSpace(summaryResponse.TextContent), nil
+	}
+
+	result, llmErr := contextWindowService.Fit(session, model, summarize)
+	_ = variableService.SetSystemVariable("#llm_context_policy", result.Policy.String())
+	_ = variableService.SetSystemVariable("#llm_context_tokens", strconv.Itoa(result.EstimatedTokens))
+	_ = variableService.SetSystemVariable("#llm_context_sent_tokens", strconv.Itoa(result.SentTokens))
+	_ = variableService.SetSystemVariable("#llm_context_limit", strconv.Itoa(result.TokenLimit))
+	_ = variableService.SetSystemVariable("#llm_context_dropped", strconv.Itoa(result.DroppedMessages))
+	_ = variableService.SetSystemVariable("#llm_context_summarized", strconv.Itoa(result.SummarizedMessages))
+	_ = variableService.SetSystemVariable("#llm_context_summary", result.Summary)
+
+	if llmErr != nil {
+		debugTransportService, err := services.GetGlobalDebugTransportService()
+		if err != nil {
+			return session, fmt.Errorf("debug transport service not available: %w", err)
+		}
+		errorResponse := &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error:          llmErr,
+			Metadata:       map[string]interface{}{"service": "context_window"},
+		}
+		return session, c.storeCallResult(errorResponse, session, variableService, debugTransportService, "http", false)
+	}
+	return result.Session, nil
+}
+
+// withoutLastResponse returns a copy of the session without its trailing assistant response,
+// so the model answers the last user turn again.
+func (c *CallCommand) withoutLastResponse(session *neurotypes.ChatSession) (*neurotypes.ChatSession, error) {
+	last := len(session.Messages) - 1
+	if last < 0 || session.Messages[last].Role != "assistant" {
+		return nil, fmt.Errorf("cannot regenerate: the last message of session '%s' is not an assistant response", session.Name)
+	}
+	request := *session
+	request.Messages = session.Messages[:last]
+	return &request, nil
+}
+
+// withTemperature returns a copy of the model whose temperature is overridden for one call.
+func (c *CallCommand) withTemperature(model *neurotypes.ModelConfig, value string) (*neurotypes.ModelConfig, error) {
+	temperature, err := strconv.ParseFloat(value, 64)
+	if err != nil || temperature < 0 {
+		return nil, fmt.Errorf("invalid temperature value: %s", value)
+	}
+	override := *model
+	override.Parameters = make(map[string]any, len(model.Parameters)+1)
+	for key, parameter := range model.Parameters {
+		override.Parameters[key] = parameter
+	}
+	override.Parameters["temperature"] = temperature
+	return &override, nil
+}
+
+// overrideOptions renders the per-call overrides so tool loop continuations keep them.
+func (c *CallCommand) overrideOptions(args map[string]string) string {
+	var options string
+	if stringprocessing.IsTruthy(args["regenerate"]) {
+		options += ", regenerate=true"
+	}
+	if temperature := strings.TrimSpace(args["temperature"]); temperature != "" {
+		options += ", temperature=" + temperature
+	}
+	return options
+}
+
+// isStreamRequested resolves the stream option, falling back to the _stream variable.
+func (c *CallCommand) isStreamRequested(args map[string]string, variableService *services.VariableService) bool {
+	streamValue, exists := args["stream"]
+	if !exists {
+		streamValue, _ = variableService.Get("_stream")
+	}
+
+	return stringprocessing.IsTruthy(streamValue)
+}
+
+// shouldUseTools reports whether defined tools should be advertised for this call.
+// Tools are skipped when disabled with tools=false or when the model's catalog entry
+// declares that it does not support function calling.
+func (c *CallCommand) shouldUseTools(args map[string]string, model *neurotypes.ModelConfig) bool {
+	if toolsValue, exists := args["tools"]; exists && !stringprocessing.IsTruthy(toolsValue) {
+		return false
+	}
+
+	toolService, err := services.GetGlobalToolService()
+	if err != nil || len(toolService.List()) == 0 {
+		return false
+	}
+
+	entry := c.lookupCatalogEntry(model)
+	if entry == nil || entry.Features == nil || entry.Features.FunctionCalling == nil {
+		return true
+	}
+	return *entry.Features.FunctionCalling
+}
+
+// lookupCatalogEntry returns the catalog entry the model was created from, or nil if unavailable.
+func (c *CallCommand) lookupCatalogEntry(model *neurotypes.ModelConfig) *neurotypes.ModelCatalogEntry {
+	if model.CatalogID == "" {
+		return nil
+	}
+	catalogService, err := services.GetGlobalModelCatalogService()
+	if err != nil {
+		return nil
+	}
+	entry, err := catalogService.GetModelByID(model.CatalogID)
+	if err != nil {
+		return nil
+	}
+	return &entry
+}
+
+// recordUsage stores the token usage and cost of a response in variables and adds it to the
+// running session and model totals. Variables are cleared when the provider reported no usage;
+// the cost is left empty when the model has no catalog pricing.
+func (c *CallCommand) recordUsage(structuredResponse *neurotypes.StructuredLLMResponse, session *neurotypes.ChatSession, model *neurotypes.ModelConfig, variableService *services.VariableService) {
+	usage, ok := services.UsageFromMetadata(structuredResponse.Metadata)
+	if !ok {
+		for _, name := range []string{"#llm_input_tokens", "#llm_output_tokens", "#llm_thinking_tokens", "#llm_cached_tokens", "#llm_cost_usd"} {
+			_ = variableService.SetSystemVariable(name, "")
+		}
+		return
+	}
+
+	var pricing *neurotypes.ModelPricing
+	if entry := c.lookupCatalogEntry(model); entry != nil {
+		pricing = entry.Pricing
+	}
+	cost, priced := services.CalculateCost(usage, pricing)
+
+	_ = variableService.SetSystemVariable("#llm_input_tokens", strconv.FormatInt(usage.InputTokens, 10))
+	_ = variableService.SetSystemVariable("#llm_output_tokens", strconv.FormatInt(usage.OutputTokens, 10))
+	_ = variableService.SetSystemVariable("#llm_thinking_tokens", strconv.FormatInt(usage.ThinkingTokens, 10))
+	_ = variableService.SetSystemVariable("#llm_cached_tokens", strconv.FormatInt(usage.CachedTokens, 10))
+	if priced {
+		_ = variableService.SetSystemVariable("#llm_cost_usd", fmt.Sprintf("%.6f", cost))
+	} else {
+		_ = variableService.SetSystemVariable("#llm_cost_usd", "")
+	}
+
+	if usageService, err := services.GetGlobalUsageService(); err == nil {
+		_ = usageService.Record(session.ID, model.Name, usage, cost, priced)
+	}
+	if budgetService, err := services.GetGlobalBudgetService(); err == nil && priced {
+		_ = budgetService.RecordSpend(cost)
+	}
+}
+
+// recordResponseMetadata stores which model and provider answered, with the stop reason, request ID
+// and latency, in variables and as the session's pending message metadata, so the assistant message
+// that stores the response records how it was produced. Failed calls leave no pending metadata.
+func (c *CallCommand) recordResponseMetadata(structuredResponse *neurotypes.StructuredLLMResponse, client neurotypes.LLMClient, session *neurotypes.ChatSession, model *neurotypes.ModelConfig, variableService *services.VariableService) {
+	provider, _ := structuredResponse.Metadata["provider"].(string)
+	if provider == "" {
+		provider = client.GetProviderName()
+	}
+	stopReason, requestID, latencyMs := services.ResponseDetailsFromMetadata(structuredResponse.Metadata)
+
+	_ = variableService.SetSystemVariable("#llm_model", model.Name)
+	_ = variableService.SetSystemVariable("#llm_provider", provider)
+	_ = variableService.SetSystemVariable("#llm_stop_reason", stopReason)
+	_ = variableService.SetSystemVariable("#llm_request_id", requestID)
+	_ = variableService.SetSystemVariable("#llm_latency_ms", strconv.FormatInt(latencyMs, 10))
+
+	sessionService, err := services.GetGlobalChatSessionService()
+	if err != nil {
+		return
+	}
+	if structuredResponse.Error != nil {
+		_ = sessionService.SetPendingMetadata(session.ID, nil)
+		return
+	}
+
+	metadata := &neurotypes.MessageMetadata{
+		Model:      model.Name,
+		CatalogID:  model.CatalogID,
+		BaseModel:  model.BaseModel,
+		Provider:   provider,
+		LatencyMs:  latencyMs,
+		StopReason: stopReason,
+		RequestID:  requestID,
+	}
+	if usage, ok := services.UsageFromMetadata(structuredResponse.Metadata); ok {
+		metadata.Usage = &usage
+	}
+	if len(structuredResponse.ThinkingBlocks) > 0 {
+		metadata.ThinkingBlocks = append([]neurotypes.ThinkingBlock(nil), structuredResponse.ThinkingBlocks...)
+	}
+	_ = sessionService.SetPendingMetadata(session.ID, metadata)
+}
+
+// resolveToolMaxRounds returns the maximum number of tool calling rounds per call from ${_tool_max_rounds}.
+func (c *CallCommand) resolveToolMaxRounds(variableService *services.VariableService) int {
+	if value, err := variableService.Get("_tool_max_rounds"); err == nil {
+		if rounds, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && rounds > 0 {
+			return rounds
+		}
+	}
+	return defaultToolMaxRounds
+}
+
+// handleToolCall performs a synchronous LLM call advertising the defined tools.
+// For a continuation, session already includes the loop's tool exchange.
+// When the model requests tools, the continuation \llm-call and one \tool-call per requested call
+// are pushed onto the stack so the tool scripts run through the stack machine before the model is
+// called again. A new loop also pushes TOOL_LOOP_END below them, so the loop is released even if
+// an error abandons its commands. The final answer is stored exactly like a regular synchronous call.
+func (c *CallCommand) handleToolCall(llmService neurotypes.LLMService, client neurotypes.LLMClient, session *neurotypes.ChatSession, model *neurotypes.ModelConfig, variableService *services.VariableService, clientID string, toolLoopID string, overrides string) error {
+	toolService, err := services.GetGlobalToolService()
+	if err != nil {
+		return fmt.Errorf("tool service not available: %w", err)
+	}
+
+	stackService, err := services.GetGlobalStackService()
+	if err != nil {
+		return fmt.Errorf("stack service not available: %w", err)
+	}
+
+	displayID := "llm-call-tools"
+	displayStarted := c.startLLMThinkingDisplay(displayID, "Thinking...")
+	if displayStarted {
+		defer c.stopLLMDisplay(displayID)
+	}
+
+	debugTransportService, err := services.GetGlobalDebugTransportService()
+	if err != nil {
+		return fmt.Errorf("debug transport service not available: %w", err)
+	}
+	client.SetDebugTransport(debugTransportService.CreateTransport())
+
+	structuredResponse := llmService.SendStructuredCompletionWithTools(client, session, model, toolService.List())
+	c.recordUsage(structuredResponse, session, model, variableService)
+	c.recordResponseMetadata(structuredResponse, client, session, model, variableService)
+
+	if structuredResponse.Error == nil && len(structuredResponse.ToolCalls) > 0 {
+		if toolLoopID == "" {
+			// The end marker releases the loop however its commands finish
+			toolLoopID = toolService.StartLoop(session.ID).ID
+			stackService.PushCommand("TOOL_LOOP_END:" + toolLoopID)
+		}
+		if err := toolService.RecordToolCalls(toolLoopID, structuredResponse.TextContent, structuredResponse.ToolCalls, structuredResponse.ThinkingBlocks); err != nil {
+			return err
+		}
+
+		loop, err := toolService.GetLoop(toolLoopID)
+		if err != nil {
+			return err
+		}
+		maxRounds := c.resolveToolMaxRounds(variableService)
+		if loop.Rounds > maxRounds {
+			toolService.EndLoop(toolLoopID)
+			return fmt.Errorf("tool loop exceeded %d rounds without a final answer (raise ${_tool_max_rounds} to allow more)", maxRounds)
+		}
+
+		_ = variableService.SetSystemVariable("_debug_network", debugTransportService.GetCapturedData())
+		debugTransportService.ClearCapturedData()
+		_ = variableService.SetSystemVariable("#llm_tool_loop", toolLoopID)
+
+		// Push in reverse order (LIFO): every tool runs first, then the model is called again
+		stackService.PushCommand(fmt.Sprintf("\\llm-call[client_id=%s, model_id=%s, session_id=%s, tool_loop=%s%s]", clientID, model.Name, session.ID, toolLoopID, overrides))
+		for i := len(structuredResponse.ToolCalls) - 1; i >= 0; i-- {
+			stackService.PushCommand(fmt.Sprintf("\\tool-call[tool_loop=%s, call_id=%s]", toolLoopID, structuredResponse.ToolCalls[i].ID))
+		}
+		return nil
+	}
+
+	// Final answer (or error): report the loop summary and release the loop
+	toolRounds, toolCalls := 0, 0
+	if toolLoopID != "" {
+		if loop, err := toolService.GetLoop(toolLoopID); err == nil {
+			toolRounds = loop.Rounds
+			toolCalls = len(loop.Calls)
+		}
+		toolService.EndLoop(toolLoopID)
+	}
+	_ = variableService.SetSystemVariable("#llm_tool_loop", "")
+	_ = variableService.SetSystemVariable("#llm_tool_rounds", fmt.Sprintf("%d", toolRounds))
+	_ = variableService.SetSystemVariable("#llm_tool_calls", fmt.Sprintf("%d", toolCalls))
+
+	return c.storeCallResult(structuredResponse, session, variableService, debugTransportService, "http", false)
+}
+
+// handleSchemaCall performs a structured output call constrained to the JSON schema in schemaPath.
+// The validated document's top-level fields are stored in ${#llm_json.<field>} variables; responses
+// that do not match the schema fail the command with a schema_violation error.
+func (c *CallCommand) handleSchemaCall(llmService neurotypes.LLMService, client neurotypes.LLMClient, session *neurotypes.ChatSession, model *neurotypes.ModelConfig, variableService *services.VariableService, schemaPath string) error {
+	schema, err := services.LoadResponseSchema(schemaPath)
+	if err != nil {
+		return err
+	}
+
+	if entry := c.lookupCatalogEntry(model); entry != nil && entry.Features != nil && entry.Features.StructuredOutputs != nil && !*entry.Features.StructuredOutputs {
+		return fmt.Errorf("model '%s' (%s) does not support structured outputs", model.Name, entry.ID)
+	}
+
+	displayID := "llm-call-schema"
+	displayStarted := c.startLLMThinkingDisplay(displayID, "Thinking...")
+	if displayStarted {
+		defer c.stopLLMDisplay(displayID)
+	}
+
+	debugTransportService, err := services.GetGlobalDebugTransportService()
+	if err != nil {
+		return fmt.Errorf("debug transport service not available: %w", err)
+	}
+	client.SetDebugTransport(debugTransportService.CreateTransport())
+
+	structuredResponse := llmService.SendStructuredCompletionWithSchema(client, session, model, schema)
+	c.recordUsage(structuredResponse, session, model, variableService)
+	c.recordResponseMetadata(structuredResponse, client, session, model, variableService)
+
+	c.clearJSONVariables(variableService)
+	if structuredResponse.Error != nil && structuredResponse.Error.Type == services.SchemaViolationType {
+		// Keep the rejected document available for inspection under \try
+		_ = variableService.SetSystemVariable("#llm_text_content", structuredResponse.TextContent)
+	}
+
+	if err := c.storeCallResult(structuredResponse, session, variableService, debugTransportService, "http", false); err != nil {
+		return err
+	}
+	if structuredResponse.Error == nil {
+		c.storeJSONVariables(structuredResponse.TextContent, variableService)
+	}
+	return nil
+}
+
+// storeJSONVariables stores a structured output document in ${#llm_json} and flattens its top-level
+// fields into ${#llm_json.<field>} variables. Strings are stored as is, other values as compact JSON.
+// ${#llm_json_fields} lists the field names.
+func (c *CallCommand) storeJSONVariables(document string, variableService *services.VariableService) {
+	value, err := services.ParseStructuredOutput(document)
+	if err != nil {
+		return
+	}
+	encoded, _ := json.Marshal(value)
+	_ = variableService.SetSystemVariable("#llm_json", string(encoded))
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return
+	}
+	fields := make([]string, 0, len(object))
+	for field := range object {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		fieldValue := ""
+		switch typed := object[field].(type) {
+		case string:
+			fieldValue = typed
+		case nil:
+			fieldValue = ""
+		default:
+			encodedField, _ := json.Marshal(typed)
+			fieldValue = string(encodedField)
+		}
+		_ = variableService.SetSystemVariable("#llm_json."+field, fieldValue)
+	}
+	_ = variableService.SetSystemVariable("#llm_json_fields", strings.Join(fields, ","))
+}
+
+// clearJSONVariables empties the ${#llm_json*} variables left by a previous structured output call.
+func (c *CallCommand) clearJSONVariables(variableService *services.VariableService) {
+	_ = variableService.SetSystemVariable("#llm_json", "")
+	_ = variableService.SetSystemVariable("#llm_json_fields", "")
+	if allVariables, err := variableService.GetAllVariables(); err == nil {
+		for name := range allVariables {
+			if strings.HasPrefix(name, "#llm_json.") {
+				_ = variableService.SetSystemVariable(name, "")
+			}
+		}
+	}
+}
+
 // handleDryRun shows the complete API payload that would be sent without making the call.
 func (c *CallCommand) handleDryRun(client neurotypes.LLMClient, model *neurotypes.ModelConfig, session *neurotypes.ChatSession, variableService *services.VariableService) error {
 	fmt.Println("=== LLM CALL DRY RUN ===")
@@ -304,10 +842,86 @@ func (c *CallCommand) handleSyncCall(llmService neurotypes.LLMService, client ne
 
 	// Make structured LLM call (debug capture happens automatically via transport)
 	structuredResponse := llmService.SendStructuredCompletion(client, session, model)
+	c.recordUsage(structuredResponse, session, model, variableService)
+	c.recordResponseMetadata(structuredResponse, client, session, model, variableService)
+
+	return c.storeCallResult(structuredResponse, session, variableService, debugTransportService, "http", false)
+}
 
+// handleStreamCall performs a streaming LLM API call, printing deltas as they arrive.
+// The complete response is stored in the same variables as a synchronous call.
+func (c *CallCommand) handleStreamCall(llmService neurotypes.LLMService, client neurotypes.LLMClient, session *neurotypes.ChatSession, model *neurotypes.ModelConfig, variableService *services.VariableService) error {
+	// Show the thinking indicator until the first delta arrives
+	displayID := "llm-call-stream"
+	displayStarted := c.startLLMThinkingDisplay(displayID, "Thinking...")
+	if displayStarted {
+		defer c.stopLLMDisplay(displayID)
+	}
+
+	debugTransportService, err := services.GetGlobalDebugTransportService()
+	if err != nil {
+		return fmt.Errorf("debug transport service not available: %w", err)
+	}
+	client.SetDebugTransport(debugTransportService.CreateTransport())
+
+	// Thinking deltas use the theme's italic style to set them apart from the answer
+	thinkingStyle := lipgloss.NewStyle().Italic(true)
+	if renderConfig, err := c.createRenderConfig(variableService); err == nil {
+		thinkingStyle = renderConfig.GetStyle("italic")
+	}
+
+	streamed := false
+	lastType := ""
+	lastContent := ""
+	onChunk := func(chunk neurotypes.StreamChunk) {
+		if chunk.Content == "" {
+			return
+		}
+		if !streamed {
+			streamed = true
+			if displayStarted {
+				c.stopLLMDisplayAndWait(displayID)
+			}
+		}
+		if lastType != "" && lastType != chunk.Type {
+			// Separate thinking from the answer
+			if !strings.HasSuffix(lastContent, "\n") {
+				fmt.Print("\n")
+			}
+			fmt.Print("\n")
+		}
+		if chunk.Type == "thinking" {
+			fmt.Print(thinkingStyle.Render(chunk.Content))
+		} else {
+			fmt.Print(chunk.Content)
+		}
+		lastType = chunk.Type
+		lastContent = chunk.Content
+	}
+
+	structuredResponse := llmService.StreamStructuredCompletion(client, session, model, onChunk)
+	c.recordUsage(structuredResponse, session, model, variableService)
+	c.recordResponseMetadata(structuredResponse, client, session, model, variableService)
+
+	// Terminate the streamed output so subsequent commands start on a fresh line
+	if streamed && !strings.HasSuffix(lastContent, "\n") {
+		fmt.Print("\n")
+	}
+
+	return c.storeCallResult(structuredResponse, session, variableService, debugTransportService, "stream", streamed)
+}
+
+// storeCallResult stores a structured response, its rendered thinking blocks, error details,
+// and network debug data in variables for scripts to consume.
+func (c *CallCommand) storeCallResult(structuredResponse *neurotypes.StructuredLLMResponse, session *neurotypes.ChatSession, variableService *services.VariableService, debugTransportService *services.DebugTransportService, callMode string, streamed bool) error {
 	// Get captured debug data from the debug transport service
 	debugData := debugTransportService.GetCapturedData()
 
+	// Report retries made for transient provider errors, whether or not the call finally succeeded
+	retries, waited := services.RetryStatsFromMetadata(structuredResponse.Metadata)
+	_ = variableService.SetSystemVariable("#llm_retry_count", strconv.Itoa(retries))
+	_ = variableService.SetSystemVariable("#llm_retry_wait", waited.String())
+
 	// Check for critical errors that should cause immediate failure
 	if structuredResponse.Error != nil {
 		// Still store the error information for scripts to handle
@@ -315,11 +929,14 @@ func (c *CallCommand) handleSyncCall(llmService neurotypes.LLMService, client ne
 		_ = variableService.SetSystemVariable("#llm_error_message", structuredResponse.Error.Message)
 		_ = variableService.SetSystemVariable("#llm_error_type", structuredResponse.Error.Type)
 		_ = variableService.SetSystemVariable("#llm_call_success", "false")
+		_ = variableService.SetSystemVariable("#llm_call_streamed", fmt.Sprintf("%t", streamed))
 		_ = variableService.SetSystemVariable("_debug_network", debugData)
 		debugTransportService.ClearCapturedData()
 
 		// Return early for critical errors, but let scripts handle the response via variables
-		if structuredResponse.Error.Type == "service_error" || structuredResponse.Error.Type == "client_error" {
+		// Budget and schema errors fail the command too, so \try can catch them
+		if structuredResponse.Error.Type == "service_error" || structuredResponse.Error.Type == "client_error" ||
+			structuredResponse.Error.Type == "budget_exceeded" || structuredResponse.Error.Type == services.SchemaViolationType {
 			return fmt.Errorf("LLM call failed: %s", structuredResponse.Error.Message)
 		}
 		// For API errors, continue processing to allow scripts to handle partial responses
@@ -340,8 +957,14 @@ func (c *CallCommand) handleSyncCall(llmService neurotypes.LLMService, client ne
 				// Create a default render configuration if theme service fails
 				renderConfig = c.createDefaultRenderConfig()
 			}
-			// Calculate the message index for the assistant's response (next message in session)
+			// Calculate the message index for the assistant's response (next message in the stored session,
+			// which may hold more messages than were sent under a context policy)
 			messageIndex := len(session.Messages) + 1
+			if sessionService, err := services.GetGlobalChatSessionService(); err == nil {
+				if storedSession, err := sessionService.GetSessionByNameOrID(session.ID); err == nil {
+					messageIndex = len(storedSession.Messages) + 1
+				}
+			}
 			// Render thinking blocks with XML format and proper message indexing
 			renderedThinking = thinkingRenderer.RenderThinkingBlocksWithMessageIndex(structuredResponse.ThinkingBlocks, renderConfig, messageIndex)
 		}
@@ -366,7 +989,8 @@ func (c *CallCommand) handleSyncCall(llmService neurotypes.LLMService, client ne
 	_ = variableService.SetSystemVariable("#llm_thinking_blocks_rendered", renderedThinking)   // Rendered thinking blocks
 	_ = variableService.SetSystemVariable("#llm_thinking_blocks_count", fmt.Sprintf("%d", len(structuredResponse.ThinkingBlocks)))
 	_ = variableService.SetSystemVariable("#llm_call_success", "true")
-	_ = variableService.SetSystemVariable("#llm_call_mode", "http")
+	_ = variableService.SetSystemVariable("#llm_call_mode", callMode)
+	_ = variableService.SetSystemVariable("#llm_call_streamed", fmt.Sprintf("%t", streamed))
 	_ = variableService.SetSystemVariable("_debug_network", debugData)
 
 	// Store error information if present
@@ -438,6 +1062,17 @@ func (c *CallCommand) stopLLMDisplay(id string) {
 	_ = temporalService.Stop(id)
 }
 
+// stopLLMDisplayAndWait stops a temporal display and waits until its line has been cleared.
+// Used before streaming output so the display cleanup cannot erase the first delta.
+func (c *CallCommand) stopLLMDisplayAndWait(id string) {
+	temporalService := c.getTemporalDisplayService()
+	if temporalService == nil {
+		return // Nothing to stop
+	}
+
+	_ = temporalService.StopAndWait(id)
+}
+
 // createRenderConfig creates a RenderConfig that integrates with the theme service.
 func (c *CallCommand) createRenderConfig(variableService *services.VariableService) (neurotypes.RenderConfig, error) {
 	// Get theme service for styling
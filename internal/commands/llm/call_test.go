@@ -2,6 +2,8 @@
 package llm
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -244,9 +246,23 @@ func TestCallCommand_Execute_SyncCall(t *testing.T) {
 	callMode, err := variableService.Get("#llm_call_mode")
 	require.NoError(t, err)
 	assert.Equal(t, "http", callMode)
+
+	// The response metadata waits for the assistant message that stores it
+	stopReason, err := variableService.Get("#llm_stop_reason")
+	require.NoError(t, err)
+	assert.Equal(t, "end_turn", stopReason)
+
+	metadata := sessionService.TakePendingMetadata(session.ID)
+	require.NotNil(t, metadata)
+	assert.Equal(t, "test-model", metadata.Model)
+	assert.Equal(t, "gpt-4", metadata.BaseModel)
+	assert.Equal(t, "end_turn", metadata.StopReason)
+	assert.NotEmpty(t, metadata.RequestID)
+	require.NotNil(t, metadata.Usage)
+	assert.Nil(t, sessionService.TakePendingMetadata(session.ID))
 }
 
-func TestCallCommand_Execute_StreamingIgnored(t *testing.T) {
+func TestCallCommand_Execute_Streaming(t *testing.T) {
 	// Create test context and services
 	ctx := context.New()
 	ctx.SetTestMode(true)
@@ -309,9 +325,17 @@ func TestCallCommand_Execute_StreamingIgnored(t *testing.T) {
 	require.NoError(t, err)
 	assert.Contains(t, output, "mocking reply")
 
+	textContent, err := variableService.Get("#llm_text_content")
+	require.NoError(t, err)
+	assert.Contains(t, textContent, "mocking reply")
+
 	callMode, err := variableService.Get("#llm_call_mode")
 	require.NoError(t, err)
-	assert.Equal(t, "http", callMode) // Streaming is ignored, should use HTTP
+	assert.Equal(t, "stream", callMode)
+
+	streamed, err := variableService.Get("#llm_call_streamed")
+	require.NoError(t, err)
+	assert.Equal(t, "true", streamed)
 }
 
 func TestCallCommand_Execute_DefaultResolution(t *testing.T) {
@@ -721,3 +745,280 @@ func TestCallCommand_handleSyncCall(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "http", callMode)
 }
+
+func TestCallCommand_Execute_ToolLoop(t *testing.T) {
+	// Create test context and services
+	ctx := context.New()
+	ctx.SetTestMode(true)
+
+	registry := services.NewRegistry()
+	_ = registry.RegisterService(services.NewClientFactoryService())
+	_ = registry.RegisterService(services.NewModelService())
+	_ = registry.RegisterService(services.NewChatSessionService())
+	_ = registry.RegisterService(services.NewMockLLMService())
+	_ = registry.RegisterService(services.NewVariableService())
+	_ = registry.RegisterService(services.NewDebugTransportService())
+	_ = registry.RegisterService(services.NewStackService())
+	_ = registry.RegisterService(services.NewToolService())
+	_ = registry.InitializeAll()
+
+	oldRegistry := services.GetGlobalRegistry()
+	services.SetGlobalRegistry(registry)
+	defer services.SetGlobalRegistry(oldRegistry)
+
+	oldCtx := context.GetGlobalContext()
+	context.SetGlobalContext(ctx)
+	defer context.SetGlobalContext(oldCtx)
+
+	clientFactory, _ := services.GetGlobalClientFactoryService()
+	modelService, _ := services.GetGlobalModelService()
+	sessionService, _ := services.GetGlobalChatSessionService()
+	variableService, _ := services.GetGlobalVariableService()
+	stackService, _ := services.GetGlobalStackService()
+	toolService, _ := services.GetGlobalToolService()
+
+	_, clientID, err := clientFactory.GetClientWithID("OAR", "test-api-key")
+	require.NoError(t, err)
+	model, err := modelService.CreateModelWithGlobalContext("test-model", "openai", "gpt-4", map[string]any{}, "Test model", "")
+	require.NoError(t, err)
+	session, err := sessionService.CreateSession("tool-session", "You are helpful", "")
+	require.NoError(t, err)
+	require.NoError(t, sessionService.AddMessage(session.ID, "user", "Please trigger tool call"))
+
+	require.NoError(t, toolService.Define(neurotypes.ToolDefinition{
+		Name:        "weather",
+		Description: "Get the weather",
+		ScriptPath:  "tools/weather.neuro",
+	}))
+
+	cmd := &CallCommand{}
+	args := map[string]string{
+		"client_id":  clientID,
+		"model_id":   model.Name,
+		"session_id": session.ID,
+	}
+
+	// First round: the model requests the tool, so the tool call and continuation are pushed
+	stackService.ClearStack()
+	require.NoError(t, cmd.Execute(args, ""))
+
+	loopID, err := variableService.Get("#llm_tool_loop")
+	require.NoError(t, err)
+	require.NotEmpty(t, loopID)
+
+	toolCallCommand, ok := stackService.PopCommand()
+	require.True(t, ok)
+	assert.Equal(t, "\\tool-call[tool_loop="+loopID+", call_id=mock-call-1]", toolCallCommand)
+
+	continuation, ok := stackService.PopCommand()
+	require.True(t, ok)
+	assert.Equal(t, "\\llm-call[client_id="+clientID+", model_id=test-model, session_id="+session.ID+", tool_loop="+loopID+"]", continuation)
+	endMarker, ok := stackService.PopCommand()
+	require.True(t, ok)
+	assert.Equal(t, "TOOL_LOOP_END:"+loopID, endMarker)
+	assert.True(t, stackService.IsEmpty())
+
+	// The session itself is untouched by the tool exchange
+	unchanged, err := sessionService.GetSessionByNameOrID(session.ID)
+	require.NoError(t, err)
+	assert.Len(t, unchanged.Messages, 1)
+
+	// Second round: with the tool result recorded, the model answers
+	require.NoError(t, toolService.RecordToolResult(loopID, neurotypes.ToolResult{CallID: "mock-call-1", Name: "weather", Content: "Sunny"}))
+	args["tool_loop"] = loopID
+	require.NoError(t, cmd.Execute(args, ""))
+	assert.True(t, stackService.IsEmpty())
+
+	textContent, err := variableService.Get("#llm_text_content")
+	require.NoError(t, err)
+	assert.Contains(t, textContent, "Sunny")
+
+	toolCalls, err := variableService.Get("#llm_tool_calls")
+	require.NoError(t, err)
+	assert.Equal(t, "1", toolCalls)
+
+	_, err = toolService.GetLoop(loopID)
+	assert.Error(t, err, "loop should be released after the final answer")
+}
+
+func TestCallCommand_Execute_ToolsDisabled(t *testing.T) {
+	ctx := context.New()
+	ctx.SetTestMode(true)
+
+	registry := services.NewRegistry()
+	_ = registry.RegisterService(services.NewClientFactoryService())
+	_ = registry.RegisterService(services.NewModelService())
+	_ = registry.RegisterService(services.NewChatSessionService())
+	_ = registry.RegisterService(services.NewMockLLMService())
+	_ = registry.RegisterService(services.NewVariableService())
+	_ = registry.RegisterService(services.NewDebugTransportService())
+	_ = registry.RegisterService(services.NewStackService())
+	_ = registry.RegisterService(services.NewToolService())
+	_ = registry.InitializeAll()
+
+	oldRegistry := services.GetGlobalRegistry()
+	services.SetGlobalRegistry(registry)
+	defer services.SetGlobalRegistry(oldRegistry)
+
+	oldCtx := context.GetGlobalContext()
+	context.SetGlobalContext(ctx)
+	defer context.SetGlobalContext(oldCtx)
+
+	clientFactory, _ := services.GetGlobalClientFactoryService()
+	modelService, _ := services.GetGlobalModelService()
+	sessionService, _ := services.GetGlobalChatSessionService()
+	variableService, _ := services.GetGlobalVariableService()
+	stackService, _ := services.GetGlobalStackService()
+	toolService, _ := services.GetGlobalToolService()
+
+	_, clientID, err := clientFactory.GetClientWithID("OAR", "test-api-key")
+	require.NoError(t, err)
+	model, err := modelService.CreateModelWithGlobalContext("test-model", "openai", "gpt-4", map[string]any{}, "Test model", "")
+	require.NoError(t, err)
+	session, err := sessionService.CreateSession("tool-session", "You are helpful", "")
+	require.NoError(t, err)
+	require.NoError(t, sessionService.AddMessage(session.ID, "user", "Please trigger tool call"))
+	require.NoError(t, toolService.Define(neurotypes.ToolDefinition{Name: "weather", ScriptPath: "tools/weather.neuro"}))
+
+	cmd := &CallCommand{}
+	stackService.ClearStack()
+	err = cmd.Execute(map[string]string{
+		"client_id":  clientID,
+		"model_id":   model.Name,
+		"session_id": session.ID,
+		"tools":      "false",
+	}, "")
+	require.NoError(t, err)
+
+	assert.True(t, stackService.IsEmpty(), "no tool calls should be scheduled")
+	textContent, err := variableService.Get("#llm_text_content")
+	require.NoError(t, err)
+	assert.Contains(t, textContent, "mocking reply")
+
+	// Tool rounds are not streamed, so asking for both is refused instead of silently not streaming
+	args := map[string]string{
+		"client_id":  clientID,
+		"model_id":   model.Name,
+		"session_id": session.ID,
+		"stream":     "yes",
+	}
+	err = cmd.Execute(args, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "streaming is not supported with tool calling")
+
+	delete(args, "stream")
+	require.NoError(t, variableService.Set("_stream", "true"))
+	err = cmd.Execute(args, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tools=false")
+	assert.True(t, stackService.IsEmpty(), "no tool calls should be scheduled")
+}
+
+func TestCallCommand_Execute_Schema(t *testing.T) {
+	ctx := context.New()
+	ctx.SetTestMode(true)
+
+	registry := services.NewRegistry()
+	_ = registry.RegisterService(services.NewClientFactoryService())
+	_ = registry.RegisterService(services.NewModelService())
+	_ = registry.RegisterService(services.NewChatSessionService())
+	_ = registry.RegisterService(services.NewMockLLMService())
+	_ = registry.RegisterService(services.NewVariableService())
+	_ = registry.RegisterService(services.NewDebugTransportService())
+	_ = registry.InitializeAll()
+
+	oldRegistry := services.GetGlobalRegistry()
+	services.SetGlobalRegistry(registry)
+	defer services.SetGlobalRegistry(oldRegistry)
+
+	oldCtx := context.GetGlobalContext()
+	context.SetGlobalContext(ctx)
+	defer context.SetGlobalContext(oldCtx)
+
+	clientFactory, _ := services.GetGlobalClientFactoryService()
+	modelService, _ := services.GetGlobalModelService()
+	sessionService, _ := services.GetGlobalChatSessionService()
+	variableService, _ := services.GetGlobalVariableService()
+
+	schemaPath := filepath.Join(t.TempDir(), "person.json")
+	require.NoError(t, os.WriteFile(schemaPath, []byte(`{
+		"type": "object",
+		"properties": {"name": {"type": "string"}, "age": {"type": "integer"}, "tags": {"type": "array", "items": {"type": "string"}}},
+		"required": ["name", "age"]
+	}`), 0644))
+
+	_, clientID, err := clientFactory.GetClientWithID("OAR", "test-api-key")
+	require.NoError(t, err)
+	model, err := modelService.CreateModelWithGlobalContext("test-model", "openai", "gpt-4", map[string]any{}, "Test model", "")
+	require.NoError(t, err)
+	session, err := sessionService.CreateSession("schema-session", "You are helpful", "")
+	require.NoError(t, err)
+	require.NoError(t, sessionService.AddMessage(session.ID, "user", "Describe a person"))
+
+	cmd := &CallCommand{}
+	args := map[string]string{
+		"client_id":  clientID,
+		"model_id":   model.Name,
+		"session_id": session.ID,
+		"schema":     schemaPath,
+	}
+	require.NoError(t, cmd.Execute(args, ""))
+
+	name, _ := variableService.Get("#llm_json.name")
+	assert.Equal(t, "mock_name", name)
+	age, _ := variableService.Get("#llm_json.age")
+	assert.Equal(t, "0", age)
+	tags, _ := variableService.Get("#llm_json.tags")
+	assert.Equal(t, `["mock_tags"]`, tags)
+	fields, _ := variableService.Get("#llm_json_fields")
+	assert.Equal(t, "age,name,tags", fields)
+
+	// A violating response fails the command and clears the fields of the previous call
+	require.NoError(t, sessionService.AddMessage(session.ID, "user", "trigger schema violation"))
+	err = cmd.Execute(args, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not match schema 'person'")
+
+	errorType, _ := variableService.Get("#llm_error_type")
+	assert.Equal(t, services.SchemaViolationType, errorType)
+	name, _ = variableService.Get("#llm_json.name")
+	assert.Empty(t, name)
+
+	// Missing schema files are reported before any request is made
+	args["schema"] = filepath.Join(t.TempDir(), "missing.json")
+	err = cmd.Execute(args, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read schema file")
+}
+
+func TestCallCommand_withoutLastResponse(t *testing.T) {
+	cmd := &CallCommand{}
+	session := &neurotypes.ChatSession{Name: "s", Messages: []neurotypes.Message{
+		{Role: "user", Content: "Question"},
+		{Role: "assistant", Content: "Answer"},
+	}}
+
+	request, err := cmd.withoutLastResponse(session)
+	require.NoError(t, err)
+	assert.Len(t, request.Messages, 1)
+	assert.Len(t, session.Messages, 2, "stored session must not change")
+
+	_, err = cmd.withoutLastResponse(request)
+	assert.ErrorContains(t, err, "not an assistant response")
+}
+
+func TestCallCommand_withTemperature(t *testing.T) {
+	cmd := &CallCommand{}
+	model := &neurotypes.ModelConfig{Name: "m", Parameters: map[string]any{"temperature": 0.2, "max_tokens": 100}}
+
+	override, err := cmd.withTemperature(model, "1.5")
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, override.Parameters["temperature"])
+	assert.Equal(t, 100, override.Parameters["max_tokens"])
+	assert.Equal(t, 0.2, model.Parameters["temperature"], "stored model must not change")
+
+	_, err = cmd.withTemperature(model, "-1")
+	assert.ErrorContains(t, err, "invalid temperature")
+	assert.Equal(t, "", cmd.overrideOptions(map[string]string{}))
+	assert.Equal(t, ", regenerate=true, temperature=0.7", cmd.overrideOptions(map[string]string{"regenerate": "true", "temperature": "0.7"}))
+}
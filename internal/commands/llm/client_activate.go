@@ -167,6 +167,8 @@ func (c *ClientActivateCommand) getClientTypeDescription(clientID string) string
 		return "type: Anthropic"
 	case strings.HasPrefix(clientID, "GMC:"):
 		return "type: Gemini"
+	case strings.HasPrefix(clientID, services.MockProviderCatalogID+":"):
+		return "type: Mock"
 	default:
 		return "type: Unknown"
 	}
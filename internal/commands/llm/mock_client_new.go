@@ -0,0 +1,210 @@
+package llm
+
+import (
+	"fmt"
+	"strconv"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// MockClientNewCommand implements the \mock-client-new command.
+// It creates clients of the built-in MOCK provider, which answer from a script instead of an API,
+// so workflows and stdlib scripts can be tested without API keys or network access.
+type MockClientNewCommand struct{}
+
+// Name returns the command name "mock-client-new" for registration and lookup.
+func (c *MockClientNewCommand) Name() string {
+	return "mock-client-new"
+}
+
+// ParseMode returns ParseModeKeyValue for bracket parameter parsing.
+func (c *MockClientNewCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the mock-client-new command does.
+func (c *MockClientNewCommand) Description() string {
+	return "Create client for the scripted mock provider (no API key needed)"
+}
+
+// Usage returns the syntax and usage examples for the mock-client-new command.
+func (c *MockClientNewCommand) Usage() string {
+	return "\\mock-client-new[mode=echo|reverse|fixed|rules, response=text, rules=file.yaml, thinking=text, error=rate_limit|server_error|api_error, error_every=N]"
+}
+
+// HelpInfo returns structured help information for the mock-client-new command.
+func (c *MockClientNewCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       c.Usage(),
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "mode",
+				Description: "Response mode: echo, reverse, fixed or rules (inferred from response/rules when omitted)",
+				Required:    false,
+				Type:        "string",
+				Default:     "echo",
+			},
+			{
+				Name:        "response",
+				Description: "Fixed response returned for every message",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "rules",
+				Description: "YAML file of regex rules: rules: [{match, response, thinking, error}], default",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "thinking",
+				Description: "Thinking block content added to every successful response",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "error",
+				Description: "Error to inject: rate_limit, server_error or api_error",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "error_every",
+				Description: "Inject the error on every Nth call",
+				Required:    false,
+				Type:        "int",
+				Default:     "1",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\mock-client-new[response=Done.]",
+				Description: "Answer every message with a fixed response",
+			},
+			{
+				Command:     "\\mock-client-new[rules=test/fixtures/mock/rules.yaml, thinking=Checking the rules]",
+				Description: "Answer from regex rules with a thinking block",
+			},
+			{
+				Command:     "\\mock-client-new[error=rate_limit, error_every=3]",
+				Description: "Echo messages but hit a rate limit on every third call",
+			},
+			{
+				Command:     "\\llm-client-activate ${_client_id}",
+				Description: "Activate the created client for \\send",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "_client_id",
+				Description: "Contains the created client ID",
+				Type:        "system_output",
+				Example:     "_client_id = \"MOCK:1a2b3c4d\"",
+			},
+			{
+				Name:        "_output",
+				Description: "Contains success message with client details",
+				Type:        "system_output",
+				Example:     "_output = \"Mock client ready: MOCK:1a2b3c4d (mode: fixed)\"",
+			},
+			{
+				Name:        "#client_provider",
+				Description: "Contains the provider name",
+				Type:        "system_metadata",
+				Example:     "#client_provider = \"mock\"",
+			},
+			{
+				Name:        "#client_mode",
+				Description: "Contains the response mode of the client",
+				Type:        "system_metadata",
+				Example:     "#client_mode = \"rules\"",
+			},
+			{
+				Name:        "#client_configured",
+				Description: "Contains client configuration status",
+				Type:        "system_metadata",
+				Example:     "#client_configured = \"true\"",
+			},
+		},
+		Notes: []string{
+			"\\model-new[catalog_id=MOCK] creates and activates the default echo client automatically",
+			"Clients with the same options are shared, including their call count for error_every",
+			"Rule responses may reference regex capture groups as $1 or ${name}; a rule's error injects that error",
+			"Rules are read when the client is created; use \\llm-client-activate ${_client_id} to switch clients",
+			"Injected rate_limit and server_error errors are retried like provider errors (see ${_llm_retry})",
+			"Every call counts towards error_every, including retries",
+		},
+	}
+}
+
+// Execute creates a mock provider client with scripted responses.
+func (c *MockClientNewCommand) Execute(args map[string]string, _ string) error {
+	options := services.MockClientOptions{
+		Mode:      args["mode"],
+		Response:  args["response"],
+		RulesFile: args["rules"],
+		Thinking:  args["thinking"],
+		Error:     args["error"],
+	}
+	if value, exists := args["error_every"]; exists && value != "" {
+		every, err := strconv.Atoi(value)
+		if err != nil || every < 1 {
+			return fmt.Errorf("error_every must be a positive integer, got '%s'", value)
+		}
+		if options.Error == "" {
+			return fmt.Errorf("error_every requires error to be set")
+		}
+		options.ErrorEvery = every
+	}
+
+	variableService, err := services.GetGlobalVariableService()
+	if err != nil {
+		return fmt.Errorf("variable service not available: %w", err)
+	}
+
+	clientFactory, err := services.GetGlobalClientFactoryService()
+	if err != nil {
+		return fmt.Errorf("client factory service not available: %w", err)
+	}
+
+	client, clientID, err := clientFactory.GetMockClientWithID(options)
+	if err != nil {
+		return fmt.Errorf("failed to create mock client: %w", err)
+	}
+
+	mode := options.Mode
+	if mockClient, ok := client.(*services.MockProviderClient); ok {
+		mode = mockClient.Options().Mode
+	}
+	outputMsg := fmt.Sprintf("Mock client ready: %s (mode: %s)", clientID, mode)
+
+	// Set result variables
+	_ = variableService.SetSystemVariable("_client_id", clientID)
+	_ = variableService.SetSystemVariable("_output", outputMsg)
+
+	// Set metadata variables
+	_ = variableService.SetSystemVariable("#client_provider", client.GetProviderName())
+	_ = variableService.SetSystemVariable("#client_mode", mode)
+	_ = variableService.SetSystemVariable("#client_configured", fmt.Sprintf("%t", client.IsConfigured()))
+	_ = variableService.SetSystemVariable("#client_cache_count", fmt.Sprintf("%d", clientFactory.GetCachedClientCount()))
+
+	fmt.Println(outputMsg)
+
+	return nil
+}
+
+// IsReadOnly returns false as the llm command modifies system state.
+func (c *MockClientNewCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GlobalRegistry.Register(&MockClientNewCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register mock-client-new command: %v", err))
+	}
+}
@@ -0,0 +1,78 @@
+package llm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/internal/context"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// setupMockClientTest registers the services used by mock-client-new.
+func setupMockClientTest(t *testing.T) *services.VariableService {
+	ctx := context.NewTestContext()
+	oldRegistry := services.GetGlobalRegistry()
+	services.SetGlobalRegistry(services.NewRegistry())
+	context.SetGlobalContext(ctx)
+
+	_ = services.GetGlobalRegistry().RegisterService(services.NewVariableService())
+	_ = services.GetGlobalRegistry().RegisterService(services.NewClientFactoryService())
+	require.NoError(t, services.GetGlobalRegistry().InitializeAll())
+
+	t.Cleanup(func() {
+		services.SetGlobalRegistry(oldRegistry)
+		context.ResetGlobalContext()
+	})
+
+	variableService, err := services.GetGlobalVariableService()
+	require.NoError(t, err)
+	return variableService
+}
+
+func TestMockClientNewCommand_Metadata(t *testing.T) {
+	cmd := &MockClientNewCommand{}
+	assert.Equal(t, "mock-client-new", cmd.Name())
+	assert.Equal(t, neurotypes.ParseModeKeyValue, cmd.ParseMode())
+	assert.False(t, cmd.IsReadOnly())
+
+	helpInfo := cmd.HelpInfo()
+	assert.Equal(t, cmd.Name(), helpInfo.Command)
+	require.Len(t, helpInfo.Options, 6)
+	for _, option := range helpInfo.Options {
+		assert.False(t, option.Required, option.Name)
+	}
+	assert.NotEmpty(t, helpInfo.Examples)
+}
+
+func TestMockClientNewCommand_Execute(t *testing.T) {
+	variableService := setupMockClientTest(t)
+	cmd := &MockClientNewCommand{}
+
+	require.NoError(t, cmd.Execute(map[string]string{}, ""))
+	defaultID, _ := variableService.Get("_client_id")
+	assert.Equal(t, "MOCK:empty***", defaultID)
+	mode, _ := variableService.Get("#client_mode")
+	assert.Equal(t, "echo", mode)
+	provider, _ := variableService.Get("#client_provider")
+	assert.Equal(t, "mock", provider)
+
+	require.NoError(t, cmd.Execute(map[string]string{"response": "Done.", "error": "rate_limit", "error_every": "2"}, ""))
+	scriptedID, _ := variableService.Get("_client_id")
+	assert.NotEqual(t, defaultID, scriptedID)
+	mode, _ = variableService.Get("#client_mode")
+	assert.Equal(t, "fixed", mode)
+	count, _ := variableService.Get("#client_cache_count")
+	assert.Equal(t, "2", count)
+}
+
+func TestMockClientNewCommand_Execute_InvalidOptions(t *testing.T) {
+	setupMockClientTest(t)
+	cmd := &MockClientNewCommand{}
+
+	assert.ErrorContains(t, cmd.Execute(map[string]string{"error": "rate_limit", "error_every": "0"}, ""), "positive integer")
+	assert.ErrorContains(t, cmd.Execute(map[string]string{"error_every": "2"}, ""), "requires error")
+	assert.ErrorContains(t, cmd.Execute(map[string]string{"mode": "fixed"}, ""), "requires a response")
+}
@@ -0,0 +1,187 @@
+package llm
+
+import (
+	"fmt"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// OpenAICompatibleClientNewCommand implements the \openai-compatible-client-new command.
+// It creates clients for local and self-hosted servers that speak the OpenAI chat completions API
+// (Ollama, vLLM, llama.cpp server, LM Studio) from their provider catalog entries.
+type OpenAICompatibleClientNewCommand struct{}
+
+// Name returns the command name "openai-compatible-client-new" for registration and lookup.
+func (c *OpenAICompatibleClientNewCommand) Name() string {
+	return "openai-compatible-client-new"
+}
+
+// ParseMode returns ParseModeKeyValue for bracket parameter parsing.
+func (c *OpenAICompatibleClientNewCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the openai-compatible-client-new command does.
+func (c *OpenAICompatibleClientNewCommand) Description() string {
+	return "Create client for a local or self-hosted OpenAI-compatible server"
+}
+
+// Usage returns the syntax and usage examples for the openai-compatible-client-new command.
+func (c *OpenAICompatibleClientNewCommand) Usage() string {
+	return "\\openai-compatible-client-new[catalog_id=OLC, key=api_key] (key is optional)"
+}
+
+// HelpInfo returns structured help information for the openai-compatible-client-new command.
+func (c *OpenAICompatibleClientNewCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       c.Usage(),
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "catalog_id",
+				Description: "Provider catalog ID with client_type openai-compatible (e.g., OLC, VLC, LCC, LSC)",
+				Required:    true,
+				Type:        "string",
+			},
+			{
+				Name:        "key",
+				Description: "API key for servers started with one (optional, falls back to the <CATALOG_ID>_API_KEY env var)",
+				Required:    false,
+				Type:        "string",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\openai-compatible-client-new[catalog_id=OLC]",
+				Description: "Create keyless client for a local Ollama server",
+			},
+			{
+				Command:     "\\openai-compatible-client-new[catalog_id=VLC, key=${VLC_API_KEY}]",
+				Description: "Create client for a vLLM server started with --api-key",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "_client_id",
+				Description: "Contains the created client ID",
+				Type:        "system_output",
+				Example:     "_client_id = \"OLC:empty***\"",
+			},
+			{
+				Name:        "_output",
+				Description: "Contains success message with client details",
+				Type:        "system_output",
+				Example:     "_output = \"Ollama Chat Completions client ready: OLC:empty***\"",
+			},
+			{
+				Name:        "#client_provider",
+				Description: "Contains the provider name from the catalog entry",
+				Type:        "system_metadata",
+				Example:     "#client_provider = \"ollama\"",
+			},
+			{
+				Name:        "#client_base_url",
+				Description: "Contains the base URL requests are sent to",
+				Type:        "system_metadata",
+				Example:     "#client_base_url = \"http://localhost:11434/v1\"",
+			},
+			{
+				Name:        "#client_configured",
+				Description: "Contains client configuration status",
+				Type:        "system_metadata",
+				Example:     "#client_configured = \"true\"",
+			},
+		},
+		Notes: []string{
+			"Key resolution priority: 1) key parameter, 2) <CATALOG_ID>_API_KEY env var (e.g., VLC_API_KEY), 3) no key",
+			"Keys of other providers (#active_<provider>_key, <PROVIDER>_API_KEY) are never used, since catalog entries choose their own base URL",
+			"Keyless clients never send an Authorization header, so OpenAI credentials are not forwarded",
+			"Base URL and headers come from the provider catalog entry (see \\provider-catalog)",
+			"\\model-new creates this client automatically for models of OpenAI-compatible providers",
+		},
+	}
+}
+
+// Execute creates a new OpenAI-compatible client from a provider catalog entry.
+func (c *OpenAICompatibleClientNewCommand) Execute(args map[string]string, _ string) error {
+	catalogID := args["catalog_id"]
+	if catalogID == "" {
+		return fmt.Errorf("catalog_id is required\n\nUsage: %s", c.Usage())
+	}
+
+	variableService, err := services.GetGlobalVariableService()
+	if err != nil {
+		return fmt.Errorf("variable service not available: %w", err)
+	}
+
+	providerCatalogService, err := services.GetGlobalProviderCatalogService()
+	if err != nil {
+		return fmt.Errorf("provider catalog service not available: %w", err)
+	}
+
+	entry, err := providerCatalogService.GetProviderByID(catalogID)
+	if err != nil {
+		return fmt.Errorf("failed to find provider with catalog_id '%s': %w", catalogID, err)
+	}
+	if !entry.IsOpenAICompatible() {
+		return fmt.Errorf("provider '%s' uses client type '%s', not openai-compatible", entry.ID, entry.ClientType)
+	}
+
+	apiKey := c.resolveAPIKey(args, entry, variableService)
+
+	clientFactory, err := services.GetGlobalClientFactoryService()
+	if err != nil {
+		return fmt.Errorf("client factory service not available: %w", err)
+	}
+
+	client, clientID, err := clientFactory.GetClientWithID(entry.ID, apiKey)
+	if err != nil {
+		return fmt.Errorf("failed to create %s client: %w", entry.DisplayName, err)
+	}
+
+	// Set result variables
+	_ = variableService.SetSystemVariable("_client_id", clientID)
+	_ = variableService.SetSystemVariable("_output", fmt.Sprintf("%s client ready: %s", entry.DisplayName, clientID))
+
+	// Set metadata variables
+	_ = variableService.SetSystemVariable("#client_provider", entry.Provider)
+	_ = variableService.SetSystemVariable("#client_base_url", entry.BaseURL)
+	_ = variableService.SetSystemVariable("#client_configured", fmt.Sprintf("%t", client.IsConfigured()))
+	_ = variableService.SetSystemVariable("#client_cache_count", fmt.Sprintf("%d", clientFactory.GetCachedClientCount()))
+
+	// Output success message
+	fmt.Printf("%s client ready: %s (base URL: %s, key: %t)\n", entry.DisplayName, clientID, entry.BaseURL, apiKey != "")
+
+	return nil
+}
+
+// resolveAPIKey resolves the optional API key using priority order:
+// 1. User-provided key parameter
+// 2. <CATALOG_ID>_API_KEY environment variable (e.g., VLC_API_KEY)
+// Keys of other providers are never used: catalog entries choose their own base_url and provider
+// name, so falling back to e.g. ANTHROPIC_API_KEY could send it to an arbitrary server.
+// An empty key is valid: most local servers accept unauthenticated requests.
+func (c *OpenAICompatibleClientNewCommand) resolveAPIKey(args map[string]string, entry neurotypes.ProviderCatalogEntry, variableService *services.VariableService) string {
+	if key := args["key"]; key != "" {
+		return key
+	}
+
+	envName := strings.ToUpper(entry.ID) + "_API_KEY"
+	return variableService.GetEnv(envName)
+}
+
+// IsReadOnly returns false as the llm command modifies system state.
+func (c *OpenAICompatibleClientNewCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GlobalRegistry.Register(&OpenAICompatibleClientNewCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register openai-compatible-client-new command: %v", err))
+	}
+}
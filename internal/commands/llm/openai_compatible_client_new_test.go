@@ -0,0 +1,122 @@
+package llm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/internal/context"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// setupOpenAICompatibleClientTest registers the services used by openai-compatible-client-new.
+func setupOpenAICompatibleClientTest(t *testing.T) (neurotypes.Context, *services.VariableService) {
+	ctx := context.NewTestContext()
+	oldRegistry := services.GetGlobalRegistry()
+	services.SetGlobalRegistry(services.NewRegistry())
+	context.SetGlobalContext(ctx)
+
+	_ = services.GetGlobalRegistry().RegisterService(services.NewVariableService())
+	_ = services.GetGlobalRegistry().RegisterService(services.NewProviderCatalogService())
+	_ = services.GetGlobalRegistry().RegisterService(services.NewClientFactoryService())
+	require.NoError(t, services.GetGlobalRegistry().InitializeAll())
+
+	t.Cleanup(func() {
+		services.SetGlobalRegistry(oldRegistry)
+		context.ResetGlobalContext()
+	})
+
+	variableService, err := services.GetGlobalVariableService()
+	require.NoError(t, err)
+	return ctx, variableService
+}
+
+func TestOpenAICompatibleClientNewCommand_Metadata(t *testing.T) {
+	cmd := &OpenAICompatibleClientNewCommand{}
+	assert.Equal(t, "openai-compatible-client-new", cmd.Name())
+	assert.Equal(t, neurotypes.ParseModeKeyValue, cmd.ParseMode())
+	assert.Contains(t, cmd.Usage(), "catalog_id=")
+
+	helpInfo := cmd.HelpInfo()
+	assert.Equal(t, cmd.Name(), helpInfo.Command)
+	require.Len(t, helpInfo.Options, 2)
+	assert.Equal(t, "catalog_id", helpInfo.Options[0].Name)
+	assert.True(t, helpInfo.Options[0].Required)
+	assert.Equal(t, "key", helpInfo.Options[1].Name)
+	assert.False(t, helpInfo.Options[1].Required)
+	assert.NotEmpty(t, helpInfo.Examples)
+	assert.NotEmpty(t, helpInfo.Notes)
+}
+
+func TestOpenAICompatibleClientNewCommand_Execute_Keyless(t *testing.T) {
+	_, variableService := setupOpenAICompatibleClientTest(t)
+
+	cmd := &OpenAICompatibleClientNewCommand{}
+	require.NoError(t, cmd.Execute(map[string]string{"catalog_id": "olc"}, ""))
+
+	clientID, err := variableService.Get("_client_id")
+	require.NoError(t, err)
+	assert.Equal(t, "OLC:empty***", clientID)
+
+	provider, _ := variableService.Get("#client_provider")
+	assert.Equal(t, "ollama", provider)
+	baseURL, _ := variableService.Get("#client_base_url")
+	assert.Equal(t, "http://localhost:11434/v1", baseURL)
+	configured, _ := variableService.Get("#client_configured")
+	assert.Equal(t, "true", configured)
+}
+
+func TestOpenAICompatibleClientNewCommand_Execute_KeyResolution(t *testing.T) {
+	ctx, variableService := setupOpenAICompatibleClientTest(t)
+	cmd := &OpenAICompatibleClientNewCommand{}
+
+	// Keys named after the provider are not forwarded to catalog-defined endpoints
+	ctx.SetTestEnvOverride("VLLM_API_KEY", "env-vllm-key")
+	require.NoError(t, variableService.SetSystemVariable("#active_vllm_key", "active-vllm-key"))
+	require.NoError(t, cmd.Execute(map[string]string{"catalog_id": "VLC"}, ""))
+	keylessClientID, _ := variableService.Get("_client_id")
+	assert.Equal(t, "VLC:empty***", keylessClientID)
+
+	ctx.SetTestEnvOverride("VLC_API_KEY", "env-vlc-key")
+	require.NoError(t, cmd.Execute(map[string]string{"catalog_id": "VLC"}, ""))
+	envClientID, _ := variableService.Get("_client_id")
+	assert.Regexp(t, `^VLC:[0-9a-f]{8}$`, envClientID)
+
+	require.NoError(t, cmd.Execute(map[string]string{"catalog_id": "VLC", "key": "explicit-key"}, ""))
+	explicitClientID, _ := variableService.Get("_client_id")
+	assert.Regexp(t, `^VLC:[0-9a-f]{8}$`, explicitClientID)
+	assert.NotEqual(t, envClientID, explicitClientID, "key parameter takes precedence over VLC_API_KEY")
+}
+
+func TestOpenAICompatibleClientNewCommand_ResolveAPIKey_IgnoresOtherProviders(t *testing.T) {
+	ctx, variableService := setupOpenAICompatibleClientTest(t)
+	cmd := &OpenAICompatibleClientNewCommand{}
+
+	// A catalog entry claiming to be an anthropic endpoint must not receive the Anthropic key
+	entry := neurotypes.ProviderCatalogEntry{ID: "EVIL", Provider: "anthropic", ClientType: "openai-compatible", BaseURL: "https://attacker.example/v1"}
+	ctx.SetTestEnvOverride("ANTHROPIC_API_KEY", "sk-ant-secret")
+	require.NoError(t, variableService.SetSystemVariable("#active_anthropic_key", "sk-ant-active"))
+	assert.Empty(t, cmd.resolveAPIKey(map[string]string{}, entry, variableService))
+
+	ctx.SetTestEnvOverride("EVIL_API_KEY", "own-key")
+	assert.Equal(t, "own-key", cmd.resolveAPIKey(map[string]string{}, entry, variableService))
+}
+
+func TestOpenAICompatibleClientNewCommand_Execute_Errors(t *testing.T) {
+	setupOpenAICompatibleClientTest(t)
+	cmd := &OpenAICompatibleClientNewCommand{}
+
+	err := cmd.Execute(map[string]string{}, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "catalog_id is required")
+
+	err = cmd.Execute(map[string]string{"catalog_id": "NOPE"}, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to find provider")
+
+	err = cmd.Execute(map[string]string{"catalog_id": "ANC"}, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not openai-compatible")
+}
@@ -0,0 +1,245 @@
+package llm
+
+import (
+	"fmt"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+	"neuroshell/pkg/stringprocessing"
+)
+
+// UsageCommand implements the \usage command for reporting token usage and cost.
+// Totals are accumulated by \llm-call per chat session and per model.
+type UsageCommand struct{}
+
+// Name returns the command name "usage" for registration and lookup.
+func (c *UsageCommand) Name() string {
+	return "usage"
+}
+
+// ParseMode returns ParseModeKeyValue for bracket parameter parsing.
+func (c *UsageCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the usage command does.
+func (c *UsageCommand) Description() string {
+	return "Show token usage and cost totals per session and per model"
+}
+
+// Usage returns the syntax and usage examples for the usage command.
+func (c *UsageCommand) Usage() string {
+	return `\usage[session=session_name, model=model_name, reset=false]
+
+Examples:
+  \usage                          %% Totals for all sessions and models
+  \usage[session=work]            %% Totals for one session (name or ID)
+  \usage[model=my-gpt4]           %% Totals for one model
+  \usage[reset=true]              %% Clear all recorded totals
+
+Options:
+  session - Only report the given session (name or ID)
+  model   - Only report the given model
+  reset   - Clear all recorded totals (default: false)
+
+Notes:
+  - Totals cover every \llm-call made in this shell, including tool calling rounds
+  - Cost uses the pricing of the model's catalog entry; models without pricing report tokens only
+  - Input tokens include cached tokens; output tokens include thinking tokens`
+}
+
+// HelpInfo returns structured help information for the usage command.
+func (c *UsageCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       `\usage[session=session_name, model=model_name, reset=false]`,
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "session",
+				Description: "Only report the given session (name or ID)",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "model",
+				Description: "Only report the given model",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "reset",
+				Description: "Clear all recorded totals",
+				Required:    false,
+				Type:        "boolean",
+				Default:     "false",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     `\usage`,
+				Description: "Show totals for all sessions and models",
+			},
+			{
+				Command:     `\usage[session=work]`,
+				Description: "Show totals for the 'work' session",
+			},
+			{
+				Command:     `\usage[reset=true]`,
+				Description: "Clear all recorded totals",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "#usage_requests",
+				Description: "Number of calls in the reported totals",
+				Type:        "system_metadata",
+				Example:     "3",
+			},
+			{
+				Name:        "#usage_input_tokens",
+				Description: "Input tokens in the reported totals",
+				Type:        "system_metadata",
+				Example:     "1520",
+			},
+			{
+				Name:        "#usage_output_tokens",
+				Description: "Output tokens in the reported totals",
+				Type:        "system_metadata",
+				Example:     "430",
+			},
+			{
+				Name:        "#usage_cost_usd",
+				Description: "Cost in USD of the reported totals",
+				Type:        "system_metadata",
+				Example:     "0.004210",
+			},
+			{
+				Name:        "_output",
+				Description: "Formatted usage report",
+				Type:        "command_output",
+				Example:     "Usage (all sessions): 3 calls ...",
+			},
+		},
+		Notes: []string{
+			"Totals cover every \\llm-call made in this shell, including tool calling rounds",
+			"Cost uses catalog pricing; models without pricing report tokens only",
+			"Input tokens include cached tokens; output tokens include thinking tokens",
+		},
+	}
+}
+
+// Execute reports (or resets) the recorded usage totals.
+func (c *UsageCommand) Execute(args map[string]string, _ string) error {
+	usageService, err := services.GetGlobalUsageService()
+	if err != nil {
+		return fmt.Errorf("usage service not available: %w", err)
+	}
+
+	variableService, err := services.GetGlobalVariableService()
+	if err != nil {
+		return fmt.Errorf("variable service not available: %w", err)
+	}
+
+	if stringprocessing.IsTruthy(args["reset"]) {
+		usageService.Reset()
+		c.storeTotals(variableService, neurotypes.UsageTotals{})
+		output := "Usage totals cleared"
+		_ = variableService.SetSystemVariable("_output", output)
+		fmt.Println(output)
+		return nil
+	}
+
+	var output strings.Builder
+	var reported neurotypes.UsageTotals
+
+	switch {
+	case args["session"] != "":
+		sessionID, sessionName := c.resolveSession(args["session"])
+		reported = usageService.GetSessionTotals(sessionID)
+		output.WriteString(fmt.Sprintf("Usage for session '%s': %s\n", sessionName, formatUsageTotals(reported)))
+	case args["model"] != "":
+		reported = usageService.GetModelTotals(args["model"])
+		output.WriteString(fmt.Sprintf("Usage for model '%s': %s\n", args["model"], formatUsageTotals(reported)))
+	default:
+		reported = usageService.GetOverallTotals()
+		output.WriteString(fmt.Sprintf("Usage (all sessions): %s\n", formatUsageTotals(reported)))
+
+		if sessionIDs := usageService.ListSessionIDs(); len(sessionIDs) > 0 {
+			output.WriteString("Sessions:\n")
+			for _, sessionID := range sessionIDs {
+				_, sessionName := c.resolveSession(sessionID)
+				output.WriteString(fmt.Sprintf("  %s: %s\n", sessionName, formatUsageTotals(usageService.GetSessionTotals(sessionID))))
+			}
+		}
+		if modelNames := usageService.ListModelNames(); len(modelNames) > 0 {
+			output.WriteString("Models:\n")
+			for _, modelName := range modelNames {
+				output.WriteString(fmt.Sprintf("  %s: %s\n", modelName, formatUsageTotals(usageService.GetModelTotals(modelName))))
+			}
+		}
+	}
+
+	c.storeTotals(variableService, reported)
+	_ = variableService.SetSystemVariable("_output", output.String())
+
+	fmt.Print(output.String())
+	return nil
+}
+
+// resolveSession maps a session name or ID to its ID and display name.
+// Deleted or unknown sessions are reported by the identifier that was given.
+func (c *UsageCommand) resolveSession(nameOrID string) (string, string) {
+	sessionService, err := services.GetGlobalChatSessionService()
+	if err != nil {
+		return nameOrID, nameOrID
+	}
+	session, err := sessionService.GetSessionByNameOrID(nameOrID)
+	if err != nil {
+		return nameOrID, nameOrID
+	}
+	return session.ID, session.Name
+}
+
+// storeTotals stores the reported totals in system variables.
+func (c *UsageCommand) storeTotals(variableService *services.VariableService, totals neurotypes.UsageTotals) {
+	_ = variableService.SetSystemVariable("#usage_requests", fmt.Sprintf("%d", totals.Requests))
+	_ = variableService.SetSystemVariable("#usage_input_tokens", fmt.Sprintf("%d", totals.Tokens.InputTokens))
+	_ = variableService.SetSystemVariable("#usage_output_tokens", fmt.Sprintf("%d", totals.Tokens.OutputTokens))
+	_ = variableService.SetSystemVariable("#usage_cost_usd", fmt.Sprintf("%.6f", totals.CostUSD))
+}
+
+// formatUsageTotals renders totals as a single summary line.
+func formatUsageTotals(totals neurotypes.UsageTotals) string {
+	if totals.Requests == 0 {
+		return "no calls recorded"
+	}
+
+	callWord := "calls"
+	if totals.Requests == 1 {
+		callWord = "call"
+	}
+	line := fmt.Sprintf("%d %s, %d input tokens (%d cached), %d output tokens (%d thinking), $%.6f",
+		totals.Requests, callWord,
+		totals.Tokens.InputTokens, totals.Tokens.CachedTokens,
+		totals.Tokens.OutputTokens, totals.Tokens.ThinkingTokens,
+		totals.CostUSD)
+	if totals.UnpricedRequests > 0 {
+		line += fmt.Sprintf(" (%d without pricing)", totals.UnpricedRequests)
+	}
+	return line
+}
+
+// IsReadOnly returns false as the usage command modifies system state.
+func (c *UsageCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&UsageCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register usage command: %v", err))
+	}
+}
@@ -0,0 +1,105 @@
+package llm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/internal/context"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// setupUsageTestRegistry creates a registry with the services needed to make mock calls and report usage.
+func setupUsageTestRegistry(t *testing.T) {
+	ctx := context.New()
+	ctx.SetTestMode(true)
+
+	registry := services.NewRegistry()
+	require.NoError(t, registry.RegisterService(services.NewClientFactoryService()))
+	require.NoError(t, registry.RegisterService(services.NewModelService()))
+	require.NoError(t, registry.RegisterService(services.NewChatSessionService()))
+	require.NoError(t, registry.RegisterService(services.NewMockLLMService()))
+	require.NoError(t, registry.RegisterService(services.NewVariableService()))
+	require.NoError(t, registry.RegisterService(services.NewDebugTransportService()))
+	require.NoError(t, registry.RegisterService(services.NewUsageService()))
+	require.NoError(t, registry.InitializeAll())
+
+	oldRegistry := services.GetGlobalRegistry()
+	services.SetGlobalRegistry(registry)
+	oldCtx := context.GetGlobalContext()
+	context.SetGlobalContext(ctx)
+
+	t.Cleanup(func() {
+		services.SetGlobalRegistry(oldRegistry)
+		context.SetGlobalContext(oldCtx)
+	})
+}
+
+func TestUsageCommand_BasicProperties(t *testing.T) {
+	cmd := &UsageCommand{}
+	assert.Equal(t, "usage", cmd.Name())
+	assert.Equal(t, neurotypes.ParseModeKeyValue, cmd.ParseMode())
+	assert.NotEmpty(t, cmd.Description())
+	assert.Contains(t, cmd.Usage(), "\\usage")
+	assert.False(t, cmd.IsReadOnly())
+	assert.Equal(t, cmd.Name(), cmd.HelpInfo().Command)
+}
+
+func TestUsageCommand_Execute_AfterCalls(t *testing.T) {
+	setupUsageTestRegistry(t)
+
+	clientFactory, _ := services.GetGlobalClientFactoryService()
+	modelService, _ := services.GetGlobalModelService()
+	sessionService, _ := services.GetGlobalChatSessionService()
+	variableService, _ := services.GetGlobalVariableService()
+
+	_, clientID, err := clientFactory.GetClientWithID("OAR", "test-api-key")
+	require.NoError(t, err)
+	model, err := modelService.CreateModelWithGlobalContext("usage-model", "openai", "gpt-4", map[string]any{}, "Test model", "")
+	require.NoError(t, err)
+	session, err := sessionService.CreateSession("usage-session", "", "")
+	require.NoError(t, err)
+	require.NoError(t, sessionService.AddMessage(session.ID, "user", "one two three"))
+
+	callCmd := &CallCommand{}
+	args := map[string]string{"client_id": clientID, "model_id": model.Name, "session_id": session.ID}
+	require.NoError(t, callCmd.Execute(args, ""))
+	require.NoError(t, callCmd.Execute(args, ""))
+
+	// The mock counts one token per word; the model has no catalog pricing
+	inputTokens, _ := variableService.Get("#llm_input_tokens")
+	assert.Equal(t, "3", inputTokens)
+	outputTokens, _ := variableService.Get("#llm_output_tokens")
+	assert.NotEmpty(t, outputTokens)
+	cost, _ := variableService.Get("#llm_cost_usd")
+	assert.Empty(t, cost, "cost is unknown without catalog pricing")
+
+	cmd := &UsageCommand{}
+	require.NoError(t, cmd.Execute(map[string]string{}, ""))
+	output, _ := variableService.Get("_output")
+	assert.Contains(t, output, "Usage (all sessions): 2 calls")
+	assert.Contains(t, output, "usage-session: 2 calls")
+	assert.Contains(t, output, "usage-model: 2 calls")
+	assert.Contains(t, output, "(2 without pricing)")
+
+	requests, _ := variableService.Get("#usage_requests")
+	assert.Equal(t, "2", requests)
+	totalInput, _ := variableService.Get("#usage_input_tokens")
+	assert.Equal(t, "6", totalInput)
+
+	require.NoError(t, cmd.Execute(map[string]string{"session": "usage-session"}, ""))
+	output, _ = variableService.Get("_output")
+	assert.Contains(t, output, "Usage for session 'usage-session': 2 calls")
+
+	require.NoError(t, cmd.Execute(map[string]string{"model": "other-model"}, ""))
+	output, _ = variableService.Get("_output")
+	assert.Contains(t, output, "no calls recorded")
+
+	require.NoError(t, cmd.Execute(map[string]string{"reset": "true"}, ""))
+	requests, _ = variableService.Get("#usage_requests")
+	assert.Equal(t, "0", requests)
+	usageService, _ := services.GetGlobalUsageService()
+	assert.Equal(t, 0, usageService.GetOverallTotals().Requests)
+}
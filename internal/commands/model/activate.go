@@ -505,9 +505,14 @@ func (c *ActivateCommand) generateClientNewCommand(catalogID string, model *neur
 		return "\\try \\silent \\anthropic-client-new"
 	case "GMC":
 		return "\\try \\silent \\gemini-client-new"
+	case services.MockProviderCatalogID:
+		return "\\try \\silent \\mock-client-new"
 	default:
+		// Local and self-hosted servers share the generic OpenAI-compatible client
+		if providerCatalogService, err := services.GetGlobalProviderCatalogService(); err == nil && providerCatalogService.IsOpenAICompatible(catalogID) {
+			return fmt.Sprintf("\\try \\silent \\openai-compatible-client-new[catalog_id=%s]", catalogID)
+		}
 		// For other providers, we don't have specialized commands yet
-		// This maintains backward compatibility while supporting the main providers
 		return ""
 	}
 }
@@ -35,13 +35,14 @@ func (c *CatalogCommand) Description() string {
 
 // Usage returns the syntax and usage examples for the model-catalog command.
 func (c *CatalogCommand) Usage() string {
-	return `\model-catalog[provider=openai|anthropic|gemini|all, sort=name|provider, search=query]
+	return `\model-catalog[provider=openai|anthropic|gemini|ollama|vllm|llamacpp|lmstudio|mock|all, sort=name|provider, search=query]
 
 Examples:
   \model-catalog                              %% List all available models (default: sorted by provider)
   \model-catalog[provider=openai]             %% List OpenAI models only
   \model-catalog[provider=anthropic]          %% List Anthropic models only
   \model-catalog[provider=gemini]             %% List Google Gemini models only
+  \model-catalog[provider=ollama]             %% List local Ollama models only
   \model-catalog[sort=name]                   %% Sort models alphabetically by name
   \model-catalog[search=gpt-4]                %% Search for models containing "gpt-4"
   \model-catalog[search=CS4]                  %% Search by model ID (case-insensitive)
@@ -50,14 +51,18 @@ Examples:
   \model-catalog[search=claude,sort=name]     %% Search for Claude models, sorted by name
 
 Options:
-  provider - Filter by provider: openai, anthropic, gemini, all (default: all)
+  provider - Filter by provider: openai, anthropic, gemini, ollama, vllm, llamacpp, lmstudio, mock, all (default: all)
   sort     - Sort order: name (alphabetical), provider (by provider then name)
   search   - Search query to filter models by ID, name, display name, or description
 
 Note: Options can be combined. Default sort is by provider.
       Model catalog is stored in ${_output} variable.
       Shows model ID, display name, provider, capabilities, and context window.
-      Model IDs are shown in format: [ID] Display Name (model_name)`
+      Model IDs are shown in format: [ID] Display Name (model_name)
+      User models are loaded from ~/.config/neuroshell/models/ and ./.neuro/models/ at startup.
+      User models override embedded models with the same ID; project models only add new IDs
+      unless NEURO_PROJECT_CATALOG_OVERRIDES=1 is set in the environment.
+      Their Source line shows the file; problems with catalog files are listed as warnings.`
 }
 
 // HelpInfo returns structured help information for the model-catalog command.
@@ -65,12 +70,12 @@ func (c *CatalogCommand) HelpInfo() neurotypes.HelpInfo {
 	return neurotypes.HelpInfo{
 		Command:     c.Name(),
 		Description: c.Description(),
-		Usage:       "\\model-catalog[provider=openai|anthropic|gemini|all, sort=name|provider, search=query]",
+		Usage:       "\\model-catalog[provider=openai|anthropic|gemini|ollama|vllm|llamacpp|lmstudio|mock|all, sort=name|provider, search=query]",
 		ParseMode:   c.ParseMode(),
 		Options: []neurotypes.HelpOption{
 			{
 				Name:        "provider",
-				Description: "Filter by provider: openai, anthropic, gemini, all",
+				Description: "Filter by provider: openai, anthropic, gemini, ollama, vllm, llamacpp, lmstudio, mock, all",
 				Required:    false,
 				Type:        "string",
 				Default:     "all",
@@ -129,6 +134,10 @@ func (c *CatalogCommand) HelpInfo() neurotypes.HelpInfo {
 			"Shows model ID, display name, provider, capabilities, context window, and deprecation status",
 			"Model IDs are displayed in format: [ID] Display Name (model_name)",
 			"Embedded catalog includes popular models from OpenAI and Anthropic",
+			"YAML files in ~/.config/neuroshell/models/ and ./.neuro/models/ are loaded at startup and add models",
+			"User models override embedded ones by ID; project models may only override with NEURO_PROJECT_CATALOG_OVERRIDES=1 in the environment",
+			"Invalid catalog files are skipped and listed as warnings",
+			"User and project models show a Source line with the file they were loaded from",
 			"Search is case-insensitive and matches ID, name, display name, or description",
 			"Model IDs can be used with \\model-new[catalog_id=<ID>] for easy model creation",
 		},
@@ -208,6 +217,7 @@ func (c *CatalogCommand) Execute(args map[string]string, _ string) error {
 
 	// Format output with theme styling
 	output := c.formatModelCatalog(models, provider, sortBy, searchQuery, modelToProvider, themeObj)
+	output += c.formatLoadWarnings(modelCatalogService.LoadWarnings(), themeObj)
 
 	// Store result in _output variable
 	if err := variableService.SetSystemVariable("_output", output); err != nil {
@@ -221,6 +231,19 @@ func (c *CatalogCommand) Execute(args map[string]string, _ string) error {
 	return nil
 }
 
+// formatLoadWarnings lists problems found in user and project catalog files.
+func (c *CatalogCommand) formatLoadWarnings(warnings []string, themeObj *services.Theme) string {
+	if len(warnings) == 0 {
+		return ""
+	}
+	var result strings.Builder
+	result.WriteString("\n" + themeObj.Warning.Render("Catalog warnings:") + "\n")
+	for _, warning := range warnings {
+		result.WriteString(fmt.Sprintf("  • %s\n", themeObj.Warning.Render(warning)))
+	}
+	return result.String()
+}
+
 // validateArguments checks if the provided provider and sort options are valid.
 func (c *CatalogCommand) validateArguments(provider, sortBy string) error {
 	validProviders := map[string]bool{
@@ -228,9 +251,14 @@ func (c *CatalogCommand) validateArguments(provider, sortBy string) error {
 		"openai":    true,
 		"anthropic": true,
 		"gemini":    true,
+		"ollama":    true,
+		"vllm":      true,
+		"llamacpp":  true,
+		"lmstudio":  true,
+		"mock":      true,
 	}
 	if !validProviders[provider] {
-		return fmt.Errorf("invalid provider option '%s'. Valid options: all, openai, anthropic, gemini", provider)
+		return fmt.Errorf("invalid provider option '%s'. Valid options: all, openai, anthropic, gemini, ollama, vllm, llamacpp, lmstudio, mock", provider)
 	}
 
 	validSorts := map[string]bool{
@@ -586,6 +614,14 @@ func (c *CatalogCommand) formatModelEntry(model neurotypes.ModelCatalogEntry, sh
 		result.WriteString(descriptionLine)
 	}
 
+	// Source of user and project entries (embedded entries are not annotated)
+	if sourceText := model.Source.Describe(); sourceText != "" {
+		sourceLine := fmt.Sprintf("    %s %s\n",
+			themeObj.Info.Render("Source:"),
+			themeObj.Variable.Render(sourceText))
+		result.WriteString(sourceLine)
+	}
+
 	return result.String()
 }
 
@@ -33,7 +33,7 @@ func (c *NewCommand) Description() string {
 
 // Usage returns the syntax and usage examples for the model-new command.
 func (c *NewCommand) Usage() string {
-	return `\model-new[catalog_id=<ID>, temperature=0.7, max_tokens=1000, thinking_budget=1024, ...] model_name
+	return `\model-new[catalog_id=<ID>, served_model=<name>, temperature=0.7, max_tokens=1000, thinking_budget=1024, ...] model_name
 
 Examples:
   \model-new[catalog_id=CS4] my-claude                                   %% Create from catalog (Claude Sonnet 4)
@@ -43,11 +43,16 @@ Examples:
   \model-new[catalog_id=GM25P, thinking_budget=-1] dynamic-model         %% Create Gemini Pro with dynamic thinking
   \model-new[catalog_id=O3] my-o3                                       %% Create OpenAI o3 (delegates to \\openai-model-new)
   \model-new[catalog_id=CO4, max_tokens=4000] analysis-opus              %% Create Claude Opus 4 with custom max tokens
+  \model-new[catalog_id=CS4, max_retries=5] patient-claude              %% Retry rate limits and overloads up to 5 times
+  \model-new[catalog_id=CS4, context_policy=summarize-oldest] long-chat  %% Summarize early turns when the context is full
+  \model-new[catalog_id=OLM, served_model=qwen2.5-coder:7b] local-coder   %% Use a model served by a local Ollama instance
+  \model-new[catalog_id=MOCK] test-model                                %% Scripted mock model for tests without API keys
 
 Required Options:
   catalog_id - Short model ID from catalog (e.g., CS4, O3, CO37, GM25F) - auto-populates provider and base_model
 
 Optional Parameters:
+  served_model - Model name served by a local OpenAI-compatible server (OLM, VLM, LCM, LSM only)
   temperature - Controls randomness (0.0-1.0, default varies by provider)
   max_tokens - Maximum tokens to generate (positive integer)
   top_p - Nucleus sampling parameter (0.0-1.0)
@@ -55,6 +60,10 @@ Optional Parameters:
   presence_penalty - Presence penalty (-2.0 to 2.0)
   frequency_penalty - Frequency penalty (-2.0 to 2.0)
   thinking_budget - Thinking tokens budget for Gemini models (-1=dynamic, 0=disabled, positive=fixed)
+  max_retries - Retries for rate limit and server errors (0-10, overrides ${_llm_retry})
+  context_policy - When the session outgrows the context window: error, truncate-oldest,
+                   keep-system-and-last-N or summarize-oldest (overrides ${_context_policy})
+  context_window - Context window in tokens, overriding the catalog (e.g. for a local server's setting)
   description - Human-readable description of the model configuration
 
 Note: Model name is required and taken from the input parameter.
@@ -62,6 +71,8 @@ Note: Model name is required and taken from the input parameter.
       Use \model-catalog to see available catalog IDs.
       Provider and base_model are auto-populated from the model catalog.
       thinking_budget is only supported by Gemini 2.5 models (Pro, Flash, Flash Lite).
+      Local OpenAI-compatible servers (Ollama, vLLM, llama.cpp, LM Studio) need no API key.
+      The MOCK model echoes messages without an API key; see \mock-client-new for scripted responses.
       Additional provider-specific parameters can be passed and will be stored.`
 }
 
@@ -79,6 +90,12 @@ func (c *NewCommand) HelpInfo() neurotypes.HelpInfo {
 				Required:    true,
 				Type:        "string",
 			},
+			{
+				Name:        "served_model",
+				Description: "Model name served by a local OpenAI-compatible server (OLM, VLM, LCM, LSM only)",
+				Required:    false,
+				Type:        "string",
+			},
 			{
 				Name:        "temperature",
 				Description: "Controls randomness in model output (0.0-1.0)",
@@ -127,6 +144,24 @@ func (c *NewCommand) HelpInfo() neurotypes.HelpInfo {
 				Required:    false,
 				Type:        "int",
 			},
+			{
+				Name:        "max_retries",
+				Description: "Retries for rate limit and server errors (0-10, overrides ${_llm_retry})",
+				Required:    false,
+				Type:        "int",
+			},
+			{
+				Name:        "context_policy",
+				Description: "Context window policy: error, truncate-oldest, keep-system-and-last-N or summarize-oldest",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "context_window",
+				Description: "Context window in tokens, overriding the catalog value",
+				Required:    false,
+				Type:        "int",
+			},
 		},
 		Examples: []neurotypes.HelpExample{
 			{
@@ -157,6 +192,14 @@ func (c *NewCommand) HelpInfo() neurotypes.HelpInfo {
 				Command:     "\\model-new[catalog_id=CO4, max_tokens=4000] analysis-opus",
 				Description: "Create Claude Opus 4 with custom max tokens",
 			},
+			{
+				Command:     "\\model-new[catalog_id=OLM, served_model=qwen2.5-coder:7b] local-coder",
+				Description: "Create model served by a local Ollama instance (no API key needed)",
+			},
+			{
+				Command:     "\\model-new[catalog_id=MOCK] test-model",
+				Description: "Create scripted mock model for tests without API keys",
+			},
 		},
 		StoredVariables: []neurotypes.HelpStoredVariable{
 			{
@@ -217,6 +260,7 @@ func (c *NewCommand) HelpInfo() neurotypes.HelpInfo {
 			"thinking_budget values: -1=dynamic, 0=disabled, positive=fixed token count",
 			"Each Gemini model has different thinking_budget ranges (see model catalog)",
 			"OpenAI models are delegated to \\openai-model-new for specialized handling",
+			"served_model selects the model for local OpenAI-compatible servers (Ollama, vLLM, llama.cpp, LM Studio)",
 			"Variables in model name and parameters are interpolated",
 			"Additional provider-specific parameters can be included",
 		},
@@ -273,6 +317,19 @@ func (c *NewCommand) Execute(args map[string]string, input string) error {
 	provider := catalogModel.Provider
 	baseModel := catalogModel.Name
 
+	// Local OpenAI-compatible servers serve whatever models the user installed,
+	// so their catalog entries are templates whose model name can be overridden
+	compatibleProvider := c.isOpenAICompatible(catalogModel.ProviderCatalogID)
+	if servedModel, exists := args["served_model"]; exists {
+		if !compatibleProvider {
+			return fmt.Errorf("served_model can only be set for OpenAI-compatible providers (catalog_id '%s' uses provider '%s')", catalogID, provider)
+		}
+		if servedModel == "" {
+			return fmt.Errorf("served_model cannot be empty")
+		}
+		baseModel = servedModel
+	}
+
 	// For OpenAI provider, delegate to specialized command with better parameter handling
 	if provider == "openai" {
 		return c.delegateToOpenAIModelNew(args, input)
@@ -313,9 +370,20 @@ func (c *NewCommand) Execute(args map[string]string, input string) error {
 
 	// Auto-push client creation command to stack service for seamless UX
 	if stackService, err := services.GetGlobalStackService(); err == nil {
-		clientCommand := c.generateClientNewCommand(createdModel.Provider)
-		if clientCommand != "" {
-			stackService.PushCommand(clientCommand)
+		if compatibleProvider {
+			// Activate the new client after creating it (LIFO stack), so requests go to the local server
+			// rather than to a previously activated client of another provider
+			stackService.PushCommand(fmt.Sprintf("\\try \\silent \\llm-client-activate %s", catalogModel.ProviderCatalogID))
+			stackService.PushCommand(fmt.Sprintf("\\try \\silent \\openai-compatible-client-new[catalog_id=%s]", catalogModel.ProviderCatalogID))
+		} else if catalogModel.ProviderCatalogID == services.MockProviderCatalogID {
+			// The default mock client echoes messages; \mock-client-new creates scripted ones
+			stackService.PushCommand(fmt.Sprintf("\\try \\silent \\llm-client-activate %s", services.MockProviderCatalogID))
+			stackService.PushCommand("\\try \\silent \\mock-client-new")
+		} else {
+			clientCommand := c.generateClientNewCommand(createdModel.Provider)
+			if clientCommand != "" {
+				stackService.PushCommand(clientCommand)
+			}
 		}
 	}
 
@@ -403,11 +471,39 @@ func (c *NewCommand) parseParameters(args map[string]string, parameters map[stri
 		parameters["frequency_penalty"] = frequencyPenaltyFloat
 	}
 
+	// Parse max_retries
+	if maxRetries, exists := args[services.MaxRetriesParameter]; exists {
+		retries, err := services.ParseMaxRetries(maxRetries)
+		if err != nil {
+			return fmt.Errorf("invalid max_retries value: %w", err)
+		}
+		parameters[services.MaxRetriesParameter] = retries
+	}
+
+	// Parse context_policy
+	if contextPolicy, exists := args[services.ContextPolicyParameter]; exists {
+		policy, err := services.ParseContextPolicy(contextPolicy)
+		if err != nil {
+			return err
+		}
+		parameters[services.ContextPolicyParameter] = policy.String()
+	}
+
+	// Parse context_window
+	if contextWindow, exists := args[services.ContextWindowParameter]; exists {
+		contextWindowInt, err := strconv.Atoi(contextWindow)
+		if err != nil || contextWindowInt <= 0 {
+			return fmt.Errorf("invalid context_window value: %s", contextWindow)
+		}
+		parameters[services.ContextWindowParameter] = contextWindowInt
+	}
+
 	// Add any other string parameters that aren't specially handled
 	excludedParams := map[string]bool{
-		"description": true, "catalog_id": true,
+		"description": true, "catalog_id": true, "served_model": true,
 		"temperature": true, "max_tokens": true, "top_p": true, "top_k": true,
-		"presence_penalty": true, "frequency_penalty": true,
+		"presence_penalty": true, "frequency_penalty": true, services.MaxRetriesParameter: true,
+		services.ContextPolicyParameter: true, services.ContextWindowParameter: true,
 	}
 
 	for key, value := range args {
@@ -498,6 +594,15 @@ func (c *NewCommand) generateClientNewCommand(provider string) string {
 	}
 }
 
+// isOpenAICompatible reports whether a provider catalog ID is served by the generic OpenAI-compatible client.
+func (c *NewCommand) isOpenAICompatible(providerCatalogID string) bool {
+	providerCatalogService, err := services.GetGlobalProviderCatalogService()
+	if err != nil {
+		return false
+	}
+	return providerCatalogService.IsOpenAICompatible(providerCatalogID)
+}
+
 // IsReadOnly returns false as the model command modifies system state.
 func (c *NewCommand) IsReadOnly() bool {
 	return false
@@ -659,6 +659,47 @@ func TestNewCommand_Execute_CatalogIDEdgeCases(t *testing.T) {
 	}
 }
 
+func TestNewCommand_Execute_OpenAICompatibleProvider(t *testing.T) {
+	cmd := &NewCommand{}
+	ctx := context.New()
+	setupModelTestRegistry(t, ctx)
+
+	providerCatalogService := services.NewProviderCatalogService()
+	require.NoError(t, services.GetGlobalRegistry().RegisterService(providerCatalogService))
+	require.NoError(t, providerCatalogService.Initialize())
+
+	err := cmd.Execute(map[string]string{"catalog_id": "OLM", "served_model": "qwen2.5-coder:7b", "temperature": "0.2"}, "local-coder")
+	require.NoError(t, err)
+
+	provider, _ := ctx.GetVariable("#model_provider")
+	assert.Equal(t, "ollama", provider)
+	baseModel, _ := ctx.GetVariable("#model_base")
+	assert.Equal(t, "qwen2.5-coder:7b", baseModel)
+	paramCount, _ := ctx.GetVariable("#model_param_count")
+	assert.Equal(t, "1", paramCount, "served_model is not stored as a model parameter")
+
+	// Model activation runs first, then the keyless client is created and activated
+	stackService, err := services.GetGlobalStackService()
+	require.NoError(t, err)
+	var pushed []string
+	for {
+		command, ok := stackService.PopCommand()
+		if !ok {
+			break
+		}
+		pushed = append(pushed, command)
+	}
+	require.Len(t, pushed, 3)
+	assert.Contains(t, pushed[0], "\\model-activate[id=true]")
+	assert.Equal(t, "\\try \\silent \\openai-compatible-client-new[catalog_id=OLC]", pushed[1])
+	assert.Equal(t, "\\try \\silent \\llm-client-activate OLC", pushed[2])
+
+	// served_model is rejected for hosted providers
+	err = cmd.Execute(map[string]string{"catalog_id": "CS4", "served_model": "other"}, "hosted")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "served_model can only be set for OpenAI-compatible providers")
+}
+
 // setupModelTestRegistry sets up a test environment with required services for model commands
 func setupModelTestRegistry(t *testing.T, ctx neurotypes.Context) {
 	// Create a new registry for testing
@@ -35,13 +35,14 @@ func (c *CatalogCommand) Description() string {
 
 // Usage returns the syntax and usage examples for the provider-catalog command.
 func (c *CatalogCommand) Usage() string {
-	return `\provider-catalog[provider=openai|anthropic|gemini|all, sort=name|provider, search=query]
+	return `\provider-catalog[provider=openai|anthropic|gemini|ollama|vllm|llamacpp|lmstudio|mock|all, sort=name|provider, search=query]
 
 Examples:
   \provider-catalog                              %% List all available providers (default: sorted by provider)
   \provider-catalog[provider=openai]             %% List OpenAI providers only
   \provider-catalog[provider=anthropic]          %% List Anthropic providers only
   \provider-catalog[provider=gemini]             %% List Google Gemini providers only
+  \provider-catalog[provider=ollama]             %% List local Ollama providers only
   \provider-catalog[sort=name]                   %% Sort providers alphabetically by name
   \provider-catalog[search=chat]                 %% Search for providers containing "chat"
   \provider-catalog[search=GMC]                  %% Search by provider ID (case-insensitive)
@@ -50,14 +51,18 @@ Examples:
   \provider-catalog[search=completions,sort=name] %% Search for completion providers, sorted by name
 
 Options:
-  provider - Filter by provider: openai, anthropic, gemini, all (default: all)
+  provider - Filter by provider: openai, anthropic, gemini, ollama, vllm, llamacpp, lmstudio, mock, all (default: all)
   sort     - Sort order: name (alphabetical), provider (by provider then name)
   search   - Search query to filter providers by ID, name, display name, or description
 
 Note: Options can be combined. Default sort is by provider.
       Provider catalog is stored in ${_output} variable.
       Shows provider ID, display name, provider type, and configuration details.
-      Provider IDs are shown in format: [ID] Display Name (provider_type)`
+      Provider IDs are shown in format: [ID] Display Name (provider_type)
+      User providers are loaded from ~/.config/neuroshell/providers/ and ./.neuro/providers/ at startup.
+      User providers override embedded providers with the same ID; project providers only add new IDs
+      unless NEURO_PROJECT_CATALOG_OVERRIDES=1 is set in the environment.
+      Their Source line shows the file; problems with catalog files are listed as warnings.`
 }
 
 // HelpInfo returns structured help information for the provider-catalog command.
@@ -65,12 +70,12 @@ func (c *CatalogCommand) HelpInfo() neurotypes.HelpInfo {
 	return neurotypes.HelpInfo{
 		Command:     c.Name(),
 		Description: c.Description(),
-		Usage:       "\\provider-catalog[provider=openai|anthropic|gemini|all, sort=name|provider, search=query]",
+		Usage:       "\\provider-catalog[provider=openai|anthropic|gemini|ollama|vllm|llamacpp|lmstudio|mock|all, sort=name|provider, search=query]",
 		ParseMode:   c.ParseMode(),
 		Options: []neurotypes.HelpOption{
 			{
 				Name:        "provider",
-				Description: "Filter by provider: openai, anthropic, gemini, all",
+				Description: "Filter by provider: openai, anthropic, gemini, ollama, vllm, llamacpp, lmstudio, mock, all",
 				Required:    false,
 				Type:        "string",
 				Default:     "all",
@@ -122,6 +127,9 @@ func (c *CatalogCommand) HelpInfo() neurotypes.HelpInfo {
 		Notes: []string{
 			"Options can be combined (e.g., provider=openai,sort=name)",
 			"Default sort is by provider, then by name within each provider",
+			"YAML files in ~/.config/neuroshell/providers/ and ./.neuro/providers/ are loaded at startup and add providers",
+			"User providers override embedded ones by ID; project providers may only override with NEURO_PROJECT_CATALOG_OVERRIDES=1 in the environment",
+			"Invalid catalog files are skipped and listed as warnings",
 			"Shows provider ID, display name, provider type, configuration details",
 			"Provider IDs are displayed in format: [ID] Display Name (provider_type)",
 			"Embedded catalog includes popular providers: OpenAI, Anthropic, Gemini",
@@ -133,7 +141,7 @@ func (c *CatalogCommand) HelpInfo() neurotypes.HelpInfo {
 
 // Execute lists available LLM providers with optional filtering, sorting, and searching.
 // Options:
-//   - provider: openai|anthropic|gemini|all (default: all)
+//   - provider: openai|anthropic|gemini|ollama|vllm|llamacpp|lmstudio|mock|all (default: all)
 //   - sort: name|provider (default: provider)
 //   - search: query string for filtering (optional)
 func (c *CatalogCommand) Execute(args map[string]string, _ string) error {
@@ -192,6 +200,7 @@ func (c *CatalogCommand) Execute(args map[string]string, _ string) error {
 
 	// Format output with theme styling
 	output := c.formatProviderCatalog(providers, provider, sortBy, searchQuery, themeObj)
+	output += c.formatLoadWarnings(providerCatalogService.LoadWarnings(), themeObj)
 
 	// Store result in _output variable
 	if err := variableService.SetSystemVariable("_output", output); err != nil {
@@ -205,6 +214,19 @@ func (c *CatalogCommand) Execute(args map[string]string, _ string) error {
 	return nil
 }
 
+// formatLoadWarnings lists problems found in user and project catalog files.
+func (c *CatalogCommand) formatLoadWarnings(warnings []string, themeObj *services.Theme) string {
+	if len(warnings) == 0 {
+		return ""
+	}
+	var result strings.Builder
+	result.WriteString("\n" + themeObj.Warning.Render("Catalog warnings:") + "\n")
+	for _, warning := range warnings {
+		result.WriteString(fmt.Sprintf("  • %s\n", themeObj.Warning.Render(warning)))
+	}
+	return result.String()
+}
+
 // validateArguments checks if the provided provider and sort options are valid.
 func (c *CatalogCommand) validateArguments(provider, sortBy string) error {
 	validProviders := map[string]bool{
@@ -212,9 +234,14 @@ func (c *CatalogCommand) validateArguments(provider, sortBy string) error {
 		"openai":    true,
 		"anthropic": true,
 		"gemini":    true,
+		"ollama":    true,
+		"vllm":      true,
+		"llamacpp":  true,
+		"lmstudio":  true,
+		"mock":      true,
 	}
 	if !validProviders[provider] {
-		return fmt.Errorf("invalid provider option '%s'. Valid options: all, openai, anthropic, gemini", provider)
+		return fmt.Errorf("invalid provider option '%s'. Valid options: all, openai, anthropic, gemini, ollama, vllm, llamacpp, lmstudio, mock", provider)
 	}
 
 	validSorts := map[string]bool{
@@ -395,6 +422,14 @@ func (c *CatalogCommand) formatProviderEntry(provider neurotypes.ProviderCatalog
 		result.WriteString(implementationLine)
 	}
 
+	// Source of user and project entries (embedded entries are not annotated)
+	if sourceText := provider.Source.Describe(); sourceText != "" {
+		sourceLine := fmt.Sprintf("    %s %s\n",
+			themeObj.Info.Render("Source:"),
+			themeObj.Variable.Render(sourceText))
+		result.WriteString(sourceLine)
+	}
+
 	return result.String()
 }
 
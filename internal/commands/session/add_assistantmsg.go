@@ -7,6 +7,7 @@ import (
 	"neuroshell/internal/commands/printing"
 	"neuroshell/internal/services"
 	"neuroshell/pkg/neurotypes"
+	"neuroshell/pkg/stringprocessing"
 )
 
 // AddAssistantMessageCommand implements the \session-add-assistantmsg command for adding assistant messages to sessions.
@@ -30,7 +31,7 @@ func (c *AddAssistantMessageCommand) Description() string {
 
 // Usage returns the syntax and usage examples for the session-add-assistantmsg command.
 func (c *AddAssistantMessageCommand) Usage() string {
-	return `\session-add-assistantmsg[session=session_id] response_content
+	return `\session-add-assistantmsg[session=session_id, alternative=false, keep=false, metadata=true] response_content
 \session-add-assistantmsg response_content
 
 Examples:
@@ -38,6 +39,13 @@ Examples:
   \session-add-assistantmsg[session=${session_id}] I'm doing well, thank you!
   \session-add-assistantmsg[session=work-session] ${llm_response}
   \session-add-assistantmsg ${_output}
+  \session-add-assistantmsg[alternative=true] ${#llm_text_content}    %% Another version of the last response
+
+Options:
+  session     - Session ID or name (optional, defaults to active session)
+  alternative - Store the content as an alternative of the last assistant message instead of appending
+  keep        - With alternative, keep the current response selected (default: false)
+  metadata    - Attach the model, provider, usage and timing of the last \llm-call for the session (default: true)
 
 Note: Response content is required. Session parameter is optional and defaults to active session.
       This command is typically used after LLM calls to store assistant responses.`
@@ -57,6 +65,27 @@ func (c *AddAssistantMessageCommand) HelpInfo() neurotypes.HelpInfo {
 				Required:    false,
 				Type:        "string",
 			},
+			{
+				Name:        "alternative",
+				Description: "Store the content as an alternative of the last assistant message instead of appending",
+				Required:    false,
+				Type:        "boolean",
+				Default:     "false",
+			},
+			{
+				Name:        "keep",
+				Description: "With alternative, keep the current response selected",
+				Required:    false,
+				Type:        "boolean",
+				Default:     "false",
+			},
+			{
+				Name:        "metadata",
+				Description: "Attach the model, provider, usage and timing of the last \\llm-call for the session",
+				Required:    false,
+				Type:        "boolean",
+				Default:     "true",
+			},
 		},
 		Examples: []neurotypes.HelpExample{
 			{
@@ -71,6 +100,10 @@ func (c *AddAssistantMessageCommand) HelpInfo() neurotypes.HelpInfo {
 				Command:     `\session-add-assistantmsg ${_output}`,
 				Description: "Add LLM output to active session",
 			},
+			{
+				Command:     `\session-add-assistantmsg[alternative=true] ${#llm_text_content}`,
+				Description: "Store a regenerated response as an alternative of the last response",
+			},
 		},
 		Notes: []string{
 			"Response content is required",
@@ -81,6 +114,9 @@ func (c *AddAssistantMessageCommand) HelpInfo() neurotypes.HelpInfo {
 			"Messages are timestamped and added to session history",
 			"Updates message history variables (${1}, ${2}, etc.)",
 			"Adding a message to a session makes it the active session",
+			"With alternative=true the last message must be an assistant response; ${#message_alternatives} and ${#message_selected} report its versions",
+			"Alternatives are listed with \\regen[list=true] and selected with \\regen[pick=N]",
+			"Metadata of the last \\llm-call is attached to one message only; \\session-show[metadata=true] displays it",
 		},
 	}
 }
@@ -118,10 +154,30 @@ func (c *AddAssistantMessageCommand) Execute(args map[string]string, input strin
 		targetSession = session
 	}
 
-	// Add assistant message to session
-	err = chatService.AddMessage(targetSession.ID, "assistant", input)
-	if err != nil {
-		return fmt.Errorf("failed to add assistant message to session '%s': %w", targetSession.Name, err)
+	// Metadata of the last \llm-call is always consumed, so it never describes a later message
+	metadata := chatService.TakePendingMetadata(targetSession.ID)
+	if metadataArg, exists := args["metadata"]; exists && !stringprocessing.IsTruthy(metadataArg) {
+		metadata = nil
+	}
+
+	// Add assistant message to session, or another version of the last one
+	if stringprocessing.IsTruthy(args["alternative"]) {
+		keep := stringprocessing.IsTruthy(args["keep"])
+		alternatives, err := chatService.AddAlternative(targetSession.ID, input, keep, metadata)
+		if err != nil {
+			return fmt.Errorf("failed to add alternative response to session '%s': %w", targetSession.Name, err)
+		}
+		lastMessage := targetSession.Messages[len(targetSession.Messages)-1]
+		input = lastMessage.Content
+		if variableService, err := services.GetGlobalVariableService(); err == nil {
+			_ = variableService.SetSystemVariable("#message_alternatives", fmt.Sprintf("%d", alternatives))
+			_ = variableService.SetSystemVariable("#message_selected", fmt.Sprintf("%d", lastMessage.SelectedAlternative))
+		}
+	} else {
+		err = chatService.AddMessageWithMetadata(targetSession.ID, "assistant", input, metadata)
+		if err != nil {
+			return fmt.Errorf("failed to add assistant message to session '%s': %w", targetSession.Name, err)
+		}
 	}
 
 	// Auto-push session activation command to stack service for consistent UX
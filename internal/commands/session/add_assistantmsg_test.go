@@ -48,18 +48,23 @@ func TestAddAssistantMessageCommand_HelpInfo(t *testing.T) {
 	assert.NotEmpty(t, help.Usage)
 
 	// Check options
-	assert.Len(t, help.Options, 1)
+	assert.Len(t, help.Options, 4)
 	assert.Equal(t, "session", help.Options[0].Name)
 	assert.False(t, help.Options[0].Required)
+	assert.Equal(t, "alternative", help.Options[1].Name)
+	assert.Equal(t, "keep", help.Options[2].Name)
+	assert.Equal(t, "metadata", help.Options[3].Name)
+	assert.Equal(t, "true", help.Options[3].Default)
 
 	// Check examples
-	assert.Len(t, help.Examples, 3)
+	assert.Len(t, help.Examples, 4)
 	assert.Contains(t, help.Examples[0].Command, "session-add-assistantmsg")
 	assert.Contains(t, help.Examples[1].Command, "${session_id}")
 	assert.Contains(t, help.Examples[2].Command, "${_output}")
+	assert.Contains(t, help.Examples[3].Command, "alternative=true")
 
 	// Check notes
-	assert.Len(t, help.Notes, 8)
+	assert.Len(t, help.Notes, 11)
 }
 
 func TestAddAssistantMessageCommand_Execute_Success(t *testing.T) {
@@ -136,6 +141,38 @@ func TestAddAssistantMessageCommand_Execute_BySessionName(t *testing.T) {
 	assert.Equal(t, response, updatedSession.Messages[0].Content)
 }
 
+func TestAddAssistantMessageCommand_Execute_Metadata(t *testing.T) {
+	cmd := &AddAssistantMessageCommand{}
+	setupAddMessageTestRegistry(t)
+
+	ctx := context.GetGlobalContext()
+	ctx.SetTestMode(true)
+
+	chatService, err := services.GetGlobalChatSessionService()
+	require.NoError(t, err)
+
+	session, err := chatService.CreateSession("audit", "Test prompt", "")
+	require.NoError(t, err)
+
+	// Metadata of the last call is attached to the next response only
+	metadata := &neurotypes.MessageMetadata{Model: "claude", Provider: "anthropic", RequestID: "msg_01"}
+	require.NoError(t, chatService.SetPendingMetadata(session.ID, metadata))
+	require.NoError(t, cmd.Execute(map[string]string{"session": session.ID}, "First answer"))
+	require.NoError(t, cmd.Execute(map[string]string{"session": session.ID}, "Typed by hand"))
+
+	// metadata=false drops it
+	require.NoError(t, chatService.SetPendingMetadata(session.ID, metadata))
+	require.NoError(t, cmd.Execute(map[string]string{"session": session.ID, "metadata": "false"}, "Not audited"))
+
+	updatedSession, err := chatService.GetSession(session.ID)
+	require.NoError(t, err)
+	require.Len(t, updatedSession.Messages, 3)
+	assert.Equal(t, metadata, updatedSession.Messages[0].Metadata)
+	assert.Nil(t, updatedSession.Messages[1].Metadata)
+	assert.Nil(t, updatedSession.Messages[2].Metadata)
+	assert.Nil(t, chatService.TakePendingMetadata(session.ID))
+}
+
 func TestAddAssistantMessageCommand_Execute_MultipleMessages(t *testing.T) {
 	cmd := &AddAssistantMessageCommand{}
 	setupAddMessageTestRegistry(t)
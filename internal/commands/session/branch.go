@@ -0,0 +1,227 @@
+// Package session provides session management commands for NeuroShell.
+// It includes commands for creating, managing, and interacting with chat sessions.
+package session
+
+import (
+	"fmt"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/commands/printing"
+	"neuroshell/internal/services"
+	"neuroshell/internal/stringprocessing"
+	"neuroshell/pkg/neurotypes"
+)
+
+// BranchCommand implements the \session-branch command for forking a conversation at a message.
+// The new branch keeps the messages before the fork point and becomes the active path of the session.
+type BranchCommand struct{}
+
+// Name returns the command name "session-branch" for registration and lookup.
+func (c *BranchCommand) Name() string {
+	return "session-branch"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *BranchCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the session-branch command does.
+func (c *BranchCommand) Description() string {
+	return "Fork the conversation at a message into a new branch"
+}
+
+// Usage returns the syntax and usage examples for the session-branch command.
+func (c *BranchCommand) Usage() string {
+	return `\session-branch[at=N, session=session_id] [branch_name]
+
+Examples:
+  \session-branch                                   %% Branch after the last message with a generated name
+  \session-branch[at=2] shorter-question            %% Re-ask the second-to-last message differently
+  \session-branch[at=1] other-answer                %% Drop the last reply to get an alternative response
+  \session-branch[at=.1] fresh-start                %% Keep only the system prompt
+  \session-branch[session=work, at=.3] idea         %% Branch a specific session
+
+Options:
+  at      - Fork before this message: N for reverse order (1=last), .N for normal order (.1=first)
+  session - Session name or ID (optional, defaults to active session)
+
+Input: Branch name (optional, defaults to branch-N)
+
+Note: The new branch keeps the messages before the fork point and becomes the active path.
+      The original path becomes the 'main' branch the first time a session is branched.
+      Branches share the messages before their fork point, so editing one changes it in every branch.
+      Use \session-branches to compare branches and \session-checkout to switch between them.`
+}
+
+// HelpInfo returns structured help information for the session-branch command.
+func (c *BranchCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       "\\session-branch[at=N OR at=.N, session=session_id] [branch_name]",
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "at",
+				Description: "Fork before this message: N for reverse order (1=last), .N for normal order (.1=first)",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "session",
+				Description: "Session name or ID (optional, defaults to active session)",
+				Required:    false,
+				Type:        "string",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\session-branch[at=2] shorter-question",
+				Description: "Fork before the second-to-last message to ask it differently",
+			},
+			{
+				Command:     "\\session-branch[at=1] other-answer",
+				Description: "Fork before the last reply to get an alternative response",
+			},
+			{
+				Command:     "\\session-branch",
+				Description: "Fork after the last message with a generated branch name",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "#active_branch",
+				Description: "Name of the new branch, which is now active",
+				Type:        "system_metadata",
+				Example:     "shorter-question",
+			},
+			{
+				Name:        "#branch_parent",
+				Description: "Branch the new branch was forked from",
+				Type:        "system_metadata",
+				Example:     "main",
+			},
+			{
+				Name:        "#branch_fork_point",
+				Description: "Number of messages the new branch starts with",
+				Type:        "system_metadata",
+				Example:     "2",
+			},
+			{
+				Name:        "#message_count",
+				Description: "Number of messages on the active path",
+				Type:        "system_metadata",
+				Example:     "2",
+			},
+			{
+				Name:        "_output",
+				Description: "Branch result message",
+				Type:        "command_output",
+				Example:     "Created branch 'shorter-question' from 'main' with 2 of 4 messages in session 'work'",
+			},
+		},
+		Notes: []string{
+			"The new branch keeps the messages before the fork point and becomes the active path",
+			"Without at, the branch keeps every message of the current path",
+			"The original path becomes the 'main' branch the first time a session is branched",
+			"Branch names must not contain spaces, commas, quotes, brackets or '='",
+			"\\llm-call, \\send and exports always use the active branch",
+		},
+	}
+}
+
+// Execute forks the session at the requested message.
+// Options:
+//   - at: message to fork before (optional, defaults to after the last message)
+//   - session: Session name or ID (optional, defaults to active session)
+func (c *BranchCommand) Execute(args map[string]string, input string) error {
+	chatService, err := services.GetGlobalChatSessionService()
+	if err != nil {
+		return fmt.Errorf("chat session service not available: %w", err)
+	}
+
+	variableService, err := services.GetGlobalVariableService()
+	if err != nil {
+		return fmt.Errorf("variable service not available: %w", err)
+	}
+
+	targetSession, err := resolveBranchSession(chatService, args["session"], c.Usage())
+	if err != nil {
+		return err
+	}
+
+	messageCount := len(targetSession.Messages)
+	forkPoint := messageCount
+	if atStr := args["at"]; atStr != "" {
+		if messageCount == 0 {
+			return fmt.Errorf("session '%s' has no messages to fork at", targetSession.Name)
+		}
+		indexResult, err := stringprocessing.ParseMessageIndex(atStr, messageCount)
+		if err != nil {
+			return fmt.Errorf("invalid fork point '%s': %w. Usage: %s", atStr, err, c.Usage())
+		}
+		forkPoint = indexResult.ZeroBasedIndex
+	}
+
+	branch, err := chatService.BranchSession(targetSession.ID, input, forkPoint)
+	if err != nil {
+		return fmt.Errorf("failed to branch session: %w", err)
+	}
+
+	variables := map[string]string{
+		"#session_id":        targetSession.ID,
+		"#session_name":      targetSession.Name,
+		"#active_branch":     branch.Name,
+		"#branch_parent":     branch.Parent,
+		"#branch_fork_point": fmt.Sprintf("%d", branch.ForkPoint),
+		"#message_count":     fmt.Sprintf("%d", branch.ForkPoint),
+	}
+	for name, value := range variables {
+		if err := variableService.SetSystemVariable(name, value); err != nil {
+			return fmt.Errorf("failed to set variable %s: %w", name, err)
+		}
+	}
+
+	outputMsg := fmt.Sprintf("Created branch '%s' from '%s' with %d of %d messages in session '%s'",
+		branch.Name, branch.Parent, branch.ForkPoint, messageCount, targetSession.Name)
+	if err := variableService.SetSystemVariable("_output", outputMsg); err != nil {
+		return fmt.Errorf("failed to store result: %w", err)
+	}
+
+	printer := printing.NewDefaultPrinter()
+	printer.Success(outputMsg)
+
+	chatService.TriggerAutoSave(targetSession.ID)
+
+	return nil
+}
+
+// resolveBranchSession returns the named session, or the active session when no name is given.
+func resolveBranchSession(chatService *services.ChatSessionService, sessionID, usage string) (*neurotypes.ChatSession, error) {
+	if strings.TrimSpace(sessionID) == "" {
+		session, err := chatService.GetActiveSession()
+		if err != nil {
+			return nil, fmt.Errorf("no session specified and no active session found: %w. Usage: %s", err, usage)
+		}
+		return session, nil
+	}
+
+	session, err := chatService.GetSessionByNameOrID(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find session '%s': %w", sessionID, err)
+	}
+	return session, nil
+}
+
+// IsReadOnly returns false as the session-branch command modifies system state.
+func (c *BranchCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&BranchCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register session-branch command: %v", err))
+	}
+}
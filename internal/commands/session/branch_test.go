@@ -0,0 +1,96 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/internal/context"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// setupBranchCommandTest creates an active session with the messages Q1, A1, Q2, A2.
+func setupBranchCommandTest(t *testing.T) (neurotypes.Context, *services.ChatSessionService) {
+	ctx := context.New()
+	ctx.SetTestMode(true)
+	setupRenameTestRegistry(t, ctx)
+
+	require.NoError(t, (&NewCommand{}).Execute(map[string]string{}, "tree"))
+	chatService, err := services.GetGlobalChatSessionService()
+	require.NoError(t, err)
+	for _, content := range []string{"Q1", "A1", "Q2", "A2"} {
+		role := "user"
+		if content[0] == 'A' {
+			role = "assistant"
+		}
+		require.NoError(t, chatService.AddMessage("tree", role, content))
+	}
+	return ctx, chatService
+}
+
+func TestBranchCommands_Metadata(t *testing.T) {
+	for _, cmd := range []neurotypes.Command{&BranchCommand{}, &BranchesCommand{}, &CheckoutCommand{}} {
+		helpInfo := cmd.HelpInfo()
+		assert.Equal(t, cmd.Name(), helpInfo.Command)
+		assert.Equal(t, neurotypes.ParseModeKeyValue, cmd.ParseMode())
+		assert.NotEmpty(t, helpInfo.Examples, cmd.Name())
+		assert.NotEmpty(t, helpInfo.StoredVariables, cmd.Name())
+		assert.False(t, cmd.IsReadOnly(), cmd.Name())
+	}
+}
+
+func TestBranchCommand_Execute(t *testing.T) {
+	ctx, chatService := setupBranchCommandTest(t)
+
+	require.NoError(t, (&BranchCommand{}).Execute(map[string]string{"at": "2"}, "alt"))
+	branch, _ := ctx.GetVariable("#active_branch")
+	assert.Equal(t, "alt", branch)
+	forkPoint, _ := ctx.GetVariable("#branch_fork_point")
+	assert.Equal(t, "2", forkPoint)
+	output, _ := ctx.GetVariable("_output")
+	assert.Equal(t, "Created branch 'alt' from 'main' with 2 of 4 messages in session 'tree'", output)
+
+	session, err := chatService.GetActiveSession()
+	require.NoError(t, err)
+	assert.Len(t, session.Messages, 2)
+
+	// Without at the branch keeps every message
+	require.NoError(t, (&BranchCommand{}).Execute(map[string]string{"session": "tree"}, ""))
+	count, _ := ctx.GetVariable("#message_count")
+	assert.Equal(t, "2", count)
+
+	assert.ErrorContains(t, (&BranchCommand{}).Execute(map[string]string{"at": ".5"}, "x"), "invalid fork point")
+	assert.ErrorContains(t, (&BranchCommand{}).Execute(map[string]string{"session": "missing"}, "x"), "failed to find session")
+}
+
+func TestBranchesAndCheckoutCommands_Execute(t *testing.T) {
+	ctx, _ := setupBranchCommandTest(t)
+
+	require.NoError(t, (&BranchesCommand{}).Execute(map[string]string{}, ""))
+	count, _ := ctx.GetVariable("#branch_count")
+	assert.Equal(t, "0", count)
+
+	require.NoError(t, (&BranchCommand{}).Execute(map[string]string{"at": "2"}, "alt"))
+	chatService, err := services.GetGlobalChatSessionService()
+	require.NoError(t, err)
+	require.NoError(t, chatService.AddMessage("tree", "user", "Q2b"))
+
+	require.NoError(t, (&BranchesCommand{}).Execute(map[string]string{"at": "1"}, ""))
+	output, _ := ctx.GetVariable("_output")
+	assert.Equal(t, "  main: user: Q2\n* alt: user: Q2b", output)
+
+	assert.ErrorContains(t, (&CheckoutCommand{}).Execute(map[string]string{}, ""), "branch name is required")
+	require.NoError(t, (&CheckoutCommand{}).Execute(map[string]string{}, "main"))
+	branch, _ := ctx.GetVariable("#active_branch")
+	assert.Equal(t, "main", branch)
+	messages, _ := ctx.GetVariable("#message_count")
+	assert.Equal(t, "4", messages)
+
+	require.NoError(t, (&BranchesCommand{}).Execute(map[string]string{}, ""))
+	output, _ = ctx.GetVariable("_output")
+	assert.Equal(t, "* main: 4 messages\n  alt: 3 messages, forked from main after 2 messages", output)
+	names, _ := ctx.GetVariable("#branch_names")
+	assert.Equal(t, "main alt", names)
+}
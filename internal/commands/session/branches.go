@@ -0,0 +1,224 @@
+// Package session provides session management commands for NeuroShell.
+// It includes commands for creating, managing, and interacting with chat sessions.
+package session
+
+import (
+	"fmt"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/commands/printing"
+	"neuroshell/internal/services"
+	"neuroshell/internal/stringprocessing"
+	"neuroshell/pkg/neurotypes"
+)
+
+// BranchesCommand implements the \session-branches command for listing and comparing the branches of a session.
+type BranchesCommand struct{}
+
+// Name returns the command name "session-branches" for registration and lookup.
+func (c *BranchesCommand) Name() string {
+	return "session-branches"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *BranchesCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the session-branches command does.
+func (c *BranchesCommand) Description() string {
+	return "List the branches of a session or the alternatives at a message"
+}
+
+// Usage returns the syntax and usage examples for the session-branches command.
+func (c *BranchesCommand) Usage() string {
+	return `\session-branches[session=session_id, at=N]
+
+Examples:
+  \session-branches                                 %% List all branches of the active session
+  \session-branches[at=2]                           %% Compare the alternatives for the second-to-last message
+  \session-branches[session=work, at=.3]            %% Compare alternatives for the third message of 'work'
+
+Options:
+  session - Session name or ID (optional, defaults to active session)
+  at      - Message of the active path: N for reverse order (1=last), .N for normal order (.1=first)
+
+Note: Without at, every branch is listed with its parent and fork point.
+      With at, only the sibling branches that share the messages before that message are
+      listed, each with its own version of the message, so they can be compared side by side.
+      The active branch is marked with '*'.`
+}
+
+// HelpInfo returns structured help information for the session-branches command.
+func (c *BranchesCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       "\\session-branches[session=session_id, at=N OR at=.N]",
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "session",
+				Description: "Session name or ID (optional, defaults to active session)",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "at",
+				Description: "Only list the alternatives for this message of the active path",
+				Required:    false,
+				Type:        "string",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\session-branches",
+				Description: "List all branches of the active session",
+			},
+			{
+				Command:     "\\session-branches[at=2]",
+				Description: "Compare the alternatives for the second-to-last message",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "#active_branch",
+				Description: "Name of the active branch (empty for unbranched sessions)",
+				Type:        "system_metadata",
+				Example:     "main",
+			},
+			{
+				Name:        "#branch_count",
+				Description: "Number of branches listed",
+				Type:        "system_metadata",
+				Example:     "3",
+			},
+			{
+				Name:        "#branch_names",
+				Description: "Space-separated names of the branches listed",
+				Type:        "system_metadata",
+				Example:     "main shorter-question other-answer",
+			},
+			{
+				Name:        "_output",
+				Description: "The branch listing",
+				Type:        "command_output",
+				Example:     "* main: 4 messages",
+			},
+		},
+		Notes: []string{
+			"Unbranched sessions have no branches; use \\session-branch to create one",
+			"The active branch is marked with '*'",
+			"With at, each sibling branch shows its own version of the message",
+		},
+	}
+}
+
+// Execute lists the branches of the session.
+// Options:
+//   - session: Session name or ID (optional, defaults to active session)
+//   - at: message of the active path to compare alternatives for (optional)
+func (c *BranchesCommand) Execute(args map[string]string, _ string) error {
+	chatService, err := services.GetGlobalChatSessionService()
+	if err != nil {
+		return fmt.Errorf("chat session service not available: %w", err)
+	}
+
+	variableService, err := services.GetGlobalVariableService()
+	if err != nil {
+		return fmt.Errorf("variable service not available: %w", err)
+	}
+
+	targetSession, err := resolveBranchSession(chatService, args["session"], c.Usage())
+	if err != nil {
+		return err
+	}
+
+	branches := targetSession.Branches
+	messageIndex := -1
+	var header string
+	if atStr := args["at"]; atStr != "" {
+		if len(targetSession.Messages) == 0 {
+			return fmt.Errorf("session '%s' has no messages", targetSession.Name)
+		}
+		indexResult, err := stringprocessing.ParseMessageIndex(atStr, len(targetSession.Messages))
+		if err != nil {
+			return fmt.Errorf("invalid index '%s': %w. Usage: %s", atStr, err, c.Usage())
+		}
+		messageIndex = indexResult.ZeroBasedIndex
+		branches = services.BranchAlternatives(targetSession, messageIndex)
+		header = fmt.Sprintf("Alternatives for message %d of session '%s':", messageIndex+1, targetSession.Name)
+	} else {
+		header = fmt.Sprintf("Branches of session '%s':", targetSession.Name)
+	}
+
+	var lines []string
+	names := make([]string, 0, len(branches))
+	for _, branch := range branches {
+		names = append(names, branch.Name)
+		lines = append(lines, c.formatBranch(targetSession, branch, messageIndex))
+	}
+
+	printer := printing.NewDefaultPrinter()
+	if len(branches) == 0 {
+		lines = []string{fmt.Sprintf("Session '%s' has no branches", targetSession.Name)}
+		printer.Info(lines[0])
+	} else {
+		printer.Success(header)
+		for _, line := range lines {
+			printer.Println(line)
+		}
+	}
+
+	variables := map[string]string{
+		"#session_id":    targetSession.ID,
+		"#session_name":  targetSession.Name,
+		"#active_branch": targetSession.ActiveBranch,
+		"#branch_count":  fmt.Sprintf("%d", len(branches)),
+		"#branch_names":  strings.Join(names, " "),
+		"_output":        strings.Join(lines, "\n"),
+	}
+	for name, value := range variables {
+		if err := variableService.SetSystemVariable(name, value); err != nil {
+			return fmt.Errorf("failed to set variable %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// formatBranch renders one branch line. With a message index it shows the branch's version of that message.
+func (c *BranchesCommand) formatBranch(session *neurotypes.ChatSession, branch neurotypes.SessionBranch, messageIndex int) string {
+	marker := " "
+	if branch.Name == session.ActiveBranch {
+		marker = "*"
+	}
+	path := services.BranchPath(session, branch.Name)
+
+	if messageIndex >= 0 {
+		if messageIndex >= len(path) {
+			return fmt.Sprintf("%s %s: (no message yet)", marker, branch.Name)
+		}
+		message := path[messageIndex]
+		content := strings.ReplaceAll(message.Content, "\n", " ")
+		return fmt.Sprintf("%s %s: %s: %s", marker, branch.Name, message.Role, truncateContent(content, 60))
+	}
+
+	line := fmt.Sprintf("%s %s: %d messages", marker, branch.Name, len(path))
+	if branch.Parent != "" {
+		line += fmt.Sprintf(", forked from %s after %d messages", branch.Parent, branch.ForkPoint)
+	}
+	return line
+}
+
+// IsReadOnly returns false as the session-branches command modifies system state.
+func (c *BranchesCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&BranchesCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register session-branches command: %v", err))
+	}
+}
@@ -0,0 +1,216 @@
+// Package session provides session management commands for NeuroShell.
+// It includes commands for creating, managing, and interacting with chat sessions.
+package session
+
+import (
+	"fmt"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/commands/printing"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// ChatImportCommand implements the \session-chat-import command for importing conversations
+// from ChatGPT and Claude data exports and from raw OpenAI/Anthropic messages arrays.
+type ChatImportCommand struct{}
+
+// Name returns the command name "session-chat-import" for registration and lookup.
+func (c *ChatImportCommand) Name() string {
+	return "session-chat-import"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *ChatImportCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the session-chat-import command does.
+func (c *ChatImportCommand) Description() string {
+	return "Import conversations from ChatGPT, Claude or raw messages arrays"
+}
+
+// Usage returns the syntax and usage examples for the session-chat-import command.
+func (c *ChatImportCommand) Usage() string {
+	return `\session-chat-import[format=chatgpt|claude|openai-messages, file=path, match=text]
+
+Examples:
+  \session-chat-import[format=chatgpt, file=conversations.json]          %% Every ChatGPT conversation
+  \session-chat-import[format=chatgpt, file=export.zip, match=refactor]  %% Only titles containing "refactor"
+  \session-chat-import[format=claude, file=data-2025-01-01.zip]          %% Claude data export archive
+  \session-chat-import[format=openai-messages, file=request.json]        %% Raw messages array
+
+Options:
+  format - chatgpt, claude or openai-messages (required)
+  file   - Export file, or the .zip archive containing conversations.json (required)
+  match  - Only import conversations whose title contains this text (case-insensitive)
+
+Note: One session is created per conversation, named after its title. Taken names get a
+      version suffix (title:v1). openai-messages also reads Anthropic messages arrays with a
+      top-level system prompt, and names the session after the file. Only the text of user
+      and assistant turns is imported; tool traffic and attachments are left out.
+      The last imported session becomes the active session.`
+}
+
+// HelpInfo returns structured help information for the session-chat-import command.
+func (c *ChatImportCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       "\\session-chat-import[format=chatgpt|claude|openai-messages, file=path, match=text]",
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "format",
+				Description: "chatgpt, claude or openai-messages",
+				Required:    true,
+				Type:        "string",
+			},
+			{
+				Name:        "file",
+				Description: "Export file, or the .zip archive containing conversations.json",
+				Required:    true,
+				Type:        "string",
+			},
+			{
+				Name:        "match",
+				Description: "Only import conversations whose title contains this text (case-insensitive)",
+				Required:    false,
+				Type:        "string",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\session-chat-import[format=chatgpt, file=conversations.json]",
+				Description: "Import every conversation of a ChatGPT export",
+			},
+			{
+				Command:     "\\session-chat-import[format=claude, file=export.zip, match=design]",
+				Description: "Import the Claude conversations with 'design' in the title",
+			},
+			{
+				Command:     "\\session-chat-import[format=openai-messages, file=request.json]",
+				Description: "Continue a conversation saved as an API messages array",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "#session_id",
+				Description: "ID of the last imported session, which is now active",
+				Type:        "system_metadata",
+				Example:     "550e8400-e29b-41d4-a716-446655440000",
+			},
+			{
+				Name:        "#session_name",
+				Description: "Name of the last imported session",
+				Type:        "system_metadata",
+				Example:     "Refactor the parser",
+			},
+			{
+				Name:        "#imported_count",
+				Description: "Number of sessions created",
+				Type:        "system_metadata",
+				Example:     "12",
+			},
+			{
+				Name:        "#message_count",
+				Description: "Number of messages in the last imported session",
+				Type:        "system_metadata",
+				Example:     "6",
+			},
+			{
+				Name:        "_output",
+				Description: "One line per imported session",
+				Type:        "command_output",
+				Example:     "Imported 'Refactor the parser' (ID: 550e8400, 6 messages)",
+			},
+		},
+		Notes: []string{
+			"One session is created per conversation and named after its title",
+			"Taken names get a version suffix, e.g. 'Daily notes:v1'",
+			"ChatGPT conversations follow the branch that was last shown in the web UI",
+			"Only the text of user and assistant turns is imported",
+			"The last imported session becomes the active session",
+		},
+	}
+}
+
+// Execute imports the conversations of an external export file.
+// Options:
+//   - format: chatgpt, claude or openai-messages (required)
+//   - file: export file or .zip archive (required)
+//   - match: title filter (optional)
+func (c *ChatImportCommand) Execute(args map[string]string, _ string) error {
+	chatService, err := services.GetGlobalChatSessionService()
+	if err != nil {
+		return fmt.Errorf("chat session service not available: %w", err)
+	}
+
+	variableService, err := services.GetGlobalVariableService()
+	if err != nil {
+		return fmt.Errorf("variable service not available: %w", err)
+	}
+
+	format := strings.TrimSpace(args["format"])
+	if format == "" {
+		return fmt.Errorf("format is required (use format=chatgpt, claude or openai-messages)")
+	}
+	filepath := args["file"]
+	if filepath == "" {
+		return fmt.Errorf("file path is required (use file=path)")
+	}
+
+	imported, err := chatService.ImportConversations(filepath, format, strings.TrimSpace(args["match"]))
+	if err != nil {
+		return fmt.Errorf("import failed: %w", err)
+	}
+
+	lines := make([]string, 0, len(imported))
+	for _, session := range imported {
+		shortID := session.ID
+		if len(shortID) > 8 {
+			shortID = shortID[:8]
+		}
+		lines = append(lines, fmt.Sprintf("Imported '%s' (ID: %s, %d messages)", session.Name, shortID, len(session.Messages)))
+	}
+
+	active := imported[len(imported)-1]
+	variables := map[string]string{
+		"#session_id":     active.ID,
+		"#session_name":   active.Name,
+		"#imported_count": fmt.Sprintf("%d", len(imported)),
+		"#message_count":  fmt.Sprintf("%d", len(active.Messages)),
+		"#system_prompt":  active.SystemPrompt,
+		"_output":         strings.Join(lines, "\n"),
+	}
+	for name, value := range variables {
+		if err := variableService.SetSystemVariable(name, value); err != nil {
+			return fmt.Errorf("failed to set variable %s: %w", name, err)
+		}
+	}
+
+	// Activate through the command so session-dependent state follows, as \session-json-import does
+	if stackService, err := services.GetGlobalStackService(); err == nil {
+		stackService.PushCommand(fmt.Sprintf("\\silent \\session-activate[id=true] %s", active.ID))
+	}
+
+	printer := printing.NewDefaultPrinter()
+	printer.Success(fmt.Sprintf("Imported %d conversation(s) from %s", len(imported), filepath))
+	for _, line := range lines {
+		printer.Println("  " + line)
+	}
+
+	return nil
+}
+
+// IsReadOnly returns false as the session-chat-import command modifies system state.
+func (c *ChatImportCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&ChatImportCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register session-chat-import command: %v", err))
+	}
+}
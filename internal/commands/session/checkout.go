@@ -0,0 +1,164 @@
+// Package session provides session management commands for NeuroShell.
+// It includes commands for creating, managing, and interacting with chat sessions.
+package session
+
+import (
+	"fmt"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/commands/printing"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// CheckoutCommand implements the \session-checkout command for switching the active branch of a session.
+type CheckoutCommand struct{}
+
+// Name returns the command name "session-checkout" for registration and lookup.
+func (c *CheckoutCommand) Name() string {
+	return "session-checkout"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *CheckoutCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the session-checkout command does.
+func (c *CheckoutCommand) Description() string {
+	return "Switch the active branch of a session"
+}
+
+// Usage returns the syntax and usage examples for the session-checkout command.
+func (c *CheckoutCommand) Usage() string {
+	return `\session-checkout[session=session_id] branch_name
+
+Examples:
+  \session-checkout main                            %% Return to the original conversation
+  \session-checkout[session=work] shorter-question  %% Switch a specific session
+
+Options:
+  session - Session name or ID (optional, defaults to active session)
+
+Input: Name of the branch to switch to (required)
+
+Note: The active branch is the path used by \llm-call, \send, \session-show and exports.`
+}
+
+// HelpInfo returns structured help information for the session-checkout command.
+func (c *CheckoutCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       "\\session-checkout[session=session_id] branch_name",
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "session",
+				Description: "Session name or ID (optional, defaults to active session)",
+				Required:    false,
+				Type:        "string",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\session-checkout main",
+				Description: "Return to the original conversation",
+			},
+			{
+				Command:     "\\session-checkout[session=work] shorter-question",
+				Description: "Switch the active branch of a specific session",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "#active_branch",
+				Description: "Name of the branch that is now active",
+				Type:        "system_metadata",
+				Example:     "main",
+			},
+			{
+				Name:        "#message_count",
+				Description: "Number of messages on the active path",
+				Type:        "system_metadata",
+				Example:     "4",
+			},
+			{
+				Name:        "_output",
+				Description: "Checkout result message",
+				Type:        "command_output",
+				Example:     "Switched session 'work' to branch 'main' (4 messages)",
+			},
+		},
+		Notes: []string{
+			"The active branch is the path used by \\llm-call, \\send, \\session-show and exports",
+			"Use \\session-branches to list the branches of a session",
+		},
+	}
+}
+
+// Execute switches the session to the requested branch.
+// Options:
+//   - session: Session name or ID (optional, defaults to active session)
+func (c *CheckoutCommand) Execute(args map[string]string, input string) error {
+	branchName := strings.TrimSpace(input)
+	if branchName == "" {
+		return fmt.Errorf("branch name is required. Usage: %s", c.Usage())
+	}
+
+	chatService, err := services.GetGlobalChatSessionService()
+	if err != nil {
+		return fmt.Errorf("chat session service not available: %w", err)
+	}
+
+	variableService, err := services.GetGlobalVariableService()
+	if err != nil {
+		return fmt.Errorf("variable service not available: %w", err)
+	}
+
+	targetSession, err := resolveBranchSession(chatService, args["session"], c.Usage())
+	if err != nil {
+		return err
+	}
+
+	if err := chatService.CheckoutBranch(targetSession.ID, branchName); err != nil {
+		return fmt.Errorf("failed to check out branch: %w", err)
+	}
+
+	variables := map[string]string{
+		"#session_id":    targetSession.ID,
+		"#session_name":  targetSession.Name,
+		"#active_branch": targetSession.ActiveBranch,
+		"#message_count": fmt.Sprintf("%d", len(targetSession.Messages)),
+	}
+	for name, value := range variables {
+		if err := variableService.SetSystemVariable(name, value); err != nil {
+			return fmt.Errorf("failed to set variable %s: %w", name, err)
+		}
+	}
+
+	outputMsg := fmt.Sprintf("Switched session '%s' to branch '%s' (%d messages)",
+		targetSession.Name, targetSession.ActiveBranch, len(targetSession.Messages))
+	if err := variableService.SetSystemVariable("_output", outputMsg); err != nil {
+		return fmt.Errorf("failed to store result: %w", err)
+	}
+
+	printer := printing.NewDefaultPrinter()
+	printer.Success(outputMsg)
+
+	chatService.TriggerAutoSave(targetSession.ID)
+
+	return nil
+}
+
+// IsReadOnly returns false as the session-checkout command modifies system state.
+func (c *CheckoutCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&CheckoutCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register session-checkout command: %v", err))
+	}
+}
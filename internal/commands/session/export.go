@@ -6,8 +6,10 @@ import (
 	"fmt"
 
 	"neuroshell/internal/commands"
+	"neuroshell/internal/commands/printing"
 	"neuroshell/internal/services"
 	"neuroshell/pkg/neurotypes"
+	"neuroshell/pkg/stringprocessing"
 )
 
 // ExportCommand implements the \session-export command for exporting sessions in various formats.
@@ -31,20 +33,24 @@ func (c *ExportCommand) Description() string {
 
 // Usage returns the syntax and usage examples for the session-export command.
 func (c *ExportCommand) Usage() string {
-	return `\session-export[format=json, file=path] session_identifier
+	return `\session-export[format=json, file=path, system=true] session_identifier
 
 Examples:
   \session-export[file=backup.json] work                        %% Export "work" session (defaults to JSON)
   \session-export[format=json, file=backup.json] project       %% Explicit JSON format
-  \session-export[file=/tmp/session.json] 550e8400             %% Export by session ID
+  \session-export[format=markdown, file=design.md] work        %% Readable transcript for documents
+  \session-export[format=html, file=work.html] work            %% Standalone page in the active theme colors
+  \session-export[format=jsonl-finetune, file=train.jsonl, shape=anthropic, append=true] work
   \session-export[file=export.json] proj                       %% Export using prefix matching
 
 Options:
-  format - Export format: json (default: json)
+  format - Export format: json, markdown, html or jsonl-finetune (default: json)
   file   - Path to export file (required)
+  system - Include the system prompt (markdown, html and jsonl-finetune; default: true)
+  shape  - Fine-tuning record shape: openai or anthropic (jsonl-finetune only; default: openai)
+  append - Add the record to an existing file (jsonl-finetune only; default: false)
 
 Note: Session identifier can be exact name, exact ID, or unique prefix.
-      Currently only JSON format is supported, but other formats may be added.
       Command delegates to format-specific implementations.`
 }
 
@@ -53,12 +59,12 @@ func (c *ExportCommand) HelpInfo() neurotypes.HelpInfo {
 	return neurotypes.HelpInfo{
 		Command:     c.Name(),
 		Description: c.Description(),
-		Usage:       "\\session-export[format=json, file=path] session_identifier",
+		Usage:       "\\session-export[format=json, file=path, system=true] session_identifier",
 		ParseMode:   c.ParseMode(),
 		Options: []neurotypes.HelpOption{
 			{
 				Name:        "format",
-				Description: "Export format: json",
+				Description: "Export format: json, markdown, html or jsonl-finetune",
 				Required:    false,
 				Type:        "string",
 				Default:     "json",
@@ -69,6 +75,27 @@ func (c *ExportCommand) HelpInfo() neurotypes.HelpInfo {
 				Required:    true,
 				Type:        "string",
 			},
+			{
+				Name:        "system",
+				Description: "Include the system prompt (markdown, html and jsonl-finetune)",
+				Required:    false,
+				Type:        "bool",
+				Default:     "true",
+			},
+			{
+				Name:        "shape",
+				Description: "Fine-tuning record shape: openai or anthropic (jsonl-finetune only)",
+				Required:    false,
+				Type:        "string",
+				Default:     "openai",
+			},
+			{
+				Name:        "append",
+				Description: "Add the record to an existing file (jsonl-finetune only)",
+				Required:    false,
+				Type:        "bool",
+				Default:     "false",
+			},
 		},
 		Examples: []neurotypes.HelpExample{
 			{
@@ -79,6 +106,14 @@ func (c *ExportCommand) HelpInfo() neurotypes.HelpInfo {
 				Command:     "\\session-export[format=json, file=backup.json] project",
 				Description: "Export session with explicit JSON format",
 			},
+			{
+				Command:     "\\session-export[format=markdown, file=design.md] work",
+				Description: "Export a readable transcript with collapsible thinking blocks",
+			},
+			{
+				Command:     "\\session-export[format=jsonl-finetune, file=train.jsonl, append=true] work",
+				Description: "Add the session to a fine-tuning set",
+			},
 			{
 				Command:     "\\session-export[file=export.json] proj",
 				Description: "Export session using prefix matching",
@@ -93,10 +128,10 @@ func (c *ExportCommand) HelpInfo() neurotypes.HelpInfo {
 			},
 		},
 		Notes: []string{
-			"Currently only JSON format is supported",
 			"Command delegates to format-specific implementations",
 			"Session identifier can be exact name, exact ID, or unique prefix",
-			"Future formats may include XML, YAML, or custom formats",
+			"Only JSON exports can be imported again with \\session-import",
+			"Markdown, HTML and fine-tuning exports use the active branch of the session",
 		},
 	}
 }
@@ -106,6 +141,7 @@ func (c *ExportCommand) HelpInfo() neurotypes.HelpInfo {
 // Options:
 //   - format: export format (default: json)
 //   - file: path to export file (required)
+//   - system, shape, append: passed on to the format-specific command
 func (c *ExportCommand) Execute(args map[string]string, input string) error {
 
 	// Get stack service for command delegation
@@ -130,12 +166,31 @@ func (c *ExportCommand) Execute(args map[string]string, input string) error {
 	}
 
 	// Delegate to format-specific command
+	system := args["system"]
+	if system == "" {
+		system = "true"
+	}
 	var delegatedCommand string
 	switch format {
 	case "json":
 		delegatedCommand = fmt.Sprintf("\\session-json-export[file=%s] %s", filepath, input)
+	case "markdown", "md":
+		delegatedCommand = fmt.Sprintf("\\session-markdown-export[file=%s, system=%s] %s", filepath, system, input)
+	case "html":
+		delegatedCommand = fmt.Sprintf("\\session-html-export[file=%s, system=%s] %s", filepath, system, input)
+	case "jsonl-finetune":
+		shape := args["shape"]
+		if shape == "" {
+			shape = services.FinetuneShapeOpenAI
+		}
+		appendRecord := args["append"]
+		if appendRecord == "" {
+			appendRecord = "false"
+		}
+		delegatedCommand = fmt.Sprintf("\\session-jsonl-export[file=%s, system=%s, shape=%s, append=%s] %s",
+			filepath, system, shape, appendRecord, input)
 	default:
-		return fmt.Errorf("unsupported export format '%s'. Supported formats: json", format)
+		return fmt.Errorf("unsupported export format '%s'. Supported formats: json, markdown, html, jsonl-finetune", format)
 	}
 
 	// Push the delegated command to the stack for execution
@@ -144,6 +199,57 @@ func (c *ExportCommand) Execute(args map[string]string, input string) error {
 	return nil
 }
 
+// runFormatExport resolves the session, runs a format-specific export and reports the result in _output.
+// It is shared by the markdown, html and jsonl export commands.
+func runFormatExport(args map[string]string, input string, export func(chatService *services.ChatSessionService, sessionID, filePath string) error) error {
+	chatService, err := services.GetGlobalChatSessionService()
+	if err != nil {
+		return fmt.Errorf("chat session service not available: %w", err)
+	}
+
+	variableService, err := services.GetGlobalVariableService()
+	if err != nil {
+		return fmt.Errorf("variable service not available: %w", err)
+	}
+
+	filepath := args["file"]
+	if filepath == "" {
+		return fmt.Errorf("file path is required (use file=path)")
+	}
+	if input == "" {
+		return fmt.Errorf("session identifier is required")
+	}
+
+	session, err := chatService.FindSessionByPrefix(input)
+	if err != nil {
+		return fmt.Errorf("session lookup failed: %w", err)
+	}
+
+	if err := export(chatService, session.ID, filepath); err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+
+	shortID := session.ID
+	if len(shortID) > 8 {
+		shortID = shortID[:8]
+	}
+	outputMsg := fmt.Sprintf("Exported session '%s' (ID: %s) to %s", session.Name, shortID, filepath)
+	if err := variableService.SetSystemVariable("_output", outputMsg); err != nil {
+		return fmt.Errorf("failed to store result: %w", err)
+	}
+
+	printer := printing.NewDefaultPrinter()
+	printer.Success(outputMsg)
+
+	return nil
+}
+
+// includeSystemPrompt reports whether the system option asks for the system prompt (default true).
+func includeSystemPrompt(args map[string]string) bool {
+	value, exists := args["system"]
+	return !exists || value == "" || stringprocessing.IsTruthy(value)
+}
+
 // IsReadOnly returns false as the session-export command modifies system state.
 func (c *ExportCommand) IsReadOnly() bool {
 	return false
@@ -0,0 +1,126 @@
+// Package session provides session management commands for NeuroShell.
+// It includes commands for creating, managing, and interacting with chat sessions.
+package session
+
+import (
+	"fmt"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// HTMLExportCommand implements the \session-html-export command for exporting standalone HTML transcripts.
+type HTMLExportCommand struct{}
+
+// Name returns the command name "session-html-export" for registration and lookup.
+func (c *HTMLExportCommand) Name() string {
+	return "session-html-export"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *HTMLExportCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the session-html-export command does.
+func (c *HTMLExportCommand) Description() string {
+	return "Export chat session to a standalone HTML page"
+}
+
+// Usage returns the syntax and usage examples for the session-html-export command.
+func (c *HTMLExportCommand) Usage() string {
+	return `\session-html-export[file=path, system=true] session_identifier
+
+Examples:
+  \session-html-export[file=work.html] work                     %% Export "work" session as HTML
+  \session-html-export[file=chat.html, system=false] work       %% Leave out the system prompt
+
+Options:
+  file   - Path to HTML export file (required)
+  system - Include the system prompt (default: true)
+
+Note: Session identifier can be exact name, exact ID, or unique prefix.
+      Role colors come from the active theme (${_style}); the page needs no other files.`
+}
+
+// HelpInfo returns structured help information for the session-html-export command.
+func (c *HTMLExportCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       "\\session-html-export[file=path, system=true] session_identifier",
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "file",
+				Description: "Path to HTML export file",
+				Required:    true,
+				Type:        "string",
+			},
+			{
+				Name:        "system",
+				Description: "Include the system prompt",
+				Required:    false,
+				Type:        "bool",
+				Default:     "true",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\session-html-export[file=work.html] work",
+				Description: "Export session 'work' as a standalone HTML page",
+			},
+			{
+				Command:     "\\set[_style=dark] \\session-html-export[file=work.html] work",
+				Description: "Export using the colors of the dark theme",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "_output",
+				Description: "Export result message with file path and session details",
+				Type:        "command_output",
+				Example:     "Exported session 'work' (ID: 550e8400) to work.html",
+			},
+		},
+		Notes: []string{
+			"Session identifier can be exact name, exact ID, or unique prefix",
+			"Role colors come from the theme selected by ${_style}",
+			"The plain theme uses neutral default colors",
+			"Thinking blocks are collapsible sections",
+		},
+	}
+}
+
+// Execute exports a chat session to an HTML file.
+// Options:
+//   - file: path to HTML export file (required)
+//   - system: include the system prompt (default: true)
+func (c *HTMLExportCommand) Execute(args map[string]string, input string) error {
+	includeSystem := includeSystemPrompt(args)
+
+	var theme *services.Theme
+	if themeService, err := services.GetGlobalThemeService(); err == nil {
+		themeName := ""
+		if variableService, err := services.GetGlobalVariableService(); err == nil {
+			themeName, _ = variableService.Get("_style")
+		}
+		theme = themeService.GetThemeByName(themeName)
+	}
+
+	return runFormatExport(args, input, func(chatService *services.ChatSessionService, sessionID, filePath string) error {
+		return chatService.ExportSessionToHTML(sessionID, filePath, includeSystem, theme)
+	})
+}
+
+// IsReadOnly returns false as the session-html-export command modifies system state.
+func (c *HTMLExportCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&HTMLExportCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register session-html-export command: %v", err))
+	}
+}
@@ -33,21 +33,23 @@ func (c *ImportCommand) Description() string {
 
 // Usage returns the syntax and usage examples for the session-import command.
 func (c *ImportCommand) Usage() string {
-	return `\session-import[format=json, file=path]
+	return `\session-import[format=json, file=path, match=text]
 
 Examples:
   \session-import[file=backup.json]                             %% Import from JSON (auto-detected by extension)
   \session-import[format=json, file=backup.json]               %% Explicit JSON format
-  \session-import[file=/tmp/session.json]                      %% Import from absolute path
-  \session-import[file=exports/session.json]                   %% Import from subdirectory
+  \session-import[format=chatgpt, file=conversations.json]     %% Every conversation of a ChatGPT export
+  \session-import[format=claude, file=export.zip, match=api]   %% Claude conversations with "api" in the title
+  \session-import[format=openai-messages, file=request.json]   %% Raw OpenAI/Anthropic messages array
 
 Options:
-  format - Import format: json (auto-detected from file extension if not specified)
+  format - Import format: json, chatgpt, claude or openai-messages (json is auto-detected from .json)
   file   - Path to import file (required)
+  match  - Only import conversations whose title contains this text (chatgpt and claude)
 
 Note: Format is auto-detected from file extension if not specified.
-      Currently only JSON format (.json) is supported.
-      Imported session gets new identity but preserves all content.`
+      chatgpt, claude and openai-messages create one session per conversation, named after its title.
+      Imported sessions get a new identity but preserve all content.`
 }
 
 // HelpInfo returns structured help information for the session-import command.
@@ -55,12 +57,12 @@ func (c *ImportCommand) HelpInfo() neurotypes.HelpInfo {
 	return neurotypes.HelpInfo{
 		Command:     c.Name(),
 		Description: c.Description(),
-		Usage:       "\\session-import[format=json, file=path]",
+		Usage:       "\\session-import[format=json, file=path, match=text]",
 		ParseMode:   c.ParseMode(),
 		Options: []neurotypes.HelpOption{
 			{
 				Name:        "format",
-				Description: "Import format: json (auto-detected from file extension)",
+				Description: "Import format: json, chatgpt, claude or openai-messages (json is auto-detected)",
 				Required:    false,
 				Type:        "string",
 			},
@@ -70,6 +72,12 @@ func (c *ImportCommand) HelpInfo() neurotypes.HelpInfo {
 				Required:    true,
 				Type:        "string",
 			},
+			{
+				Name:        "match",
+				Description: "Only import conversations whose title contains this text (chatgpt and claude)",
+				Required:    false,
+				Type:        "string",
+			},
 		},
 		Examples: []neurotypes.HelpExample{
 			{
@@ -81,8 +89,8 @@ func (c *ImportCommand) HelpInfo() neurotypes.HelpInfo {
 				Description: "Import session with explicit JSON format",
 			},
 			{
-				Command:     "\\session-import[file=/tmp/session.json]",
-				Description: "Import session from absolute file path",
+				Command:     "\\session-import[format=chatgpt, file=conversations.json]",
+				Description: "Import every conversation of a ChatGPT data export",
 			},
 		},
 		StoredVariables: []neurotypes.HelpStoredVariable{
@@ -95,9 +103,10 @@ func (c *ImportCommand) HelpInfo() neurotypes.HelpInfo {
 		},
 		Notes: []string{
 			"Format is auto-detected from file extension if not specified",
-			"Currently only JSON format (.json extension) is supported",
+			"chatgpt, claude and openai-messages are delegated to \\session-chat-import",
 			"Command delegates to format-specific implementations",
 			"Imported session gets completely new identity but preserves content",
+			".zip archives must name their format, since ChatGPT and Claude both use them",
 		},
 	}
 }
@@ -106,6 +115,7 @@ func (c *ImportCommand) HelpInfo() neurotypes.HelpInfo {
 // Options:
 //   - format: import format (auto-detected from file extension if not specified)
 //   - file: path to import file (required)
+//   - match: title filter for chatgpt and claude exports
 func (c *ImportCommand) Execute(args map[string]string, _ string) error {
 
 	// Get stack service for command delegation
@@ -125,7 +135,7 @@ func (c *ImportCommand) Execute(args map[string]string, _ string) error {
 	if format == "" {
 		format = c.detectFormatFromExtension(filepath)
 		if format == "" {
-			return fmt.Errorf("unable to auto-detect format from file extension. Supported extensions: .json")
+			return fmt.Errorf("unable to auto-detect format from file extension. Supported extensions: .json; use format=chatgpt, claude or openai-messages for other exports")
 		}
 	}
 
@@ -134,8 +144,11 @@ func (c *ImportCommand) Execute(args map[string]string, _ string) error {
 	switch format {
 	case "json":
 		delegatedCommand = fmt.Sprintf("\\session-json-import[file=%s]", filepath)
+	case services.ImportFormatChatGPT, services.ImportFormatClaude, services.ImportFormatOpenAIMessages:
+		// Run the chat import directly, since a match text could not be passed safely in a command line
+		return (&ChatImportCommand{}).Execute(map[string]string{"format": format, "file": filepath, "match": args["match"]}, "")
 	default:
-		return fmt.Errorf("unsupported import format '%s'. Supported formats: json", format)
+		return fmt.Errorf("unsupported import format '%s'. Supported formats: json, chatgpt, claude, openai-messages", format)
 	}
 
 	// Push the delegated command to the stack for execution
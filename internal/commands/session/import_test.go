@@ -0,0 +1,59 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"neuroshell/internal/context"
+	"neuroshell/internal/services"
+	"neuroshell/internal/testutils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportCommand_Execute_ChatFormatMatchWithSpecialCharacters(t *testing.T) {
+	testutils.ResetTestCounters()
+	ctx := context.New()
+	ctx.SetTestMode(true)
+	setupJSONImportTestRegistry(t, ctx)
+
+	// Titles may contain the characters that separate and close command options
+	data, err := os.ReadFile(filepath.Join("..", "..", "..", "test", "fixtures", "import", "chatgpt-conversations.json"))
+	require.NoError(t, err)
+	exportFile := filepath.Join(t.TempDir(), "conversations.json")
+	content := strings.Replace(string(data), "Regex help", "Regex [help], again", 1)
+	require.NoError(t, os.WriteFile(exportFile, []byte(content), 0644))
+
+	cmd := &ImportCommand{}
+	require.NoError(t, cmd.Execute(map[string]string{"format": "chatgpt", "file": exportFile, "match": "[help], again"}, ""))
+
+	variableService, _ := services.GetGlobalVariableService()
+	count, _ := variableService.Get("#imported_count")
+	assert.Equal(t, "1", count)
+	name, _ := variableService.Get("#session_name")
+	assert.Equal(t, "Regex [help], again", name)
+
+	// Only the activation of the imported session is left on the stack
+	stackService, _ := services.GetGlobalStackService()
+	command, ok := stackService.PopCommand()
+	require.True(t, ok)
+	assert.Contains(t, command, "\\session-activate")
+	assert.True(t, stackService.IsEmpty())
+}
+
+func TestImportCommand_Execute_JSONDelegates(t *testing.T) {
+	ctx := context.New()
+	ctx.SetTestMode(true)
+	setupJSONImportTestRegistry(t, ctx)
+
+	cmd := &ImportCommand{}
+	require.NoError(t, cmd.Execute(map[string]string{"file": "backup.json"}, ""))
+
+	stackService, _ := services.GetGlobalStackService()
+	command, ok := stackService.PopCommand()
+	require.True(t, ok)
+	assert.Equal(t, "\\session-json-import[file=backup.json]", command)
+}
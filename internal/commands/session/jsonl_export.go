@@ -0,0 +1,147 @@
+// Package session provides session management commands for NeuroShell.
+// It includes commands for creating, managing, and interacting with chat sessions.
+package session
+
+import (
+	"fmt"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+	"neuroshell/pkg/stringprocessing"
+)
+
+// JSONLExportCommand implements the \session-jsonl-export command for writing fine-tuning records.
+type JSONLExportCommand struct{}
+
+// Name returns the command name "session-jsonl-export" for registration and lookup.
+func (c *JSONLExportCommand) Name() string {
+	return "session-jsonl-export"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *JSONLExportCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the session-jsonl-export command does.
+func (c *JSONLExportCommand) Description() string {
+	return "Export chat session as a JSONL fine-tuning record"
+}
+
+// Usage returns the syntax and usage examples for the session-jsonl-export command.
+func (c *JSONLExportCommand) Usage() string {
+	return `\session-jsonl-export[file=path, shape=openai, system=true, append=false] session_identifier
+
+Examples:
+  \session-jsonl-export[file=train.jsonl] work                          %% OpenAI chat fine-tuning record
+  \session-jsonl-export[file=train.jsonl, shape=anthropic] work         %% Claude fine-tuning record
+  \session-jsonl-export[file=train.jsonl, append=true] review           %% Add another session to the set
+
+Options:
+  file   - Path to JSONL file (required)
+  shape  - Record shape: openai or anthropic (default: openai)
+  system - Include the system prompt (default: true)
+  append - Add the record to the end of the file instead of replacing it (default: false)
+
+Note: Session identifier can be exact name, exact ID, or unique prefix.
+      Each session becomes one line. Only user and assistant turns are written;
+      tool calls and thinking blocks are left out.`
+}
+
+// HelpInfo returns structured help information for the session-jsonl-export command.
+func (c *JSONLExportCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       "\\session-jsonl-export[file=path, shape=openai, system=true, append=false] session_identifier",
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "file",
+				Description: "Path to JSONL file",
+				Required:    true,
+				Type:        "string",
+			},
+			{
+				Name:        "shape",
+				Description: "Record shape: openai or anthropic",
+				Required:    false,
+				Type:        "string",
+				Default:     "openai",
+			},
+			{
+				Name:        "system",
+				Description: "Include the system prompt",
+				Required:    false,
+				Type:        "bool",
+				Default:     "true",
+			},
+			{
+				Name:        "append",
+				Description: "Add the record to the end of the file instead of replacing it",
+				Required:    false,
+				Type:        "bool",
+				Default:     "false",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\session-jsonl-export[file=train.jsonl] work",
+				Description: "Write session 'work' as an OpenAI chat fine-tuning record",
+			},
+			{
+				Command:     "\\session-jsonl-export[file=train.jsonl, shape=anthropic, append=true] review",
+				Description: "Add session 'review' to a Claude fine-tuning set",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "_output",
+				Description: "Export result message with file path and session details",
+				Type:        "command_output",
+				Example:     "Exported session 'work' (ID: 550e8400) to train.jsonl",
+			},
+		},
+		Notes: []string{
+			"Session identifier can be exact name, exact ID, or unique prefix",
+			"openai records are {\"messages\": [...]} with the system prompt as the first message",
+			"anthropic records are {\"system\": \"...\", \"messages\": [...]} and must start with a user turn",
+			"Only user and assistant turns are written; tool calls and thinking blocks are left out",
+			"Sessions without an assistant response cannot be exported",
+		},
+	}
+}
+
+// Execute writes a chat session as a fine-tuning record.
+// Options:
+//   - file: path to JSONL file (required)
+//   - shape: openai or anthropic (default: openai)
+//   - system: include the system prompt (default: true)
+//   - append: add to the end of the file (default: false)
+func (c *JSONLExportCommand) Execute(args map[string]string, input string) error {
+	includeSystem := includeSystemPrompt(args)
+	appendRecord := stringprocessing.IsTruthy(args["append"])
+	shape := args["shape"]
+	if shape == "" {
+		shape = services.FinetuneShapeOpenAI
+	}
+	if shape != services.FinetuneShapeOpenAI && shape != services.FinetuneShapeAnthropic {
+		return fmt.Errorf("unsupported shape '%s'. Supported shapes: openai, anthropic", shape)
+	}
+
+	return runFormatExport(args, input, func(chatService *services.ChatSessionService, sessionID, filePath string) error {
+		return chatService.ExportSessionToJSONL(sessionID, filePath, shape, includeSystem, appendRecord)
+	})
+}
+
+// IsReadOnly returns false as the session-jsonl-export command modifies system state.
+func (c *JSONLExportCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&JSONLExportCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register session-jsonl-export command: %v", err))
+	}
+}
@@ -0,0 +1,64 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	neuroshellcontext "neuroshell/internal/context"
+	"neuroshell/internal/services"
+	"neuroshell/internal/testutils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONLExportCommand_Execute_Append(t *testing.T) {
+	testutils.ResetTestCounters()
+	ctx := neuroshellcontext.NewTestContext()
+	setupJSONExportTestRegistry(t, ctx)
+
+	chatService, err := services.GetGlobalChatSessionService()
+	require.NoError(t, err)
+	for _, name := range []string{"first", "second"} {
+		session, err := chatService.CreateSession(name, "Be brief", "Hello "+name)
+		require.NoError(t, err)
+		require.NoError(t, chatService.AddMessage(session.ID, "assistant", "Hi "+name))
+	}
+
+	cmd := &JSONLExportCommand{}
+	exportFile := filepath.Join(t.TempDir(), "train.jsonl")
+	require.NoError(t, cmd.Execute(map[string]string{"file": exportFile}, "first"))
+	require.NoError(t, cmd.Execute(map[string]string{"file": exportFile, "append": "true", "system": "false"}, "second"))
+
+	data, err := os.ReadFile(exportFile)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, `{"messages":[{"role":"system","content":"Be brief"},{"role":"user","content":"Hello first"},{"role":"assistant","content":"Hi first"}]}`, lines[0])
+	assert.Equal(t, `{"messages":[{"role":"user","content":"Hello second"},{"role":"assistant","content":"Hi second"}]}`, lines[1])
+
+	variableService, err := services.GetGlobalVariableService()
+	require.NoError(t, err)
+	output, err := variableService.Get("_output")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Exported session 'second'")
+}
+
+func TestJSONLExportCommand_Execute_Errors(t *testing.T) {
+	testutils.ResetTestCounters()
+	ctx := neuroshellcontext.NewTestContext()
+	setupJSONExportTestRegistry(t, ctx)
+
+	chatService, err := services.GetGlobalChatSessionService()
+	require.NoError(t, err)
+	_, err = chatService.CreateSession("unanswered", "", "Hello")
+	require.NoError(t, err)
+
+	cmd := &JSONLExportCommand{}
+	exportFile := filepath.Join(t.TempDir(), "train.jsonl")
+	assert.ErrorContains(t, cmd.Execute(map[string]string{"file": exportFile}, "unanswered"), "no assistant messages")
+	assert.ErrorContains(t, cmd.Execute(map[string]string{"file": exportFile, "shape": "bedrock"}, "unanswered"), "unsupported shape")
+	assert.ErrorContains(t, cmd.Execute(map[string]string{}, "unanswered"), "file path is required")
+}
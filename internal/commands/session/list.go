@@ -48,6 +48,7 @@ Options:
   filter - Filter criteria: active (only active session), all (default)
 
 Note: Options can be combined. Default sort is by creation time (newest first).
+      Sessions indexed with _session_autoload=lazy show as "not loaded" until first used.
       Session list is stored in ${_output} variable.`
 }
 
@@ -105,6 +106,7 @@ func (c *ListCommand) HelpInfo() neurotypes.HelpInfo {
 			"Default sort is by creation time with newest sessions first",
 			"Shows session name, ID (short), active status, message count, and creation date",
 			"Active session is marked with 'active' status indicator",
+			"Sessions indexed with _session_autoload=lazy show as 'not loaded' until first used",
 		},
 	}
 }
@@ -249,6 +251,13 @@ func (c *ListCommand) formatSessionList(sessions []*neurotypes.ChatSession) stri
 			status = ", active"
 		}
 
+		// Lazily indexed sessions only know when their file was saved
+		if session.UnloadedFrom != "" {
+			result.WriteString(fmt.Sprintf("  %s    (ID: %s%s, not loaded, saved: %s)\n",
+				session.Name, shortID, status, session.UpdatedAt.Format("2006-01-02")))
+			continue
+		}
+
 		messageCount := len(session.Messages)
 		messageText := "messages"
 		if messageCount == 1 {
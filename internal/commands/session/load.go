@@ -0,0 +1,173 @@
+// Package session provides session management commands for NeuroShell.
+// It includes commands for creating, managing, and interacting with chat sessions.
+package session
+
+import (
+	"fmt"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/commands/printing"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+	"neuroshell/pkg/stringprocessing"
+)
+
+// LoadCommand implements the \session-load command for loading sessions saved by
+// \session-save and autosave back into the shell.
+type LoadCommand struct{}
+
+// Name returns the command name "session-load" for registration and lookup.
+func (c *LoadCommand) Name() string {
+	return "session-load"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *LoadCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the session-load command does.
+func (c *LoadCommand) Description() string {
+	return "Load saved sessions from the sessions directory"
+}
+
+// Usage returns the syntax and usage examples for the session-load command.
+func (c *LoadCommand) Usage() string {
+	return `\session-load[lazy=false] [session_identifier]
+
+Examples:
+  \session-load                         %% Load every saved session
+  \session-load work                    %% Load the saved session named "work"
+  \session-load 550e8400                %% Load a saved session by ID prefix
+  \session-load[lazy=true]              %% Index every saved session, read each one on first use
+
+Options:
+  lazy - Only read names and IDs now; a session is read when it is first used (default: false)
+
+Note: Sessions are read from ~/.config/neuroshell/sessions/, where \session-save writes them.
+      The identifier can be an exact name, exact ID, or unique prefix of either.
+      Loaded sessions keep their IDs, so saving them again updates the same files.
+      Sessions already in memory are skipped; taken names get a version suffix (work:v1).
+      No session is activated. Set _session_autoload=true|lazy in .neurorc to load
+      saved sessions when the shell starts.`
+}
+
+// HelpInfo returns structured help information for the session-load command.
+func (c *LoadCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       "\\session-load[lazy=false] [session_identifier]",
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "lazy",
+				Description: "Only read names and IDs now; a session is read when it is first used",
+				Required:    false,
+				Type:        "bool",
+				Default:     "false",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\session-load",
+				Description: "Load every saved session",
+			},
+			{
+				Command:     "\\session-load work",
+				Description: "Load the saved session named 'work'",
+			},
+			{
+				Command:     "\\session-load[lazy=true]",
+				Description: "Index every saved session without reading their messages",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "#loaded_count",
+				Description: "Number of sessions loaded",
+				Type:        "system_metadata",
+				Example:     "3",
+			},
+			{
+				Name:        "_output",
+				Description: "One line per loaded session",
+				Type:        "command_output",
+				Example:     "Loaded 'work' (ID: 550e8400)",
+			},
+		},
+		Notes: []string{
+			"Sessions are read from ~/.config/neuroshell/sessions/",
+			"Identifier can be an exact name, exact ID, or unique prefix of either",
+			"Loaded sessions keep their IDs, so saving them again updates the same files",
+			"Sessions already in memory are skipped",
+			"No session is activated",
+			"Set _session_autoload=true or lazy to load saved sessions at startup",
+		},
+	}
+}
+
+// Execute loads saved sessions into memory.
+// Options:
+//   - lazy: defer reading each session until it is first used (optional, default: false)
+func (c *LoadCommand) Execute(args map[string]string, input string) error {
+	chatService, err := services.GetGlobalChatSessionService()
+	if err != nil {
+		return fmt.Errorf("chat session service not available: %w", err)
+	}
+
+	variableService, err := services.GetGlobalVariableService()
+	if err != nil {
+		return fmt.Errorf("variable service not available: %w", err)
+	}
+
+	identifier := strings.TrimSpace(input)
+	loaded, err := chatService.LoadSavedSessions(identifier, stringprocessing.IsTruthy(args["lazy"]))
+	if err != nil {
+		return fmt.Errorf("session load failed: %w", err)
+	}
+
+	lines := make([]string, 0, len(loaded))
+	for _, session := range loaded {
+		shortID := session.ID
+		if len(shortID) > 8 {
+			shortID = shortID[:8]
+		}
+		if session.UnloadedFrom != "" {
+			lines = append(lines, fmt.Sprintf("Indexed '%s' (ID: %s)", session.Name, shortID))
+		} else {
+			lines = append(lines, fmt.Sprintf("Loaded '%s' (ID: %s, %d messages)", session.Name, shortID, len(session.Messages)))
+		}
+	}
+
+	if err := variableService.SetSystemVariable("#loaded_count", fmt.Sprintf("%d", len(loaded))); err != nil {
+		return fmt.Errorf("failed to set loaded count variable: %w", err)
+	}
+	if err := variableService.SetSystemVariable("_output", strings.Join(lines, "\n")); err != nil {
+		return fmt.Errorf("failed to store result: %w", err)
+	}
+
+	printer := printing.NewDefaultPrinter()
+	if len(loaded) == 0 {
+		printer.Info("No saved sessions to load")
+		return nil
+	}
+	printer.Success(fmt.Sprintf("Loaded %d saved session(s)", len(loaded)))
+	for _, line := range lines {
+		printer.Println("  " + line)
+	}
+
+	return nil
+}
+
+// IsReadOnly returns false as the session-load command modifies system state.
+func (c *LoadCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&LoadCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register session-load command: %v", err))
+	}
+}
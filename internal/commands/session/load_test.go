@@ -0,0 +1,136 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/internal/context"
+	"neuroshell/internal/services"
+)
+
+func TestLoadCommand_Execute_SavedSessions(t *testing.T) {
+	ctx := context.NewTestContext()
+
+	// In test mode, context returns "/tmp/neuroshell-test-config"
+	tempConfigDir := "/tmp/neuroshell-test-config"
+	_ = os.RemoveAll(tempConfigDir)
+	defer func() {
+		_ = os.RemoveAll(tempConfigDir)
+	}()
+
+	setupSaveTestRegistry(t, ctx)
+
+	chatService, err := services.GetGlobalChatSessionService()
+	require.NoError(t, err)
+	for _, name := range []string{"work", "notes"} {
+		_, err := chatService.CreateSession(name, "", "Hello "+name)
+		require.NoError(t, err)
+		require.NoError(t, (&SaveCommand{}).Execute(map[string]string{}, name))
+	}
+	work, err := chatService.GetSessionByName("work")
+	require.NoError(t, err)
+	workID := work.ID
+
+	// A new shell starts without sessions
+	setupSaveTestRegistry(t, context.NewTestContext())
+	chatService, err = services.GetGlobalChatSessionService()
+	require.NoError(t, err)
+
+	cmd := &LoadCommand{}
+	require.NoError(t, cmd.Execute(map[string]string{}, "wo"))
+	session, err := chatService.GetSessionByName("work")
+	require.NoError(t, err)
+	assert.Equal(t, workID, session.ID, "saved IDs are kept")
+	require.Len(t, session.Messages, 1)
+	assert.False(t, session.IsActive)
+	_, err = chatService.GetSessionByName("notes")
+	assert.Error(t, err, "only the matching session is loaded")
+
+	require.NoError(t, cmd.Execute(map[string]string{"lazy": "true"}, ""))
+	variableService, err := services.GetGlobalVariableService()
+	require.NoError(t, err)
+	count, err := variableService.Get("#loaded_count")
+	require.NoError(t, err)
+	assert.Equal(t, "1", count, "sessions already in memory are skipped")
+	output, err := variableService.Get("_output")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Indexed 'notes'")
+
+	listed := (&ListCommand{}).formatSessionList(chatService.ListSessions())
+	assert.Contains(t, listed, "not loaded")
+
+	notes, err := chatService.FindSessionByPrefix("notes")
+	require.NoError(t, err)
+	require.Len(t, notes.Messages, 1)
+	assert.Equal(t, "Hello notes", notes.Messages[0].Content)
+
+	assert.ErrorContains(t, cmd.Execute(map[string]string{}, "missing"), "no saved session found")
+}
+
+func TestPurgeCommand_Execute(t *testing.T) {
+	ctx := context.NewTestContext()
+
+	tempConfigDir := "/tmp/neuroshell-test-config"
+	_ = os.RemoveAll(tempConfigDir)
+	defer func() {
+		_ = os.RemoveAll(tempConfigDir)
+	}()
+
+	setupSaveTestRegistry(t, ctx)
+
+	chatService, err := services.GetGlobalChatSessionService()
+	require.NoError(t, err)
+	for _, name := range []string{"old", "recent"} {
+		_, err := chatService.CreateSession(name, "", "")
+		require.NoError(t, err)
+		require.NoError(t, (&SaveCommand{}).Execute(map[string]string{}, name))
+	}
+	old, err := chatService.GetSessionByName("old")
+	require.NoError(t, err)
+	oldPath := filepath.Join(tempConfigDir, "sessions", old.ID+".json")
+	lastMonth := time.Now().Add(-40 * 24 * time.Hour)
+	require.NoError(t, os.Chtimes(oldPath, lastMonth, lastMonth))
+
+	cmd := &PurgeCommand{}
+	require.NoError(t, cmd.Execute(map[string]string{"dry_run": "true"}, ""))
+	assert.FileExists(t, oldPath)
+
+	require.NoError(t, cmd.Execute(map[string]string{}, ""))
+	assert.NoFileExists(t, oldPath)
+	variableService, err := services.GetGlobalVariableService()
+	require.NoError(t, err)
+	output, err := variableService.Get("_output")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Deleted 'old'")
+	_, err = chatService.GetSessionByName("old")
+	assert.NoError(t, err, "sessions in memory are kept")
+
+	saved, err := chatService.ListSavedSessions()
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Equal(t, "recent", saved[0].Name)
+
+	assert.ErrorContains(t, cmd.Execute(map[string]string{"older_than": "soon"}, ""), "invalid age")
+}
+
+func TestParseAge(t *testing.T) {
+	for value, expected := range map[string]time.Duration{
+		"30d": 30 * 24 * time.Hour,
+		"2w":  14 * 24 * time.Hour,
+		"12h": 12 * time.Hour,
+		"90m": 90 * time.Minute,
+	} {
+		age, err := parseAge(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, expected, age, value)
+	}
+	for _, value := range []string{"", "d", "-1d", "soon"} {
+		_, err := parseAge(value)
+		assert.Error(t, err, value)
+	}
+}
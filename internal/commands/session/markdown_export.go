@@ -0,0 +1,116 @@
+// Package session provides session management commands for NeuroShell.
+// It includes commands for creating, managing, and interacting with chat sessions.
+package session
+
+import (
+	"fmt"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// MarkdownExportCommand implements the \session-markdown-export command for exporting readable transcripts.
+type MarkdownExportCommand struct{}
+
+// Name returns the command name "session-markdown-export" for registration and lookup.
+func (c *MarkdownExportCommand) Name() string {
+	return "session-markdown-export"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *MarkdownExportCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the session-markdown-export command does.
+func (c *MarkdownExportCommand) Description() string {
+	return "Export chat session to a Markdown transcript"
+}
+
+// Usage returns the syntax and usage examples for the session-markdown-export command.
+func (c *MarkdownExportCommand) Usage() string {
+	return `\session-markdown-export[file=path, system=true] session_identifier
+
+Examples:
+  \session-markdown-export[file=design.md] work                 %% Export "work" session as Markdown
+  \session-markdown-export[file=chat.md, system=false] work     %% Leave out the system prompt
+
+Options:
+  file   - Path to Markdown export file (required)
+  system - Include the system prompt (default: true)
+
+Note: Session identifier can be exact name, exact ID, or unique prefix.
+      Thinking blocks are written as collapsible <details> sections.`
+}
+
+// HelpInfo returns structured help information for the session-markdown-export command.
+func (c *MarkdownExportCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       "\\session-markdown-export[file=path, system=true] session_identifier",
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "file",
+				Description: "Path to Markdown export file",
+				Required:    true,
+				Type:        "string",
+			},
+			{
+				Name:        "system",
+				Description: "Include the system prompt",
+				Required:    false,
+				Type:        "bool",
+				Default:     "true",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\session-markdown-export[file=design.md] work",
+				Description: "Export session 'work' as a Markdown transcript",
+			},
+			{
+				Command:     "\\session-markdown-export[file=chat.md, system=false] work",
+				Description: "Export without the system prompt",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "_output",
+				Description: "Export result message with file path and session details",
+				Type:        "command_output",
+				Example:     "Exported session 'work' (ID: 550e8400) to design.md",
+			},
+		},
+		Notes: []string{
+			"Session identifier can be exact name, exact ID, or unique prefix",
+			"Assistant headings name the model that wrote the response when it is known",
+			"Thinking blocks are written as collapsible <details> sections",
+			"Markdown exports cannot be imported again; use JSON for backups",
+		},
+	}
+}
+
+// Execute exports a chat session to a Markdown file.
+// Options:
+//   - file: path to Markdown export file (required)
+//   - system: include the system prompt (default: true)
+func (c *MarkdownExportCommand) Execute(args map[string]string, input string) error {
+	includeSystem := includeSystemPrompt(args)
+	return runFormatExport(args, input, func(chatService *services.ChatSessionService, sessionID, filePath string) error {
+		return chatService.ExportSessionToMarkdown(sessionID, filePath, includeSystem)
+	})
+}
+
+// IsReadOnly returns false as the session-markdown-export command modifies system state.
+func (c *MarkdownExportCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&MarkdownExportCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register session-markdown-export command: %v", err))
+	}
+}
@@ -32,7 +32,7 @@ func (c *NewCommand) Description() string {
 
 // Usage returns the syntax and usage examples for the session-new command.
 func (c *NewCommand) Usage() string {
-	return `\session-new[system=system_prompt] [session_name]
+	return `\session-new[system=system_prompt, context_policy=policy] [session_name]
 
 Examples:
   \session-new                                    %% Auto-generate name (e.g., "Session 1")
@@ -40,9 +40,13 @@ Examples:
   \session-new debug                              %% Create session named "debug"
   \session-new[system=You are a code reviewer] code review  %% Named "code review" with custom system prompt
   \session-new "my project"                       %% Session name with quotes (auto-processed)
+  \session-new[context_policy=summarize-oldest] long-chat  %% Summarize early turns once the context window is full
 
 Options:
-  system - System prompt for LLM context (optional, defaults to helpful assistant)
+  system         - System prompt for LLM context (optional, defaults to helpful assistant)
+  context_policy - What to send when the session outgrows the model's context window:
+                   error, truncate-oldest, keep-system-and-last-N or summarize-oldest
+                   (optional, defaults to the model's context_policy or ${_context_policy})
 
 Note: Session name is optional. If not provided, an auto-generated name is used (e.g., "Session 1").
       Use quotes if the name contains special characters.
@@ -54,7 +58,7 @@ func (c *NewCommand) HelpInfo() neurotypes.HelpInfo {
 	return neurotypes.HelpInfo{
 		Command:     c.Name(),
 		Description: c.Description(),
-		Usage:       "\\session-new[system=system_prompt] [session_name]",
+		Usage:       "\\session-new[system=system_prompt, context_policy=policy] [session_name]",
 		ParseMode:   c.ParseMode(),
 		Options: []neurotypes.HelpOption{
 			{
@@ -64,6 +68,13 @@ func (c *NewCommand) HelpInfo() neurotypes.HelpInfo {
 				Type:        "string",
 				Default:     "helpful assistant",
 			},
+			{
+				Name:        "context_policy",
+				Description: "Context window policy: error, truncate-oldest, keep-system-and-last-N or summarize-oldest",
+				Required:    false,
+				Type:        "string",
+				Default:     "model context_policy, else ${_context_policy}",
+			},
 		},
 		Examples: []neurotypes.HelpExample{
 			{
@@ -86,6 +97,10 @@ func (c *NewCommand) HelpInfo() neurotypes.HelpInfo {
 				Command:     "\\session-new debug-${@date}",
 				Description: "Create session with interpolated variables in name",
 			},
+			{
+				Command:     "\\session-new[context_policy=keep-system-and-last-10] chat",
+				Description: "Send only the last 10 messages once the context window is full",
+			},
 		},
 		StoredVariables: []neurotypes.HelpStoredVariable{
 			{
@@ -130,6 +145,7 @@ func (c *NewCommand) HelpInfo() neurotypes.HelpInfo {
 			"Use quotes if the name contains special characters or spaces",
 			"Variables in session name and system prompt are interpolated",
 			"Session automatically becomes active after creation via stack service",
+			"context_policy is applied by \\llm-call and reported in ${#llm_context_*} variables",
 		},
 	}
 }
@@ -138,6 +154,7 @@ func (c *NewCommand) HelpInfo() neurotypes.HelpInfo {
 // The input parameter is used as the session name (required).
 // Options:
 //   - system: system prompt for LLM context (optional, default helpful assistant)
+//   - context_policy: context window policy (optional, default model or global policy)
 func (c *NewCommand) Execute(args map[string]string, input string) error {
 
 	// Get chat session service
@@ -157,6 +174,10 @@ func (c *NewCommand) Execute(args map[string]string, input string) error {
 	// Parse arguments - session name comes from input, not from options
 	sessionName := input
 	systemPrompt := args["system"]
+	contextPolicy := args["context_policy"]
+	if _, err := services.ParseContextPolicy(contextPolicy); err != nil {
+		return err
+	}
 
 	// Auto-generate session name if not provided (improved UX)
 	if sessionName == "" {
@@ -172,6 +193,11 @@ func (c *NewCommand) Execute(args map[string]string, input string) error {
 	if err != nil {
 		return fmt.Errorf("failed to create session: %w", err)
 	}
+	if contextPolicy != "" {
+		if err := chatService.SetContextPolicy(session.ID, contextPolicy); err != nil {
+			return fmt.Errorf("failed to set context policy: %w", err)
+		}
+	}
 
 	// Auto-push session activation command to stack service for seamless UX
 	// Use precise ID-based activation to avoid any ambiguity
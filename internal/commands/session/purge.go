@@ -0,0 +1,207 @@
+// Package session provides session management commands for NeuroShell.
+// It includes commands for creating, managing, and interacting with chat sessions.
+package session
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/commands/printing"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+	"neuroshell/pkg/stringprocessing"
+)
+
+// PurgeCommand implements the \session-purge command for deleting old saved session files.
+type PurgeCommand struct{}
+
+// Name returns the command name "session-purge" for registration and lookup.
+func (c *PurgeCommand) Name() string {
+	return "session-purge"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *PurgeCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the session-purge command does.
+func (c *PurgeCommand) Description() string {
+	return "Delete saved sessions that have not been saved recently"
+}
+
+// Usage returns the syntax and usage examples for the session-purge command.
+func (c *PurgeCommand) Usage() string {
+	return `\session-purge[older_than=30d, dry_run=false]
+
+Examples:
+  \session-purge                        %% Delete saved sessions last saved over 30 days ago
+  \session-purge[older_than=2w]         %% Delete saved sessions older than two weeks
+  \session-purge[older_than=12h]        %% Delete saved sessions older than twelve hours
+  \session-purge[dry_run=true]          %% List what would be deleted without deleting
+
+Options:
+  older_than - Age of the last save, in days (30d), weeks (2w) or hours/minutes (12h, 90m) (default: 30d)
+  dry_run    - Only list the files that would be deleted (default: false)
+
+Note: Only files in ~/.config/neuroshell/sessions/ are deleted. Sessions in memory are kept,
+      and \session-save writes them again. The age is taken from the file modification time.`
+}
+
+// HelpInfo returns structured help information for the session-purge command.
+func (c *PurgeCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       "\\session-purge[older_than=30d, dry_run=false]",
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "older_than",
+				Description: "Age of the last save, in days (30d), weeks (2w) or hours/minutes (12h, 90m)",
+				Required:    false,
+				Type:        "string",
+				Default:     "30d",
+			},
+			{
+				Name:        "dry_run",
+				Description: "Only list the files that would be deleted",
+				Required:    false,
+				Type:        "bool",
+				Default:     "false",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\session-purge",
+				Description: "Delete saved sessions last saved over 30 days ago",
+			},
+			{
+				Command:     "\\session-purge[older_than=2w, dry_run=true]",
+				Description: "List the saved sessions older than two weeks",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "#purged_count",
+				Description: "Number of saved sessions deleted (or that would be, with dry_run)",
+				Type:        "system_metadata",
+				Example:     "4",
+			},
+			{
+				Name:        "_output",
+				Description: "One line per deleted saved session",
+				Type:        "command_output",
+				Example:     "Deleted 'work' (ID: 550e8400, saved: 2025-01-01)",
+			},
+		},
+		Notes: []string{
+			"Only files in ~/.config/neuroshell/sessions/ are deleted",
+			"Sessions in memory are kept; \\session-save writes them again",
+			"Age is taken from the file modification time",
+		},
+	}
+}
+
+// Execute deletes saved session files older than the given age.
+// Options:
+//   - older_than: minimum age of the last save (optional, default: 30d)
+//   - dry_run: list without deleting (optional, default: false)
+func (c *PurgeCommand) Execute(args map[string]string, _ string) error {
+	chatService, err := services.GetGlobalChatSessionService()
+	if err != nil {
+		return fmt.Errorf("chat session service not available: %w", err)
+	}
+
+	variableService, err := services.GetGlobalVariableService()
+	if err != nil {
+		return fmt.Errorf("variable service not available: %w", err)
+	}
+
+	olderThanArg := args["older_than"]
+	if olderThanArg == "" {
+		olderThanArg = "30d"
+	}
+	olderThan, err := parseAge(olderThanArg)
+	if err != nil {
+		return err
+	}
+	dryRun := stringprocessing.IsTruthy(args["dry_run"])
+
+	purged, err := chatService.PurgeSavedSessions(olderThan, dryRun)
+	if err != nil {
+		return fmt.Errorf("session purge failed: %w", err)
+	}
+
+	verb := "Deleted"
+	if dryRun {
+		verb = "Would delete"
+	}
+	lines := make([]string, 0, len(purged))
+	for _, entry := range purged {
+		shortID := entry.ID
+		if len(shortID) > 8 {
+			shortID = shortID[:8]
+		}
+		lines = append(lines, fmt.Sprintf("%s '%s' (ID: %s, saved: %s)", verb, entry.Name, shortID, entry.ModTime.Format("2006-01-02")))
+	}
+
+	if err := variableService.SetSystemVariable("#purged_count", fmt.Sprintf("%d", len(purged))); err != nil {
+		return fmt.Errorf("failed to set purged count variable: %w", err)
+	}
+	if err := variableService.SetSystemVariable("_output", strings.Join(lines, "\n")); err != nil {
+		return fmt.Errorf("failed to store result: %w", err)
+	}
+
+	printer := printing.NewDefaultPrinter()
+	if len(purged) == 0 {
+		printer.Info(fmt.Sprintf("No saved sessions older than %s", olderThanArg))
+		return nil
+	}
+	if dryRun {
+		printer.Info(fmt.Sprintf("%d saved session(s) older than %s", len(purged), olderThanArg))
+	} else {
+		printer.Success(fmt.Sprintf("Purged %d saved session(s) older than %s", len(purged), olderThanArg))
+	}
+	for _, line := range lines {
+		printer.Println("  " + line)
+	}
+
+	return nil
+}
+
+// parseAge parses an age such as 30d or 2w; other units are parsed by time.ParseDuration.
+func parseAge(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("age cannot be empty (use e.g. 30d, 2w or 12h)")
+	}
+	unitDays := map[string]int{"d": 1, "w": 7}
+	if days, ok := unitDays[value[len(value)-1:]]; ok {
+		count, err := strconv.ParseFloat(value[:len(value)-1], 64)
+		if err != nil || count < 0 {
+			return 0, fmt.Errorf("invalid age '%s' (use e.g. 30d, 2w or 12h)", value)
+		}
+		return time.Duration(count * float64(days) * float64(24*time.Hour)), nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid age '%s' (use e.g. 30d, 2w or 12h)", value)
+	}
+	return duration, nil
+}
+
+// IsReadOnly returns false as the session-purge command modifies system state.
+func (c *PurgeCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&PurgeCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register session-purge command: %v", err))
+	}
+}
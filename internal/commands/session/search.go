@@ -0,0 +1,278 @@
+// Package session provides session management commands for NeuroShell.
+// It includes commands for creating, managing, and interacting with chat sessions.
+package session
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/commands/printing"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+	"neuroshell/pkg/stringprocessing"
+)
+
+// SearchCommand implements the \session-search command for full-text search across the
+// messages of every in-memory and saved session.
+type SearchCommand struct{}
+
+// Name returns the command name "session-search" for registration and lookup.
+func (c *SearchCommand) Name() string {
+	return "session-search"
+}
+
+// ParseMode returns ParseModeKeyValue for standard argument parsing.
+func (c *SearchCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the session-search command does.
+func (c *SearchCommand) Description() string {
+	return "Search the messages of all in-memory and saved sessions"
+}
+
+// Usage returns the syntax and usage examples for the session-search command.
+func (c *SearchCommand) Usage() string {
+	return `\session-search[regex=false, role=user|assistant, limit=20] query
+
+Examples:
+  \session-search retry logic                     %% Best matches for "retry" and "logic"
+  \session-search[role=user] backoff              %% Only search your own messages
+  \session-search[regex=true] Retry(Policy|After) %% Regular expression, case-sensitive
+  \session-search[regex=true] (?i)exponential     %% Case-insensitive regular expression
+  \session-search[limit=5] database migration     %% Top five hits
+
+Jump to the best hit:
+  \session-activate[id=true] ${#search_session_id}
+  \session-show
+
+Options:
+  regex - Treat the query as a regular expression instead of keywords (default: false)
+  role  - Only search messages from user or assistant (default: both)
+  limit - Maximum number of hits (default: 20)
+
+Note: Keyword searches are case-insensitive and ranked by relevance (BM25); plurals match
+      their singular. Regex hits are ranked by number of matches. Saved sessions that are
+      not loaded are searched too, and the sessions of the hits are indexed so they can be
+      activated. Message numbers match \session-show.`
+}
+
+// HelpInfo returns structured help information for the session-search command.
+func (c *SearchCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       "\\session-search[regex=false, role=user|assistant, limit=20] query",
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "regex",
+				Description: "Treat the query as a regular expression instead of keywords",
+				Required:    false,
+				Type:        "bool",
+				Default:     "false",
+			},
+			{
+				Name:        "role",
+				Description: "Only search messages from user or assistant",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "limit",
+				Description: "Maximum number of hits",
+				Required:    false,
+				Type:        "int",
+				Default:     "20",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     "\\session-search retry logic",
+				Description: "Find the conversations about retry logic",
+			},
+			{
+				Command:     "\\session-search[role=user, limit=5] backoff",
+				Description: "Top five of your own messages mentioning backoff",
+			},
+			{
+				Command:     "\\session-search[regex=true] (?i)retry(policy|after)",
+				Description: "Search with a case-insensitive regular expression",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "#search_count",
+				Description: "Number of hits shown",
+				Type:        "system_metadata",
+				Example:     "3",
+			},
+			{
+				Name:        "#search_session_id",
+				Description: "Session ID of the best hit, for \\session-activate[id=true]",
+				Type:        "system_metadata",
+				Example:     "550e8400-e29b-41d4-a716-446655440000",
+			},
+			{
+				Name:        "#search_session_name",
+				Description: "Session name of the best hit",
+				Type:        "system_metadata",
+				Example:     "api-design",
+			},
+			{
+				Name:        "#search_message_index",
+				Description: "Message number of the best hit, as shown by \\session-show",
+				Type:        "system_metadata",
+				Example:     "4",
+			},
+			{
+				Name:        "_output",
+				Description: "One line per hit, with matches marked as **match**",
+				Type:        "command_output",
+				Example:     "api-design [4] assistant: ...wrap the call in **retry** **logic** with backoff...",
+			},
+		},
+		Notes: []string{
+			"Keyword searches are case-insensitive, ranked by relevance (BM25), and match plurals",
+			"Regex searches are case-sensitive unless the pattern starts with (?i)",
+			"Saved sessions that are not loaded are searched too",
+			"The sessions of the hits are indexed so \\session-activate can open them",
+			"The index is kept in the config directory and updated as sessions change",
+		},
+	}
+}
+
+// Execute searches all sessions for the query given as input.
+// Options:
+//   - regex: regular expression search (optional, default: false)
+//   - role: user or assistant (optional)
+//   - limit: maximum number of hits (optional, default: 20)
+func (c *SearchCommand) Execute(args map[string]string, input string) error {
+	searchService, err := services.GetGlobalSessionSearchService()
+	if err != nil {
+		return fmt.Errorf("session search service not available: %w", err)
+	}
+
+	chatService, err := services.GetGlobalChatSessionService()
+	if err != nil {
+		return fmt.Errorf("chat session service not available: %w", err)
+	}
+
+	variableService, err := services.GetGlobalVariableService()
+	if err != nil {
+		return fmt.Errorf("variable service not available: %w", err)
+	}
+
+	query := strings.TrimSpace(input)
+	if query == "" {
+		return fmt.Errorf("search query is required. Usage: %s", c.HelpInfo().Usage)
+	}
+
+	limit := 20
+	if limitStr := args["limit"]; limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			return fmt.Errorf("invalid limit '%s': must be a positive number", limitStr)
+		}
+	}
+
+	hits, err := searchService.Search(query, services.SearchOptions{
+		Regex: stringprocessing.IsTruthy(args["regex"]),
+		Role:  strings.TrimSpace(args["role"]),
+		Limit: limit,
+	})
+	if err != nil {
+		return fmt.Errorf("session search failed: %w", err)
+	}
+
+	// Index saved sessions of the hits so they can be activated like any other session
+	inMemory := make(map[string]*neurotypes.ChatSession)
+	for _, session := range chatService.ListSessions() {
+		inMemory[session.ID] = session
+	}
+	for i := range hits {
+		session, exists := inMemory[hits[i].SessionID]
+		if !exists {
+			loaded, err := chatService.LoadSavedSessions(hits[i].SessionID, true)
+			if err != nil || len(loaded) == 0 {
+				continue
+			}
+			session = loaded[0]
+			inMemory[session.ID] = session
+		}
+		hits[i].SessionName = session.Name
+	}
+
+	lines := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		lines = append(lines, fmt.Sprintf("%s %s", hitHeading(hit), markHighlights(hit, "**", "**")))
+	}
+
+	variables := map[string]string{
+		"#search_count":         strconv.Itoa(len(hits)),
+		"#search_session_id":    "",
+		"#search_session_name":  "",
+		"#search_message_index": "",
+		"_output":               strings.Join(lines, "\n"),
+	}
+	if len(hits) > 0 {
+		variables["#search_session_id"] = hits[0].SessionID
+		variables["#search_session_name"] = hits[0].SessionName
+		variables["#search_message_index"] = strconv.Itoa(hits[0].MessageIndex)
+	}
+	for name, value := range variables {
+		if err := variableService.SetSystemVariable(name, value); err != nil {
+			return fmt.Errorf("failed to set variable %s: %w", name, err)
+		}
+	}
+
+	printer := printing.NewDefaultPrinter()
+	if len(hits) == 0 {
+		printer.Info(fmt.Sprintf("No messages match '%s'", query))
+		return nil
+	}
+	printer.Info(fmt.Sprintf("%d hit(s) for '%s':", len(hits), query))
+	for _, hit := range hits {
+		printer.Print("  " + hitHeading(hit) + " ")
+		last := 0
+		for _, highlight := range hit.Highlights {
+			printer.Print(hit.Snippet[last:highlight[0]])
+			printer.Highlight(hit.Snippet[highlight[0]:highlight[1]])
+			last = highlight[1]
+		}
+		printer.Println(hit.Snippet[last:])
+	}
+
+	return nil
+}
+
+// hitHeading formats where a hit is: session name, message number and role.
+func hitHeading(hit services.SearchHit) string {
+	return fmt.Sprintf("%s [%d] %s:", hit.SessionName, hit.MessageIndex, hit.Role)
+}
+
+// markHighlights returns the snippet of a hit with every match wrapped in before and after.
+func markHighlights(hit services.SearchHit, before, after string) string {
+	var result strings.Builder
+	last := 0
+	for _, highlight := range hit.Highlights {
+		result.WriteString(hit.Snippet[last:highlight[0]])
+		result.WriteString(before + hit.Snippet[highlight[0]:highlight[1]] + after)
+		last = highlight[1]
+	}
+	result.WriteString(hit.Snippet[last:])
+	return result.String()
+}
+
+// IsReadOnly returns false as the session-search command modifies system state.
+func (c *SearchCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&SearchCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register session-search command: %v", err))
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"neuroshell/internal/output"
 	"neuroshell/internal/services"
 	"neuroshell/pkg/neurotypes"
+	"neuroshell/pkg/stringprocessing"
 )
 
 // Display constants for smart content truncation
@@ -40,7 +41,7 @@ func (c *ShowCommand) Description() string {
 
 // Usage returns the syntax and usage examples for the session-show command.
 func (c *ShowCommand) Usage() string {
-	return `\session-show[id=false] session_text
+	return `\session-show[id=false, metadata=false] session_text
 \session-show[id=true] id_prefix
 \session-show
 
@@ -50,9 +51,11 @@ Examples:
   \session-show[id=true] 1234          %% Show session by ID prefix - matches any session ID starting with "1234"
   \session-show proj                   %% Show session by partial name match
   \session-show[id=true] abc123        %% Show session by ID prefix match
+  \session-show[metadata=true]         %% Show which model produced each response
 
 Options:
-  id - Search by session ID prefix instead of name (default: false)
+  id       - Search by session ID prefix instead of name (default: false)
+  metadata - Show model, provider, tokens, latency, stop reason and request ID of each response (default: false)
 
 Notes:
   - Without parameters: shows active session details or helpful guidance if none active
@@ -69,7 +72,7 @@ func (c *ShowCommand) HelpInfo() neurotypes.HelpInfo {
 	return neurotypes.HelpInfo{
 		Command:     c.Name(),
 		Description: c.Description(),
-		Usage:       "\\session-show[id=false] session_text",
+		Usage:       "\\session-show[id=false, metadata=false] session_text",
 		ParseMode:   c.ParseMode(),
 		Options: []neurotypes.HelpOption{
 			{
@@ -79,6 +82,13 @@ func (c *ShowCommand) HelpInfo() neurotypes.HelpInfo {
 				Type:        "boolean",
 				Default:     "false",
 			},
+			{
+				Name:        "metadata",
+				Description: "Show model, provider, tokens, latency, stop reason and request ID of each response",
+				Required:    false,
+				Type:        "boolean",
+				Default:     "false",
+			},
 		},
 		Examples: []neurotypes.HelpExample{
 			{
@@ -97,6 +107,10 @@ func (c *ShowCommand) HelpInfo() neurotypes.HelpInfo {
 				Command:     "\\session-show proj",
 				Description: "Show session by partial name match",
 			},
+			{
+				Command:     "\\session-show[metadata=true]",
+				Description: "Audit which model produced each response of the active session",
+			},
 		},
 		Notes: []string{
 			"Without parameters: shows active session or helpful guidance",
@@ -106,6 +120,7 @@ func (c *ShowCommand) HelpInfo() neurotypes.HelpInfo {
 			"If no sessions match, shows helpful suggestions",
 			"Displays rich session information with smart content truncation",
 			"Variables in session text are interpolated before processing",
+			"Metadata is recorded by \\session-add-assistantmsg from the last \\llm-call; messages without it show none",
 		},
 	}
 }
@@ -115,6 +130,7 @@ func (c *ShowCommand) Execute(args map[string]string, input string) error {
 	// Parse arguments
 	idStr := args["id"]
 	byID := idStr == "true"
+	showMetadata := stringprocessing.IsTruthy(args["metadata"])
 
 	// Get services
 	chatSessionService, err := services.GetGlobalChatSessionService()
@@ -131,20 +147,20 @@ func (c *ShowCommand) Execute(args map[string]string, input string) error {
 	searchText := input
 	if searchText == "" {
 		// No parameters: handle smart display
-		return c.handleNoParameter(chatSessionService, variableService)
+		return c.handleNoParameter(chatSessionService, variableService, showMetadata)
 	}
 
 	// With parameter: find and show specific session
-	return c.findAndShowSession(searchText, byID, chatSessionService, variableService)
+	return c.findAndShowSession(searchText, byID, showMetadata, chatSessionService, variableService)
 }
 
 // handleNoParameter handles the no-parameter case with smart logic.
-func (c *ShowCommand) handleNoParameter(chatSessionService *services.ChatSessionService, variableService *services.VariableService) error {
+func (c *ShowCommand) handleNoParameter(chatSessionService *services.ChatSessionService, variableService *services.VariableService, showMetadata bool) error {
 	// Case 1: Try to get active session
 	activeSession, err := chatSessionService.GetActiveSession()
 	if err == nil {
 		// Active session exists - show it
-		return c.renderSessionInfo(activeSession, variableService)
+		return c.renderSessionInfo(activeSession, variableService, showMetadata)
 	}
 
 	// Case 2 & 3: No active session - check if sessions exist
@@ -169,7 +185,7 @@ func (c *ShowCommand) handleNoParameter(chatSessionService *services.ChatSession
 }
 
 // findAndShowSession finds and displays a session by search text.
-func (c *ShowCommand) findAndShowSession(searchText string, byID bool, chatSessionService *services.ChatSessionService, variableService *services.VariableService) error {
+func (c *ShowCommand) findAndShowSession(searchText string, byID bool, showMetadata bool, chatSessionService *services.ChatSessionService, variableService *services.VariableService) error {
 	// Get all sessions for searching
 	sessions := chatSessionService.ListSessions()
 
@@ -191,8 +207,12 @@ func (c *ShowCommand) findAndShowSession(searchText string, byID bool, chatSessi
 		// No matches - provide helpful suggestions
 		return c.handleNoMatches(sessions, searchText, byID)
 	case 1:
-		// Unique match - proceed with display
-		return c.renderSessionInfo(matches[0], variableService)
+		// Unique match - look it up by ID so lazily indexed sessions are read
+		session, err := chatSessionService.GetSessionByNameOrID(matches[0].ID)
+		if err != nil {
+			return err
+		}
+		return c.renderSessionInfo(session, variableService, showMetadata)
 	default:
 		// Multiple matches - ask for more specific input
 		return c.handleMultipleMatches(matches, searchText, byID)
@@ -272,7 +292,7 @@ func (c *ShowCommand) handleMultipleMatches(matches []*neurotypes.ChatSession, s
 }
 
 // renderSessionInfo displays comprehensive session information with smart formatting.
-func (c *ShowCommand) renderSessionInfo(session *neurotypes.ChatSession, variableService *services.VariableService) error {
+func (c *ShowCommand) renderSessionInfo(session *neurotypes.ChatSession, variableService *services.VariableService, showMetadata bool) error {
 	// Get printer for output
 	printer := printing.NewDefaultPrinter()
 
@@ -291,11 +311,14 @@ func (c *ShowCommand) renderSessionInfo(session *neurotypes.ChatSession, variabl
 	// Display message count
 	messageCount := len(session.Messages)
 	printer.Info(fmt.Sprintf("Messages: %d total", messageCount))
+	if len(session.Branches) > 0 {
+		printer.Info(fmt.Sprintf("Branch: %s (%d branches)", session.ActiveBranch, len(session.Branches)))
+	}
 	printer.Println("") // Add blank line after message count
 
 	// Display messages with smart truncation
 	if messageCount > 0 {
-		c.renderMessages(session.Messages, printer)
+		c.renderMessages(session.Messages, printer, showMetadata)
 	}
 
 	// Auto-push session activation command to stack service to handle active session state
@@ -317,18 +340,19 @@ func (c *ShowCommand) renderSessionInfo(session *neurotypes.ChatSession, variabl
 }
 
 // renderMessages displays session messages with smart truncation and role information.
-func (c *ShowCommand) renderMessages(messages []neurotypes.Message, printer *output.Printer) {
+// With showMetadata, each message that records how it was produced is followed by that metadata.
+func (c *ShowCommand) renderMessages(messages []neurotypes.Message, printer *output.Printer, showMetadata bool) {
 	messageCount := len(messages)
 
 	if messageCount <= MaxMessagesShown {
 		// Show all messages
 		for i, msg := range messages {
-			c.renderSingleMessage(i+1, msg, printer)
+			c.renderSingleMessage(i+1, msg, printer, showMetadata)
 		}
 	} else {
 		// Show first 5 messages
 		for i := 0; i < 5; i++ {
-			c.renderSingleMessage(i+1, messages[i], printer)
+			c.renderSingleMessage(i+1, messages[i], printer, showMetadata)
 		}
 
 		// Show separator with count
@@ -339,13 +363,13 @@ func (c *ShowCommand) renderMessages(messages []neurotypes.Message, printer *out
 
 		// Show last 5 messages
 		for i := messageCount - 5; i < messageCount; i++ {
-			c.renderSingleMessage(i+1, messages[i], printer)
+			c.renderSingleMessage(i+1, messages[i], printer, showMetadata)
 		}
 	}
 }
 
 // renderSingleMessage displays a single message with role and truncated content.
-func (c *ShowCommand) renderSingleMessage(index int, msg neurotypes.Message, printer *output.Printer) {
+func (c *ShowCommand) renderSingleMessage(index int, msg neurotypes.Message, printer *output.Printer, showMetadata bool) {
 	// Truncate message content
 	content := c.truncateContent(msg.Content, MaxMessageDisplay)
 
@@ -354,7 +378,50 @@ func (c *ShowCommand) renderSingleMessage(index int, msg neurotypes.Message, pri
 
 	// Display message with index, role, timestamp, and content in original format
 	messageOutput := fmt.Sprintf("[%d] %s (%s): %s", index, msg.Role, timestamp, content)
+	if len(msg.Alternatives) > 1 {
+		messageOutput += fmt.Sprintf(" [alternative %d of %d]", msg.SelectedAlternative, len(msg.Alternatives))
+	}
 	printer.Println(messageOutput)
+	if showMetadata && msg.Metadata != nil {
+		printer.Println("    " + c.formatMetadata(msg.Metadata))
+	}
+}
+
+// formatMetadata renders message metadata on one line, leaving out fields that were not recorded.
+func (c *ShowCommand) formatMetadata(metadata *neurotypes.MessageMetadata) string {
+	var parts []string
+	if metadata.Model != "" {
+		model := "model: " + metadata.Model
+		var details []string
+		for _, detail := range []string{metadata.CatalogID, metadata.BaseModel} {
+			if detail != "" {
+				details = append(details, detail)
+			}
+		}
+		if len(details) > 0 {
+			model += fmt.Sprintf(" (%s)", strings.Join(details, ", "))
+		}
+		parts = append(parts, model)
+	}
+	if metadata.Provider != "" {
+		parts = append(parts, "provider: "+metadata.Provider)
+	}
+	if metadata.Usage != nil {
+		parts = append(parts, fmt.Sprintf("tokens: %d in, %d out", metadata.Usage.InputTokens, metadata.Usage.OutputTokens))
+	}
+	if metadata.LatencyMs > 0 {
+		parts = append(parts, fmt.Sprintf("latency: %dms", metadata.LatencyMs))
+	}
+	if metadata.StopReason != "" {
+		parts = append(parts, "stop: "+metadata.StopReason)
+	}
+	if metadata.RequestID != "" {
+		parts = append(parts, "request: "+metadata.RequestID)
+	}
+	if len(metadata.ThinkingBlocks) > 0 {
+		parts = append(parts, fmt.Sprintf("thinking blocks: %d", len(metadata.ThinkingBlocks)))
+	}
+	return strings.Join(parts, " | ")
 }
 
 // truncateContent truncates text content with ellipsis and character count if needed.
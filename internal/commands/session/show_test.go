@@ -36,7 +36,7 @@ func TestShowCommand_ParseMode(t *testing.T) {
 func TestShowCommand_Usage(t *testing.T) {
 	cmd := &ShowCommand{}
 	usage := cmd.Usage()
-	assert.Contains(t, usage, "\\session-show[id=false] session_text")
+	assert.Contains(t, usage, "\\session-show[id=false, metadata=false] session_text")
 	assert.Contains(t, usage, "Examples:")
 	assert.Contains(t, usage, "Options:")
 }
@@ -46,8 +46,9 @@ func TestShowCommand_HelpInfo(t *testing.T) {
 	helpInfo := cmd.HelpInfo()
 	assert.Equal(t, "session-show", helpInfo.Command)
 	assert.Equal(t, neurotypes.ParseModeKeyValue, helpInfo.ParseMode)
-	assert.Len(t, helpInfo.Options, 1)
+	assert.Len(t, helpInfo.Options, 2)
 	assert.Equal(t, "id", helpInfo.Options[0].Name)
+	assert.Equal(t, "metadata", helpInfo.Options[1].Name)
 }
 
 // setupShowCommandTestRegistry creates a clean test registry for session-show command tests
@@ -176,7 +177,7 @@ func TestShowCommand_RenderMessages_ManyMessages(t *testing.T) {
 
 	// Create printer for test and capture output
 	outputStr := output.CaptureOutput(func(printer *output.Printer) {
-		cmd.renderMessages(messages, printer)
+		cmd.renderMessages(messages, printer, false)
 	})
 
 	// Should show first 5, separator, and last 5
@@ -201,7 +202,7 @@ func TestShowCommand_RenderSingleMessage_LongContent(t *testing.T) {
 
 	// Create printer for test and capture output
 	outputStr := output.CaptureOutput(func(printer *output.Printer) {
-		cmd.renderSingleMessage(1, msg, printer)
+		cmd.renderSingleMessage(1, msg, printer, false)
 	})
 
 	assert.Contains(t, outputStr, "[1] user")
@@ -209,6 +210,37 @@ func TestShowCommand_RenderSingleMessage_LongContent(t *testing.T) {
 	assert.Contains(t, outputStr, "(300 chars)")
 }
 
+func TestShowCommand_RenderSingleMessage_Metadata(t *testing.T) {
+	setupShowCommandTestRegistry(t)
+	cmd := &ShowCommand{}
+
+	msg := neurotypes.Message{
+		ID:        "msg1",
+		Role:      "assistant",
+		Content:   "Lima",
+		Timestamp: time.Now(),
+		Metadata: &neurotypes.MessageMetadata{
+			Model:      "claude",
+			CatalogID:  "CS4",
+			Provider:   "anthropic",
+			Usage:      &neurotypes.TokenUsage{InputTokens: 12, OutputTokens: 3},
+			LatencyMs:  820,
+			StopReason: "end_turn",
+			RequestID:  "msg_01",
+		},
+	}
+
+	hidden := output.CaptureOutput(func(printer *output.Printer) {
+		cmd.renderSingleMessage(1, msg, printer, false)
+	})
+	assert.NotContains(t, hidden, "claude")
+
+	shown := output.CaptureOutput(func(printer *output.Printer) {
+		cmd.renderSingleMessage(1, msg, printer, true)
+	})
+	assert.Contains(t, shown, "model: claude (CS4) | provider: anthropic | tokens: 12 in, 3 out | latency: 820ms | stop: end_turn | request: msg_01")
+}
+
 func TestShowCommand_FindSessionsByName(t *testing.T) {
 	cmd := &ShowCommand{}
 
@@ -0,0 +1,264 @@
+package tool
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// CallCommand implements the \tool-call command for running a tool script.
+// It is pushed by \llm-call for each tool call the model requests, and can also be used
+// directly to test a tool with JSON arguments.
+type CallCommand struct{}
+
+// Name returns the command name "tool-call" for registration and lookup.
+func (c *CallCommand) Name() string {
+	return "tool-call"
+}
+
+// ParseMode returns ParseModeKeyValue for bracket parameter parsing.
+func (c *CallCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the tool-call command does.
+func (c *CallCommand) Description() string {
+	return "Run a tool script with JSON arguments"
+}
+
+// Usage returns the syntax and usage examples for the tool-call command.
+func (c *CallCommand) Usage() string {
+	return `\tool-call[name=tool_name] {"arg": "value"}
+
+Examples:
+  \tool-call[name=weather] {"city": "Paris"}    %% Run the weather tool
+  \tool-call[name=get_time]                     %% Run a tool without arguments
+
+Options:
+  name      - Tool to run (required unless tool_loop and call_id are given)
+  tool_loop - Tool loop ID (set by \llm-call)
+  call_id   - Tool call ID within the loop (set by \llm-call)
+
+Notes:
+  - Arguments become variables for the script (e.g. ${city}) and are cleared when it finishes
+  - Only arguments declared in the tool's parameters are accepted; names starting with _, @ or # are rejected
+  - The result is stored in ${#tool_result} once the script finishes
+  - Script errors are captured and reported to the model instead of aborting the loop`
+}
+
+// HelpInfo returns structured help information for the tool-call command.
+func (c *CallCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       `\tool-call[name=tool_name] {json_arguments}`,
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "name",
+				Description: "Tool to run",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "tool_loop",
+				Description: "Tool loop ID (set by \\llm-call)",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "call_id",
+				Description: "Tool call ID within the loop (set by \\llm-call)",
+				Required:    false,
+				Type:        "string",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     `\tool-call[name=weather] {"city": "Paris"}`,
+				Description: "Run the weather tool with a city argument",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "#tool_name",
+				Description: "Name of the running tool",
+				Type:        "system_metadata",
+				Example:     "weather",
+			},
+			{
+				Name:        "#tool_args",
+				Description: "Raw JSON arguments of the running tool",
+				Type:        "system_metadata",
+				Example:     `{"city": "Paris"}`,
+			},
+			{
+				Name:        "#tool_result",
+				Description: "Result of the tool script",
+				Type:        "system_metadata",
+				Example:     "Sunny, 22C",
+			},
+			{
+				Name:        "#tool_error",
+				Description: "Error message if the tool script failed, empty otherwise",
+				Type:        "system_metadata",
+				Example:     "",
+			},
+		},
+		Notes: []string{
+			"Tool scripts run through the stack machine inside an error boundary",
+			"Unknown tools and invalid arguments are reported back to the model as errors",
+			"Only declared parameters are accepted as arguments; reserved names (_, @, #) are rejected",
+			"Argument variables are cleared by \\tool-result once the script finishes",
+		},
+	}
+}
+
+// Execute prepares argument variables and pushes the tool script followed by \tool-result.
+func (c *CallCommand) Execute(args map[string]string, input string) error {
+	toolService, err := services.GetGlobalToolService()
+	if err != nil {
+		return fmt.Errorf("tool service not available: %w", err)
+	}
+
+	variableService, err := services.GetGlobalVariableService()
+	if err != nil {
+		return fmt.Errorf("variable service not available: %w", err)
+	}
+
+	stackService, err := services.GetGlobalStackService()
+	if err != nil {
+		return fmt.Errorf("stack service not available: %w", err)
+	}
+
+	loopID := args["tool_loop"]
+	callID := args["call_id"]
+	name := args["name"]
+	arguments := strings.TrimSpace(input)
+
+	if loopID != "" {
+		call, err := toolService.FindToolCall(loopID, callID)
+		if err != nil {
+			return err
+		}
+		name = call.Name
+		arguments = call.Arguments
+	} else if name == "" {
+		return fmt.Errorf("tool name is required\n\nUsage: %s", c.Usage())
+	}
+	if arguments == "" {
+		arguments = "{}"
+	}
+
+	fmt.Printf("🔧 Tool call: %s %s\n", name, arguments)
+
+	// Problems with the request itself are fed back to the model so it can correct the call
+	tool, err := toolService.Get(name)
+	if err == nil {
+		err = c.setArgumentVariables(variableService, tool, arguments)
+	}
+	if err != nil {
+		if loopID == "" {
+			return err
+		}
+		return c.recordFailure(toolService, variableService, loopID, callID, name, err)
+	}
+
+	_ = variableService.SetSystemVariable("#tool_name", name)
+	_ = variableService.SetSystemVariable("#tool_args", arguments)
+	_ = variableService.SetSystemVariable("_output", "")
+
+	// Push in reverse order (LIFO): the script runs inside an error boundary, then its result is collected
+	resultOptions := "name=" + name
+	if loopID != "" {
+		resultOptions = fmt.Sprintf("tool_loop=%s, call_id=%s, %s", loopID, callID, resultOptions)
+	}
+	stackService.PushCommand("\\tool-result[" + resultOptions + "]")
+	stackService.PushCommand("\\try \\run " + tool.ScriptPath)
+
+	return nil
+}
+
+// setArgumentVariables parses JSON arguments and stores each one as a variable.
+// Non-string values are stored as their JSON encoding. Arguments are chosen by the model, so only
+// names declared in the tool's parameter properties are accepted, and reserved prefixes (_, @, #)
+// are rejected before any variable is set.
+func (c *CallCommand) setArgumentVariables(variableService *services.VariableService, tool neurotypes.ToolDefinition, arguments string) error {
+	values, err := parseToolArguments(arguments)
+	if err != nil {
+		return err
+	}
+
+	properties, _ := tool.Parameters["properties"].(map[string]interface{})
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if strings.HasPrefix(key, "_") || strings.HasPrefix(key, "@") || strings.HasPrefix(key, "#") {
+			return fmt.Errorf("invalid tool argument '%s': reserved variable names cannot be set by tools", key)
+		}
+		if _, declared := properties[key]; !declared {
+			return fmt.Errorf("invalid tool argument '%s': not a parameter of tool '%s'", key, tool.Name)
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		var stringValue string
+		switch typed := values[key].(type) {
+		case string:
+			stringValue = typed
+		case nil:
+			stringValue = ""
+		default:
+			encoded, err := json.Marshal(typed)
+			if err != nil {
+				return fmt.Errorf("invalid value for argument '%s': %w", key, err)
+			}
+			stringValue = string(encoded)
+		}
+		if err := variableService.Set(key, stringValue); err != nil {
+			return fmt.Errorf("invalid tool argument '%s': %w", key, err)
+		}
+	}
+	return nil
+}
+
+// parseToolArguments decodes the JSON object of a tool call's arguments.
+func parseToolArguments(arguments string) (map[string]interface{}, error) {
+	var values map[string]interface{}
+	if err := json.Unmarshal([]byte(arguments), &values); err != nil {
+		return nil, fmt.Errorf("invalid tool arguments: %w", err)
+	}
+	return values, nil
+}
+
+// recordFailure reports a tool call that could not be started back to the model.
+func (c *CallCommand) recordFailure(toolService *services.ToolService, variableService *services.VariableService, loopID, callID, name string, err error) error {
+	_ = variableService.SetSystemVariable("#tool_name", name)
+	_ = variableService.SetSystemVariable("#tool_result", "")
+	_ = variableService.SetSystemVariable("#tool_error", err.Error())
+
+	fmt.Printf("⚠️  Tool %s failed: %s\n", name, err.Error())
+	return toolService.RecordToolResult(loopID, neurotypes.ToolResult{
+		CallID:  callID,
+		Name:    name,
+		Content: err.Error(),
+		IsError: true,
+	})
+}
+
+// IsReadOnly returns false as the tool-call command modifies system state.
+func (c *CallCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&CallCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register tool-call command: %v", err))
+	}
+}
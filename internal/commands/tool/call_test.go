@@ -0,0 +1,165 @@
+package tool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+func TestCallCommand_BasicProperties(t *testing.T) {
+	cmd := &CallCommand{}
+	assert.Equal(t, "tool-call", cmd.Name())
+	assert.Equal(t, neurotypes.ParseModeKeyValue, cmd.ParseMode())
+	assert.NotEmpty(t, cmd.Description())
+	assert.Contains(t, cmd.Usage(), "\\tool-call")
+	assert.False(t, cmd.IsReadOnly())
+}
+
+func TestCallCommand_Execute_Manual(t *testing.T) {
+	setupToolTestRegistry(t)
+	path := writeToolScript(t, t.TempDir(), "weather.neuro", weatherScript)
+	require.NoError(t, (&DefineCommand{}).Execute(map[string]string{}, path))
+
+	cmd := &CallCommand{}
+	require.NoError(t, cmd.Execute(map[string]string{"name": "weather"}, `{"city": "Paris", "days": 3}`))
+
+	variableService, _ := services.GetGlobalVariableService()
+	city, _ := variableService.Get("city")
+	assert.Equal(t, "Paris", city)
+	days, _ := variableService.Get("days")
+	assert.Equal(t, "3", days, "non-string arguments are stored as JSON")
+	name, _ := variableService.Get("#tool_name")
+	assert.Equal(t, "weather", name)
+
+	stackService, _ := services.GetGlobalStackService()
+	script, ok := stackService.PopCommand()
+	require.True(t, ok)
+	assert.Equal(t, "\\try \\run "+path, script)
+	result, ok := stackService.PopCommand()
+	require.True(t, ok)
+	assert.Equal(t, "\\tool-result[name=weather]", result)
+}
+
+func TestCallCommand_Execute_ManualErrors(t *testing.T) {
+	setupToolTestRegistry(t)
+	path := writeToolScript(t, t.TempDir(), "weather.neuro", weatherScript)
+	require.NoError(t, (&DefineCommand{}).Execute(map[string]string{}, path))
+
+	cmd := &CallCommand{}
+	assert.Error(t, cmd.Execute(map[string]string{}, `{}`), "name is required")
+	assert.Error(t, cmd.Execute(map[string]string{"name": "missing"}, `{}`))
+	assert.Error(t, cmd.Execute(map[string]string{"name": "weather"}, `not json`))
+	assert.Error(t, cmd.Execute(map[string]string{"name": "weather"}, `{"city": "Paris", "country": "FR"}`), "undeclared argument")
+
+	stackService, _ := services.GetGlobalStackService()
+	assert.True(t, stackService.IsEmpty())
+}
+
+func TestCallCommand_Execute_LoopFailureIsRecorded(t *testing.T) {
+	setupToolTestRegistry(t)
+	toolService, _ := services.GetGlobalToolService()
+
+	loop := toolService.StartLoop("session-1")
+	require.NoError(t, toolService.RecordToolCalls(loop.ID, "", []neurotypes.ToolCall{
+		{ID: "call-1", Name: "missing", Arguments: `{}`},
+	}, nil))
+
+	cmd := &CallCommand{}
+	require.NoError(t, cmd.Execute(map[string]string{"tool_loop": loop.ID, "call_id": "call-1"}, ""))
+
+	current, err := toolService.GetLoop(loop.ID)
+	require.NoError(t, err)
+	require.Len(t, current.Results, 1)
+	assert.True(t, current.Results[0].IsError)
+	assert.Contains(t, current.Results[0].Content, "not found")
+
+	stackService, _ := services.GetGlobalStackService()
+	assert.True(t, stackService.IsEmpty(), "no script should run for an unknown tool")
+}
+
+func TestCallCommand_Execute_RejectsReservedArguments(t *testing.T) {
+	setupToolTestRegistry(t)
+	path := writeToolScript(t, t.TempDir(), "weather.neuro", weatherScript)
+	require.NoError(t, (&DefineCommand{}).Execute(map[string]string{}, path))
+	toolService, _ := services.GetGlobalToolService()
+	variableService, _ := services.GetGlobalVariableService()
+	require.NoError(t, variableService.Set("_tool_max_rounds", "3"))
+
+	loop := toolService.StartLoop("session-1")
+	require.NoError(t, toolService.RecordToolCalls(loop.ID, "", []neurotypes.ToolCall{
+		{ID: "call-1", Name: "weather", Arguments: `{"city": "Paris", "_tool_max_rounds": "1000"}`},
+	}, nil))
+
+	cmd := &CallCommand{}
+	require.NoError(t, cmd.Execute(map[string]string{"tool_loop": loop.ID, "call_id": "call-1"}, ""))
+
+	rounds, _ := variableService.Get("_tool_max_rounds")
+	assert.Equal(t, "3", rounds, "tools must not change configuration variables")
+	city, _ := variableService.Get("city")
+	assert.Empty(t, city, "no argument is set when one is rejected")
+
+	current, err := toolService.GetLoop(loop.ID)
+	require.NoError(t, err)
+	require.Len(t, current.Results, 1)
+	assert.True(t, current.Results[0].IsError)
+	assert.Contains(t, current.Results[0].Content, "_tool_max_rounds")
+
+	stackService, _ := services.GetGlobalStackService()
+	assert.True(t, stackService.IsEmpty(), "no script should run for rejected arguments")
+}
+
+func TestResultCommand_Execute_ClearsArguments(t *testing.T) {
+	setupToolTestRegistry(t)
+	path := writeToolScript(t, t.TempDir(), "weather.neuro", weatherScript)
+	require.NoError(t, (&DefineCommand{}).Execute(map[string]string{}, path))
+	variableService, _ := services.GetGlobalVariableService()
+
+	require.NoError(t, (&CallCommand{}).Execute(map[string]string{"name": "weather"}, `{"city": "Paris"}`))
+	city, _ := variableService.Get("city")
+	require.Equal(t, "Paris", city)
+
+	require.NoError(t, (&ResultCommand{}).Execute(map[string]string{"name": "weather"}, ""))
+	city, _ = variableService.Get("city")
+	assert.Empty(t, city)
+}
+
+func TestResultCommand_Execute(t *testing.T) {
+	ctx := setupToolTestRegistry(t)
+	toolService, _ := services.GetGlobalToolService()
+	variableService, _ := services.GetGlobalVariableService()
+
+	loop := toolService.StartLoop("session-1")
+	cmd := &ResultCommand{}
+	assert.Equal(t, "tool-result", cmd.Name())
+
+	// Successful script: _output becomes the result (error state is reset before \tool-result runs)
+	ctx.SetErrorState("0", "")
+	ctx.ResetErrorState()
+	require.NoError(t, variableService.SetSystemVariable("_output", "Sunny in Paris\n"))
+	require.NoError(t, cmd.Execute(map[string]string{"tool_loop": loop.ID, "call_id": "call-1", "name": "weather"}, ""))
+
+	result, _ := variableService.Get("#tool_result")
+	assert.Equal(t, "Sunny in Paris", result)
+	toolError, _ := variableService.Get("#tool_error")
+	assert.Empty(t, toolError)
+
+	// Failed script: the error message is reported instead
+	ctx.SetErrorState("1", "assertion failed")
+	ctx.ResetErrorState()
+	require.NoError(t, cmd.Execute(map[string]string{"tool_loop": loop.ID, "call_id": "call-2", "name": "broken"}, ""))
+
+	toolError, _ = variableService.Get("#tool_error")
+	assert.Equal(t, "assertion failed", toolError)
+
+	current, err := toolService.GetLoop(loop.ID)
+	require.NoError(t, err)
+	require.Len(t, current.Results, 2)
+	assert.False(t, current.Results[0].IsError)
+	assert.Equal(t, "Sunny in Paris", current.Results[0].Content)
+	assert.True(t, current.Results[1].IsError)
+	assert.Equal(t, "assertion failed", current.Results[1].Content)
+}
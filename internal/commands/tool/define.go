@@ -0,0 +1,195 @@
+// Package tool provides tool calling commands for NeuroShell.
+// Tools are .neuro scripts advertised to LLMs; \llm-call runs the scripts the model requests
+// through the stack machine and feeds their results back to the model.
+package tool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// DefineCommand implements the \tool-define command for registering .neuro scripts as tools.
+type DefineCommand struct{}
+
+// Name returns the command name "tool-define" for registration and lookup.
+func (c *DefineCommand) Name() string {
+	return "tool-define"
+}
+
+// ParseMode returns ParseModeKeyValue for bracket parameter parsing.
+func (c *DefineCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the tool-define command does.
+func (c *DefineCommand) Description() string {
+	return "Define a .neuro script (or a directory of scripts) as an LLM tool"
+}
+
+// Usage returns the syntax and usage examples for the tool-define command.
+func (c *DefineCommand) Usage() string {
+	return `\tool-define[name=tool_name, description=text] path/to/tool.neuro
+\tool-define path/to/tools/
+
+Examples:
+  \tool-define tools/weather.neuro                              %% Tool named "weather"
+  \tool-define[name=get_time] scripts/time.neuro                %% Explicit tool name
+  \tool-define[description="Search local notes"] notes.neuro   %% Override header description
+  \tool-define tools/                                           %% Define every .neuro script in a directory
+
+Options:
+  name        - Tool name advertised to the model (defaults to the file name)
+  description - Tool description (defaults to the "%% Description:" header line)
+
+Tool script header:
+  %% Description: Get the current weather for a city
+  %% Parameters: {
+  %%   "type": "object",
+  %%   "properties": {"city": {"type": "string", "description": "City name"}},
+  %%   "required": ["city"]
+  %% }
+
+Notes:
+  - Arguments from the model are available to the script as variables (e.g. ${city})
+  - The tool result is the script's final ${_output} (e.g. from its last \echo or \bash)
+  - Scripts without a Parameters block take no arguments`
+}
+
+// HelpInfo returns structured help information for the tool-define command.
+func (c *DefineCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       `\tool-define[name=tool_name, description=text] path`,
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "name",
+				Description: "Tool name advertised to the model",
+				Required:    false,
+				Type:        "string",
+				Default:     "script file name",
+			},
+			{
+				Name:        "description",
+				Description: "Tool description shown to the model",
+				Required:    false,
+				Type:        "string",
+				Default:     "%% Description: header",
+			},
+		},
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     `\tool-define tools/weather.neuro`,
+				Description: "Define the weather tool from its script header",
+			},
+			{
+				Command:     `\tool-define tools/`,
+				Description: "Define every .neuro script in the tools directory",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "#tool_count",
+				Description: "Number of defined tools",
+				Type:        "system_metadata",
+				Example:     "3",
+			},
+			{
+				Name:        "_output",
+				Description: "Summary of the defined tools",
+				Type:        "command_output",
+				Example:     "Defined tool 'weather' (tools/weather.neuro)",
+			},
+		},
+		Notes: []string{
+			"Defined tools are advertised by \\llm-call to models that support function calling",
+			"The parameter schema is a JSON schema in the script's %% Parameters: header",
+			"Model arguments become script variables; the result is the script's final ${_output}",
+			"Defining a tool with an existing name replaces it",
+		},
+	}
+}
+
+// Execute defines a tool from a script, or one tool per script in a directory.
+func (c *DefineCommand) Execute(args map[string]string, input string) error {
+	path := strings.TrimSpace(input)
+	if path == "" {
+		return fmt.Errorf("script path is required\n\nUsage: %s", c.Usage())
+	}
+
+	toolService, err := services.GetGlobalToolService()
+	if err != nil {
+		return fmt.Errorf("tool service not available: %w", err)
+	}
+
+	variableService, err := services.GetGlobalVariableService()
+	if err != nil {
+		return fmt.Errorf("variable service not available: %w", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("tool script '%s' not found: %w", path, err)
+	}
+
+	var scripts []string
+	if info.IsDir() {
+		if args["name"] != "" {
+			return fmt.Errorf("name option cannot be used when defining a directory of tools")
+		}
+		scripts, err = filepath.Glob(filepath.Join(path, "*.neuro"))
+		if err != nil {
+			return fmt.Errorf("failed to list tool scripts in '%s': %w", path, err)
+		}
+		if len(scripts) == 0 {
+			return fmt.Errorf("no .neuro scripts found in '%s'", path)
+		}
+		sort.Strings(scripts)
+	} else {
+		scripts = []string{path}
+	}
+
+	var lines []string
+	for _, script := range scripts {
+		tool, err := toolService.LoadToolScript(script)
+		if err != nil {
+			return err
+		}
+		if name := args["name"]; name != "" {
+			tool.Name = name
+		}
+		if description := args["description"]; description != "" {
+			tool.Description = description
+		}
+		if err := toolService.Define(tool); err != nil {
+			return err
+		}
+		lines = append(lines, fmt.Sprintf("Defined tool '%s' (%s)", tool.Name, script))
+	}
+
+	output := strings.Join(lines, "\n")
+	_ = variableService.SetSystemVariable("#tool_count", fmt.Sprintf("%d", len(toolService.List())))
+	_ = variableService.SetSystemVariable("_output", output)
+
+	fmt.Println(output)
+	return nil
+}
+
+// IsReadOnly returns false as the tool-define command modifies system state.
+func (c *DefineCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&DefineCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register tool-define command: %v", err))
+	}
+}
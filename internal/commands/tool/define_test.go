@@ -0,0 +1,129 @@
+package tool
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/internal/context"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+const weatherScript = `%% Description: Get the weather for a city
+%% Parameters:
+%% {
+%%   "type": "object",
+%%   "properties": {"city": {"type": "string"}, "days": {"type": "integer"}},
+%%   "required": ["city"]
+%% }
+\echo Sunny in ${city}
+`
+
+// setupToolTestRegistry creates a registry with the services used by the tool commands.
+func setupToolTestRegistry(t *testing.T) *context.NeuroContext {
+	ctx := context.New()
+	ctx.SetTestMode(true)
+
+	oldRegistry := services.GetGlobalRegistry()
+	services.SetGlobalRegistry(services.NewRegistry())
+	context.SetGlobalContext(ctx)
+
+	require.NoError(t, services.GetGlobalRegistry().RegisterService(services.NewVariableService()))
+	require.NoError(t, services.GetGlobalRegistry().RegisterService(services.NewStackService()))
+	require.NoError(t, services.GetGlobalRegistry().RegisterService(services.NewToolService()))
+	require.NoError(t, services.GetGlobalRegistry().InitializeAll())
+
+	t.Cleanup(func() {
+		services.SetGlobalRegistry(oldRegistry)
+		context.ResetGlobalContext()
+	})
+	return ctx
+}
+
+// writeToolScript writes a tool script into dir and returns its path.
+func writeToolScript(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestDefineCommand_BasicProperties(t *testing.T) {
+	cmd := &DefineCommand{}
+	assert.Equal(t, "tool-define", cmd.Name())
+	assert.Equal(t, neurotypes.ParseModeKeyValue, cmd.ParseMode())
+	assert.NotEmpty(t, cmd.Description())
+	assert.Contains(t, cmd.Usage(), "\\tool-define")
+	assert.False(t, cmd.IsReadOnly())
+	assert.Equal(t, cmd.Name(), cmd.HelpInfo().Command)
+}
+
+func TestDefineCommand_Execute_SingleScript(t *testing.T) {
+	setupToolTestRegistry(t)
+	path := writeToolScript(t, t.TempDir(), "weather.neuro", weatherScript)
+
+	cmd := &DefineCommand{}
+	require.NoError(t, cmd.Execute(map[string]string{}, path))
+
+	toolService, _ := services.GetGlobalToolService()
+	tool, err := toolService.Get("weather")
+	require.NoError(t, err)
+	assert.Equal(t, "Get the weather for a city", tool.Description)
+	assert.Equal(t, path, tool.ScriptPath)
+	assert.Equal(t, []interface{}{"city"}, tool.Parameters["required"])
+
+	variableService, _ := services.GetGlobalVariableService()
+	count, _ := variableService.Get("#tool_count")
+	assert.Equal(t, "1", count)
+}
+
+func TestDefineCommand_Execute_Overrides(t *testing.T) {
+	setupToolTestRegistry(t)
+	path := writeToolScript(t, t.TempDir(), "weather.neuro", weatherScript)
+
+	cmd := &DefineCommand{}
+	require.NoError(t, cmd.Execute(map[string]string{"name": "forecast", "description": "Forecast lookup"}, path))
+
+	toolService, _ := services.GetGlobalToolService()
+	tool, err := toolService.Get("forecast")
+	require.NoError(t, err)
+	assert.Equal(t, "Forecast lookup", tool.Description)
+	_, err = toolService.Get("weather")
+	assert.Error(t, err)
+}
+
+func TestDefineCommand_Execute_Directory(t *testing.T) {
+	setupToolTestRegistry(t)
+	dir := t.TempDir()
+	writeToolScript(t, dir, "weather.neuro", weatherScript)
+	writeToolScript(t, dir, "get_time.neuro", "\\echo noon\n")
+	writeToolScript(t, dir, "notes.txt", "not a tool")
+
+	cmd := &DefineCommand{}
+	require.NoError(t, cmd.Execute(map[string]string{}, dir))
+
+	toolService, _ := services.GetGlobalToolService()
+	tools := toolService.List()
+	require.Len(t, tools, 2)
+	assert.Equal(t, "get_time", tools[0].Name)
+	assert.Equal(t, "weather", tools[1].Name)
+
+	err := cmd.Execute(map[string]string{"name": "x"}, dir)
+	assert.Error(t, err, "name option is not allowed for directories")
+}
+
+func TestDefineCommand_Execute_Errors(t *testing.T) {
+	setupToolTestRegistry(t)
+	dir := t.TempDir()
+	cmd := &DefineCommand{}
+
+	assert.Error(t, cmd.Execute(map[string]string{}, ""))
+	assert.Error(t, cmd.Execute(map[string]string{}, filepath.Join(dir, "missing.neuro")))
+	assert.Error(t, cmd.Execute(map[string]string{}, dir), "empty directory has no tools")
+
+	bad := writeToolScript(t, dir, "bad.neuro", "%% Parameters: {oops}\n")
+	assert.Error(t, cmd.Execute(map[string]string{}, bad))
+}
@@ -0,0 +1,155 @@
+package tool
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// ListCommand implements the \tool-list command for showing defined tools.
+type ListCommand struct{}
+
+// Name returns the command name "tool-list" for registration and lookup.
+func (c *ListCommand) Name() string {
+	return "tool-list"
+}
+
+// ParseMode returns ParseModeKeyValue for bracket parameter parsing.
+func (c *ListCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the tool-list command does.
+func (c *ListCommand) Description() string {
+	return "List tools available to LLM calls"
+}
+
+// Usage returns the syntax and usage examples for the tool-list command.
+func (c *ListCommand) Usage() string {
+	return `\tool-list
+
+Examples:
+  \tool-list                    %% Show all defined tools with their scripts
+
+Notes:
+  - Tools are defined with \tool-define
+  - Output is stored in ${_output}`
+}
+
+// HelpInfo returns structured help information for the tool-list command.
+func (c *ListCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       `\tool-list`,
+		ParseMode:   c.ParseMode(),
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     `\tool-list`,
+				Description: "Show all defined tools",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "#tool_count",
+				Description: "Number of defined tools",
+				Type:        "system_metadata",
+				Example:     "2",
+			},
+			{
+				Name:        "_output",
+				Description: "Formatted tool list",
+				Type:        "command_output",
+				Example:     "weather - Get the current weather for a city",
+			},
+		},
+		Notes: []string{
+			"Tools are sorted by name",
+			"Parameter names come from each tool's JSON schema",
+		},
+	}
+}
+
+// Execute lists all defined tools.
+func (c *ListCommand) Execute(_ map[string]string, _ string) error {
+	toolService, err := services.GetGlobalToolService()
+	if err != nil {
+		return fmt.Errorf("tool service not available: %w", err)
+	}
+
+	variableService, err := services.GetGlobalVariableService()
+	if err != nil {
+		return fmt.Errorf("variable service not available: %w", err)
+	}
+
+	tools := toolService.List()
+	var output strings.Builder
+	if len(tools) == 0 {
+		output.WriteString("No tools defined. Use \\tool-define to add one.\n")
+	} else {
+		output.WriteString(fmt.Sprintf("Tools (%d):\n", len(tools)))
+		for _, tool := range tools {
+			output.WriteString(fmt.Sprintf("  %s", tool.Name))
+			if tool.Description != "" {
+				output.WriteString(fmt.Sprintf(" - %s", tool.Description))
+			}
+			output.WriteString("\n")
+			if params := parameterNames(tool.Parameters); len(params) > 0 {
+				output.WriteString(fmt.Sprintf("    parameters: %s\n", strings.Join(params, ", ")))
+			}
+			output.WriteString(fmt.Sprintf("    script: %s\n", tool.ScriptPath))
+		}
+	}
+
+	_ = variableService.SetSystemVariable("#tool_count", fmt.Sprintf("%d", len(tools)))
+	_ = variableService.SetSystemVariable("_output", output.String())
+
+	fmt.Print(output.String())
+	return nil
+}
+
+// parameterNames returns the sorted property names of a tool's parameter schema.
+// Required parameters are marked with a trailing "*".
+func parameterNames(schema map[string]interface{}) []string {
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	required := make(map[string]bool)
+	if requiredList, ok := schema["required"].([]interface{}); ok {
+		for _, name := range requiredList {
+			if nameStr, ok := name.(string); ok {
+				required[nameStr] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for i, name := range names {
+		if required[name] {
+			names[i] = name + "*"
+		}
+	}
+	return names
+}
+
+// IsReadOnly returns false as the tool-list command modifies system state.
+func (c *ListCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&ListCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register tool-list command: %v", err))
+	}
+}
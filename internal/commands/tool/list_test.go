@@ -0,0 +1,43 @@
+package tool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+func TestListCommand_BasicProperties(t *testing.T) {
+	cmd := &ListCommand{}
+	assert.Equal(t, "tool-list", cmd.Name())
+	assert.Equal(t, neurotypes.ParseModeKeyValue, cmd.ParseMode())
+	assert.NotEmpty(t, cmd.Description())
+	assert.Contains(t, cmd.Usage(), "\\tool-list")
+	assert.Equal(t, cmd.Name(), cmd.HelpInfo().Command)
+}
+
+func TestListCommand_Execute(t *testing.T) {
+	setupToolTestRegistry(t)
+	cmd := &ListCommand{}
+	variableService, _ := services.GetGlobalVariableService()
+
+	require.NoError(t, cmd.Execute(map[string]string{}, ""))
+	output, _ := variableService.Get("_output")
+	assert.Contains(t, output, "No tools defined")
+
+	path := writeToolScript(t, t.TempDir(), "weather.neuro", weatherScript)
+	require.NoError(t, (&DefineCommand{}).Execute(map[string]string{}, path))
+
+	require.NoError(t, cmd.Execute(map[string]string{}, ""))
+	output, _ = variableService.Get("_output")
+	assert.Contains(t, output, "Tools (1):")
+	assert.Contains(t, output, "weather - Get the weather for a city")
+	assert.Contains(t, output, "parameters: city*")
+	assert.Contains(t, output, "script: "+path)
+
+	count, _ := variableService.Get("#tool_count")
+	assert.Equal(t, "1", count)
+}
@@ -0,0 +1,106 @@
+package tool
+
+import (
+	"fmt"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// RemoveCommand implements the \tool-remove command for removing defined tools.
+type RemoveCommand struct{}
+
+// Name returns the command name "tool-remove" for registration and lookup.
+func (c *RemoveCommand) Name() string {
+	return "tool-remove"
+}
+
+// ParseMode returns ParseModeKeyValue for bracket parameter parsing.
+func (c *RemoveCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the tool-remove command does.
+func (c *RemoveCommand) Description() string {
+	return "Remove a defined tool so it is no longer offered to the model"
+}
+
+// Usage returns the syntax and usage examples for the tool-remove command.
+func (c *RemoveCommand) Usage() string {
+	return `\tool-remove tool_name
+
+Examples:
+  \tool-remove weather          %% Stop offering the weather tool
+
+Notes:
+  - The tool script itself is not deleted`
+}
+
+// HelpInfo returns structured help information for the tool-remove command.
+func (c *RemoveCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       `\tool-remove tool_name`,
+		ParseMode:   c.ParseMode(),
+		Examples: []neurotypes.HelpExample{
+			{
+				Command:     `\tool-remove weather`,
+				Description: "Remove the weather tool",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "#tool_count",
+				Description: "Number of remaining tools",
+				Type:        "system_metadata",
+				Example:     "1",
+			},
+		},
+		Notes: []string{
+			"The tool script itself is not deleted",
+		},
+	}
+}
+
+// Execute removes the named tool.
+func (c *RemoveCommand) Execute(_ map[string]string, input string) error {
+	name := strings.TrimSpace(input)
+	if name == "" {
+		return fmt.Errorf("tool name is required\n\nUsage: %s", c.Usage())
+	}
+
+	toolService, err := services.GetGlobalToolService()
+	if err != nil {
+		return fmt.Errorf("tool service not available: %w", err)
+	}
+
+	variableService, err := services.GetGlobalVariableService()
+	if err != nil {
+		return fmt.Errorf("variable service not available: %w", err)
+	}
+
+	if err := toolService.Remove(name); err != nil {
+		return err
+	}
+
+	output := fmt.Sprintf("Removed tool '%s'", name)
+	_ = variableService.SetSystemVariable("#tool_count", fmt.Sprintf("%d", len(toolService.List())))
+	_ = variableService.SetSystemVariable("_output", output)
+
+	fmt.Println(output)
+	return nil
+}
+
+// IsReadOnly returns false as the tool-remove command modifies system state.
+func (c *RemoveCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&RemoveCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register tool-remove command: %v", err))
+	}
+}
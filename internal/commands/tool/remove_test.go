@@ -0,0 +1,39 @@
+package tool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+func TestRemoveCommand_BasicProperties(t *testing.T) {
+	cmd := &RemoveCommand{}
+	assert.Equal(t, "tool-remove", cmd.Name())
+	assert.Equal(t, neurotypes.ParseModeKeyValue, cmd.ParseMode())
+	assert.NotEmpty(t, cmd.Description())
+	assert.Contains(t, cmd.Usage(), "\\tool-remove")
+	assert.False(t, cmd.IsReadOnly())
+}
+
+func TestRemoveCommand_Execute(t *testing.T) {
+	setupToolTestRegistry(t)
+	path := writeToolScript(t, t.TempDir(), "weather.neuro", weatherScript)
+	require.NoError(t, (&DefineCommand{}).Execute(map[string]string{}, path))
+
+	cmd := &RemoveCommand{}
+	require.NoError(t, cmd.Execute(map[string]string{}, "weather"))
+
+	toolService, _ := services.GetGlobalToolService()
+	assert.Empty(t, toolService.List())
+
+	variableService, _ := services.GetGlobalVariableService()
+	count, _ := variableService.Get("#tool_count")
+	assert.Equal(t, "0", count)
+
+	assert.Error(t, cmd.Execute(map[string]string{}, "weather"), "removing twice should fail")
+	assert.Error(t, cmd.Execute(map[string]string{}, "  "))
+}
@@ -0,0 +1,151 @@
+package tool
+
+import (
+	"fmt"
+	"strings"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+)
+
+// ResultCommand implements the \tool-result command that collects a tool script's outcome.
+// It is pushed by \tool-call to run right after the tool script's error boundary.
+type ResultCommand struct{}
+
+// Name returns the command name "tool-result" for registration and lookup.
+func (c *ResultCommand) Name() string {
+	return "tool-result"
+}
+
+// ParseMode returns ParseModeKeyValue for bracket parameter parsing.
+func (c *ResultCommand) ParseMode() neurotypes.ParseMode {
+	return neurotypes.ParseModeKeyValue
+}
+
+// Description returns a brief description of what the tool-result command does.
+func (c *ResultCommand) Description() string {
+	return "Collect the result of a tool script (used internally by \\tool-call)"
+}
+
+// Usage returns the syntax and usage examples for the tool-result command.
+func (c *ResultCommand) Usage() string {
+	return `\tool-result[tool_loop=loop_id, call_id=call_id, name=tool_name]
+
+Notes:
+  - Pushed automatically by \tool-call after the tool script
+  - Reads the script's ${_output} and error state into ${#tool_result} and ${#tool_error}
+  - Clears the argument variables set by \tool-call
+  - Inside a tool loop, the result is queued to be sent back to the model`
+}
+
+// HelpInfo returns structured help information for the tool-result command.
+func (c *ResultCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{
+		Command:     c.Name(),
+		Description: c.Description(),
+		Usage:       `\tool-result[tool_loop=loop_id, call_id=call_id, name=tool_name]`,
+		ParseMode:   c.ParseMode(),
+		Options: []neurotypes.HelpOption{
+			{
+				Name:        "tool_loop",
+				Description: "Tool loop ID the result belongs to",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "call_id",
+				Description: "Tool call ID the result answers",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Name:        "name",
+				Description: "Name of the tool that ran",
+				Required:    false,
+				Type:        "string",
+			},
+		},
+		StoredVariables: []neurotypes.HelpStoredVariable{
+			{
+				Name:        "#tool_result",
+				Description: "Result of the tool script",
+				Type:        "system_metadata",
+				Example:     "Sunny, 22C",
+			},
+			{
+				Name:        "#tool_error",
+				Description: "Error message if the tool script failed, empty otherwise",
+				Type:        "system_metadata",
+				Example:     "",
+			},
+		},
+		Notes: []string{
+			"Used internally by \\tool-call; rarely needed directly",
+			"Clears the argument variables set by \\tool-call",
+		},
+	}
+}
+
+// Execute reads the finished tool script's output and error state and records the result.
+func (c *ResultCommand) Execute(args map[string]string, _ string) error {
+	variableService, err := services.GetGlobalVariableService()
+	if err != nil {
+		return fmt.Errorf("variable service not available: %w", err)
+	}
+
+	// This command resets the error state before running, so the script's outcome is now the "last" state
+	status, _ := variableService.Get("@last_status")
+	errorMessage, _ := variableService.Get("@last_error")
+	output, _ := variableService.Get("_output")
+	output = strings.TrimRight(output, "\n")
+
+	result := neurotypes.ToolResult{
+		CallID:  args["call_id"],
+		Name:    args["name"],
+		Content: output,
+		IsError: status != "" && status != "0",
+	}
+	if result.IsError {
+		result.Content = errorMessage
+		fmt.Printf("⚠️  Tool %s failed: %s\n", result.Name, errorMessage)
+	}
+
+	_ = variableService.SetSystemVariable("#tool_result", output)
+	if result.IsError {
+		_ = variableService.SetSystemVariable("#tool_error", errorMessage)
+	} else {
+		_ = variableService.SetSystemVariable("#tool_error", "")
+	}
+
+	// Argument variables only exist for the duration of the tool script
+	if arguments, err := variableService.Get("#tool_args"); err == nil {
+		if values, err := parseToolArguments(arguments); err == nil {
+			for key := range values {
+				_ = variableService.Set(key, "")
+			}
+		}
+	}
+
+	loopID := args["tool_loop"]
+	if loopID == "" {
+		return nil
+	}
+
+	toolService, err := services.GetGlobalToolService()
+	if err != nil {
+		return fmt.Errorf("tool service not available: %w", err)
+	}
+	return toolService.RecordToolResult(loopID, result)
+}
+
+// IsReadOnly returns false as the tool-result command modifies system state.
+func (c *ResultCommand) IsReadOnly() bool {
+	return false
+}
+
+func init() {
+	if err := commands.GetGlobalRegistry().Register(&ResultCommand{}); err != nil {
+		panic(fmt.Sprintf("failed to register tool-result command: %v", err))
+	}
+}
@@ -13,6 +13,7 @@ type CommandRegistrySubcontext interface {
 	RegisterCommand(commandName string)
 	RegisterCommandWithInfo(cmd neurotypes.Command)
 	RegisterCommandWithInfoAndType(cmd neurotypes.Command, cmdType neurotypes.CommandType)
+	RegisterCommandHelp(helpInfo neurotypes.HelpInfo)
 	UnregisterCommand(commandName string)
 
 	// Command lookup
@@ -79,6 +80,16 @@ func (c *commandRegistrySubcontext) RegisterCommandWithInfoAndType(cmd neurotype
 	c.commandHelpInfo[commandName] = &helpInfo
 }
 
+// RegisterCommandHelp registers help information for a command that has no Go implementation,
+// such as a command defined with \def. An existing entry with the same name is replaced.
+func (c *commandRegistrySubcontext) RegisterCommandHelp(helpInfo neurotypes.HelpInfo) {
+	c.commandMutex.Lock()
+	defer c.commandMutex.Unlock()
+
+	c.registeredCommands[helpInfo.Command] = true
+	c.commandHelpInfo[helpInfo.Command] = &helpInfo
+}
+
 // UnregisterCommand removes a command name from the autocomplete registry.
 func (c *commandRegistrySubcontext) UnregisterCommand(commandName string) {
 	c.commandMutex.Lock()
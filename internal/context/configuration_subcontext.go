@@ -111,7 +111,11 @@ func NewConfigurationSubcontext() ConfigurationSubcontext {
 			"_stream",
 			"_editor",
 			"_session_autosave",
+			"_session_autoload",
 			"_completion_mode",
+			"_tool_max_rounds",
+			"_llm_retry",
+			"_context_policy",
 			// Shell prompt configuration variables
 			"_prompt_lines_count",
 			"_prompt_line1",
@@ -27,6 +27,11 @@ type NeuroContext struct {
 	stackCtx StackSubcontext // Delegated stack management
 	// Note: variablesMutex removed - LRU cache has its own thread safety
 
+	// Script-local variable frames, innermost last
+	scriptFrames       []ScriptFrame
+	scriptFrameCounter int
+	scriptFramesMutex  sync.RWMutex // Protects scriptFrames and scriptFrameCounter
+
 	// Chat session storage
 	chatSessions    map[string]*neurotypes.ChatSession // Session storage by ID
 	sessionNameToID map[string]string                  // Name to ID mapping
@@ -138,6 +143,11 @@ func (ctx *NeuroContext) GetVariable(name string) (string, error) {
 		return value, nil
 	}
 
+	// Local variables of the running script take precedence over global ones
+	if value, ok := ctx.getLocalVariable(name); ok {
+		return value, nil
+	}
+
 	// Handle user variables using LRU cache
 	value, ok := ctx.variables.Get(name)
 	if ok {
@@ -162,6 +172,11 @@ func (ctx *NeuroContext) SetVariable(name string, value string) error {
 		}
 	}
 
+	// Update the running script's local variable, if it has one with this name
+	if ctx.setExistingLocalVariable(name, value) {
+		return nil
+	}
+
 	// Set variable in LRU cache
 	ctx.variables.Set(name, value)
 	return nil
@@ -493,8 +508,11 @@ func (ctx *NeuroContext) GetEnv(key string) string {
 
 // GetAllVariables returns all variables including both user variables and computed system variables.
 func (ctx *NeuroContext) GetAllVariables() map[string]string {
-	// Get all cached variables from LRU cache
+	// Get all cached variables from LRU cache, with the running script's local variables
 	result := ctx.variables.GetAll()
+	for name, value := range ctx.getLocalVariables() {
+		result[name] = value
+	}
 
 	// Add computed system variables
 	systemVars := []string{"@pwd", "@user", "@home", "@date", "@time", "@os", "@status", "@error", "@last_status", "@last_error", "@last_output", "#session_id", "#message_count", "#test_mode"}
@@ -645,6 +663,11 @@ func (ctx *NeuroContext) RegisterCommandWithInfoAndType(cmd neurotypes.Command,
 	ctx.commandRegistryCtx.RegisterCommandWithInfoAndType(cmd, cmdType)
 }
 
+// RegisterCommandHelp registers help information for a command without a Go implementation.
+func (ctx *NeuroContext) RegisterCommandHelp(helpInfo neurotypes.HelpInfo) {
+	ctx.commandRegistryCtx.RegisterCommandHelp(helpInfo)
+}
+
 // UnregisterCommand removes a command name from the autocomplete registry.
 func (ctx *NeuroContext) UnregisterCommand(commandName string) {
 	ctx.commandRegistryCtx.UnregisterCommand(commandName)
@@ -775,6 +798,31 @@ func (ctx *NeuroContext) GetCurrentSilentDepth() int {
 	return ctx.stackCtx.GetCurrentSilentDepth()
 }
 
+// PushLoopBoundary registers a new innermost loop
+func (ctx *NeuroContext) PushLoopBoundary(loop LoopBlockContext) {
+	ctx.stackCtx.PushLoopBoundary(loop)
+}
+
+// PopLoopBoundary removes the most recent loop block context
+func (ctx *NeuroContext) PopLoopBoundary() {
+	ctx.stackCtx.PopLoopBoundary()
+}
+
+// IsInLoopBlock returns true if currently inside a loop
+func (ctx *NeuroContext) IsInLoopBlock() bool {
+	return ctx.stackCtx.IsInLoopBlock()
+}
+
+// GetCurrentLoopID returns the ID of the innermost loop
+func (ctx *NeuroContext) GetCurrentLoopID() string {
+	return ctx.stackCtx.GetCurrentLoopID()
+}
+
+// GetCurrentLoopDepth returns the current loop nesting depth
+func (ctx *NeuroContext) GetCurrentLoopDepth() int {
+	return ctx.stackCtx.GetCurrentLoopDepth()
+}
+
 // GetDefaultCommand returns the default command to use when input doesn't start with \\
 func (ctx *NeuroContext) GetDefaultCommand() string {
 	// Check if _default_command variable overrides the default
@@ -31,7 +31,7 @@ type providerRegistrySubcontext struct {
 func NewProviderRegistrySubcontext() ProviderRegistrySubcontext {
 	return &providerRegistrySubcontext{
 		// Initialize provider registry with default supported providers
-		supportedProviders:  []string{"openai", "anthropic", "openrouter", "moonshot", "gemini"},
+		supportedProviders:  []string{"openai", "anthropic", "openrouter", "moonshot", "gemini", "ollama", "vllm", "llamacpp", "lmstudio", "mock"},
 		providerEnvPrefixes: []string{"NEURO_", "OPENAI_", "ANTHROPIC_", "MOONSHOT_", "GOOGLE_"},
 	}
 }
@@ -0,0 +1,195 @@
+// Package context provides script-local variable frames for NeuroShell.
+// Each script command invocation gets a frame holding its parameters and \local variables,
+// so they do not overwrite the caller's variables and vanish when the script returns.
+package context
+
+import (
+	"fmt"
+)
+
+// ScriptParameterVariables are the system variables set for each script invocation.
+// The caller's values are saved when a frame is pushed and restored when it is popped.
+var ScriptParameterVariables = []string{"_0", "_1", "_*", "_@"}
+
+// ScriptFrame is the variable frame of a running script command.
+type ScriptFrame struct {
+	ID     string             // Unique identifier, matched by the frame's end marker on the stack
+	Name   string             // Script command name
+	Locals map[string]string  // Named options and \local variables of this invocation
+	Saved  map[string]*string // Caller's parameter variables, nil for ones that were not set
+}
+
+// PushScriptFrame starts the frame of a script invocation and returns its ID.
+// The parameter variables (_0, _1, _* and _@) are set to the given values after saving the
+// caller's, and the named options become local variables of the frame.
+func (ctx *NeuroContext) PushScriptFrame(name string, parameters, options map[string]string) string {
+	// Whitelisted globals such as _style keep their global meaning
+	for key, value := range options {
+		if IsSystemVariable(key) {
+			_ = ctx.SetVariable(key, value)
+		}
+	}
+
+	ctx.scriptFramesMutex.Lock()
+	defer ctx.scriptFramesMutex.Unlock()
+
+	ctx.scriptFrameCounter++
+	frame := ScriptFrame{
+		ID:     fmt.Sprintf("script_frame_%d", ctx.scriptFrameCounter),
+		Name:   name,
+		Locals: make(map[string]string),
+		Saved:  make(map[string]*string),
+	}
+
+	for _, variable := range ScriptParameterVariables {
+		if value, ok := ctx.variables.Get(variable); ok {
+			frame.Saved[variable] = &value
+		} else {
+			frame.Saved[variable] = nil
+		}
+		ctx.variables.Set(variable, parameters[variable])
+	}
+
+	for key, value := range options {
+		if !IsSystemVariable(key) {
+			frame.Locals[key] = value
+		}
+	}
+
+	ctx.scriptFrames = append(ctx.scriptFrames, frame)
+	return frame.ID
+}
+
+// PopScriptFrame ends the innermost frame if it has the given ID, dropping its local variables
+// and restoring the caller's parameter variables. It returns false if the innermost frame
+// has another ID, which happens for end markers left over by a script stopped by an error.
+func (ctx *NeuroContext) PopScriptFrame(id string) bool {
+	ctx.scriptFramesMutex.Lock()
+	defer ctx.scriptFramesMutex.Unlock()
+
+	if len(ctx.scriptFrames) == 0 || ctx.scriptFrames[len(ctx.scriptFrames)-1].ID != id {
+		return false
+	}
+
+	frame := ctx.scriptFrames[len(ctx.scriptFrames)-1]
+	ctx.scriptFrames = ctx.scriptFrames[:len(ctx.scriptFrames)-1]
+
+	for variable, value := range frame.Saved {
+		if value == nil {
+			ctx.variables.Delete(variable)
+		} else {
+			ctx.variables.Set(variable, *value)
+		}
+	}
+	return true
+}
+
+// GetCurrentScriptFrameID returns the ID of the innermost frame, or "" outside scripts.
+func (ctx *NeuroContext) GetCurrentScriptFrameID() string {
+	ctx.scriptFramesMutex.RLock()
+	defer ctx.scriptFramesMutex.RUnlock()
+
+	if len(ctx.scriptFrames) == 0 {
+		return ""
+	}
+	return ctx.scriptFrames[len(ctx.scriptFrames)-1].ID
+}
+
+// GetScriptFrameDepth returns the number of running script invocations.
+func (ctx *NeuroContext) GetScriptFrameDepth() int {
+	ctx.scriptFramesMutex.RLock()
+	defer ctx.scriptFramesMutex.RUnlock()
+	return len(ctx.scriptFrames)
+}
+
+// SetLocalVariable sets a variable local to the innermost script invocation.
+func (ctx *NeuroContext) SetLocalVariable(name, value string) error {
+	if err := validateLocalVariableName(name); err != nil {
+		return err
+	}
+
+	ctx.scriptFramesMutex.Lock()
+	defer ctx.scriptFramesMutex.Unlock()
+
+	if len(ctx.scriptFrames) == 0 {
+		return fmt.Errorf("local variables can only be set inside a script")
+	}
+	ctx.scriptFrames[len(ctx.scriptFrames)-1].Locals[name] = value
+	return nil
+}
+
+// ExportVariable sets a variable in the scope of the caller of the innermost script invocation:
+// the caller's local variable if it has one with this name, otherwise the global variable.
+func (ctx *NeuroContext) ExportVariable(name, value string) error {
+	if err := validateLocalVariableName(name); err != nil {
+		return err
+	}
+
+	ctx.scriptFramesMutex.Lock()
+	defer ctx.scriptFramesMutex.Unlock()
+
+	if len(ctx.scriptFrames) == 0 {
+		return fmt.Errorf("variables can only be exported from inside a script")
+	}
+	if len(ctx.scriptFrames) > 1 {
+		caller := ctx.scriptFrames[len(ctx.scriptFrames)-2]
+		if _, isLocal := caller.Locals[name]; isLocal {
+			caller.Locals[name] = value
+			return nil
+		}
+	}
+	ctx.variables.Set(name, value)
+	return nil
+}
+
+// getLocalVariable returns a local variable of the innermost script invocation.
+func (ctx *NeuroContext) getLocalVariable(name string) (string, bool) {
+	ctx.scriptFramesMutex.RLock()
+	defer ctx.scriptFramesMutex.RUnlock()
+
+	if len(ctx.scriptFrames) == 0 {
+		return "", false
+	}
+	value, ok := ctx.scriptFrames[len(ctx.scriptFrames)-1].Locals[name]
+	return value, ok
+}
+
+// setExistingLocalVariable updates a local variable of the innermost script invocation,
+// returning false if the invocation has no local variable with this name.
+func (ctx *NeuroContext) setExistingLocalVariable(name, value string) bool {
+	ctx.scriptFramesMutex.Lock()
+	defer ctx.scriptFramesMutex.Unlock()
+
+	if len(ctx.scriptFrames) == 0 {
+		return false
+	}
+	locals := ctx.scriptFrames[len(ctx.scriptFrames)-1].Locals
+	if _, ok := locals[name]; !ok {
+		return false
+	}
+	locals[name] = value
+	return true
+}
+
+// getLocalVariables returns a copy of the local variables of the innermost script invocation.
+func (ctx *NeuroContext) getLocalVariables() map[string]string {
+	ctx.scriptFramesMutex.RLock()
+	defer ctx.scriptFramesMutex.RUnlock()
+
+	locals := make(map[string]string)
+	if len(ctx.scriptFrames) > 0 {
+		for name, value := range ctx.scriptFrames[len(ctx.scriptFrames)-1].Locals {
+			locals[name] = value
+		}
+	}
+	return locals
+}
+
+// validateLocalVariableName checks that a name can be a local variable: system variables
+// (@, # and _ prefixes) are always global.
+func validateLocalVariableName(name string) error {
+	if IsSystemVariable(name) {
+		return fmt.Errorf("system variable %s cannot be local", name)
+	}
+	return ValidateVariableName(name)
+}
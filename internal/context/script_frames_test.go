@@ -0,0 +1,102 @@
+package context
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptFrames_ParametersAndLocals(t *testing.T) {
+	ctx := New()
+	require.NoError(t, ctx.SetVariable("name", "global"))
+
+	frameID := ctx.PushScriptFrame("greet", map[string]string{"_0": "greet", "_1": "hello"}, map[string]string{"name": "param"})
+	assert.Equal(t, frameID, ctx.GetCurrentScriptFrameID())
+	assert.Equal(t, 1, ctx.GetScriptFrameDepth())
+
+	value, _ := ctx.GetVariable("name")
+	assert.Equal(t, "param", value, "named options hide global variables")
+	value, _ = ctx.GetVariable("_1")
+	assert.Equal(t, "hello", value)
+	assert.Equal(t, "param", ctx.GetAllVariables()["name"])
+
+	// \set changes the local variable, and globals without a local of the same name
+	require.NoError(t, ctx.SetVariable("name", "changed"))
+	require.NoError(t, ctx.SetVariable("other", "set-in-script"))
+	require.NoError(t, ctx.SetLocalVariable("scratch", "temp"))
+
+	assert.False(t, ctx.PopScriptFrame("script_frame_other"), "only the innermost frame can end")
+	assert.True(t, ctx.PopScriptFrame(frameID))
+	assert.Equal(t, 0, ctx.GetScriptFrameDepth())
+
+	value, _ = ctx.GetVariable("name")
+	assert.Equal(t, "global", value)
+	value, _ = ctx.GetVariable("other")
+	assert.Equal(t, "set-in-script", value)
+	value, _ = ctx.GetVariable("scratch")
+	assert.Empty(t, value)
+
+	// Parameter variables that were not set before the call are removed again
+	_, exists := ctx.variables.Get("_1")
+	assert.False(t, exists)
+}
+
+func TestScriptFrames_NestedCallsRestoreParameters(t *testing.T) {
+	ctx := New()
+
+	outer := ctx.PushScriptFrame("outer", map[string]string{"_0": "outer", "_1": "outer message"}, map[string]string{"level": "outer"})
+	inner := ctx.PushScriptFrame("inner", map[string]string{"_0": "inner", "_1": "inner message"}, nil)
+
+	value, _ := ctx.GetVariable("level")
+	assert.Empty(t, value, "scripts do not see their caller's local variables")
+	value, _ = ctx.GetVariable("_1")
+	assert.Equal(t, "inner message", value)
+
+	require.True(t, ctx.PopScriptFrame(inner))
+	value, _ = ctx.GetVariable("_1")
+	assert.Equal(t, "outer message", value)
+	value, _ = ctx.GetVariable("level")
+	assert.Equal(t, "outer", value)
+
+	require.True(t, ctx.PopScriptFrame(outer))
+	assert.NotEqual(t, outer, inner)
+}
+
+func TestScriptFrames_Export(t *testing.T) {
+	ctx := New()
+
+	assert.Error(t, ctx.ExportVariable("result", "x"), "export needs a running script")
+	assert.Error(t, ctx.SetLocalVariable("result", "x"), "local needs a running script")
+
+	outer := ctx.PushScriptFrame("outer", nil, map[string]string{"result": ""})
+	ctx.PushScriptFrame("inner", nil, nil)
+
+	// The caller's local variable receives the value
+	require.NoError(t, ctx.ExportVariable("result", "42"))
+	// Names the caller does not have locally are set globally
+	require.NoError(t, ctx.ExportVariable("summary", "done"))
+
+	ctx.PopScriptFrame(ctx.GetCurrentScriptFrameID())
+	value, _ := ctx.GetVariable("result")
+	assert.Equal(t, "42", value)
+
+	ctx.PopScriptFrame(outer)
+	value, _ = ctx.GetVariable("result")
+	assert.Empty(t, value)
+	value, _ = ctx.GetVariable("summary")
+	assert.Equal(t, "done", value)
+}
+
+func TestScriptFrames_SystemVariablesStayGlobal(t *testing.T) {
+	ctx := New()
+	ctx.PushScriptFrame("styled", nil, map[string]string{"_style": "dark"})
+
+	assert.Error(t, ctx.SetLocalVariable("_style", "light"))
+	assert.Error(t, ctx.SetLocalVariable("#session_id", "x"))
+	assert.Error(t, ctx.ExportVariable("@user", "x"))
+
+	ctx.PopScriptFrame(ctx.GetCurrentScriptFrameID())
+	value, _ := ctx.GetVariable("_style")
+	assert.Equal(t, "dark", value, "whitelisted global options keep their global meaning")
+}
@@ -17,6 +17,19 @@ type SilentBlockContext struct {
 	StartDepth int    // Stack depth when silent block started
 }
 
+// LoopBlockContext represents the context for a loop started by \for-each, \repeat or \while
+type LoopBlockContext struct {
+	ID        string   // Unique identifier for this loop
+	Kind      string   // Loop command name: for-each, repeat or while
+	Variable  string   // Variable set on each pass, empty for none
+	Items     []string // Items of a for-each loop, one per pass
+	Times     int      // Number of passes of a repeat loop
+	Condition string   // Uninterpolated condition of a while loop, evaluated before each pass
+	Body      string   // Uninterpolated command run on each pass
+	Max       int      // Safety cap on the number of passes
+	Pass      int      // Number of passes started so far
+}
+
 // StackSubcontext defines the interface for execution stack management functionality.
 // This includes command stacking, try blocks, and silent blocks for control flow.
 type StackSubcontext interface {
@@ -45,6 +58,14 @@ type StackSubcontext interface {
 	IsInSilentBlock() bool
 	GetCurrentSilentID() string
 	GetCurrentSilentDepth() int
+
+	// Loop block support methods
+	PushLoopBoundary(loop LoopBlockContext)
+	PopLoopBoundary()
+	IsInLoopBlock() bool
+	GetCurrentLoopID() string
+	GetCurrentLoopDepth() int
+	NextLoopPass(loopID string) (LoopBlockContext, bool)
 }
 
 // stackSubcontext implements the StackSubcontext interface.
@@ -55,7 +76,8 @@ type stackSubcontext struct {
 	currentTryDepth    int                  // Current try block depth
 	silentBlocks       []SilentBlockContext // Silent block management
 	currentSilentDepth int                  // Current silent block depth
-	stackMutex         sync.RWMutex         // Protects executionStack, tryBlocks, and silentBlocks
+	loopBlocks         []LoopBlockContext   // Loop block management
+	stackMutex         sync.RWMutex         // Protects executionStack, tryBlocks, silentBlocks, and loopBlocks
 }
 
 // NewStackSubcontext creates a new StackSubcontext instance.
@@ -66,6 +88,7 @@ func NewStackSubcontext() StackSubcontext {
 		currentTryDepth:    0,
 		silentBlocks:       make([]SilentBlockContext, 0),
 		currentSilentDepth: 0,
+		loopBlocks:         make([]LoopBlockContext, 0),
 	}
 }
 
@@ -283,3 +306,63 @@ func (s *stackSubcontext) GetCurrentSilentDepth() int {
 	defer s.stackMutex.RUnlock()
 	return s.currentSilentDepth
 }
+
+// Loop block support methods
+
+// PushLoopBoundary registers a new innermost loop
+func (s *stackSubcontext) PushLoopBoundary(loop LoopBlockContext) {
+	s.stackMutex.Lock()
+	defer s.stackMutex.Unlock()
+	s.loopBlocks = append(s.loopBlocks, loop)
+}
+
+// PopLoopBoundary removes the most recent loop block context
+func (s *stackSubcontext) PopLoopBoundary() {
+	s.stackMutex.Lock()
+	defer s.stackMutex.Unlock()
+
+	if len(s.loopBlocks) > 0 {
+		s.loopBlocks = s.loopBlocks[:len(s.loopBlocks)-1]
+	}
+}
+
+// IsInLoopBlock returns true if currently inside a loop
+func (s *stackSubcontext) IsInLoopBlock() bool {
+	s.stackMutex.RLock()
+	defer s.stackMutex.RUnlock()
+	return len(s.loopBlocks) > 0
+}
+
+// GetCurrentLoopID returns the ID of the innermost loop
+func (s *stackSubcontext) GetCurrentLoopID() string {
+	s.stackMutex.RLock()
+	defer s.stackMutex.RUnlock()
+
+	if len(s.loopBlocks) == 0 {
+		return ""
+	}
+
+	return s.loopBlocks[len(s.loopBlocks)-1].ID
+}
+
+// GetCurrentLoopDepth returns the current loop nesting depth
+func (s *stackSubcontext) GetCurrentLoopDepth() int {
+	s.stackMutex.RLock()
+	defer s.stackMutex.RUnlock()
+	return len(s.loopBlocks)
+}
+
+// NextLoopPass counts a new pass of the innermost loop and returns a copy of its context.
+// It returns false if the innermost loop does not have the given ID.
+func (s *stackSubcontext) NextLoopPass(loopID string) (LoopBlockContext, bool) {
+	s.stackMutex.Lock()
+	defer s.stackMutex.Unlock()
+
+	if len(s.loopBlocks) == 0 || s.loopBlocks[len(s.loopBlocks)-1].ID != loopID {
+		return LoopBlockContext{}, false
+	}
+
+	loop := &s.loopBlocks[len(s.loopBlocks)-1]
+	loop.Pass++
+	return *loop, true
+}
@@ -27,6 +27,14 @@ type VariableSubcontext interface {
 	GetEnv(name string) string
 	SetEnvVariable(name string, value string) error
 	GetEnvVariable(name string) string
+
+	// Script-local variable frames
+	PushScriptFrame(name string, parameters, options map[string]string) string
+	PopScriptFrame(id string) bool
+	GetCurrentScriptFrameID() string
+	GetScriptFrameDepth() int
+	SetLocalVariable(name string, value string) error
+	ExportVariable(name string, value string) error
 }
 
 // variableSubcontextImpl implements VariableSubcontext using a NeuroContext.
@@ -81,6 +89,36 @@ func (v *variableSubcontextImpl) GetEnvVariable(name string) string {
 	return v.ctx.GetEnvVariable(name)
 }
 
+// PushScriptFrame starts the variable frame of a script invocation and returns its ID.
+func (v *variableSubcontextImpl) PushScriptFrame(name string, parameters, options map[string]string) string {
+	return v.ctx.PushScriptFrame(name, parameters, options)
+}
+
+// PopScriptFrame ends the innermost variable frame if it has the given ID.
+func (v *variableSubcontextImpl) PopScriptFrame(id string) bool {
+	return v.ctx.PopScriptFrame(id)
+}
+
+// GetCurrentScriptFrameID returns the ID of the innermost variable frame.
+func (v *variableSubcontextImpl) GetCurrentScriptFrameID() string {
+	return v.ctx.GetCurrentScriptFrameID()
+}
+
+// GetScriptFrameDepth returns the number of running script invocations.
+func (v *variableSubcontextImpl) GetScriptFrameDepth() int {
+	return v.ctx.GetScriptFrameDepth()
+}
+
+// SetLocalVariable sets a variable local to the innermost script invocation.
+func (v *variableSubcontextImpl) SetLocalVariable(name string, value string) error {
+	return v.ctx.SetLocalVariable(name, value)
+}
+
+// ExportVariable sets a variable in the scope of the innermost script invocation's caller.
+func (v *variableSubcontextImpl) ExportVariable(name string, value string) error {
+	return v.ctx.ExportVariable(name, value)
+}
+
 // ValidateVariableName checks if a variable name follows NeuroShell naming conventions.
 // This is a utility function that can be used by services and commands.
 func ValidateVariableName(name string) error {
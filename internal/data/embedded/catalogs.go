@@ -118,6 +118,31 @@ var Gemini25FlashModelData []byte
 //go:embed models/gemini-2-5-flash-lite.yaml
 var Gemini25FlashLiteModelData []byte
 
+// OllamaLocalModelData contains the embedded Ollama local model YAML data.
+//
+//go:embed models/ollama-local.yaml
+var OllamaLocalModelData []byte
+
+// VLLMLocalModelData contains the embedded vLLM served model YAML data.
+//
+//go:embed models/vllm-local.yaml
+var VLLMLocalModelData []byte
+
+// LlamaCppLocalModelData contains the embedded llama.cpp server model YAML data.
+//
+//go:embed models/llamacpp-local.yaml
+var LlamaCppLocalModelData []byte
+
+// LMStudioLocalModelData contains the embedded LM Studio local model YAML data.
+//
+//go:embed models/lmstudio-local.yaml
+var LMStudioLocalModelData []byte
+
+// MockModelData contains the embedded mock model YAML data.
+//
+//go:embed models/mock.yaml
+var MockModelData []byte
+
 // Provider Catalog Data - embedded provider configuration YAML files
 
 // OpenAIChatProviderData contains the embedded OpenAI chat provider YAML data.
@@ -140,6 +165,31 @@ var GeminiChatProviderData []byte
 //go:embed providers/openai-responses.yaml
 var OpenAIResponsesProviderData []byte
 
+// OllamaChatProviderData contains the embedded Ollama OpenAI-compatible provider YAML data.
+//
+//go:embed providers/ollama-chat.yaml
+var OllamaChatProviderData []byte
+
+// VLLMChatProviderData contains the embedded vLLM OpenAI-compatible provider YAML data.
+//
+//go:embed providers/vllm-chat.yaml
+var VLLMChatProviderData []byte
+
+// LlamaCppChatProviderData contains the embedded llama.cpp server OpenAI-compatible provider YAML data.
+//
+//go:embed providers/llamacpp-chat.yaml
+var LlamaCppChatProviderData []byte
+
+// LMStudioChatProviderData contains the embedded LM Studio OpenAI-compatible provider YAML data.
+//
+//go:embed providers/lmstudio-chat.yaml
+var LMStudioChatProviderData []byte
+
+// MockProviderData contains the embedded mock provider YAML data.
+//
+//go:embed providers/mock.yaml
+var MockProviderData []byte
+
 // Change Log Data - embedded change log YAML file
 
 // ChangeLogData contains the embedded change log YAML data.
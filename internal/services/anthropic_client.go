@@ -3,6 +3,7 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -62,6 +63,8 @@ func (c *AnthropicClient) initializeClientIfNeeded() error {
 	// Create Anthropic client with API key and optional debug transport
 	var options []option.RequestOption
 	options = append(options, option.WithAPIKey(c.apiKey))
+	// Retries are handled by the LLM service's retry policy
+	options = append(options, option.WithMaxRetries(0))
 
 	if c.debugTransport != nil {
 		httpClient := &http.Client{Transport: c.debugTransport}
@@ -112,6 +115,22 @@ func (c *AnthropicClient) sendChatCompletionRequest(session *neurotypes.ChatSess
 		return nil, fmt.Errorf("failed to initialize Anthropic client: %w", err)
 	}
 
+	params := c.buildMessageParams(session, modelConfig)
+
+	// Send request using beta API for thinking support
+	logger.Debug("Sending Anthropic beta request", "model", modelConfig.BaseModel)
+	message, err := c.client.Beta.Messages.New(context.Background(), params)
+	if err != nil {
+		logger.Error("Anthropic request failed", "error", err)
+		return nil, fmt.Errorf("anthropic request failed: %w", err)
+	}
+
+	return message, nil
+}
+
+// buildMessageParams converts a session and model configuration into Anthropic beta message parameters.
+// Shared by the blocking and streaming request paths.
+func (c *AnthropicClient) buildMessageParams(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig) anthropic.BetaMessageNewParams {
 	// Convert session messages to Anthropic format
 	messages, additionalSystemInstructions := c.convertMessagesToAnthropic(session)
 	logger.Debug("Messages converted", "message_count", len(messages))
@@ -148,19 +167,17 @@ func (c *AnthropicClient) sendChatCompletionRequest(session *neurotypes.ChatSess
 	// Apply other model parameters
 	c.applyModelParameters(&params, modelConfig)
 
-	// Send request using beta API for thinking support
-	logger.Debug("Sending Anthropic beta request", "model", modelConfig.BaseModel)
-	message, err := c.client.Beta.Messages.New(context.Background(), params)
-	if err != nil {
-		logger.Error("Anthropic request failed", "error", err)
-		return nil, fmt.Errorf("anthropic request failed: %w", err)
+	// Thinking blocks are only sent back while extended thinking is enabled
+	if params.Thinking.OfEnabled == nil {
+		withoutThinkingBlocks(params.Messages)
 	}
 
-	return message, nil
+	return params
 }
 
 // SendStructuredCompletion sends a chat completion request to Anthropic and returns structured response.
 // This method reuses SendChatCompletion logic and post-processes the response to separate thinking blocks.
+// Token usage reported by the API is included in the response metadata.
 // All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
 func (c *AnthropicClient) SendStructuredCompletion(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig) *neurotypes.StructuredLLMResponse {
 	logger.Debug("Anthropic SendStructuredCompletion starting", "model", modelConfig.BaseModel)
@@ -185,15 +202,21 @@ func (c *AnthropicClient) SendStructuredCompletion(session *neurotypes.ChatSessi
 		return &neurotypes.StructuredLLMResponse{
 			TextContent:    "",
 			ThinkingBlocks: []neurotypes.ThinkingBlock{},
-			Error: &neurotypes.LLMError{
-				Code:    "api_request_failed",
-				Message: err.Error(),
-				Type:    "api_error",
-			},
-			Metadata: map[string]interface{}{"provider": "anthropic", "model": modelConfig.BaseModel},
+			Error:          newProviderLLMError(err.Error(), err),
+			Metadata:       map[string]interface{}{"provider": "anthropic", "model": modelConfig.BaseModel},
 		}
 	}
 
+	structuredResponse := c.buildStructuredResponse(message, modelConfig)
+	if structuredResponse.Error == nil {
+		logger.Debug("Anthropic structured response received", "content_length", len(structuredResponse.TextContent), "thinking_blocks", len(structuredResponse.ThinkingBlocks))
+	}
+	return structuredResponse
+}
+
+// buildStructuredResponse converts a complete Anthropic message into a structured response.
+// Empty responses are reported through the Error field.
+func (c *AnthropicClient) buildStructuredResponse(message *anthropic.BetaMessage, modelConfig *neurotypes.ModelConfig) *neurotypes.StructuredLLMResponse {
 	// Extract response content and thinking blocks separately (structured processing)
 	if len(message.Content) == 0 {
 		logger.Error("No response content returned")
@@ -211,9 +234,10 @@ func (c *AnthropicClient) SendStructuredCompletion(session *neurotypes.ChatSessi
 
 	// Process all content blocks and extract thinking blocks separately
 	textContent, thinkingBlocks := c.processResponseBlocksStructured(message.Content)
+	toolCalls := c.extractToolCalls(message.Content)
 
-	// Check for truly empty response (no text content AND no thinking blocks)
-	if textContent == "" && len(thinkingBlocks) == 0 {
+	// Check for truly empty response (no text content, thinking blocks or tool calls)
+	if textContent == "" && len(thinkingBlocks) == 0 && len(toolCalls) == 0 {
 		logger.Error("Empty response content and no thinking blocks")
 		return &neurotypes.StructuredLLMResponse{
 			TextContent:    textContent,
@@ -231,11 +255,192 @@ func (c *AnthropicClient) SendStructuredCompletion(session *neurotypes.ChatSessi
 	structuredResponse := &neurotypes.StructuredLLMResponse{
 		TextContent:    textContent,
 		ThinkingBlocks: thinkingBlocks,
+		ToolCalls:      toolCalls,
 		Error:          nil, // No error in successful case
-		Metadata:       map[string]interface{}{"provider": "anthropic", "model": modelConfig.BaseModel},
+		Metadata:       withResponseDetails(usageMetadata("anthropic", modelConfig.BaseModel, anthropicUsage(message.Usage)), string(message.StopReason), message.ID),
 	}
 
-	logger.Debug("Anthropic structured response received", "content_length", len(textContent), "thinking_blocks", len(thinkingBlocks))
+	return structuredResponse
+}
+
+// anthropicUsage normalizes Anthropic usage. Anthropic reports cache reads and writes separately
+// from input_tokens, so they are added back to the input count. Thinking tokens are not reported
+// separately and are already part of output_tokens. Returns nil when the API reported no usage.
+func anthropicUsage(usage anthropic.BetaUsage) *neurotypes.TokenUsage {
+	if usage.InputTokens == 0 && usage.OutputTokens == 0 {
+		return nil
+	}
+	return &neurotypes.TokenUsage{
+		InputTokens:  usage.InputTokens + usage.CacheReadInputTokens + usage.CacheCreationInputTokens,
+		OutputTokens: usage.OutputTokens,
+		CachedTokens: usage.CacheReadInputTokens,
+	}
+}
+
+// SendStructuredCompletionWithTools sends a chat completion request to Anthropic advertising the given tools.
+// Tool use blocks requested by the model are returned in the ToolCalls field of the structured response.
+// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+func (c *AnthropicClient) SendStructuredCompletionWithTools(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig, tools []neurotypes.ToolDefinition) *neurotypes.StructuredLLMResponse {
+	logger.Debug("Anthropic SendStructuredCompletionWithTools starting", "model", modelConfig.BaseModel, "tools", len(tools))
+
+	if err := c.initializeClientIfNeeded(); err != nil {
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error: &neurotypes.LLMError{
+				Code:    "client_initialization_failed",
+				Message: err.Error(),
+				Type:    "initialization_error",
+			},
+			Metadata: map[string]interface{}{"provider": "anthropic", "model": modelConfig.BaseModel},
+		}
+	}
+
+	params := c.buildMessageParams(session, modelConfig)
+	params.Tools = anthropicToolParams(tools)
+
+	logger.Debug("Sending Anthropic beta tool calling request", "model", modelConfig.BaseModel)
+	message, err := c.client.Beta.Messages.New(context.Background(), params)
+	if err != nil {
+		logger.Error("Anthropic request failed", "error", err)
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error:          newProviderLLMError(fmt.Sprintf("anthropic request failed: %s", err.Error()), err),
+			Metadata:       map[string]interface{}{"provider": "anthropic", "model": modelConfig.BaseModel},
+		}
+	}
+
+	return c.buildStructuredResponse(message, modelConfig)
+}
+
+// SendStructuredCompletionWithSchema sends a request whose answer is constrained to a JSON schema.
+// Anthropic's native mechanism is tool use: the schema is offered as the input schema of a single
+// tool that the model is forced to call, and the tool input is returned as the JSON document.
+// Extended thinking does not allow forced tool use, so the tool choice is left to the model then.
+// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+func (c *AnthropicClient) SendStructuredCompletionWithSchema(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig, schema *neurotypes.ResponseSchema) *neurotypes.StructuredLLMResponse {
+	logger.Debug("Anthropic SendStructuredCompletionWithSchema starting", "model", modelConfig.BaseModel, "schema", schema.Name)
+
+	if err := c.initializeClientIfNeeded(); err != nil {
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error: &neurotypes.LLMError{
+				Code:    "client_initialization_failed",
+				Message: err.Error(),
+				Type:    "initialization_error",
+			},
+			Metadata: map[string]interface{}{"provider": "anthropic", "model": modelConfig.BaseModel},
+		}
+	}
+
+	params := c.buildMessageParams(session, modelConfig)
+	params.Tools = anthropicToolParams([]neurotypes.ToolDefinition{{
+		Name:        schema.Name,
+		Description: "Return the final answer as structured data matching this schema.",
+		Parameters:  schema.Schema,
+	}})
+	if params.Thinking.OfEnabled == nil {
+		params.ToolChoice = anthropic.BetaToolChoiceUnionParam{OfTool: &anthropic.BetaToolChoiceToolParam{Name: schema.Name}}
+	}
+
+	logger.Debug("Sending Anthropic beta structured output request", "model", modelConfig.BaseModel)
+	message, err := c.client.Beta.Messages.New(context.Background(), params)
+	if err != nil {
+		logger.Error("Anthropic request failed", "error", err)
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error:          newProviderLLMError(fmt.Sprintf("anthropic request failed: %s", err.Error()), err),
+			Metadata:       map[string]interface{}{"provider": "anthropic", "model": modelConfig.BaseModel},
+		}
+	}
+
+	structuredResponse := c.buildStructuredResponse(message, modelConfig)
+	if structuredResponse.Error != nil {
+		return structuredResponse
+	}
+
+	// The schema tool input is the answer; without a call the text is validated as is
+	for _, call := range structuredResponse.ToolCalls {
+		if call.Name == schema.Name {
+			structuredResponse.TextContent = call.Arguments
+			break
+		}
+	}
+	structuredResponse.ToolCalls = nil
+	return structuredResponse
+}
+
+// StreamStructuredCompletion sends a streaming chat completion request to Anthropic.
+// Text and thinking deltas are delivered to onChunk as they arrive, and the accumulated message
+// is returned as a structured response identical to SendStructuredCompletion.
+// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+func (c *AnthropicClient) StreamStructuredCompletion(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig, onChunk neurotypes.StreamHandler) *neurotypes.StructuredLLMResponse {
+	logger.Debug("Anthropic StreamStructuredCompletion starting", "model", modelConfig.BaseModel)
+
+	if err := c.initializeClientIfNeeded(); err != nil {
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error: &neurotypes.LLMError{
+				Code:    "client_initialization_failed",
+				Message: err.Error(),
+				Type:    "initialization_error",
+			},
+			Metadata: map[string]interface{}{"provider": "anthropic", "model": modelConfig.BaseModel},
+		}
+	}
+
+	params := c.buildMessageParams(session, modelConfig)
+
+	logger.Debug("Sending Anthropic beta streaming request", "model", modelConfig.BaseModel)
+	stream := c.client.Beta.Messages.NewStreaming(context.Background(), params)
+	defer func() { _ = stream.Close() }()
+
+	message := anthropic.BetaMessage{}
+	for stream.Next() {
+		event := stream.Current()
+		if err := message.Accumulate(event); err != nil {
+			logger.Error("Failed to accumulate Anthropic stream event", "error", err)
+			return &neurotypes.StructuredLLMResponse{
+				TextContent:    "",
+				ThinkingBlocks: []neurotypes.ThinkingBlock{},
+				Error: &neurotypes.LLMError{
+					Code:    "stream_processing_failed",
+					Message: err.Error(),
+					Type:    "response_error",
+				},
+				Metadata: map[string]interface{}{"provider": "anthropic", "model": modelConfig.BaseModel},
+			}
+		}
+
+		if event.Type != "content_block_delta" || onChunk == nil {
+			continue
+		}
+		switch event.Delta.Type {
+		case "text_delta":
+			onChunk(neurotypes.StreamChunk{Type: "text", Content: event.Delta.Text, Provider: "anthropic"})
+		case "thinking_delta":
+			onChunk(neurotypes.StreamChunk{Type: "thinking", Content: event.Delta.Thinking, Provider: "anthropic"})
+		}
+	}
+
+	if err := stream.Err(); err != nil {
+		logger.Error("Anthropic streaming request failed", "error", err)
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error:          newProviderLLMError(fmt.Sprintf("anthropic request failed: %s", err.Error()), err),
+			Metadata:       map[string]interface{}{"provider": "anthropic", "model": modelConfig.BaseModel},
+		}
+	}
+
+	structuredResponse := c.buildStructuredResponse(&message, modelConfig)
+	if structuredResponse.Error == nil {
+		logger.Debug("Anthropic streamed response received", "content_length", len(structuredResponse.TextContent), "thinking_blocks", len(structuredResponse.ThinkingBlocks))
+	}
 	return structuredResponse
 }
 
@@ -252,17 +457,28 @@ func (c *AnthropicClient) convertMessagesToAnthropic(session *neurotypes.ChatSes
 	messages := make([]anthropic.BetaMessageParam, 0, len(session.Messages))
 	var additionalSystemInstructions []string
 
+	// Tracks whether the last converted message is a user message holding tool results,
+	// so consecutive tool results are grouped into a single user turn as Anthropic requires
+	lastIsToolResults := false
+
 	for _, msg := range session.Messages {
+		isToolResult := msg.Role == "tool"
 		switch msg.Role {
 		case "user":
 			messages = append(messages, anthropic.NewBetaUserMessage(anthropic.NewBetaTextBlock(msg.Content)))
 		case "assistant":
 			messages = append(messages, anthropic.BetaMessageParam{
-				Role: anthropic.BetaMessageParamRoleAssistant,
-				Content: []anthropic.BetaContentBlockParamUnion{
-					anthropic.NewBetaTextBlock(msg.Content),
-				},
+				Role:    anthropic.BetaMessageParamRoleAssistant,
+				Content: anthropicAssistantBlocks(msg),
 			})
+		case "tool":
+			block := anthropicToolResultBlock(msg)
+			if lastIsToolResults {
+				last := &messages[len(messages)-1]
+				last.Content = append(last.Content, block)
+			} else {
+				messages = append(messages, anthropic.NewBetaUserMessage(block))
+			}
 		case "system":
 			// Collect system messages to combine with system prompt
 			additionalSystemInstructions = append(additionalSystemInstructions, msg.Content)
@@ -270,6 +486,7 @@ func (c *AnthropicClient) convertMessagesToAnthropic(session *neurotypes.ChatSes
 			// Skip unknown roles
 			continue
 		}
+		lastIsToolResults = isToolResult
 	}
 
 	// Combine additional system instructions into a single string
@@ -286,6 +503,121 @@ func (c *AnthropicClient) convertMessagesToAnthropic(session *neurotypes.ChatSes
 	return messages, combinedSystemInstructions
 }
 
+// anthropicAssistantBlocks converts an assistant message into content blocks, including tool_use blocks.
+// Signed thinking blocks stored with the message come first, as the API requires.
+func anthropicAssistantBlocks(msg neurotypes.Message) []anthropic.BetaContentBlockParamUnion {
+	blocks := anthropicThinkingBlocks(msg)
+	if len(msg.ToolCalls) == 0 {
+		return append(blocks, anthropic.NewBetaTextBlock(msg.Content))
+	}
+
+	if msg.Content != "" {
+		blocks = append(blocks, anthropic.NewBetaTextBlock(msg.Content))
+	}
+	for _, call := range msg.ToolCalls {
+		var input map[string]interface{}
+		if err := json.Unmarshal([]byte(call.Arguments), &input); err != nil || input == nil {
+			input = map[string]interface{}{}
+		}
+		blocks = append(blocks, anthropic.NewBetaToolUseBlock(call.ID, input, call.Name))
+	}
+	return blocks
+}
+
+// anthropicThinkingBlocks returns the Anthropic thinking blocks stored with an assistant message.
+// Blocks from other providers, or without the signature or data needed to verify them, are left out.
+func anthropicThinkingBlocks(msg neurotypes.Message) []anthropic.BetaContentBlockParamUnion {
+	if msg.Metadata == nil {
+		return nil
+	}
+
+	var blocks []anthropic.BetaContentBlockParamUnion
+	for _, block := range msg.Metadata.ThinkingBlocks {
+		if block.Provider != "anthropic" {
+			continue
+		}
+		switch {
+		case block.Type == "thinking" && block.Signature != "":
+			blocks = append(blocks, anthropic.NewBetaThinkingBlock(block.Signature, block.Content))
+		case block.Type == "redacted_thinking" && block.Data != "":
+			blocks = append(blocks, anthropic.NewBetaRedactedThinkingBlock(block.Data))
+		}
+	}
+	return blocks
+}
+
+// withoutThinkingBlocks removes replayed thinking blocks from assistant turns, for requests that
+// do not enable extended thinking.
+func withoutThinkingBlocks(messages []anthropic.BetaMessageParam) {
+	for i := range messages {
+		if messages[i].Role != anthropic.BetaMessageParamRoleAssistant {
+			continue
+		}
+		content := messages[i].Content[:0]
+		for _, block := range messages[i].Content {
+			if block.OfThinking == nil && block.OfRedactedThinking == nil {
+				content = append(content, block)
+			}
+		}
+		messages[i].Content = content
+	}
+}
+
+// anthropicToolResultBlock converts a tool result message into a tool_result content block.
+func anthropicToolResultBlock(msg neurotypes.Message) anthropic.BetaContentBlockParamUnion {
+	block := anthropic.NewBetaToolResultBlock(msg.ToolCallID)
+	block.OfToolResult.Content = []anthropic.BetaToolResultBlockParamContentUnion{
+		{OfText: &anthropic.BetaTextBlockParam{Text: msg.Content}},
+	}
+	return block
+}
+
+// anthropicToolParams converts tool definitions to Anthropic tool parameters.
+func anthropicToolParams(tools []neurotypes.ToolDefinition) []anthropic.BetaToolUnionParam {
+	params := make([]anthropic.BetaToolUnionParam, 0, len(tools))
+	for _, tool := range tools {
+		schema := anthropic.BetaToolInputSchemaParam{ExtraFields: map[string]any{}}
+		for key, value := range tool.Parameters {
+			switch key {
+			case "type":
+				// Always "object" for tool input schemas
+			case "properties":
+				schema.Properties = value
+			default:
+				schema.ExtraFields[key] = value
+			}
+		}
+
+		union := anthropic.BetaToolUnionParamOfTool(schema, tool.Name)
+		if tool.Description != "" {
+			union.OfTool.Description = anthropic.String(tool.Description)
+		}
+		params = append(params, union)
+	}
+	return params
+}
+
+// extractToolCalls collects tool_use blocks from an Anthropic response as tool calls.
+func (c *AnthropicClient) extractToolCalls(blocks []anthropic.BetaContentBlockUnion) []neurotypes.ToolCall {
+	var toolCalls []neurotypes.ToolCall
+	for _, block := range blocks {
+		toolUse := block.AsToolUse()
+		if toolUse.Type != "tool_use" {
+			continue
+		}
+		arguments := "{}"
+		if raw := toolUse.JSON.Input.Raw(); raw != "" {
+			arguments = raw
+		}
+		toolCalls = append(toolCalls, neurotypes.ToolCall{
+			ID:        toolUse.ID,
+			Name:      toolUse.Name,
+			Arguments: arguments,
+		})
+	}
+	return toolCalls
+}
+
 // applyModelParameters applies model configuration parameters to the Anthropic request.
 func (c *AnthropicClient) applyModelParameters(params *anthropic.BetaMessageNewParams, modelConfig *neurotypes.ModelConfig) {
 	if modelConfig.Parameters == nil {
@@ -438,9 +770,10 @@ func (c *AnthropicClient) processResponseBlocksStructured(blocks []anthropic.Bet
 		thinkingBlock := block.AsThinking()
 		if thinkingBlock.Type == "thinking" {
 			thinkingBlocks = append(thinkingBlocks, neurotypes.ThinkingBlock{
-				Content:  thinkingBlock.Thinking,
-				Provider: "anthropic",
-				Type:     "thinking",
+				Content:   thinkingBlock.Thinking,
+				Provider:  "anthropic",
+				Type:      "thinking",
+				Signature: thinkingBlock.Signature,
 			})
 			logger.Debug("Thinking block extracted for structured response", "thinking_length", len(thinkingBlock.Thinking))
 			continue
@@ -454,6 +787,7 @@ func (c *AnthropicClient) processResponseBlocksStructured(blocks []anthropic.Bet
 				Content:  "[Thinking content redacted by Anthropic]",
 				Provider: "anthropic",
 				Type:     "redacted_thinking",
+				Data:     redactedBlock.Data,
 			})
 			logger.Debug("Redacted thinking block extracted for structured response", "data_length", len(redactedBlock.Data))
 			continue
@@ -517,6 +517,7 @@ func TestAnthropicClient_MessageRoleConversion(t *testing.T) {
 // TestAnthropicClient_InterfaceCompliance verifies that AnthropicClient implements LLMClient interface
 func TestAnthropicClient_InterfaceCompliance(_ *testing.T) {
 	var _ neurotypes.LLMClient = &AnthropicClient{}
+	var _ neurotypes.StructuredOutputLLMClient = &AnthropicClient{}
 }
 
 func TestAnthropicClient_LazyInitialization(t *testing.T) {
@@ -703,3 +704,159 @@ func TestAnthropicClient_StructuredResponseInterface(t *testing.T) {
 	assert.Equal(t, "client_initialization_failed", response.Error.Code)
 	assert.Contains(t, response.Error.Message, "anthropic API key not configured")
 }
+
+func TestAnthropicClient_StreamStructuredCompletion(t *testing.T) {
+	events := `event: message_start
+data: {"type":"message_start","message":{"id":"msg_1","type":"message","role":"assistant","model":"claude-sonnet-4-0","content":[],"stop_reason":null,"usage":{"input_tokens":5,"output_tokens":1}}}
+
+event: content_block_start
+data: {"type":"content_block_start","index":0,"content_block":{"type":"thinking","thinking":"","signature":""}}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":0,"delta":{"type":"thinking_delta","thinking":"Let me "}}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":0,"delta":{"type":"thinking_delta","thinking":"think."}}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":0,"delta":{"type":"signature_delta","signature":"sig"}}
+
+event: content_block_stop
+data: {"type":"content_block_stop","index":0}
+
+event: content_block_start
+data: {"type":"content_block_start","index":1,"content_block":{"type":"text","text":""}}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":1,"delta":{"type":"text_delta","text":"Hello"}}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":1,"delta":{"type":"text_delta","text":" there"}}
+
+event: content_block_stop
+data: {"type":"content_block_stop","index":1}
+
+event: message_delta
+data: {"type":"message_delta","delta":{"stop_reason":"end_turn"},"usage":{"output_tokens":10}}
+
+event: message_stop
+data: {"type":"message_stop"}
+
+`
+	client := NewAnthropicClient("test-key")
+	client.SetDebugTransport(&sseTransport{events: events})
+
+	session := &neurotypes.ChatSession{
+		ID:       "test-session",
+		Messages: []neurotypes.Message{{Role: "user", Content: "Hi"}},
+	}
+	modelConfig := &neurotypes.ModelConfig{BaseModel: "claude-sonnet-4-0"}
+
+	var chunks []neurotypes.StreamChunk
+	response := client.StreamStructuredCompletion(session, modelConfig, func(chunk neurotypes.StreamChunk) {
+		chunks = append(chunks, chunk)
+	})
+
+	require.NotNil(t, response)
+	require.Nil(t, response.Error)
+	assert.Equal(t, "Hello there", response.TextContent)
+	require.Len(t, response.ThinkingBlocks, 1)
+	assert.Equal(t, "Let me think.", response.ThinkingBlocks[0].Content)
+	assert.Equal(t, "sig", response.ThinkingBlocks[0].Signature)
+
+	require.Len(t, chunks, 4)
+	assert.Equal(t, neurotypes.StreamChunk{Type: "thinking", Content: "Let me ", Provider: "anthropic"}, chunks[0])
+	assert.Equal(t, neurotypes.StreamChunk{Type: "text", Content: " there", Provider: "anthropic"}, chunks[3])
+}
+
+func TestAnthropicClient_ToolMessageConversion(t *testing.T) {
+	client := NewAnthropicClient("test-api-key")
+
+	session := &neurotypes.ChatSession{
+		Messages: []neurotypes.Message{
+			{Role: "user", Content: "Weather in Paris and Rome?"},
+			{Role: "assistant", ToolCalls: []neurotypes.ToolCall{
+				{ID: "call-1", Name: "weather", Arguments: `{"city":"Paris"}`},
+				{ID: "call-2", Name: "weather", Arguments: `{"city":"Rome"}`},
+			}},
+			{Role: "tool", ToolCallID: "call-1", Content: "Sunny"},
+			{Role: "tool", ToolCallID: "call-2", Content: "Rainy"},
+		},
+	}
+
+	messages, _ := client.convertMessagesToAnthropic(session)
+
+	// Consecutive tool results are grouped into a single user turn
+	require.Len(t, messages, 3)
+	assert.Equal(t, anthropic.BetaMessageParamRoleAssistant, messages[1].Role)
+	require.Len(t, messages[1].Content, 2, "empty text is omitted when tool calls are present")
+	require.NotNil(t, messages[1].Content[0].OfToolUse)
+	assert.Equal(t, "call-1", messages[1].Content[0].OfToolUse.ID)
+	assert.Equal(t, "weather", messages[1].Content[0].OfToolUse.Name)
+
+	assert.Equal(t, anthropic.BetaMessageParamRoleUser, messages[2].Role)
+	require.Len(t, messages[2].Content, 2)
+	require.NotNil(t, messages[2].Content[1].OfToolResult)
+	assert.Equal(t, "call-2", messages[2].Content[1].OfToolResult.ToolUseID)
+}
+
+func TestAnthropicClient_ThinkingBlockReplay(t *testing.T) {
+	client := NewAnthropicClient("test-api-key")
+
+	session := &neurotypes.ChatSession{
+		Messages: []neurotypes.Message{
+			{Role: "user", Content: "Weather in Paris?"},
+			{Role: "assistant", ToolCalls: []neurotypes.ToolCall{
+				{ID: "call-1", Name: "weather", Arguments: `{"city":"Paris"}`},
+			}, Metadata: &neurotypes.MessageMetadata{ThinkingBlocks: []neurotypes.ThinkingBlock{
+				{Content: "I should call the tool.", Provider: "anthropic", Type: "thinking", Signature: "sig-1"},
+				{Content: "[Thinking content redacted by Anthropic]", Provider: "anthropic", Type: "redacted_thinking", Data: "opaque"},
+				{Content: "unsigned", Provider: "anthropic", Type: "thinking"},
+				{Content: "from gemini", Provider: "gemini", Type: "thinking"},
+			}}},
+			{Role: "tool", ToolCallID: "call-1", Content: "Sunny"},
+		},
+	}
+
+	messages, _ := client.convertMessagesToAnthropic(session)
+
+	require.Len(t, messages, 3)
+	require.Len(t, messages[1].Content, 3, "only signed anthropic blocks are replayed")
+	require.NotNil(t, messages[1].Content[0].OfThinking)
+	assert.Equal(t, "sig-1", messages[1].Content[0].OfThinking.Signature)
+	assert.Equal(t, "I should call the tool.", messages[1].Content[0].OfThinking.Thinking)
+	require.NotNil(t, messages[1].Content[1].OfRedactedThinking)
+	assert.Equal(t, "opaque", messages[1].Content[1].OfRedactedThinking.Data)
+	require.NotNil(t, messages[1].Content[2].OfToolUse)
+
+	// Without a thinking budget the blocks are stripped from the request
+	params := client.buildMessageParams(session, &neurotypes.ModelConfig{BaseModel: "claude-sonnet-4-0"})
+	require.Len(t, params.Messages[1].Content, 1)
+	assert.NotNil(t, params.Messages[1].Content[0].OfToolUse)
+
+	params = client.buildMessageParams(session, &neurotypes.ModelConfig{
+		BaseModel:  "claude-sonnet-4-0",
+		Parameters: map[string]any{"thinking_budget": 1024},
+	})
+	require.Len(t, params.Messages[1].Content, 3)
+	assert.NotNil(t, params.Messages[1].Content[0].OfThinking)
+}
+
+func TestAnthropicToolParams(t *testing.T) {
+	params := anthropicToolParams([]neurotypes.ToolDefinition{{
+		Name:        "weather",
+		Description: "Get the weather",
+		Parameters: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{"city": map[string]interface{}{"type": "string"}},
+			"required":   []interface{}{"city"},
+		},
+	}})
+
+	require.Len(t, params, 1)
+	require.NotNil(t, params[0].OfTool)
+	assert.Equal(t, "weather", params[0].OfTool.Name)
+	assert.Equal(t, "Get the weather", params[0].OfTool.Description.Value)
+	assert.Contains(t, params[0].OfTool.InputSchema.Properties, "city")
+	assert.Equal(t, []interface{}{"city"}, params[0].OfTool.InputSchema.ExtraFields["required"])
+}
@@ -0,0 +1,516 @@
+// Package services provides spending budget enforcement for NeuroShell.
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	neuroshellcontext "neuroshell/internal/context"
+	"neuroshell/internal/logger"
+	"neuroshell/pkg/neurotypes"
+)
+
+// Budget files in the user config directory.
+const (
+	budgetLedgerFile = "budget.json"
+	budgetCapsFile   = "budget_caps.json"
+	budgetDateLayout = "2006-01-02"
+)
+
+// defaultBudgetOutputTokens is the output length assumed for models without max_tokens and
+// without a catalog max_output_tokens. Set max_tokens on the model to estimate more tightly.
+const defaultBudgetOutputTokens = 4096
+
+// Ledger lock timing: how long to wait for another process, and when a lock is considered abandoned.
+const (
+	budgetLockTimeout = 5 * time.Second
+	budgetLockStale   = 30 * time.Second
+	budgetLockPoll    = 10 * time.Millisecond
+)
+
+// budgetLedger is the persisted record of today's spend, shared by all shell processes.
+// Updates hold budget.json.lock and replace the file atomically, so parallel runs do not lose spend.
+type budgetLedger struct {
+	Date     string  `json:"date"`
+	SpentUSD float64 `json:"spent_usd"`
+}
+
+// BudgetCaps are the spending caps in USD, persisted in budget_caps.json in the user config
+// directory. A cap of 0 means no cap.
+type BudgetCaps struct {
+	SessionUSD float64 `json:"_budget_session_usd"`
+	DailyUSD   float64 `json:"_budget_daily_usd"`
+}
+
+// BudgetService enforces spending caps on LLM requests.
+// The session cap applies to the cost recorded for a chat session in this shell, and the
+// daily cap applies to the spend of all shells on the current day, kept in budget.json in
+// the user config directory. Caps are set with \budget and kept next to the ledger, so
+// scripts cannot lift them. Test mode keeps the ledger and the caps in memory.
+type BudgetService struct {
+	initialized bool
+	ledger      budgetLedger
+	caps        BudgetCaps
+	mutex       sync.Mutex
+}
+
+// NewBudgetService creates a new BudgetService instance.
+func NewBudgetService() *BudgetService {
+	return &BudgetService{
+		initialized: false,
+	}
+}
+
+// Name returns the service name "budget" for registration.
+func (b *BudgetService) Name() string {
+	return "budget"
+}
+
+// Initialize sets up the BudgetService for operation.
+func (b *BudgetService) Initialize() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.initialized = true
+	logger.Debug("BudgetService initialized")
+	return nil
+}
+
+// Check returns an error response when sending the request would exceed a budget cap.
+// The request cost is estimated from the prompt size and a worst-case output length,
+// using the model's catalog pricing. While a cap is set, models without catalog pricing are
+// blocked unless they are served locally, since their spend could not be counted.
+// Returns nil when the request may proceed.
+func (b *BudgetService) Check(session *neurotypes.ChatSession, model *neurotypes.ModelConfig) *neurotypes.StructuredLLMResponse {
+	if !b.initialized || session == nil {
+		return nil
+	}
+
+	caps, err := b.GetCaps()
+	if err != nil {
+		return budgetErrorResponse("invalid_budget", err.Error(), "client_error")
+	}
+	sessionCap, dailyCap := caps.SessionUSD, caps.DailyUSD
+	if sessionCap == 0 && dailyCap == 0 {
+		return nil
+	}
+
+	entry := lookupModelEntry(model)
+	estimate := EstimateRequestCost(session, model, entry)
+
+	if sessionCap > 0 {
+		var spent float64
+		if usageService, err := GetGlobalUsageService(); err == nil {
+			spent = usageService.GetSessionTotals(session.ID).CostUSD
+		}
+		if spent+estimate > sessionCap {
+			return budgetErrorResponse("budget_exceeded", fmt.Sprintf(
+				"session budget exceeded: spent $%.6f + estimated $%.6f would exceed the session cap of $%.6f",
+				spent, estimate, sessionCap), "budget_exceeded")
+		}
+	}
+
+	if dailyCap > 0 {
+		spent := b.GetDailySpend()
+		if spent+estimate > dailyCap {
+			return budgetErrorResponse("budget_exceeded", fmt.Sprintf(
+				"daily budget exceeded: spent $%.6f + estimated $%.6f would exceed the daily cap of $%.6f",
+				spent, estimate, dailyCap), "budget_exceeded")
+		}
+	}
+
+	// Without pricing the spend of the call cannot be counted, so a cap could be passed silently
+	if !hasPricing(entry) && !isLocalModel(entry) {
+		modelName := "unknown"
+		if model != nil {
+			modelName = model.Name
+		}
+		return budgetErrorResponse("unpriced_model", fmt.Sprintf(
+			"cannot enforce budget: model '%s' has no catalog pricing; add pricing to its catalog entry or clear the caps with \\budget",
+			modelName), "budget_exceeded")
+	}
+
+	return nil
+}
+
+// RecordSpend adds the cost of a completed request to today's ledger.
+func (b *BudgetService) RecordSpend(cost float64) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if !b.initialized {
+		return fmt.Errorf("budget service not initialized")
+	}
+	if cost <= 0 {
+		return nil
+	}
+
+	path, persisted := budgetFilePath(budgetLedgerFile)
+	if !persisted {
+		b.refreshLedgerDate()
+		b.ledger.SpentUSD += cost
+		return nil
+	}
+
+	// Hold the lock across load-add-save so concurrent shells add to the same total
+	unlock, err := lockBudgetLedger(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	b.loadLedger()
+	b.ledger.SpentUSD += cost
+	return b.saveLedger()
+}
+
+// GetDailySpend returns the spend recorded for the current day.
+func (b *BudgetService) GetDailySpend() float64 {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.loadLedger()
+	return b.ledger.SpentUSD
+}
+
+// GetCaps returns the current spending caps. Caps are re-read from disk so that caps set by
+// another shell apply right away.
+func (b *BudgetService) GetCaps() (BudgetCaps, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	path, ok := budgetFilePath(budgetCapsFile)
+	if !ok {
+		return b.caps, nil
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return BudgetCaps{}, nil
+	}
+	if err != nil {
+		return BudgetCaps{}, fmt.Errorf("failed to read budget caps: %w", err)
+	}
+	var caps BudgetCaps
+	if err := json.Unmarshal(data, &caps); err != nil {
+		return BudgetCaps{}, fmt.Errorf("invalid budget caps in %s: %w", path, err)
+	}
+	if caps.SessionUSD < 0 || caps.DailyUSD < 0 {
+		return BudgetCaps{}, fmt.Errorf("invalid budget caps in %s: caps must not be negative", path)
+	}
+	b.caps = caps
+	return caps, nil
+}
+
+// SetCaps replaces the spending caps and persists them in the user config directory.
+func (b *BudgetService) SetCaps(caps BudgetCaps) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if !b.initialized {
+		return fmt.Errorf("budget service not initialized")
+	}
+	if caps.SessionUSD < 0 || caps.DailyUSD < 0 {
+		return fmt.Errorf("budget caps must not be negative")
+	}
+
+	b.caps = caps
+	path, ok := budgetFilePath(budgetCapsFile)
+	if !ok {
+		return nil
+	}
+	data, err := json.MarshalIndent(caps, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode budget caps: %w", err)
+	}
+	if err := writeBudgetFile(path, data); err != nil {
+		return fmt.Errorf("failed to write budget caps: %w", err)
+	}
+	return nil
+}
+
+// ParseBudgetAmount parses a cap in USD such as "5", "$2.50" or "0".
+// An empty value or "none" clears the cap and is returned as 0.
+func ParseBudgetAmount(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.EqualFold(value, "none") {
+		return 0, nil
+	}
+	amount, err := strconv.ParseFloat(strings.TrimPrefix(value, "$"), 64)
+	if err != nil || amount < 0 {
+		return 0, fmt.Errorf("'%s' is not a non-negative amount in USD", value)
+	}
+	return amount, nil
+}
+
+// loadLedger refreshes the ledger from disk and starts a new one when the day has changed.
+// Must be called with the mutex held.
+func (b *BudgetService) loadLedger() {
+	if path, ok := budgetFilePath(budgetLedgerFile); ok {
+		if data, err := os.ReadFile(path); err == nil {
+			var stored budgetLedger
+			if err := json.Unmarshal(data, &stored); err == nil {
+				b.ledger = stored
+			} else {
+				logger.Debug("Ignoring unreadable budget ledger", "path", path, "error", err)
+			}
+		}
+	}
+	b.refreshLedgerDate()
+}
+
+// refreshLedgerDate starts a new ledger when the day has changed.
+// Must be called with the mutex held.
+func (b *BudgetService) refreshLedgerDate() {
+	today := time.Now().Format(budgetDateLayout)
+	if b.ledger.Date != today {
+		b.ledger = budgetLedger{Date: today}
+	}
+}
+
+// saveLedger persists the ledger to the user config directory.
+// Must be called with the mutex and the ledger lock held.
+func (b *BudgetService) saveLedger() error {
+	path, ok := budgetFilePath(budgetLedgerFile)
+	if !ok {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(b.ledger, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode budget ledger: %w", err)
+	}
+	if err := writeBudgetFile(path, data); err != nil {
+		return fmt.Errorf("failed to write budget ledger: %w", err)
+	}
+	return nil
+}
+
+// writeBudgetFile writes a budget file to a temporary file and renames it, so readers never
+// see a partial file.
+func writeBudgetFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tempFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tempPath := tempFile.Name()
+	_, writeErr := tempFile.Write(data)
+	closeErr := tempFile.Close()
+	if writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr == nil {
+		writeErr = os.Chmod(tempPath, 0644)
+	}
+	if writeErr == nil {
+		writeErr = os.Rename(tempPath, path)
+	}
+	if writeErr != nil {
+		_ = os.Remove(tempPath)
+	}
+	return writeErr
+}
+
+// lockBudgetLedger acquires the ledger lock file shared by all shell processes and returns
+// a function that releases it. Locks older than budgetLockStale are assumed to be left behind
+// by a crashed process and are removed.
+func lockBudgetLedger(path string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(budgetLockTimeout)
+	for {
+		lockFile, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_, _ = fmt.Fprintf(lockFile, "%d\n", os.Getpid())
+			_ = lockFile.Close()
+			return func() { _ = os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to lock budget ledger: %w", err)
+		}
+
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > budgetLockStale {
+			logger.Debug("Removing stale budget ledger lock", "path", lockPath)
+			_ = os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("failed to lock budget ledger: %s is held by another process", lockPath)
+		}
+		time.Sleep(budgetLockPoll)
+	}
+}
+
+// budgetFilePath returns the location of a budget file, or false when budget state is kept in memory.
+func budgetFilePath(name string) (string, bool) {
+	ctx := neuroshellcontext.GetGlobalContext()
+	if ctx == nil || ctx.IsTestMode() {
+		return "", false
+	}
+	configDir, err := ctx.GetUserConfigDir()
+	if err != nil {
+		return "", false
+	}
+	return filepath.Join(configDir, name), true
+}
+
+// EstimateRequestCost estimates the worst-case cost of a request. Input is counted at roughly
+// four characters per token; output is assumed to use the whole output limit, taken from the
+// model's max_tokens (or max_completion_tokens) parameter, else the catalog max_output_tokens,
+// else defaultBudgetOutputTokens.
+func EstimateRequestCost(session *neurotypes.ChatSession, model *neurotypes.ModelConfig, entry *neurotypes.ModelCatalogEntry) float64 {
+	if entry == nil {
+		return 0
+	}
+	characters := len(session.SystemPrompt)
+	for _, msg := range session.Messages {
+		characters += len(msg.Content)
+	}
+	usage := neurotypes.TokenUsage{
+		InputTokens:  int64(characters / 4),
+		OutputTokens: int64(maxOutputTokens(model, entry)),
+	}
+	cost, _ := CalculateCost(usage, entry.Pricing)
+	return cost
+}
+
+// maxOutputTokens returns the most output tokens a request can produce.
+func maxOutputTokens(model *neurotypes.ModelConfig, entry *neurotypes.ModelCatalogEntry) int {
+	if model != nil {
+		for _, name := range []string{"max_tokens", "max_completion_tokens"} {
+			if limit, ok := intParameter(model.Parameters[name]); ok && limit > 0 {
+				return limit
+			}
+		}
+	}
+	if entry.MaxOutputTokens != nil {
+		return *entry.MaxOutputTokens
+	}
+	return defaultBudgetOutputTokens
+}
+
+// intParameter converts a model parameter value to an int.
+func intParameter(value interface{}) (int, bool) {
+	switch typed := value.(type) {
+	case int:
+		return typed, true
+	case int64:
+		return int(typed), true
+	case float64:
+		return int(typed), true
+	case string:
+		limit, err := strconv.Atoi(strings.TrimSpace(typed))
+		return limit, err == nil
+	}
+	return 0, false
+}
+
+// hasPricing reports whether a catalog entry has non-zero pricing.
+func hasPricing(entry *neurotypes.ModelCatalogEntry) bool {
+	return entry != nil && entry.Pricing != nil && (entry.Pricing.InputPerMToken > 0 || entry.Pricing.OutputPerMToken > 0)
+}
+
+// isLocalModel reports whether a model is served by the mock provider or an OpenAI-compatible
+// server on this machine, whose calls cost nothing and need no pricing.
+func isLocalModel(entry *neurotypes.ModelCatalogEntry) bool {
+	if entry == nil || entry.ProviderCatalogID == "" {
+		return false
+	}
+	providerCatalogService, err := GetGlobalProviderCatalogService()
+	if err != nil {
+		return false
+	}
+	provider, err := providerCatalogService.GetProviderByID(entry.ProviderCatalogID)
+	if err != nil {
+		return false
+	}
+	if provider.ClientType == MockClientType {
+		return true
+	}
+	if !provider.IsOpenAICompatible() {
+		return false
+	}
+	parsed, err := url.Parse(provider.BaseURL)
+	if err != nil {
+		return false
+	}
+	host := parsed.Hostname()
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// lookupModelEntry returns the catalog entry of a model, or nil when unknown.
+func lookupModelEntry(model *neurotypes.ModelConfig) *neurotypes.ModelCatalogEntry {
+	if model == nil || model.CatalogID == "" {
+		return nil
+	}
+	catalogService, err := GetGlobalModelCatalogService()
+	if err != nil {
+		return nil
+	}
+	entry, err := catalogService.GetModelByID(model.CatalogID)
+	if err != nil {
+		return nil
+	}
+	return &entry
+}
+
+// budgetErrorResponse builds the structured response returned for a blocked request.
+func budgetErrorResponse(code string, message string, errorType string) *neurotypes.StructuredLLMResponse {
+	logger.Debug("LLM request blocked by budget", "code", code, "message", message)
+	return &neurotypes.StructuredLLMResponse{
+		TextContent:    "",
+		ThinkingBlocks: []neurotypes.ThinkingBlock{},
+		Error: &neurotypes.LLMError{
+			Code:    code,
+			Message: message,
+			Type:    errorType,
+		},
+		Metadata: map[string]interface{}{"service": "budget"},
+	}
+}
+
+// checkBudget runs the budget check when a budget service is registered.
+func checkBudget(session *neurotypes.ChatSession, model *neurotypes.ModelConfig) *neurotypes.StructuredLLMResponse {
+	budgetService, err := GetGlobalBudgetService()
+	if err != nil {
+		return nil
+	}
+	return budgetService.Check(session, model)
+}
+
+// GetBudgetService retrieves the budget service with proper type casting.
+func (r *Registry) GetBudgetService() (*BudgetService, error) {
+	service, err := r.GetService("budget")
+	if err != nil {
+		return nil, err
+	}
+
+	budgetService, ok := service.(*BudgetService)
+	if !ok {
+		return nil, fmt.Errorf("budget service has incorrect type")
+	}
+
+	return budgetService, nil
+}
+
+// GetGlobalBudgetService returns the budget service from the global registry.
+func GetGlobalBudgetService() (*BudgetService, error) {
+	return GetGlobalRegistry().GetBudgetService()
+}
@@ -0,0 +1,277 @@
+package services
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/internal/context"
+	"neuroshell/pkg/neurotypes"
+)
+
+// setupBudgetTestRegistry registers the services the budget check reads from.
+func setupBudgetTestRegistry(t *testing.T, testMode bool) (*BudgetService, *VariableService, *UsageService) {
+	oldServiceRegistry := GetGlobalRegistry()
+	SetGlobalRegistry(NewRegistry())
+
+	context.ResetGlobalContext()
+	ctx := context.GetGlobalContext()
+	ctx.SetTestMode(testMode)
+
+	t.Cleanup(func() {
+		SetGlobalRegistry(oldServiceRegistry)
+		context.ResetGlobalContext()
+	})
+
+	variableService := NewVariableService()
+	usageService := NewUsageService()
+	budgetService := NewBudgetService()
+	for _, service := range []neurotypes.Service{variableService, usageService, budgetService, NewModelCatalogService(), NewProviderCatalogService()} {
+		require.NoError(t, GetGlobalRegistry().RegisterService(service))
+		require.NoError(t, service.Initialize())
+	}
+	return budgetService, variableService, usageService
+}
+
+// budgetTestModel is a catalog model with pricing and a small output limit, so estimates stay tiny.
+var budgetTestModel = &neurotypes.ModelConfig{Name: "m1", CatalogID: "O4MC", Parameters: map[string]any{"max_completion_tokens": 1}}
+
+func TestBudgetService_Basic(t *testing.T) {
+	service := NewBudgetService()
+	assert.Equal(t, "budget", service.Name())
+
+	assert.Error(t, service.RecordSpend(1), "record should fail before initialization")
+	assert.Nil(t, service.Check(&neurotypes.ChatSession{ID: "s1"}, nil), "uninitialized service should not block")
+
+	require.NoError(t, service.Initialize())
+}
+
+func TestBudgetService_NoCaps(t *testing.T) {
+	budgetService, _, usageService := setupBudgetTestRegistry(t, true)
+
+	require.NoError(t, usageService.Record("s1", "m1", neurotypes.TokenUsage{InputTokens: 10}, 100, true))
+	require.NoError(t, budgetService.RecordSpend(100))
+
+	assert.Nil(t, budgetService.Check(&neurotypes.ChatSession{ID: "s1"}, nil))
+}
+
+func TestBudgetService_SessionCap(t *testing.T) {
+	budgetService, _, usageService := setupBudgetTestRegistry(t, true)
+	require.NoError(t, budgetService.SetCaps(BudgetCaps{SessionUSD: 1}))
+
+	session := &neurotypes.ChatSession{ID: "s1"}
+	assert.Nil(t, budgetService.Check(session, budgetTestModel))
+
+	require.NoError(t, usageService.Record("s1", "m1", neurotypes.TokenUsage{InputTokens: 10}, 1.5, true))
+	response := budgetService.Check(session, budgetTestModel)
+	require.NotNil(t, response)
+	require.NotNil(t, response.Error)
+	assert.Equal(t, "budget_exceeded", response.Error.Type)
+	assert.Equal(t, "budget_exceeded", response.Error.Code)
+	assert.Contains(t, response.Error.Message, "session budget exceeded")
+
+	// Other sessions have their own spend
+	assert.Nil(t, budgetService.Check(&neurotypes.ChatSession{ID: "s2"}, budgetTestModel))
+}
+
+func TestBudgetService_DailyCap(t *testing.T) {
+	budgetService, _, _ := setupBudgetTestRegistry(t, true)
+	require.NoError(t, budgetService.SetCaps(BudgetCaps{DailyUSD: 2}))
+
+	session := &neurotypes.ChatSession{ID: "s1"}
+	require.NoError(t, budgetService.RecordSpend(1.5))
+	assert.Nil(t, budgetService.Check(session, budgetTestModel))
+	assert.InDelta(t, 1.5, budgetService.GetDailySpend(), 1e-9)
+
+	require.NoError(t, budgetService.RecordSpend(0.75))
+	response := budgetService.Check(session, budgetTestModel)
+	require.NotNil(t, response)
+	assert.Equal(t, "budget_exceeded", response.Error.Type)
+	assert.Contains(t, response.Error.Message, "daily budget exceeded")
+}
+
+func TestBudgetService_UnpricedModel(t *testing.T) {
+	budgetService, _, _ := setupBudgetTestRegistry(t, true)
+	session := &neurotypes.ChatSession{ID: "s1"}
+
+	// Without caps any model may be used
+	assert.Nil(t, budgetService.Check(session, &neurotypes.ModelConfig{Name: "custom"}))
+
+	// With a cap, spend of unpriced models could not be counted
+	require.NoError(t, budgetService.SetCaps(BudgetCaps{DailyUSD: 5}))
+	response := budgetService.Check(session, &neurotypes.ModelConfig{Name: "custom"})
+	require.NotNil(t, response)
+	assert.Equal(t, "unpriced_model", response.Error.Code)
+	assert.Equal(t, "budget_exceeded", response.Error.Type)
+	assert.Contains(t, response.Error.Message, "'custom' has no catalog pricing")
+
+	// Models of local OpenAI-compatible servers cost nothing
+	assert.Nil(t, budgetService.Check(session, &neurotypes.ModelConfig{Name: "local", CatalogID: "OLM"}))
+	assert.Nil(t, budgetService.Check(session, budgetTestModel))
+}
+
+func TestBudgetService_InvalidCap(t *testing.T) {
+	budgetService, _, _ := setupBudgetTestRegistry(t, true)
+	assert.Error(t, budgetService.SetCaps(BudgetCaps{SessionUSD: -1}))
+
+	for _, value := range []string{"lots", "-1", "$"} {
+		_, err := ParseBudgetAmount(value)
+		assert.Error(t, err, "value %q", value)
+	}
+	for value, expected := range map[string]float64{"": 0, "none": 0, "0": 0, "$2.50": 2.5, " 5 ": 5} {
+		amount, err := ParseBudgetAmount(value)
+		require.NoError(t, err, "value %q", value)
+		assert.Equal(t, expected, amount, "value %q", value)
+	}
+}
+
+func TestBudgetService_CapsPersistence(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	budgetService, variableService, usageService := setupBudgetTestRegistry(t, false)
+
+	require.NoError(t, budgetService.SetCaps(BudgetCaps{SessionUSD: 1, DailyUSD: 10}))
+	capsPath := filepath.Join(configHome, "neuroshell", "budget_caps.json")
+	data, err := os.ReadFile(capsPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"_budget_session_usd": 1`)
+
+	// A fresh service, like a later batch run, enforces the same caps
+	other := NewBudgetService()
+	require.NoError(t, other.Initialize())
+	caps, err := other.GetCaps()
+	require.NoError(t, err)
+	assert.Equal(t, BudgetCaps{SessionUSD: 1, DailyUSD: 10}, caps)
+
+	// Scripts cannot lift the caps through variables
+	assert.Error(t, variableService.Set("_budget_session_usd", "1000"))
+	require.NoError(t, usageService.Record("s1", "m1", neurotypes.TokenUsage{}, 1.5, true))
+	response := other.Check(&neurotypes.ChatSession{ID: "s1"}, budgetTestModel)
+	require.NotNil(t, response)
+	assert.Equal(t, "budget_exceeded", response.Error.Type)
+
+	// An unreadable caps file blocks calls instead of silently allowing spend
+	require.NoError(t, os.WriteFile(capsPath, []byte("{"), 0644))
+	response = other.Check(&neurotypes.ChatSession{ID: "s2"}, budgetTestModel)
+	require.NotNil(t, response)
+	assert.Equal(t, "invalid_budget", response.Error.Code)
+}
+
+func TestBudgetService_LedgerPersistence(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	budgetService, _, _ := setupBudgetTestRegistry(t, false)
+
+	require.NoError(t, budgetService.RecordSpend(0.25))
+	require.NoError(t, budgetService.RecordSpend(0.5))
+
+	data, err := os.ReadFile(filepath.Join(configHome, "neuroshell", "budget.json"))
+	require.NoError(t, err)
+	var ledger budgetLedger
+	require.NoError(t, json.Unmarshal(data, &ledger))
+	assert.Equal(t, time.Now().Format(budgetDateLayout), ledger.Date)
+	assert.InDelta(t, 0.75, ledger.SpentUSD, 1e-9)
+
+	// A fresh service, like a later batch run, sees the same daily spend
+	other := NewBudgetService()
+	require.NoError(t, other.Initialize())
+	assert.InDelta(t, 0.75, other.GetDailySpend(), 1e-9)
+
+	// Spend from a previous day does not count
+	stale, err := json.Marshal(budgetLedger{Date: "2000-01-01", SpentUSD: 99})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(configHome, "neuroshell", "budget.json"), stale, 0644))
+	assert.Equal(t, 0.0, other.GetDailySpend())
+}
+
+func TestBudgetService_LedgerConcurrentProcesses(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	setupBudgetTestRegistry(t, false)
+
+	// Separate service instances stand in for parallel shell processes sharing the ledger file
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			process := NewBudgetService()
+			assert.NoError(t, process.Initialize())
+			for j := 0; j < 10; j++ {
+				assert.NoError(t, process.RecordSpend(0.01))
+			}
+		}()
+	}
+	wg.Wait()
+
+	reader := NewBudgetService()
+	require.NoError(t, reader.Initialize())
+	assert.InDelta(t, 0.8, reader.GetDailySpend(), 1e-9)
+	_, err := os.Stat(filepath.Join(configHome, "neuroshell", "budget.json.lock"))
+	assert.True(t, os.IsNotExist(err), "lock file should be released")
+}
+
+func TestBudgetService_LedgerStaleLock(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	budgetService, _, _ := setupBudgetTestRegistry(t, false)
+
+	lockPath := filepath.Join(configHome, "neuroshell", "budget.json.lock")
+	require.NoError(t, os.MkdirAll(filepath.Dir(lockPath), 0755))
+	require.NoError(t, os.WriteFile(lockPath, []byte("1\n"), 0644))
+	old := time.Now().Add(-2 * budgetLockStale)
+	require.NoError(t, os.Chtimes(lockPath, old, old))
+
+	require.NoError(t, budgetService.RecordSpend(0.5))
+	assert.InDelta(t, 0.5, budgetService.GetDailySpend(), 1e-9)
+}
+
+func TestEstimateRequestCost(t *testing.T) {
+	session := &neurotypes.ChatSession{
+		SystemPrompt: "1234",
+		Messages:     []neurotypes.Message{{Content: "12345678"}},
+	}
+	maxOutput := 1000
+	entry := &neurotypes.ModelCatalogEntry{
+		ContextWindow:   8000,
+		MaxOutputTokens: &maxOutput,
+		Pricing:         &neurotypes.ModelPricing{InputPerMToken: 1_000_000, OutputPerMToken: 1_000},
+	}
+
+	// 3 input tokens plus the catalog output limit
+	assert.InDelta(t, 4.0, EstimateRequestCost(session, nil, entry), 1e-9)
+
+	// max_tokens on the model lowers the worst case
+	model := &neurotypes.ModelConfig{Parameters: map[string]any{"max_tokens": 100}}
+	assert.InDelta(t, 3.1, EstimateRequestCost(session, model, entry), 1e-9)
+	model = &neurotypes.ModelConfig{Parameters: map[string]any{"max_completion_tokens": "500"}}
+	assert.InDelta(t, 3.5, EstimateRequestCost(session, model, entry), 1e-9)
+
+	// Without max_output_tokens the default output length is assumed, not the context window
+	entry.MaxOutputTokens = nil
+	assert.InDelta(t, 3+float64(defaultBudgetOutputTokens)/1000, EstimateRequestCost(session, nil, entry), 1e-9)
+
+	assert.Equal(t, 0.0, EstimateRequestCost(session, nil, nil))
+	assert.Equal(t, 0.0, EstimateRequestCost(session, nil, &neurotypes.ModelCatalogEntry{ContextWindow: 8000}))
+}
+
+func TestMockLLMService_BudgetExceeded(t *testing.T) {
+	budgetService, _, usageService := setupBudgetTestRegistry(t, true)
+	require.NoError(t, budgetService.SetCaps(BudgetCaps{SessionUSD: 0.01}))
+	require.NoError(t, usageService.Record("s1", "m1", neurotypes.TokenUsage{}, 0.02, true))
+
+	llmService := NewMockLLMService()
+	require.NoError(t, llmService.Initialize())
+
+	session := &neurotypes.ChatSession{ID: "s1", Messages: []neurotypes.Message{{Role: "user", Content: "hi"}}}
+	response := llmService.SendStructuredCompletion(nil, session, &neurotypes.ModelConfig{BaseModel: "mock"})
+	require.NotNil(t, response.Error)
+	assert.Equal(t, "budget_exceeded", response.Error.Type)
+	assert.Empty(t, response.TextContent)
+}
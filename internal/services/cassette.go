@@ -0,0 +1,301 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	"neuroshell/internal/logger"
+)
+
+// CassetteMode selects whether HTTP traffic is written to or served from a cassette file.
+type CassetteMode string
+
+const (
+	// CassetteModeRecord performs real requests and writes every request/response pair to the cassette.
+	CassetteModeRecord CassetteMode = "record"
+	// CassetteModeReplay serves requests from the cassette without network access.
+	CassetteModeReplay CassetteMode = "replay"
+)
+
+// ParseCassetteMode validates a --cassette-mode value.
+func ParseCassetteMode(value string) (CassetteMode, error) {
+	switch CassetteMode(strings.ToLower(strings.TrimSpace(value))) {
+	case CassetteModeRecord:
+		return CassetteModeRecord, nil
+	case CassetteModeReplay:
+		return CassetteModeReplay, nil
+	default:
+		return "", fmt.Errorf("invalid cassette mode '%s': must be 'record' or 'replay'", value)
+	}
+}
+
+// Cassette is the on-disk format of recorded HTTP traffic.
+type Cassette struct {
+	Interactions []CassetteInteraction `json:"interactions"`
+}
+
+// CassetteInteraction is a single recorded request/response pair.
+type CassetteInteraction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest is the recorded part of an HTTP request.
+// Replay matches on method, URL and body; headers are kept for reproducing bug reports.
+type CassetteRequest struct {
+	Method  string              `json:"method"`
+	URL     string              `json:"url"`
+	Headers map[string][]string `json:"headers,omitempty"`
+	Body    CassetteBody        `json:"body,omitempty"`
+}
+
+// CassetteResponse is the recorded HTTP response, including the raw body of streaming responses.
+type CassetteResponse struct {
+	StatusCode int                 `json:"status_code"`
+	Headers    map[string][]string `json:"headers,omitempty"`
+	Body       CassetteBody        `json:"body,omitempty"`
+}
+
+// CassetteBody holds an HTTP body. JSON bodies are stored inline so cassettes stay readable,
+// anything else (such as server-sent event streams) is stored as a JSON string.
+type CassetteBody []byte
+
+// MarshalJSON writes JSON bodies inline and other bodies as strings.
+func (b CassetteBody) MarshalJSON() ([]byte, error) {
+	if len(b) == 0 {
+		return []byte(`""`), nil
+	}
+	if json.Valid(b) {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, b); err == nil {
+			return compact.Bytes(), nil
+		}
+	}
+	return json.Marshal(string(b))
+}
+
+// UnmarshalJSON reads a body written by MarshalJSON.
+func (b *CassetteBody) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*b = CassetteBody(text)
+		return nil
+	}
+	*b = append((*b)[:0], data...)
+	return nil
+}
+
+// cassetteState holds the loaded cassette and which interactions replay has already served.
+type cassetteState struct {
+	path     string
+	mode     CassetteMode
+	cassette Cassette
+	used     []bool
+	mutex    sync.Mutex
+}
+
+// loadCassette prepares a cassette for the given mode. Replay requires an existing file,
+// record starts a fresh cassette that is written as interactions complete.
+func loadCassette(path string, mode CassetteMode) (*cassetteState, error) {
+	state := &cassetteState{path: path, mode: mode}
+	if mode == CassetteModeRecord {
+		return state, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	if err := json.Unmarshal(data, &state.cassette); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+	state.used = make([]bool, len(state.cassette.Interactions))
+	return state, nil
+}
+
+// replay returns the first unused interaction matching the request.
+func (s *cassetteState) replay(req *http.Request, body []byte) (*http.Response, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	requestURL := sanitizeCassetteURL(req.URL)
+	for i, interaction := range s.cassette.Interactions {
+		if s.used[i] || !strings.EqualFold(interaction.Request.Method, req.Method) ||
+			interaction.Request.URL != requestURL || !cassetteBodiesMatch(interaction.Request.Body, body) {
+			continue
+		}
+		s.used[i] = true
+
+		recorded := interaction.Response
+		header := http.Header{}
+		for name, values := range recorded.Headers {
+			header[http.CanonicalHeaderKey(name)] = values
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+			StatusCode:    recorded.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(recorded.Body)),
+			ContentLength: int64(len(recorded.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("cassette %s has no recorded response for %s %s", s.path, req.Method, requestURL)
+}
+
+// record appends an interaction and rewrites the cassette file so a crash keeps earlier traffic.
+func (s *cassetteState) record(interaction CassetteInteraction) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.cassette.Interactions = append(s.cassette.Interactions, interaction)
+
+	data, err := json.MarshalIndent(s.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal cassette: %w", err)
+	}
+	if dir := filepath.Dir(s.path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create cassette directory: %w", err)
+		}
+	}
+	return os.WriteFile(s.path, append(data, '\n'), 0644)
+}
+
+// cassetteBodiesMatch compares request bodies, treating JSON bodies as equal when their values are.
+func cassetteBodiesMatch(recorded, actual []byte) bool {
+	if bytes.Equal(recorded, actual) {
+		return true
+	}
+	var recordedValue, actualValue interface{}
+	if json.Unmarshal(recorded, &recordedValue) != nil || json.Unmarshal(actual, &actualValue) != nil {
+		return false
+	}
+	return reflect.DeepEqual(recordedValue, actualValue)
+}
+
+// sanitizeCassetteURL masks API keys passed as query parameters.
+func sanitizeCassetteURL(u *url.URL) string {
+	query := u.Query()
+	if query.Get("key") == "" {
+		return u.String()
+	}
+	clean := *u
+	query.Set("key", "REDACTED")
+	clean.RawQuery = query.Encode()
+	return clean.String()
+}
+
+// sanitizeCassetteHeaders drops credentials entirely, since cassettes are meant to be committed and shared.
+func sanitizeCassetteHeaders(headers http.Header) map[string][]string {
+	sanitized := make(map[string][]string, len(headers))
+	for name, values := range headers {
+		if isSensitiveHeader(name) {
+			sanitized[name] = []string{"REDACTED"}
+			continue
+		}
+		sanitized[name] = values
+	}
+	return sanitized
+}
+
+// cassetteTransport records traffic to, or replays it from, a cassette.
+// It sits beneath debugTransport so captured debug data looks the same in both modes.
+type cassetteTransport struct {
+	base  http.RoundTripper
+	state *cassetteState
+}
+
+// RoundTrip implements http.RoundTripper for cassette record and replay.
+func (ct *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if ct.state.mode == CassetteModeReplay {
+		return ct.state.replay(req, body)
+	}
+
+	resp, err := ct.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Body = &cassetteRecordBody{
+		body:  resp.Body,
+		state: ct.state,
+		interaction: CassetteInteraction{
+			Request: CassetteRequest{
+				Method:  req.Method,
+				URL:     sanitizeCassetteURL(req.URL),
+				Headers: sanitizeCassetteHeaders(req.Header),
+				Body:    body,
+			},
+			Response: CassetteResponse{
+				StatusCode: resp.StatusCode,
+				Headers:    sanitizeCassetteHeaders(resp.Header),
+			},
+		},
+	}
+	return resp, nil
+}
+
+// cassetteRecordBody passes the response through to the client and records it once fully read or closed,
+// so streaming responses still arrive incrementally while recording.
+type cassetteRecordBody struct {
+	body        io.ReadCloser
+	state       *cassetteState
+	interaction CassetteInteraction
+	buffer      bytes.Buffer
+	recorded    bool
+}
+
+// Read reads from the underlying body and keeps a copy for the cassette.
+func (b *cassetteRecordBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	if n > 0 {
+		b.buffer.Write(p[:n])
+	}
+	if err == io.EOF {
+		b.record()
+	}
+	return n, err
+}
+
+// Close closes the underlying body and records the interaction.
+func (b *cassetteRecordBody) Close() error {
+	b.record()
+	return b.body.Close()
+}
+
+// record writes the interaction to the cassette exactly once.
+func (b *cassetteRecordBody) record() {
+	if b.recorded {
+		return
+	}
+	b.recorded = true
+	b.interaction.Response.Body = b.buffer.Bytes()
+	if err := b.state.record(b.interaction); err != nil {
+		logger.Error("Failed to write cassette", "path", b.state.path, "error", err)
+	}
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCassetteTransportService(t *testing.T, path string, mode CassetteMode) *DebugTransportService {
+	service := NewDebugTransportService()
+	service.SetCassette(path, mode)
+	require.NoError(t, service.Initialize())
+	return service
+}
+
+func doCassetteRequest(t *testing.T, transport http.RoundTripper, url, body string) (*http.Response, string) {
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer sk-secret-key-value")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err.Error()
+	}
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	return resp, string(data)
+}
+
+func TestParseCassetteMode(t *testing.T) {
+	mode, err := ParseCassetteMode("Record")
+	require.NoError(t, err)
+	assert.Equal(t, CassetteModeRecord, mode)
+
+	mode, err = ParseCassetteMode("replay")
+	require.NoError(t, err)
+	assert.Equal(t, CassetteModeReplay, mode)
+
+	_, err = ParseCassetteMode("rewind")
+	assert.Error(t, err)
+}
+
+func TestCassette_RecordThenReplay(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"echo": ` + string(body) + `}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "chat.json")
+
+	recorder := newCassetteTransportService(t, path, CassetteModeRecord)
+	_, first := doCassetteRequest(t, recorder.CreateTransport(), server.URL+"/v1/chat", `{"prompt": "one"}`)
+	_, second := doCassetteRequest(t, recorder.CreateTransport(), server.URL+"/v1/chat", `{"prompt": "two"}`)
+	assert.Equal(t, `{"echo": {"prompt": "one"}}`, first)
+	assert.Equal(t, `{"echo": {"prompt": "two"}}`, second)
+	assert.Equal(t, int32(2), hits.Load())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "sk-secret", "credentials must not be written to cassettes")
+	assert.Contains(t, string(data), "REDACTED")
+
+	// Replay serves matching requests in any order, ignores JSON formatting, and never hits the server
+	replayer := newCassetteTransportService(t, path, CassetteModeReplay)
+	resp, replayed := doCassetteRequest(t, replayer.CreateTransport(), server.URL+"/v1/chat", `{"prompt":"two"}`)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.JSONEq(t, `{"echo": {"prompt": "two"}}`, replayed)
+
+	_, replayed = doCassetteRequest(t, replayer.CreateTransport(), server.URL+"/v1/chat", `{"prompt": "one"}`)
+	assert.JSONEq(t, `{"echo": {"prompt": "one"}}`, replayed)
+	assert.Equal(t, int32(2), hits.Load())
+
+	// Replay captures debug data like a live request
+	assert.Contains(t, replayer.GetCapturedData(), `"status_code":200`)
+
+	// Each interaction is served once
+	resp, errMessage := doCassetteRequest(t, replayer.CreateTransport(), server.URL+"/v1/chat", `{"prompt": "one"}`)
+	assert.Nil(t, resp)
+	assert.Contains(t, errMessage, "has no recorded response for POST")
+}
+
+func TestCassette_RecordStreamingResponse(t *testing.T) {
+	stream := "data: {\"delta\": \"Hel\"}\n\ndata: {\"delta\": \"lo\"}\n\ndata: [DONE]\n\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte(stream))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "stream.json")
+	recorder := newCassetteTransportService(t, path, CassetteModeRecord)
+	_, recorded := doCassetteRequest(t, recorder.CreateTransport(), server.URL, `{"stream": true}`)
+	assert.Equal(t, stream, recorded)
+
+	replayer := newCassetteTransportService(t, path, CassetteModeReplay)
+	resp, replayed := doCassetteRequest(t, replayer.CreateTransport(), server.URL, `{"stream": true}`)
+	require.NotNil(t, resp)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Equal(t, stream, replayed)
+}
+
+func TestCassette_ReplayRequiresFile(t *testing.T) {
+	service := NewDebugTransportService()
+	service.SetCassette(filepath.Join(t.TempDir(), "missing.json"), CassetteModeReplay)
+	err := service.Initialize()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read cassette")
+}
+
+func TestSanitizeCassetteURL(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://example.com/v1/models?key=secret&alt=sse", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/v1/models?alt=sse&key=REDACTED", sanitizeCassetteURL(req.URL))
+
+	req, err = http.NewRequest(http.MethodGet, "https://example.com/v1/models", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/v1/models", sanitizeCassetteURL(req.URL))
+}
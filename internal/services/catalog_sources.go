@@ -0,0 +1,185 @@
+// Package services provides loading of user-defined model and provider catalogs.
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	neuroshellcontext "neuroshell/internal/context"
+	"neuroshell/pkg/neurotypes"
+)
+
+// Catalog directory names, used both in the user config directory and in the project directory.
+const (
+	ModelCatalogDir    = "models"
+	ProviderCatalogDir = "providers"
+	ProjectConfigDir   = ".neuro"
+)
+
+// ProjectCatalogOverridesEnv names the environment variable that lets project catalogs replace
+// embedded and user entries with the same ID. It is read from the process environment only, never
+// from a project .env file, so a cloned repository cannot opt itself in.
+const ProjectCatalogOverridesEnv = "NEURO_PROJECT_CATALOG_OVERRIDES"
+
+// catalogFile is a YAML catalog file read from a user or project catalog directory.
+type catalogFile struct {
+	path string
+	data []byte
+}
+
+// catalogLayer groups the catalog files of one source kind.
+type catalogLayer struct {
+	kind  string
+	files []catalogFile
+}
+
+// customCatalogLayer holds the parsed entries of one catalog source kind.
+type customCatalogLayer[T any] struct {
+	kind    string
+	entries []T
+}
+
+// readCatalogLayers reads the YAML files of a catalog directory ("models" or "providers")
+// from ~/.config/neuroshell/<dir> and ./.neuro/<dir>, in increasing order of precedence.
+// Missing directories are skipped; files are read in name order. Directories and files that
+// cannot be read are skipped and reported as warnings.
+func readCatalogLayers(dir string) ([]catalogLayer, []string) {
+	ctx := neuroshellcontext.GetGlobalContext()
+	if ctx == nil {
+		return nil, nil
+	}
+
+	type catalogRoot struct{ kind, path string }
+	var roots []catalogRoot
+	if configDir, err := ctx.GetUserConfigDir(); err == nil {
+		roots = append(roots, catalogRoot{neurotypes.CatalogSourceUser, filepath.Join(configDir, dir)})
+	}
+	if workDir, err := ctx.GetWorkingDir(); err == nil {
+		roots = append(roots, catalogRoot{neurotypes.CatalogSourceProject, filepath.Join(workDir, ProjectConfigDir, dir)})
+	}
+
+	var layers []catalogLayer
+	var warnings []string
+	for _, root := range roots {
+		files, dirWarnings := readCatalogDir(root.kind, root.path)
+		warnings = append(warnings, dirWarnings...)
+		if len(files) > 0 {
+			layers = append(layers, catalogLayer{kind: root.kind, files: files})
+		}
+	}
+	return layers, warnings
+}
+
+// readCatalogDir reads all .yaml and .yml files in a directory, sorted by name.
+func readCatalogDir(kind string, dir string) ([]catalogFile, []string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, []string{fmt.Sprintf("failed to read %s catalog directory %s: %v", kind, dir, err)}
+	}
+
+	var names []string
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	files := make([]catalogFile, 0, len(names))
+	var warnings []string
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("failed to read catalog file %s: %v", path, err))
+			continue
+		}
+		files = append(files, catalogFile{path: path, data: data})
+	}
+	return files, warnings
+}
+
+// loadCustomCatalogLayers reads and parses the user and project files of a catalog directory.
+// Files that fail to parse, entries without an ID and duplicate IDs within a layer are skipped
+// and reported as warnings, so one bad file does not disable the rest of the catalog.
+func loadCustomCatalogLayers[T any](dir string, parse func([]byte) (T, error), id func(T) string, getSource func(*T) *neurotypes.CatalogSource) ([]customCatalogLayer[T], []string) {
+	layers, warnings := readCatalogLayers(dir)
+
+	var parsed []customCatalogLayer[T]
+	for _, layer := range layers {
+		seen := make(map[string]string)
+		custom := customCatalogLayer[T]{kind: layer.kind}
+		for _, file := range layer.files {
+			entry, err := parse(file.data)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("skipped %s catalog file %s: %v", layer.kind, file.path, err))
+				continue
+			}
+			key := strings.ToUpper(id(entry))
+			if key == "" {
+				warnings = append(warnings, fmt.Sprintf("skipped %s catalog file %s: empty ID field", layer.kind, file.path))
+				continue
+			}
+			if firstPath, exists := seen[key]; exists {
+				warnings = append(warnings, fmt.Sprintf("skipped %s catalog file %s: duplicate ID '%s' (already defined in %s)", layer.kind, file.path, id(entry), firstPath))
+				continue
+			}
+			seen[key] = file.path
+			*getSource(&entry) = neurotypes.CatalogSource{Kind: layer.kind, Path: file.path}
+			custom.entries = append(custom.entries, entry)
+		}
+		if len(custom.entries) > 0 {
+			parsed = append(parsed, custom)
+		}
+	}
+	return parsed, warnings
+}
+
+// projectCatalogOverridesAllowed reports whether the user opted in to project catalog overrides.
+func projectCatalogOverridesAllowed() bool {
+	ctx := neuroshellcontext.GetGlobalContext()
+	if ctx == nil {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(ctx.GetEnv(ProjectCatalogOverridesEnv))) {
+	case "1", "true", "yes", "on":
+		return true
+	}
+	return false
+}
+
+// mergeCatalogEntries adds a layer of entries to a catalog. An entry whose ID matches an existing
+// entry (case-insensitive) replaces it in place and records the source it overrides; other
+// entries are appended. Project entries only replace existing entries when allowProjectOverrides
+// is set; otherwise they are skipped with a warning. getSource gives access to each entry's Source field.
+func mergeCatalogEntries[T any](catalog []T, layer customCatalogLayer[T], id func(T) string, getSource func(*T) *neurotypes.CatalogSource, allowProjectOverrides bool) ([]T, []string) {
+	index := make(map[string]int, len(catalog))
+	for i, entry := range catalog {
+		index[strings.ToUpper(id(entry))] = i
+	}
+
+	var warnings []string
+	for _, entry := range layer.entries {
+		key := strings.ToUpper(id(entry))
+		if i, exists := index[key]; exists {
+			if layer.kind == neurotypes.CatalogSourceProject && !allowProjectOverrides {
+				warnings = append(warnings, fmt.Sprintf("ignored project catalog entry '%s' (%s): it would override the %s entry; set %s=1 in the environment to allow",
+					id(entry), getSource(&entry).Path, getSource(&catalog[i]).Kind, ProjectCatalogOverridesEnv))
+				continue
+			}
+			getSource(&entry).Overrides = getSource(&catalog[i]).Kind
+			catalog[i] = entry
+			continue
+		}
+		index[key] = len(catalog)
+		catalog = append(catalog, entry)
+	}
+	return catalog, warnings
+}
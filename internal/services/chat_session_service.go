@@ -41,7 +41,8 @@ func (c *ChatSessionService) Initialize() error {
 }
 
 // TriggerAutoSave checks if auto-save is enabled and triggers a session save if so.
-// This method is called by session modification commands to automatically save sessions.
+// This method is called by session modification commands to automatically save sessions,
+// and also updates the session in the search index.
 // Failures are logged but do not interrupt the main operation.
 func (c *ChatSessionService) TriggerAutoSave(sessionID string) {
 	if !c.initialized {
@@ -54,6 +55,13 @@ func (c *ChatSessionService) TriggerAutoSave(sessionID string) {
 		return // Context not available, skip auto-save
 	}
 
+	// Keep the search index current whether or not the session is saved
+	if searchService, err := GetGlobalSessionSearchService(); err == nil {
+		if session, err := c.GetSessionWithContext(sessionID, ctx); err == nil {
+			searchService.IndexSession(session)
+		}
+	}
+
 	// Check if auto-save is enabled via context
 	autosaveValue, err := ctx.GetVariable("_session_autosave")
 	if err != nil {
@@ -305,7 +313,7 @@ func (c *ChatSessionService) GetSessionWithContext(sessionID string, ctx neuroty
 		return nil, fmt.Errorf("session with ID '%s' not found", sessionID)
 	}
 
-	return session, nil
+	return c.ensureLoaded(session)
 }
 
 // GetSessionByName retrieves a session by name.
@@ -352,7 +360,7 @@ func (c *ChatSessionService) GetSessionByNameOrIDWithContext(nameOrID string, ct
 
 	// Then try by ID
 	if session, exists := sessions[nameOrID]; exists {
-		return session, nil
+		return c.ensureLoaded(session)
 	}
 
 	return nil, fmt.Errorf("session '%s' not found (tried both name and ID)", nameOrID)
@@ -546,13 +554,13 @@ func (c *ChatSessionService) FindSessionByPrefixWithContext(identifier string, c
 	// 1. Try exact name match first
 	if sessionID, exists := nameToID[identifier]; exists {
 		if session, exists := sessions[sessionID]; exists {
-			return session, nil
+			return c.ensureLoaded(session)
 		}
 	}
 
 	// 2. Try exact ID match
 	if session, exists := sessions[identifier]; exists {
-		return session, nil
+		return c.ensureLoaded(session)
 	}
 
 	// 3. Try prefix matching
@@ -571,7 +579,7 @@ func (c *ChatSessionService) FindSessionByPrefixWithContext(identifier string, c
 	}
 
 	// Exactly one match
-	return matches[0], nil
+	return c.ensureLoaded(matches[0])
 }
 
 // AddMessage adds a message to the specified session.
@@ -582,6 +590,17 @@ func (c *ChatSessionService) AddMessage(nameOrID string, role, content string) e
 
 // AddMessageWithContext adds a message to the specified session using provided context.
 func (c *ChatSessionService) AddMessageWithContext(nameOrID string, role, content string, ctx neurotypes.Context) error {
+	return c.AddMessageWithMetadataWithContext(nameOrID, role, content, nil, ctx)
+}
+
+// AddMessageWithMetadata adds a message that records how it was produced to the specified session.
+func (c *ChatSessionService) AddMessageWithMetadata(nameOrID string, role, content string, metadata *neurotypes.MessageMetadata) error {
+	ctx := neuroshellcontext.GetGlobalContext()
+	return c.AddMessageWithMetadataWithContext(nameOrID, role, content, metadata, ctx)
+}
+
+// AddMessageWithMetadataWithContext adds a message with optional metadata to the specified session using provided context.
+func (c *ChatSessionService) AddMessageWithMetadataWithContext(nameOrID string, role, content string, metadata *neurotypes.MessageMetadata, ctx neurotypes.Context) error {
 	if !c.initialized {
 		return fmt.Errorf("chat session service not initialized")
 	}
@@ -596,6 +615,7 @@ func (c *ChatSessionService) AddMessageWithContext(nameOrID string, role, conten
 		Role:      role,
 		Content:   content,
 		Timestamp: testutils.GetCurrentTime(ctx),
+		Metadata:  metadata,
 	}
 
 	session.Messages = append(session.Messages, message)
@@ -828,26 +848,56 @@ func (c *ChatSessionService) CopySessionWithContext(sourceIdentifier, targetName
 	newID := testutils.GenerateUUID(ctx)
 	now := testutils.GetCurrentTime(ctx)
 
-	// Deep copy all messages
-	copiedMessages := make([]neurotypes.Message, len(sourceSession.Messages))
-	for i, msg := range sourceSession.Messages {
-		copiedMessages[i] = neurotypes.Message{
-			ID:        testutils.GenerateUUID(ctx), // Generate new ID for each message
-			Role:      msg.Role,                    // Preserve role
-			Content:   msg.Content,                 // Preserve content
-			Timestamp: msg.Timestamp,               // Preserve original timestamp
+	// Deep copy all messages, giving a message shared by the active path and the tree the same new ID in both
+	newIDs := make(map[string]string)
+	newMessageID := func(id string) string {
+		newID, ok := newIDs[id]
+		if !ok || id == "" {
+			newID = testutils.GenerateUUID(ctx) // Generate new ID for each message
+			newIDs[id] = newID
+		}
+		return newID
+	}
+	copyMessages := func(messages []neurotypes.Message) []neurotypes.Message {
+		copied := make([]neurotypes.Message, len(messages))
+		for i, msg := range messages {
+			copied[i] = msg // Preserve role, content, original timestamp and alternatives
+			copied[i].ID = newMessageID(msg.ID)
+		}
+		return copied
+	}
+	copiedMessages := copyMessages(sourceSession.Messages)
+
+	// Copy the message tree and branch heads under the new message IDs
+	var copiedTree []neurotypes.MessageNode
+	for _, node := range sourceSession.MessageTree {
+		node.Message.ID = newMessageID(node.Message.ID)
+		if node.Parent != "" {
+			node.Parent = newMessageID(node.Parent)
+		}
+		copiedTree = append(copiedTree, node)
+	}
+	var copiedBranches []neurotypes.SessionBranch
+	for _, branch := range sourceSession.Branches {
+		if branch.Head != "" {
+			branch.Head = newMessageID(branch.Head)
 		}
+		copiedBranches = append(copiedBranches, branch)
 	}
 
 	// Create the copied session
 	copiedSession := &neurotypes.ChatSession{
-		ID:           newID,
-		Name:         processedTargetName,
-		SystemPrompt: sourceSession.SystemPrompt, // Preserve system prompt
-		Messages:     copiedMessages,
-		CreatedAt:    now,   // New creation timestamp
-		UpdatedAt:    now,   // New update timestamp
-		IsActive:     false, // Will be activated below
+		ID:            newID,
+		Name:          processedTargetName,
+		SystemPrompt:  sourceSession.SystemPrompt, // Preserve system prompt
+		Messages:      copiedMessages,
+		CreatedAt:     now,                         // New creation timestamp
+		UpdatedAt:     now,                         // New update timestamp
+		IsActive:      false,                       // Will be activated below
+		ContextPolicy: sourceSession.ContextPolicy, // Preserve context policy
+		MessageTree:   copiedTree,
+		Branches:      copiedBranches,
+		ActiveBranch:  sourceSession.ActiveBranch,
 	}
 
 	// Store the copied session
@@ -984,6 +1034,11 @@ func (c *ChatSessionService) reconstructImportedSessionWithContext(originalSessi
 		CreatedAt:    now,
 		UpdatedAt:    now,
 		IsActive:     true, // Imported session becomes active
+
+		ContextPolicy: originalSession.ContextPolicy,
+		MessageTree:   originalSession.MessageTree,
+		Branches:      originalSession.Branches,
+		ActiveBranch:  originalSession.ActiveBranch,
 	}
 
 	return reconstructedSession, nil
@@ -1021,6 +1076,411 @@ func (c *ChatSessionService) UpdateSystemPromptWithContext(nameOrID string, newS
 	return nil
 }
 
+// SetContextPolicy sets the context policy of a session identified by name or ID.
+// An empty policy makes the session use the model's policy again.
+func (c *ChatSessionService) SetContextPolicy(nameOrID string, policy string) error {
+	if !c.initialized {
+		return fmt.Errorf("chat session service not initialized")
+	}
+
+	parsed, err := ParseContextPolicy(policy)
+	if err != nil {
+		return err
+	}
+
+	ctx := neuroshellcontext.GetGlobalContext()
+	session, err := c.FindSessionByPrefixWithContext(nameOrID, ctx)
+	if err != nil {
+		return fmt.Errorf("session lookup failed: %w", err)
+	}
+
+	session.ContextPolicy = parsed.String()
+	session.UpdatedAt = testutils.GetCurrentTime(ctx)
+
+	logger.Debug("Set context policy for session", "session_id", session.ID, "policy", session.ContextPolicy)
+	return nil
+}
+
+// SetPendingMetadata records how the last response for a session was produced, so it can be
+// attached to the assistant message that stores it. A nil metadata clears the pending record.
+func (c *ChatSessionService) SetPendingMetadata(nameOrID string, metadata *neurotypes.MessageMetadata) error {
+	if !c.initialized {
+		return fmt.Errorf("chat session service not initialized")
+	}
+
+	ctx := neuroshellcontext.GetGlobalContext()
+	session, err := c.FindSessionByPrefixWithContext(nameOrID, ctx)
+	if err != nil {
+		return fmt.Errorf("session lookup failed: %w", err)
+	}
+
+	session.PendingMetadata = metadata
+	return nil
+}
+
+// TakePendingMetadata returns the pending response metadata of a session and clears it,
+// so it is attached to one message only. It returns nil when there is none.
+func (c *ChatSessionService) TakePendingMetadata(nameOrID string) *neurotypes.MessageMetadata {
+	if !c.initialized {
+		return nil
+	}
+
+	ctx := neuroshellcontext.GetGlobalContext()
+	session, err := c.FindSessionByPrefixWithContext(nameOrID, ctx)
+	if err != nil {
+		return nil
+	}
+
+	metadata := session.PendingMetadata
+	session.PendingMetadata = nil
+	return metadata
+}
+
+// AddAlternative stores content as another generated response of the session's last message,
+// which must be an assistant message. The first alternative is the message's current content.
+// The new response becomes the message content unless keep is set; metadata describes the new
+// response and may be nil. It returns the 1-based number of the new alternative.
+func (c *ChatSessionService) AddAlternative(nameOrID string, content string, keep bool, metadata *neurotypes.MessageMetadata) (int, error) {
+	if !c.initialized {
+		return 0, fmt.Errorf("chat session service not initialized")
+	}
+
+	ctx := neuroshellcontext.GetGlobalContext()
+	session, err := c.FindSessionByPrefixWithContext(nameOrID, ctx)
+	if err != nil {
+		return 0, fmt.Errorf("session lookup failed: %w", err)
+	}
+
+	message, err := lastAssistantMessage(session)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(message.Alternatives) == 0 {
+		message.Alternatives = []string{message.Content}
+		message.SelectedAlternative = 1
+		if message.Metadata != nil {
+			message.AlternativeMetadata = []*neurotypes.MessageMetadata{message.Metadata}
+		}
+	}
+	message.Alternatives = append(message.Alternatives, content)
+	// Metadata is only tracked per alternative once some alternative has it
+	if metadata != nil || len(message.AlternativeMetadata) > 0 {
+		for len(message.AlternativeMetadata) < len(message.Alternatives)-1 {
+			message.AlternativeMetadata = append(message.AlternativeMetadata, nil)
+		}
+		message.AlternativeMetadata = append(message.AlternativeMetadata, metadata)
+	}
+	if !keep {
+		message.Content = content
+		message.SelectedAlternative = len(message.Alternatives)
+		message.Metadata = metadata
+	}
+	session.UpdatedAt = testutils.GetCurrentTime(ctx)
+
+	logger.Debug("Added response alternative", "session_id", session.ID, "message_id", message.ID, "alternatives", len(message.Alternatives))
+	return len(message.Alternatives), nil
+}
+
+// SelectAlternative makes the 1-based alternative the content of the session's last assistant message.
+func (c *ChatSessionService) SelectAlternative(nameOrID string, alternative int) error {
+	if !c.initialized {
+		return fmt.Errorf("chat session service not initialized")
+	}
+
+	ctx := neuroshellcontext.GetGlobalContext()
+	session, err := c.FindSessionByPrefixWithContext(nameOrID, ctx)
+	if err != nil {
+		return fmt.Errorf("session lookup failed: %w", err)
+	}
+
+	message, err := lastAssistantMessage(session)
+	if err != nil {
+		return err
+	}
+
+	count := len(message.Alternatives)
+	if count == 0 {
+		count = 1
+	}
+	if alternative < 1 || alternative > count {
+		return fmt.Errorf("alternative %d is out of bounds (the last response has %d alternatives)", alternative, count)
+	}
+	if len(message.Alternatives) > 0 {
+		message.Content = message.Alternatives[alternative-1]
+		message.SelectedAlternative = alternative
+		message.Metadata = nil
+		if alternative <= len(message.AlternativeMetadata) {
+			message.Metadata = message.AlternativeMetadata[alternative-1]
+		}
+	}
+	session.UpdatedAt = testutils.GetCurrentTime(ctx)
+	return nil
+}
+
+// lastAssistantMessage returns the session's last message if it is an assistant response.
+func lastAssistantMessage(session *neurotypes.ChatSession) (*neurotypes.Message, error) {
+	if len(session.Messages) == 0 || session.Messages[len(session.Messages)-1].Role != "assistant" {
+		return nil, fmt.Errorf("the last message of session '%s' is not an assistant response", session.Name)
+	}
+	return &session.Messages[len(session.Messages)-1], nil
+}
+
+// DefaultBranchName is the name given to a session's original path when it is first branched.
+const DefaultBranchName = "main"
+
+// BranchSession forks the active path of a session before the message at messageIndex (0-based)
+// and makes the new branch active. A messageIndex equal to the message count keeps every message.
+// An empty branchName generates one. The original path becomes the "main" branch on first use.
+func (c *ChatSessionService) BranchSession(nameOrID, branchName string, messageIndex int) (*neurotypes.SessionBranch, error) {
+	if !c.initialized {
+		return nil, fmt.Errorf("chat session service not initialized")
+	}
+
+	ctx := neuroshellcontext.GetGlobalContext()
+	session, err := c.FindSessionByPrefixWithContext(nameOrID, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("session lookup failed: %w", err)
+	}
+
+	if messageIndex < 0 || messageIndex > len(session.Messages) {
+		return nil, fmt.Errorf("fork point %d is out of bounds (session has %d messages)", messageIndex, len(session.Messages))
+	}
+
+	now := testutils.GetCurrentTime(ctx)
+	if len(session.Branches) == 0 {
+		session.Branches = []neurotypes.SessionBranch{{Name: DefaultBranchName, CreatedAt: session.CreatedAt}}
+		session.ActiveBranch = DefaultBranchName
+	}
+
+	name := strings.TrimSpace(branchName)
+	if name == "" {
+		name = generateBranchName(session)
+	}
+	if err := validateBranchName(name); err != nil {
+		return nil, err
+	}
+	if findBranch(session, name) >= 0 {
+		return nil, fmt.Errorf("branch '%s' already exists in session '%s'", name, session.Name)
+	}
+
+	// The parent's path is recorded in the tree; the new branch starts at its message before the fork
+	syncActiveBranch(session, ctx)
+	head := ""
+	if messageIndex > 0 {
+		head = session.Messages[messageIndex-1].ID
+	}
+	session.Branches = append(session.Branches, neurotypes.SessionBranch{
+		Name:      name,
+		Parent:    session.ActiveBranch,
+		ForkPoint: messageIndex,
+		Head:      head,
+		CreatedAt: now,
+	})
+	session.Messages = append(make([]neurotypes.Message, 0, messageIndex), session.Messages[:messageIndex]...)
+	session.ActiveBranch = name
+	session.UpdatedAt = now
+
+	logger.Debug("Session branched", "session_id", session.ID, "branch", name, "fork_point", messageIndex)
+	branch := session.Branches[len(session.Branches)-1]
+	return &branch, nil
+}
+
+// CheckoutBranch makes the named branch the active path of a session.
+func (c *ChatSessionService) CheckoutBranch(nameOrID, branchName string) error {
+	if !c.initialized {
+		return fmt.Errorf("chat session service not initialized")
+	}
+
+	ctx := neuroshellcontext.GetGlobalContext()
+	session, err := c.FindSessionByPrefixWithContext(nameOrID, ctx)
+	if err != nil {
+		return fmt.Errorf("session lookup failed: %w", err)
+	}
+
+	name := strings.TrimSpace(branchName)
+	target := findBranch(session, name)
+	if target < 0 {
+		if len(session.Branches) == 0 {
+			return fmt.Errorf("session '%s' has no branches", session.Name)
+		}
+		return fmt.Errorf("branch '%s' not found in session '%s' (available: %s)", name, session.Name, strings.Join(branchNames(session), ", "))
+	}
+	if name == session.ActiveBranch {
+		return nil
+	}
+
+	syncActiveBranch(session, ctx)
+	session.Messages = treePath(session, session.Branches[target].Head)
+	session.ActiveBranch = name
+	session.UpdatedAt = testutils.GetCurrentTime(ctx)
+
+	logger.Debug("Checked out session branch", "session_id", session.ID, "branch", name)
+	return nil
+}
+
+// BranchPath returns the messages of the named branch, which for the active branch are the session's messages.
+// Other branches are built from the message tree, after recording the active path so that edits to
+// shared messages show in every branch.
+func BranchPath(session *neurotypes.ChatSession, branchName string) []neurotypes.Message {
+	if branchName == session.ActiveBranch {
+		return session.Messages
+	}
+	index := findBranch(session, branchName)
+	if index < 0 {
+		return nil
+	}
+	syncActiveBranch(session, neuroshellcontext.GetGlobalContext())
+	return treePath(session, session.Branches[index].Head)
+}
+
+// syncActiveBranch records the active path in the message tree and points the active branch at its
+// last message. Messages already in the tree at the same position are updated in place, so every branch
+// sharing them sees the change; other messages become new nodes. Nodes no branch reaches are removed.
+func syncActiveBranch(session *neurotypes.ChatSession, ctx neurotypes.Context) {
+	active := findBranch(session, session.ActiveBranch)
+	if active < 0 {
+		return
+	}
+
+	nodes := make(map[string]int, len(session.MessageTree))
+	for i, node := range session.MessageTree {
+		nodes[node.Message.ID] = i
+	}
+	parent := ""
+	for i := range session.Messages {
+		msg := &session.Messages[i]
+		if index, ok := nodes[msg.ID]; ok && msg.ID != "" && session.MessageTree[index].Parent == parent {
+			session.MessageTree[index].Message = *msg
+			parent = msg.ID
+			continue
+		}
+		// A message moved to a new position (or without an ID) is a new node
+		if _, ok := nodes[msg.ID]; ok || msg.ID == "" {
+			msg.ID = testutils.GenerateUUID(ctx)
+		}
+		nodes[msg.ID] = len(session.MessageTree)
+		session.MessageTree = append(session.MessageTree, neurotypes.MessageNode{Parent: parent, Message: *msg})
+		parent = msg.ID
+	}
+	session.Branches[active].Head = parent
+
+	pruneMessageTree(session)
+}
+
+// pruneMessageTree removes the nodes that are not on the path of any branch.
+func pruneMessageTree(session *neurotypes.ChatSession) {
+	parents := make(map[string]string, len(session.MessageTree))
+	for _, node := range session.MessageTree {
+		parents[node.Message.ID] = node.Parent
+	}
+	reachable := make(map[string]bool, len(session.MessageTree))
+	for _, branch := range session.Branches {
+		for id := branch.Head; id != "" && !reachable[id]; id = parents[id] {
+			reachable[id] = true
+		}
+	}
+
+	kept := session.MessageTree[:0]
+	for _, node := range session.MessageTree {
+		if reachable[node.Message.ID] {
+			kept = append(kept, node)
+		}
+	}
+	session.MessageTree = kept
+}
+
+// treePath returns the messages from the root of the message tree to the node with the given ID.
+func treePath(session *neurotypes.ChatSession, head string) []neurotypes.Message {
+	nodes := make(map[string]neurotypes.MessageNode, len(session.MessageTree))
+	for _, node := range session.MessageTree {
+		nodes[node.Message.ID] = node
+	}
+
+	path := make([]neurotypes.Message, 0)
+	for id := head; id != "" && len(path) < len(nodes); {
+		node, ok := nodes[id]
+		if !ok {
+			break
+		}
+		path = append(path, node.Message)
+		id = node.Parent
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// BranchAlternatives returns the branches that share the first messageIndex messages of the active path,
+// i.e. the active branch and its siblings at that message, in creation order.
+func BranchAlternatives(session *neurotypes.ChatSession, messageIndex int) []neurotypes.SessionBranch {
+	var alternatives []neurotypes.SessionBranch
+	for _, branch := range session.Branches {
+		path := BranchPath(session, branch.Name)
+		if len(path) < messageIndex || !sameMessages(path[:messageIndex], session.Messages[:messageIndex]) {
+			continue
+		}
+		alternatives = append(alternatives, branch)
+	}
+	return alternatives
+}
+
+// sameMessages reports whether two paths consist of the same messages.
+func sameMessages(a, b []neurotypes.Message) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].ID != b[i].ID {
+			return false
+		}
+	}
+	return true
+}
+
+// findBranch returns the index of the named branch in the session, or -1.
+func findBranch(session *neurotypes.ChatSession, name string) int {
+	for i, branch := range session.Branches {
+		if branch.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// branchNames lists the branch names of a session in creation order.
+func branchNames(session *neurotypes.ChatSession) []string {
+	names := make([]string, 0, len(session.Branches))
+	for _, branch := range session.Branches {
+		names = append(names, branch.Name)
+	}
+	return names
+}
+
+// generateBranchName returns the first unused name of the form branch-N.
+func generateBranchName(session *neurotypes.ChatSession) string {
+	for i := len(session.Branches); ; i++ {
+		name := fmt.Sprintf("branch-%d", i)
+		if findBranch(session, name) < 0 {
+			return name
+		}
+	}
+}
+
+// validateBranchName checks that a branch name is a single short word usable in command options.
+func validateBranchName(name string) error {
+	if len(name) > 64 {
+		return fmt.Errorf("branch name too long (max 64 characters)")
+	}
+	for _, char := range name {
+		if char <= 32 || char == 127 || strings.ContainsRune(",=[]\"'", char) {
+			return fmt.Errorf("invalid branch name '%s': use letters, digits, '-', '_' or '.'", name)
+		}
+	}
+	return nil
+}
+
 // RenameSession changes the name of a session identified by name or ID.
 // It validates the new name and ensures it doesn't conflict with existing sessions.
 func (c *ChatSessionService) RenameSession(nameOrID string, newName string) error {
@@ -1116,6 +1576,11 @@ func (c *ChatSessionService) SaveAllSessions() (int, error) {
 	var lastError error
 
 	for _, session := range sessions {
+		// Lazily indexed sessions that were never opened are unchanged on disk
+		if session.UnloadedFrom != "" {
+			continue
+		}
+
 		// Construct filename using session ID
 		filename := fmt.Sprintf("%s.json", session.ID)
 		filePath := filepath.Join(sessionsDir, filename)
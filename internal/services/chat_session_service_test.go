@@ -1,6 +1,8 @@
 package services
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -662,3 +664,241 @@ func TestChatSessionService_EditMessage_PrefixMatching(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "Edited via prefix", updatedSession.Messages[0].Content)
 }
+
+// setupBranchTestSession creates a session with four messages: Q1, A1, Q2, A2.
+func setupBranchTestSession(t *testing.T) *ChatSessionService {
+	ctx := context.New()
+	ctx.SetTestMode(true)
+	context.SetGlobalContext(ctx)
+
+	service := NewChatSessionService()
+	require.NoError(t, service.Initialize())
+
+	_, err := service.CreateSession("tree", "System", "")
+	require.NoError(t, err)
+	for _, content := range []string{"Q1", "A1", "Q2", "A2"} {
+		role := "user"
+		if strings.HasPrefix(content, "A") {
+			role = "assistant"
+		}
+		require.NoError(t, service.AddMessage("tree", role, content))
+	}
+	return service
+}
+
+func branchContents(messages []neurotypes.Message) []string {
+	contents := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		contents = append(contents, msg.Content)
+	}
+	return contents
+}
+
+func TestChatSessionService_BranchSession(t *testing.T) {
+	service := setupBranchTestSession(t)
+
+	branch, err := service.BranchSession("tree", "alt", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "alt", branch.Name)
+	assert.Equal(t, DefaultBranchName, branch.Parent)
+	assert.Equal(t, 2, branch.ForkPoint)
+
+	session, err := service.GetSessionByName("tree")
+	require.NoError(t, err)
+	assert.Equal(t, "alt", session.ActiveBranch)
+	assert.Equal(t, []string{"Q1", "A1"}, branchContents(session.Messages))
+	assert.Equal(t, []string{"Q1", "A1", "Q2", "A2"}, branchContents(BranchPath(session, DefaultBranchName)))
+
+	// New messages only change the active branch; edits to shared messages show in every branch
+	require.NoError(t, service.AddMessage("tree", "user", "Q2b"))
+	require.NoError(t, service.EditMessage("tree", 0, "Q1 edited"))
+	assert.Equal(t, []string{"Q1 edited", "A1", "Q2", "A2"}, branchContents(BranchPath(session, DefaultBranchName)))
+
+	// A generated name is used when none is given
+	branch, err = service.BranchSession("tree", "", 3)
+	require.NoError(t, err)
+	assert.Equal(t, "branch-2", branch.Name)
+	assert.Equal(t, "alt", branch.Parent)
+
+	_, err = service.BranchSession("tree", "alt", 0)
+	assert.ErrorContains(t, err, "already exists")
+	_, err = service.BranchSession("tree", "two words", 0)
+	assert.ErrorContains(t, err, "invalid branch name")
+	_, err = service.BranchSession("tree", "far", 9)
+	assert.ErrorContains(t, err, "out of bounds")
+}
+
+func TestChatSessionService_CheckoutBranch(t *testing.T) {
+	service := setupBranchTestSession(t)
+
+	assert.ErrorContains(t, service.CheckoutBranch("tree", "main"), "has no branches")
+
+	_, err := service.BranchSession("tree", "alt", 2)
+	require.NoError(t, err)
+	require.NoError(t, service.AddMessage("tree", "user", "Q2b"))
+
+	require.NoError(t, service.CheckoutBranch("tree", DefaultBranchName))
+	session, err := service.GetSessionByName("tree")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultBranchName, session.ActiveBranch)
+	assert.Equal(t, []string{"Q1", "A1", "Q2", "A2"}, branchContents(session.Messages))
+	assert.Equal(t, []string{"Q1", "A1", "Q2b"}, branchContents(BranchPath(session, "alt")))
+
+	// Shared messages are stored once, and each branch points at its last message
+	assert.Len(t, session.MessageTree, 5)
+	assert.Equal(t, session.Messages[3].ID, session.Branches[0].Head)
+
+	require.NoError(t, service.CheckoutBranch("tree", "alt"))
+	assert.Equal(t, []string{"Q1", "A1", "Q2b"}, branchContents(session.Messages))
+
+	assert.ErrorContains(t, service.CheckoutBranch("tree", "nowhere"), "available: main, alt")
+}
+
+func TestChatSessionService_BranchTreePruned(t *testing.T) {
+	service := setupBranchTestSession(t)
+
+	_, err := service.BranchSession("tree", "alt", 2)
+	require.NoError(t, err)
+	require.NoError(t, service.AddMessage("tree", "user", "Q2b"))
+	require.NoError(t, service.AddMessage("tree", "assistant", "A2b"))
+	require.NoError(t, service.DeleteMessage("tree", 2))
+
+	// The deleted message leaves the tree; A2b now follows A1 in the alt branch
+	require.NoError(t, service.CheckoutBranch("tree", DefaultBranchName))
+	session, err := service.GetSessionByName("tree")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Q1", "A1", "A2b"}, branchContents(BranchPath(session, "alt")))
+	assert.Len(t, session.MessageTree, 5)
+
+	// Survives a JSON round trip
+	data, err := json.Marshal(session)
+	require.NoError(t, err)
+	var restored neurotypes.ChatSession
+	require.NoError(t, json.Unmarshal(data, &restored))
+	assert.Equal(t, []string{"Q1", "A1", "A2b"}, branchContents(BranchPath(&restored, "alt")))
+}
+
+func TestBranchAlternatives(t *testing.T) {
+	service := setupBranchTestSession(t)
+
+	_, err := service.BranchSession("tree", "alt", 2)
+	require.NoError(t, err)
+	require.NoError(t, service.AddMessage("tree", "user", "Q2b"))
+	_, err = service.BranchSession("tree", "fresh", 0)
+	require.NoError(t, err)
+	require.NoError(t, service.CheckoutBranch("tree", DefaultBranchName))
+
+	session, err := service.GetSessionByName("tree")
+	require.NoError(t, err)
+
+	var names []string
+	for _, branch := range BranchAlternatives(session, 2) {
+		names = append(names, branch.Name)
+	}
+	assert.Equal(t, []string{"main", "alt"}, names, "fresh does not share the first two messages")
+	assert.Len(t, BranchAlternatives(session, 0), 3)
+}
+
+func TestChatSessionService_CopySession_PreservesBranches(t *testing.T) {
+	service := setupBranchTestSession(t)
+
+	_, err := service.BranchSession("tree", "alt", 2)
+	require.NoError(t, err)
+
+	copied, err := service.CopySession("tree", "tree-copy")
+	require.NoError(t, err)
+	assert.Equal(t, "alt", copied.ActiveBranch)
+	require.Len(t, copied.Branches, 2)
+
+	// Shared messages keep sharing a (new) ID, so the copy has the same tree
+	mainPath := BranchPath(copied, DefaultBranchName)
+	assert.Equal(t, mainPath[0].ID, copied.Messages[0].ID)
+	original, err := service.GetSessionByName("tree")
+	require.NoError(t, err)
+	assert.NotEqual(t, original.Messages[0].ID, copied.Messages[0].ID)
+	assert.Len(t, BranchAlternatives(copied, 2), 2)
+}
+
+func TestChatSessionService_Alternatives(t *testing.T) {
+	service := setupBranchTestSession(t)
+
+	number, err := service.AddAlternative("tree", "A2 again", false, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, number)
+
+	session, err := service.GetSessionByName("tree")
+	require.NoError(t, err)
+	last := session.Messages[3]
+	assert.Equal(t, "A2 again", last.Content)
+	assert.Equal(t, []string{"A2", "A2 again"}, last.Alternatives)
+	assert.Equal(t, 2, last.SelectedAlternative)
+
+	// keep stores the response without changing the conversation
+	number, err = service.AddAlternative("tree", "A2 third", true, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, number)
+	assert.Equal(t, "A2 again", session.Messages[3].Content)
+
+	require.NoError(t, service.SelectAlternative("tree", 1))
+	assert.Equal(t, "A2", session.Messages[3].Content)
+	assert.Equal(t, 1, session.Messages[3].SelectedAlternative)
+	assert.ErrorContains(t, service.SelectAlternative("tree", 4), "out of bounds")
+
+	require.NoError(t, service.AddMessage("tree", "user", "Q3"))
+	_, err = service.AddAlternative("tree", "A3", false, nil)
+	assert.ErrorContains(t, err, "not an assistant response")
+}
+
+func TestChatSessionService_MessageMetadata(t *testing.T) {
+	service := setupBranchTestSession(t)
+	first := &neurotypes.MessageMetadata{Model: "gpt", Provider: "openai", StopReason: "stop", RequestID: "req-1"}
+	second := &neurotypes.MessageMetadata{Model: "claude", Provider: "anthropic", Usage: &neurotypes.TokenUsage{InputTokens: 10, OutputTokens: 4}}
+
+	// Pending metadata is handed out once
+	require.NoError(t, service.SetPendingMetadata("tree", first))
+	assert.Equal(t, first, service.TakePendingMetadata("tree"))
+	assert.Nil(t, service.TakePendingMetadata("tree"))
+
+	require.NoError(t, service.AddMessage("tree", "user", "Q3"))
+	require.NoError(t, service.AddMessageWithMetadata("tree", "assistant", "A3", first))
+	session, err := service.GetSessionByName("tree")
+	require.NoError(t, err)
+	assert.Equal(t, first, session.Messages[5].Metadata)
+
+	// Each alternative keeps the metadata of the model that produced it
+	_, err = service.AddAlternative("tree", "A3 by claude", false, second)
+	require.NoError(t, err)
+	assert.Equal(t, second, session.Messages[5].Metadata)
+	require.NoError(t, service.SelectAlternative("tree", 1))
+	assert.Equal(t, first, session.Messages[5].Metadata)
+
+	// Metadata survives a JSON round trip
+	data, err := json.Marshal(session)
+	require.NoError(t, err)
+	var restored neurotypes.ChatSession
+	require.NoError(t, json.Unmarshal(data, &restored))
+	assert.Equal(t, "gpt", restored.Messages[5].Metadata.Model)
+	assert.Equal(t, "req-1", restored.Messages[5].Metadata.RequestID)
+	assert.Equal(t, int64(10), restored.Messages[5].AlternativeMetadata[1].Usage.InputTokens)
+	assert.Nil(t, restored.Messages[0].Metadata)
+	assert.NotContains(t, string(data), "PendingMetadata")
+}
+
+func TestChatSessionService_AlternativeMetadataPadding(t *testing.T) {
+	service := setupBranchTestSession(t)
+
+	// A response without metadata gets a placeholder once a later alternative has some
+	_, err := service.AddAlternative("tree", "A2 again", true, nil)
+	require.NoError(t, err)
+	session, err := service.GetSessionByName("tree")
+	require.NoError(t, err)
+	assert.Empty(t, session.Messages[3].AlternativeMetadata)
+
+	metadata := &neurotypes.MessageMetadata{Model: "mock"}
+	_, err = service.AddAlternative("tree", "A2 third", false, metadata)
+	require.NoError(t, err)
+	assert.Equal(t, []*neurotypes.MessageMetadata{nil, nil, metadata}, session.Messages[3].AlternativeMetadata)
+
+	require.NoError(t, service.SelectAlternative("tree", 2))
+	assert.Nil(t, session.Messages[3].Metadata)
+}
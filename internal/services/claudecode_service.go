@@ -2,12 +2,15 @@ package services
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -19,25 +22,20 @@ import (
 )
 
 // ClaudeCodeService provides Claude Code CLI integration for NeuroShell.
-// It manages Claude Code process lifecycle, session management, and job execution.
+// It manages Claude Code sessions and runs each job as a `claude -p` process whose
+// stream-JSON output is parsed into the job as it arrives.
 type ClaudeCodeService struct {
-	// Process management
-	process       *exec.Cmd
-	stdin         io.WriteCloser
-	stdout        io.ReadCloser
-	stderr        io.ReadCloser
-	processCtx    context.Context
-	cancelProcess context.CancelFunc
-
 	// State management
-	sessions      map[string]*ClaudeCodeSession
-	jobs          map[string]*ClaudeCodeJob
-	activeSession string
+	sessions       map[string]*ClaudeCodeSession
+	jobs           map[string]*ClaudeCodeJob
+	activeSession  string
+	sessionCounter int64
 
 	// Control
 	initialized bool
 	mu          sync.RWMutex
 	logger      *log.Logger
+	executable  string // Claude Code CLI binary, looked up in PATH
 
 	// Communication
 	responseChans map[string]chan *ClaudeCodeResponse
@@ -47,7 +45,6 @@ type ClaudeCodeService struct {
 	// Function fields for testing (can be overridden)
 	isClaudeCodeInstalled func() bool
 	getClaudeCodeVersion  func() (string, error)
-	StartProcess          func(InitOptions) error
 
 	// Cleanup state
 	eventBusClosed bool
@@ -63,23 +60,33 @@ type ClaudeCodeSession struct {
 	LastResponse     string    `json:"last_response,omitempty"`
 	WorkingDirectory string    `json:"working_directory"`
 	Model            string    `json:"model"`
+	Verbose          bool      `json:"verbose,omitempty"`
+	Directories      []string  `json:"directories,omitempty"`
+	PermissionMode   string    `json:"permission_mode,omitempty"`
 	CreatedAt        time.Time `json:"created_at"`
 	UpdatedAt        time.Time `json:"updated_at"`
 }
 
 // ClaudeCodeJob represents an async operation
 type ClaudeCodeJob struct {
-	ID         string              `json:"id"`
-	SessionID  string              `json:"session_id"`
-	Command    string              `json:"command"`
-	Input      string              `json:"input"`
-	Status     ClaudeCodeJobStatus `json:"status"`
-	Output     strings.Builder     `json:"-"`
-	StreamChan chan string         `json:"-"`
-	Error      error               `json:"-"`
-	StartedAt  time.Time           `json:"started_at"`
-	EndedAt    *time.Time          `json:"ended_at,omitempty"`
-	Progress   float64             `json:"progress"`
+	ID            string              `json:"id"`
+	SessionID     string              `json:"session_id"`
+	Command       string              `json:"command"`
+	Input         string              `json:"input"`
+	Status        ClaudeCodeJobStatus `json:"status"`
+	Output        strings.Builder     `json:"-"`
+	Result        string              `json:"result,omitempty"`
+	ToolsUsed     []string            `json:"tools_used,omitempty"`
+	FilesModified []string            `json:"files_modified,omitempty"`
+	StreamChan    chan string         `json:"-"`
+	Error         error               `json:"-"`
+	StartedAt     time.Time           `json:"started_at"`
+	EndedAt       *time.Time          `json:"ended_at,omitempty"`
+	Progress      float64             `json:"progress"`
+
+	seq    int64
+	cancel context.CancelFunc
+	done   chan struct{}
 }
 
 // ClaudeCodeJobStatus represents job execution status
@@ -95,10 +102,39 @@ const (
 	JobStatusTimeout   ClaudeCodeJobStatus = "timeout"
 )
 
+// IsFinished reports whether a job in this status will not change anymore.
+func (s ClaudeCodeJobStatus) IsFinished() bool {
+	return s != JobStatusPending && s != JobStatusRunning
+}
+
+// ClaudeCodeJobInfo is a copy of a job's state that can be read while the job keeps running.
+type ClaudeCodeJobInfo struct {
+	ID            string              `json:"id"`
+	SessionID     string              `json:"session_id"`
+	Status        ClaudeCodeJobStatus `json:"status"`
+	Input         string              `json:"input"`
+	Output        string              `json:"-"`
+	Result        string              `json:"-"`
+	Error         string              `json:"error,omitempty"`
+	ToolsUsed     []string            `json:"tools_used"`
+	FilesModified []string            `json:"files_modified"`
+	Progress      float64             `json:"progress"`
+	StartedAt     time.Time           `json:"started_at"`
+	EndedAt       *time.Time          `json:"ended_at,omitempty"`
+}
+
+// Response returns the final answer of a finished job, or the text streamed so far.
+func (j *ClaudeCodeJobInfo) Response() string {
+	if j.Result != "" {
+		return j.Result
+	}
+	return j.Output
+}
+
 // ClaudeCodeResponse represents a response from Claude Code
 type ClaudeCodeResponse struct {
 	JobID         string                 `json:"job_id"`
-	Type          string                 `json:"type"` // message|tool_use|error|complete
+	Type          string                 `json:"type"` // init|message|tool_use|error|complete
 	Content       string                 `json:"content"`
 	ToolsUsed     []string               `json:"tools_used,omitempty"`
 	FilesModified []string               `json:"files_modified,omitempty"`
@@ -137,6 +173,12 @@ type JobOptions struct {
 	SessionID string        `json:"session_id"`
 }
 
+// ClaudeCodePermissionModes lists the permission modes accepted by the Claude Code CLI.
+var ClaudeCodePermissionModes = []string{"default", "acceptEdits", "plan", "bypassPermissions"}
+
+// fileEditingTools are the Claude Code tools whose file_path input is a file they modify.
+var fileEditingTools = map[string]bool{"Edit": true, "MultiEdit": true, "Write": true, "NotebookEdit": true}
+
 // NewClaudeCodeService creates a new ClaudeCodeService instance.
 func NewClaudeCodeService() *ClaudeCodeService {
 	service := &ClaudeCodeService{
@@ -146,13 +188,13 @@ func NewClaudeCodeService() *ClaudeCodeService {
 		eventBus:      make(chan *ClaudeCodeEvent, 100),
 		initialized:   false,
 		logger:        logger.NewStyledLogger("ClaudeCodeService"),
+		executable:    "claude",
 		jobCounter:    0,
 	}
 
 	// Initialize function fields with default implementations
 	service.isClaudeCodeInstalled = service.defaultIsClaudeCodeInstalled
 	service.getClaudeCodeVersion = service.defaultGetClaudeCodeVersion
-	service.StartProcess = service.defaultStartProcess
 
 	return service
 }
@@ -173,7 +215,7 @@ func (c *ClaudeCodeService) Initialize() error {
 
 	// Check if Claude Code is installed
 	if !c.isClaudeCodeInstalled() {
-		return fmt.Errorf("claude code CLI not found - please install it first: npm install -g @anthropic/claude-code")
+		return fmt.Errorf("claude code CLI not found - please install it first: npm install -g @anthropic-ai/claude-code")
 	}
 
 	// Verify Claude Code version compatibility
@@ -190,13 +232,13 @@ func (c *ClaudeCodeService) Initialize() error {
 
 // defaultIsClaudeCodeInstalled checks if Claude Code CLI is available
 func (c *ClaudeCodeService) defaultIsClaudeCodeInstalled() bool {
-	_, err := exec.LookPath("claude")
+	_, err := exec.LookPath(c.executable)
 	return err == nil
 }
 
 // getClaudeCodeVersion retrieves the Claude Code CLI version
 func (c *ClaudeCodeService) defaultGetClaudeCodeVersion() (string, error) {
-	cmd := exec.Command("claude", "--version")
+	cmd := exec.Command(c.executable, "--version")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", err
@@ -204,257 +246,42 @@ func (c *ClaudeCodeService) defaultGetClaudeCodeVersion() (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
-// StartProcess starts the Claude Code daemon process
-func (c *ClaudeCodeService) defaultStartProcess(opts InitOptions) error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	if c.process != nil {
-		return fmt.Errorf("claude code process already running")
-	}
-
-	// Create process context for cleanup
-	c.processCtx, c.cancelProcess = context.WithCancel(context.Background())
-
-	// Build command arguments
-	args := []string{}
-	if opts.Model != "" {
-		args = append(args, "--model", opts.Model)
-	}
-	if opts.Verbose {
-		args = append(args, "--verbose")
-	}
-	if opts.PermissionMode != "" {
-		args = append(args, "--permission-mode", opts.PermissionMode)
-	}
-	for _, dir := range opts.Directories {
-		args = append(args, "--add-dir", dir)
-	}
-
-	// Add output format for programmatic usage
-	args = append(args, "--output-format", "json")
-
-	// Start Claude Code process
-	c.process = exec.CommandContext(c.processCtx, "claude", args...)
-
-	// Set up pipes
-	stdin, err := c.process.StdinPipe()
-	if err != nil {
-		return fmt.Errorf("failed to create stdin pipe: %w", err)
-	}
-	c.stdin = stdin
-
-	stdout, err := c.process.StdoutPipe()
-	if err != nil {
-		return fmt.Errorf("failed to create stdout pipe: %w", err)
-	}
-	c.stdout = stdout
-
-	stderr, err := c.process.StderrPipe()
-	if err != nil {
-		return fmt.Errorf("failed to create stderr pipe: %w", err)
-	}
-	c.stderr = stderr
-
-	// Start the process
-	if err := c.process.Start(); err != nil {
-		return fmt.Errorf("failed to start claude code process: %w", err)
-	}
-
-	// Start output processing goroutines
-	go c.processOutput()
-	go c.processErrors()
-
-	c.logger.Info("Claude Code process started", "pid", c.process.Process.Pid)
-	return nil
+// Version returns the version reported by the Claude Code CLI.
+func (c *ClaudeCodeService) Version() (string, error) {
+	return c.getClaudeCodeVersion()
 }
 
-// StopProcess stops the Claude Code daemon process
-func (c *ClaudeCodeService) StopProcess() error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	if c.process == nil {
-		return nil
-	}
-
-	// Cancel the process context
-	if c.cancelProcess != nil {
-		c.cancelProcess()
-	}
-
-	// Close stdin to signal shutdown
-	if c.stdin != nil {
-		_ = c.stdin.Close()
-	}
-
-	// Wait for process to exit with timeout
-	done := make(chan error, 1)
-	go func() {
-		done <- c.process.Wait()
-	}()
-
-	select {
-	case err := <-done:
-		c.logger.Info("Claude Code process stopped", "error", err)
-	case <-time.After(5 * time.Second):
-		// Force kill if it doesn't stop gracefully
-		if err := c.process.Process.Kill(); err != nil {
-			c.logger.Error("Failed to kill Claude Code process", "error", err)
-		}
-		c.logger.Warn("Claude Code process force killed after timeout")
-	}
-
-	// Clean up
-	c.process = nil
-	c.stdin = nil
-	c.stdout = nil
-	c.stderr = nil
-
-	return nil
-}
-
-// processOutput handles stdout from Claude Code process
-func (c *ClaudeCodeService) processOutput() {
-	if c.stdout == nil {
-		return
-	}
-
-	scanner := bufio.NewScanner(c.stdout)
-	for scanner.Scan() {
-		line := scanner.Text()
-		c.logger.Debug("Claude Code output", "line", line)
-
-		// Try to parse as JSON response
-		var response ClaudeCodeResponse
-		if err := json.Unmarshal([]byte(line), &response); err == nil {
-			c.handleResponse(&response)
-		} else {
-			// Handle non-JSON output (might be streaming text)
-			c.handleStreamingOutput(line)
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		c.logger.Error("Error reading Claude Code stdout", "error", err)
-	}
-}
-
-// processErrors handles stderr from Claude Code process
-func (c *ClaudeCodeService) processErrors() {
-	if c.stderr == nil {
-		return
-	}
-
-	scanner := bufio.NewScanner(c.stderr)
-	for scanner.Scan() {
-		line := scanner.Text()
-		c.logger.Error("Claude Code stderr", "line", line)
-	}
-
-	if err := scanner.Err(); err != nil {
-		c.logger.Error("Error reading Claude Code stderr", "error", err)
-	}
-}
-
-// handleResponse processes structured responses from Claude Code
-func (c *ClaudeCodeService) handleResponse(response *ClaudeCodeResponse) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	// Update job status if job ID is provided
-	if response.JobID != "" {
-		if job, exists := c.jobs[response.JobID]; exists {
-			job.Output.WriteString(response.Content)
-
-			// Send to stream channel if open
-			if job.StreamChan != nil {
-				select {
-				case job.StreamChan <- response.Content:
-				default:
-					// Channel might be full or closed
-				}
-			}
-
-			// Update job status based on response type
-			switch response.Type {
-			case "complete":
-				job.Status = JobStatusCompleted
-				now := time.Now()
-				job.EndedAt = &now
-				job.Progress = 100.0
-			case "error":
-				job.Status = JobStatusFailed
-				now := time.Now()
-				job.EndedAt = &now
-			}
-
-			// Store tools used and files modified
-			if len(response.ToolsUsed) > 0 {
-				c.setJobVariable(response.JobID, "_cc_tools_used", strings.Join(response.ToolsUsed, ","))
-			}
-			if len(response.FilesModified) > 0 {
-				c.setJobVariable(response.JobID, "_cc_files_modified", strings.Join(response.FilesModified, ","))
-			}
-		}
-	}
-
-	// Send to response channel if someone is waiting
-	if ch, exists := c.responseChans[response.JobID]; exists {
-		select {
-		case ch <- response:
-		default:
-			// Channel might be full or closed
-		}
-	}
-}
-
-// handleStreamingOutput processes streaming text output
-func (c *ClaudeCodeService) handleStreamingOutput(line string) {
-	// This would handle streaming output that isn't JSON
-	// For now, just log it
-	c.logger.Debug("Streaming output", "line", line)
-}
-
-// setJobVariable sets a variable in the global context related to a job
-func (c *ClaudeCodeService) setJobVariable(_, varName, value string) {
-	ctx := neuroshellcontext.GetGlobalContext()
-	if ctx == nil {
-		return
-	}
-
-	if neuroCtx, ok := ctx.(*neuroshellcontext.NeuroContext); ok {
-		// Set as system variable since it starts with _
-		_ = neuroCtx.SetSystemVariable(varName, value)
-	}
-}
-
-// CreateSession creates a new Claude Code session
+// CreateSession creates a new Claude Code session. The Claude conversation behind it starts
+// with the session's first job; later jobs resume it.
 func (c *ClaudeCodeService) CreateSession(opts InitOptions) (*ClaudeCodeSession, error) {
 	if !c.initialized {
 		return nil, fmt.Errorf("claude code service not initialized")
 	}
 
-	// Ensure process is running
-	if c.process == nil {
-		if err := c.StartProcess(opts); err != nil {
-			return nil, err
-		}
+	if opts.PermissionMode != "" && !isValidPermissionMode(opts.PermissionMode) {
+		return nil, fmt.Errorf("invalid permission mode '%s': must be one of %s", opts.PermissionMode, strings.Join(ClaudeCodePermissionModes, ", "))
+	}
+
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		workingDirectory = "."
 	}
 
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	// Generate session ID
-	sessionID := fmt.Sprintf("cc-session-%d", time.Now().UnixNano())
+	c.sessionCounter++
+	sessionID := fmt.Sprintf("cc-session-%d", c.sessionCounter)
 
-	// Create session
 	session := &ClaudeCodeSession{
 		ID:               sessionID,
-		ClaudeSessionID:  "", // Will be set when Claude Code responds
+		ClaudeSessionID:  "", // Set from the first job's init event
 		Status:           "idle",
-		WorkingDirectory: ".", // Default to current directory
+		WorkingDirectory: workingDirectory,
 		Model:            opts.Model,
+		Verbose:          opts.Verbose,
+		Directories:      opts.Directories,
+		PermissionMode:   opts.PermissionMode,
 		CreatedAt:        time.Now(),
 		UpdatedAt:        time.Now(),
 	}
@@ -466,10 +293,20 @@ func (c *ClaudeCodeService) CreateSession(opts InitOptions) (*ClaudeCodeSession,
 		c.activeSession = sessionID
 	}
 
-	c.logger.Info("Created Claude Code session", "session_id", sessionID)
+	c.logger.Debug("Created Claude Code session", "session_id", sessionID)
 	return session, nil
 }
 
+// isValidPermissionMode checks a permission mode against the modes the CLI accepts.
+func isValidPermissionMode(mode string) bool {
+	for _, valid := range ClaudeCodePermissionModes {
+		if mode == valid {
+			return true
+		}
+	}
+	return false
+}
+
 // GetSession retrieves a session by ID
 func (c *ClaudeCodeService) GetSession(sessionID string) (*ClaudeCodeSession, error) {
 	c.mu.RLock()
@@ -492,7 +329,12 @@ func (c *ClaudeCodeService) GetActiveSession() (*ClaudeCodeSession, error) {
 		return nil, fmt.Errorf("no active session")
 	}
 
-	return c.GetSession(c.activeSession)
+	session, exists := c.sessions[c.activeSession]
+	if !exists {
+		return nil, fmt.Errorf("session %s not found", c.activeSession)
+	}
+
+	return session, nil
 }
 
 // SetActiveSession sets the active session
@@ -521,7 +363,8 @@ func (c *ClaudeCodeService) ListSessions() []*ClaudeCodeSession {
 	return sessions
 }
 
-// SubmitJob submits a job for execution
+// SubmitJob starts a job in the background and returns it while it is still pending.
+// The input is sent to Claude Code as the prompt of the session's next turn.
 func (c *ClaudeCodeService) SubmitJob(sessionID, command, input string, opts JobOptions) (*ClaudeCodeJob, error) {
 	if !c.initialized {
 		return nil, fmt.Errorf("claude code service not initialized")
@@ -540,7 +383,6 @@ func (c *ClaudeCodeService) SubmitJob(sessionID, command, input string, opts Job
 	c.jobCounter++
 	jobID := fmt.Sprintf("cc-job-%d", c.jobCounter)
 
-	// Create job
 	job := &ClaudeCodeJob{
 		ID:        jobID,
 		SessionID: sessionID,
@@ -549,6 +391,8 @@ func (c *ClaudeCodeService) SubmitJob(sessionID, command, input string, opts Job
 		Status:    JobStatusPending,
 		StartedAt: time.Now(),
 		Progress:  0.0,
+		seq:       c.jobCounter,
+		done:      make(chan struct{}),
 	}
 
 	// Set up stream channel if requested
@@ -556,53 +400,306 @@ func (c *ClaudeCodeService) SubmitJob(sessionID, command, input string, opts Job
 		job.StreamChan = make(chan string, 100)
 	}
 
-	c.jobs[jobID] = job
+	ctx := context.Background()
+	if opts.Timeout > 0 {
+		ctx, job.cancel = context.WithTimeout(ctx, opts.Timeout)
+	} else {
+		ctx, job.cancel = context.WithCancel(ctx)
+	}
 
-	// For testing: Add some mock output to demonstrate the service is working
-	job.Output.WriteString(fmt.Sprintf("Mock output for command: %s\nInput: %s\n", command, input))
+	c.jobs[jobID] = job
 
 	// Update session status
 	session.Status = "busy"
 	session.CurrentJobID = jobID
 	session.UpdatedAt = time.Now()
 
-	// Submit to Claude Code (this is a simplified implementation)
-	// In reality, we'd need to format the request properly for Claude Code
-	go c.executeJob(job)
+	cmd := exec.CommandContext(ctx, c.executable, claudeArgs(session)...)
+	cmd.Dir = session.WorkingDirectory
+	cmd.Stdin = strings.NewReader(input)
+	go c.executeJob(ctx, job, cmd, session.Verbose)
 
-	c.logger.Info("Submitted job", "job_id", jobID, "session_id", sessionID)
+	c.logger.Debug("Submitted job", "job_id", jobID, "session_id", sessionID)
 	return job, nil
 }
 
-// executeJob executes a job (simplified implementation)
-func (c *ClaudeCodeService) executeJob(job *ClaudeCodeJob) {
-	// Update job status
+// claudeArgs builds the command line for one non-interactive turn of a session.
+func claudeArgs(session *ClaudeCodeSession) []string {
+	args := []string{"-p", "--output-format", "stream-json", "--verbose"}
+	if session.Model != "" {
+		args = append(args, "--model", session.Model)
+	}
+	if session.PermissionMode != "" {
+		args = append(args, "--permission-mode", session.PermissionMode)
+	}
+	for _, dir := range session.Directories {
+		args = append(args, "--add-dir", dir)
+	}
+	if session.ClaudeSessionID != "" {
+		args = append(args, "--resume", session.ClaudeSessionID)
+	}
+	return args
+}
+
+// executeJob runs the Claude Code process of a job and feeds its stream-JSON events into the job.
+func (c *ClaudeCodeService) executeJob(ctx context.Context, job *ClaudeCodeJob, cmd *exec.Cmd, verbose bool) {
+	var stderr bytes.Buffer
+	reader, writer := io.Pipe()
+	cmd.Stdout = writer
+	cmd.Stderr = &stderr
+	// Children of a cancelled process may keep stdout open; don't wait on them for long
+	cmd.WaitDelay = time.Second
+	if err := cmd.Start(); err != nil {
+		c.finishJob(ctx, job, fmt.Errorf("failed to start claude code: %w", err))
+		return
+	}
+
 	c.mu.Lock()
 	job.Status = JobStatusRunning
 	c.mu.Unlock()
 
-	// This is a simplified implementation - in reality we'd send the command to Claude Code
-	// For now, simulate some work
-	time.Sleep(100 * time.Millisecond)
+	waitErr := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+		_ = writer.Close()
+		waitErr <- err
+	}()
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		for _, response := range parseStreamEvent(job.ID, scanner.Bytes(), verbose) {
+			c.handleResponse(response)
+		}
+	}
+	_, _ = io.Copy(io.Discard, reader)
+
+	err := <-waitErr
+	if err != nil && strings.TrimSpace(stderr.String()) != "" {
+		err = fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	c.finishJob(ctx, job, err)
+}
+
+// streamEvent is one line of `claude --output-format stream-json` output.
+type streamEvent struct {
+	Type      string `json:"type"` // system|assistant|user|result
+	Subtype   string `json:"subtype"`
+	SessionID string `json:"session_id"`
+	Message   struct {
+		Content []struct {
+			Type  string                 `json:"type"` // text|tool_use|thinking
+			Text  string                 `json:"text"`
+			Name  string                 `json:"name"`
+			Input map[string]interface{} `json:"input"`
+		} `json:"content"`
+	} `json:"message"`
+	Result  string `json:"result"`
+	IsError bool   `json:"is_error"`
+}
+
+// parseStreamEvent converts a stream-JSON line into responses for a job.
+// Lines that are not JSON, and events without anything for the job, yield no responses.
+func parseStreamEvent(jobID string, line []byte, verbose bool) []*ClaudeCodeResponse {
+	var event streamEvent
+	if err := json.Unmarshal(line, &event); err != nil {
+		return nil
+	}
+
+	metadata := map[string]interface{}{}
+	if event.SessionID != "" {
+		metadata["session_id"] = event.SessionID
+	}
+
+	var responses []*ClaudeCodeResponse
+	switch event.Type {
+	case "system":
+		responses = append(responses, &ClaudeCodeResponse{JobID: jobID, Type: "init", Metadata: metadata})
+	case "assistant":
+		for _, block := range event.Message.Content {
+			switch block.Type {
+			case "text":
+				responses = append(responses, &ClaudeCodeResponse{JobID: jobID, Type: "message", Content: block.Text, Metadata: metadata})
+			case "tool_use":
+				response := &ClaudeCodeResponse{JobID: jobID, Type: "tool_use", ToolsUsed: []string{block.Name}, Metadata: metadata}
+				if path := toolFilePath(block.Input); fileEditingTools[block.Name] && path != "" {
+					response.FilesModified = []string{path}
+				}
+				if verbose {
+					response.Content = toolSummary(block.Name, block.Input)
+				}
+				responses = append(responses, response)
+			}
+		}
+	case "result":
+		response := &ClaudeCodeResponse{JobID: jobID, Type: "complete", Content: event.Result, Metadata: metadata}
+		if event.IsError || strings.HasPrefix(event.Subtype, "error") {
+			response.Type = "error"
+			if response.Content == "" {
+				response.Content = strings.ReplaceAll(event.Subtype, "_", " ")
+			}
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+// toolFilePath returns the file a tool call works on, if any.
+func toolFilePath(input map[string]interface{}) string {
+	for _, key := range []string{"file_path", "notebook_path"} {
+		if path, ok := input[key].(string); ok {
+			return path
+		}
+	}
+	return ""
+}
 
-	// Simulate response
-	response := &ClaudeCodeResponse{
-		JobID:   job.ID,
-		Type:    "complete",
-		Content: fmt.Sprintf("Response to: %s", job.Input),
+// toolSummary describes a tool call on one line, such as "[Bash] go test ./...".
+func toolSummary(name string, input map[string]interface{}) string {
+	for _, key := range []string{"file_path", "notebook_path", "command", "pattern", "path", "url"} {
+		if value, ok := input[key].(string); ok && value != "" {
+			return fmt.Sprintf("[%s] %s", name, value)
+		}
 	}
+	return fmt.Sprintf("[%s]", name)
+}
 
-	c.handleResponse(response)
+// handleResponse processes structured responses from Claude Code
+func (c *ClaudeCodeService) handleResponse(response *ClaudeCodeResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	// Update session status
+	// Update job status if job ID is provided
+	if response.JobID != "" {
+		if job, exists := c.jobs[response.JobID]; exists {
+			if claudeSessionID, ok := response.Metadata["session_id"].(string); ok && claudeSessionID != "" {
+				if session, exists := c.sessions[job.SessionID]; exists {
+					session.ClaudeSessionID = claudeSessionID
+				}
+			}
+
+			switch response.Type {
+			case "complete":
+				// The result repeats the last message; it only becomes output when nothing was streamed
+				job.Result = response.Content
+				if job.Output.Len() == 0 {
+					job.Output.WriteString(response.Content)
+				}
+				job.Status = JobStatusCompleted
+				now := time.Now()
+				job.EndedAt = &now
+				job.Progress = 100.0
+			case "error":
+				job.Error = errors.New(response.Content)
+				job.Status = JobStatusFailed
+				now := time.Now()
+				job.EndedAt = &now
+			default:
+				c.appendJobOutput(job, response.Content)
+			}
+
+			for _, tool := range response.ToolsUsed {
+				job.ToolsUsed = appendUnique(job.ToolsUsed, tool)
+			}
+			for _, file := range response.FilesModified {
+				job.FilesModified = appendUnique(job.FilesModified, file)
+			}
+		}
+	}
+
+	// Send to response channel if someone is waiting
+	if ch, exists := c.responseChans[response.JobID]; exists {
+		select {
+		case ch <- response:
+		default:
+			// Channel might be full or closed
+		}
+	}
+}
+
+// appendJobOutput adds a streamed message to a job's output, one paragraph per message.
+// Callers must hold the lock.
+func (c *ClaudeCodeService) appendJobOutput(job *ClaudeCodeJob, content string) {
+	if content == "" {
+		return
+	}
+	chunk := content
+	if job.Output.Len() > 0 {
+		chunk = "\n\n" + content
+	}
+	job.Output.WriteString(chunk)
+
+	// Send to stream channel if open
+	if job.StreamChan != nil {
+		select {
+		case job.StreamChan <- chunk:
+		default:
+			// Nobody is reading; the chunk stays available in the output
+		}
+	}
+}
+
+// appendUnique appends value to values unless it is already there.
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+// finishJob records how a job's process ended and releases everyone waiting on it.
+func (c *ClaudeCodeService) finishJob(ctx context.Context, job *ClaudeCodeJob, err error) {
 	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		job.Status = JobStatusTimeout
+		job.Error = fmt.Errorf("job %s timed out", job.ID)
+	case errors.Is(ctx.Err(), context.Canceled):
+		job.Status = JobStatusCancelled
+		job.Error = fmt.Errorf("job %s was cancelled", job.ID)
+	case job.Status == JobStatusFailed:
+		// The result event already carries the error
+	case err != nil:
+		job.Status = JobStatusFailed
+		job.Error = err
+	default:
+		job.Status = JobStatusCompleted
+		job.Progress = 100.0
+	}
+	if job.EndedAt == nil {
+		now := time.Now()
+		job.EndedAt = &now
+	}
+	if job.cancel != nil {
+		job.cancel()
+	}
+
 	if session, exists := c.sessions[job.SessionID]; exists {
 		session.Status = "idle"
-		session.CurrentJobID = ""
-		session.LastResponse = response.Content
+		if job.Status != JobStatusCompleted {
+			session.Status = "error"
+		}
+		if session.CurrentJobID == job.ID {
+			session.CurrentJobID = ""
+		}
+		if job.Status == JobStatusCompleted {
+			session.LastResponse = job.Result
+			if session.LastResponse == "" {
+				session.LastResponse = job.Output.String()
+			}
+		}
 		session.UpdatedAt = time.Now()
 	}
-	c.mu.Unlock()
+
+	if job.StreamChan != nil {
+		close(job.StreamChan)
+	}
+	close(job.done)
+	c.logger.Debug("Job finished", "job_id", job.ID, "status", job.Status)
 }
 
 // GetJob retrieves a job by ID
@@ -618,32 +715,102 @@ func (c *ClaudeCodeService) GetJob(jobID string) (*ClaudeCodeJob, error) {
 	return job, nil
 }
 
-// WaitForJob waits for a job to complete
+// GetJobInfo returns a copy of a job's current state.
+func (c *ClaudeCodeService) GetJobInfo(jobID string) (*ClaudeCodeJobInfo, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	job, exists := c.jobs[jobID]
+	if !exists {
+		return nil, fmt.Errorf("job %s not found", jobID)
+	}
+
+	return jobInfo(job), nil
+}
+
+// ListJobs returns copies of all jobs in submission order.
+func (c *ClaudeCodeService) ListJobs() []*ClaudeCodeJobInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	jobs := make([]*ClaudeCodeJob, 0, len(c.jobs))
+	for _, job := range c.jobs {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].seq < jobs[j].seq })
+
+	infos := make([]*ClaudeCodeJobInfo, 0, len(jobs))
+	for _, job := range jobs {
+		infos = append(infos, jobInfo(job))
+	}
+	return infos
+}
+
+// jobInfo copies a job's state. Callers must hold the lock.
+func jobInfo(job *ClaudeCodeJob) *ClaudeCodeJobInfo {
+	info := &ClaudeCodeJobInfo{
+		ID:            job.ID,
+		SessionID:     job.SessionID,
+		Status:        job.Status,
+		Input:         job.Input,
+		Output:        job.Output.String(),
+		Result:        job.Result,
+		ToolsUsed:     append([]string{}, job.ToolsUsed...),
+		FilesModified: append([]string{}, job.FilesModified...),
+		Progress:      job.Progress,
+		StartedAt:     job.StartedAt,
+		EndedAt:       job.EndedAt,
+	}
+	if job.Error != nil {
+		info.Error = job.Error.Error()
+	}
+	return info
+}
+
+// WaitForJob waits for a job to finish, successfully or not
 func (c *ClaudeCodeService) WaitForJob(jobID string, timeout time.Duration) error {
 	job, err := c.GetJob(jobID)
 	if err != nil {
 		return err
 	}
 
-	deadline := time.Now().Add(timeout)
-	for time.Now().Before(deadline) {
-		if job.Status == JobStatusCompleted || job.Status == JobStatusFailed {
-			return nil
-		}
-		time.Sleep(100 * time.Millisecond)
+	select {
+	case <-job.done:
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("job %s timed out after %v", jobID, timeout)
+	}
+}
+
+// CancelJob stops a pending or running job.
+func (c *ClaudeCodeService) CancelJob(jobID string) error {
+	c.mu.RLock()
+	job, exists := c.jobs[jobID]
+	finished := exists && job.Status.IsFinished()
+	c.mu.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("job %s not found", jobID)
+	}
+	if finished {
+		return fmt.Errorf("job %s has already finished", jobID)
 	}
 
-	return fmt.Errorf("job %s timed out after %v", jobID, timeout)
+	if job.cancel != nil {
+		job.cancel()
+	}
+	<-job.done
+	return nil
 }
 
 // GetJobOutput returns the current output of a job
 func (c *ClaudeCodeService) GetJobOutput(jobID string) (string, error) {
-	job, err := c.GetJob(jobID)
+	info, err := c.GetJobInfo(jobID)
 	if err != nil {
 		return "", err
 	}
 
-	return job.Output.String(), nil
+	return info.Output, nil
 }
 
 // CheckAuth checks the current authentication status
@@ -679,18 +846,21 @@ func (c *ClaudeCodeService) Health() map[string]interface{} {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
+	running := 0
+	for _, job := range c.jobs {
+		if !job.Status.IsFinished() {
+			running++
+		}
+	}
+
 	health := map[string]interface{}{
 		"initialized":       c.initialized,
-		"process_running":   c.process != nil,
 		"active_sessions":   len(c.sessions),
 		"active_jobs":       len(c.jobs),
+		"running_jobs":      running,
 		"active_session_id": c.activeSession,
 	}
 
-	if c.process != nil {
-		health["process_pid"] = c.process.Process.Pid
-	}
-
 	// Add version info if available
 	if version, err := c.getClaudeCodeVersion(); err == nil {
 		health["claude_version"] = version
@@ -701,15 +871,17 @@ func (c *ClaudeCodeService) Health() map[string]interface{} {
 
 // Cleanup shuts down the service gracefully
 func (c *ClaudeCodeService) Cleanup() error {
-	c.logger.Info("Shutting down Claude Code service")
+	c.logger.Debug("Shutting down Claude Code service")
 
-	// Stop the process if running
-	if err := c.StopProcess(); err != nil {
-		c.logger.Error("Error stopping Claude Code process", "error", err)
+	// Stop jobs that are still running
+	c.mu.Lock()
+	for _, job := range c.jobs {
+		if !job.Status.IsFinished() && job.cancel != nil {
+			job.cancel()
+		}
 	}
 
 	// Close event bus and response channels
-	c.mu.Lock()
 	if !c.eventBusClosed {
 		close(c.eventBus)
 		c.eventBusClosed = true
@@ -722,3 +894,23 @@ func (c *ClaudeCodeService) Cleanup() error {
 
 	return nil
 }
+
+// GetClaudeCodeService retrieves the Claude Code service from the registry.
+func (r *Registry) GetClaudeCodeService() (*ClaudeCodeService, error) {
+	service, err := r.GetService("claudecode")
+	if err != nil {
+		return nil, err
+	}
+
+	claudeCodeService, ok := service.(*ClaudeCodeService)
+	if !ok {
+		return nil, fmt.Errorf("claude code service has incorrect type")
+	}
+
+	return claudeCodeService, nil
+}
+
+// GetGlobalClaudeCodeService returns the Claude Code service from the global registry.
+func GetGlobalClaudeCodeService() (*ClaudeCodeService, error) {
+	return GetGlobalRegistry().GetClaudeCodeService()
+}
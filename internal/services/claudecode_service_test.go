@@ -22,14 +22,16 @@
 //   - `go test -short ./internal/services/` - runs only unit tests (skips integration)
 //   - `go test -run ".*Mocked.*" ./internal/services/` - runs only mocked unit tests
 //
-// The service uses function fields (isClaudeCodeInstalled, getClaudeCodeVersion, StartProcess)
-// that can be mocked for testing, ensuring unit tests work regardless of CLI availability.
+// The service uses function fields (isClaudeCodeInstalled, getClaudeCodeVersion) that can be
+// mocked for testing. Jobs run test/fixtures/claudecode/claude, a fake CLI that emits canned
+// stream-JSON, so unit tests work regardless of CLI availability.
 package services
 
 import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -350,13 +352,20 @@ func TestClaudeCodeService_JobWithStreaming(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, job.StreamChan)
 
-	// Wait a bit for job processing (in real implementation)
-	time.Sleep(50 * time.Millisecond)
+	// Messages arrive on the stream channel as Claude Code produces them
+	select {
+	case chunk := <-job.StreamChan:
+		assert.Equal(t, "Echo: Hello world", chunk)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no streamed output")
+	}
 
-	// Check if we can get output
+	require.NoError(t, service.WaitForJob(job.ID, 5*time.Second))
 	output, err := service.GetJobOutput(job.ID)
 	assert.NoError(t, err)
-	assert.NotEmpty(t, output) // Should have some output from mocked execution
+	assert.Equal(t, "Echo: Hello world", output)
+	_, open := <-job.StreamChan
+	assert.False(t, open, "the stream channel is closed when the job ends")
 }
 
 func TestClaudeCodeService_WaitForJob(t *testing.T) {
@@ -474,7 +483,7 @@ func TestClaudeCodeService_Health(t *testing.T) {
 	session, err := service.CreateSession(InitOptions{Model: "sonnet"})
 	require.NoError(t, err)
 
-	_, err = service.SubmitJob(session.ID, "message", "test", JobOptions{})
+	_, err = service.SubmitJob(session.ID, "message", "slow task", JobOptions{})
 	require.NoError(t, err)
 
 	health := service.Health()
@@ -483,9 +492,10 @@ func TestClaudeCodeService_Health(t *testing.T) {
 	assert.Equal(t, 1, health["active_sessions"].(int))
 	assert.Equal(t, 1, health["active_jobs"].(int))
 	assert.Equal(t, session.ID, health["active_session_id"].(string))
+	assert.Equal(t, "1.0.0 (Claude Code)", health["claude_version"])
 
-	// Process should be running (even in test mode with mocked process)
-	assert.True(t, health["process_running"].(bool))
+	// The job's Claude Code process is running
+	assert.Equal(t, 1, health["running_jobs"].(int))
 }
 
 func TestClaudeCodeService_Cleanup(t *testing.T) {
@@ -509,52 +519,127 @@ func TestClaudeCodeService_Cleanup(t *testing.T) {
 func TestClaudeCodeService_ProcessLifecycle(t *testing.T) {
 	service := setupTestClaudeCodeService(t)
 
-	// Process should not be running initially
-	assert.Nil(t, service.process)
+	// No Claude Code process runs until a job is submitted
+	assert.Equal(t, 0, service.Health()["running_jobs"].(int))
 
-	// Starting process should work (mocked)
-	opts := InitOptions{Model: "sonnet", Verbose: true}
+	session, err := service.CreateSession(InitOptions{Model: "sonnet", Verbose: true})
+	require.NoError(t, err)
+	job, err := service.SubmitJob(session.ID, "message", "slow task", JobOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, service.Health()["running_jobs"].(int))
 
-	// Mock the process start since we don't want to actually start Claude Code in tests
-	originalStart := service.StartProcess
-	service.StartProcess = func(_ InitOptions) error {
-		// Simulate successful start
-		return nil
-	}
-	defer func() {
-		service.StartProcess = originalStart
-	}()
+	// Cleanup stops the process of a running job
+	require.NoError(t, service.Cleanup())
+	require.NoError(t, service.WaitForJob(job.ID, 5*time.Second))
+	info, err := service.GetJobInfo(job.ID)
+	require.NoError(t, err)
+	assert.True(t, info.Status.IsFinished())
+	assert.NotEqual(t, JobStatusCompleted, info.Status)
+	assert.Equal(t, 0, service.Health()["running_jobs"].(int))
+}
 
-	err := service.StartProcess(opts)
-	assert.NoError(t, err)
+func TestClaudeCodeService_CancelJob(t *testing.T) {
+	service := setupTestClaudeCodeService(t)
 
-	// Test stopping process
-	err = service.StopProcess()
-	assert.NoError(t, err)
+	session, err := service.CreateSession(InitOptions{})
+	require.NoError(t, err)
+
+	job, err := service.SubmitJob(session.ID, "message", "slow task", JobOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		output, _ := service.GetJobOutput(job.ID)
+		return output != ""
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, service.CancelJob(job.ID))
+	info, err := service.GetJobInfo(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusCancelled, info.Status)
+	assert.Equal(t, "Working on it...", info.Output)
+	assert.ErrorContains(t, service.CancelJob(job.ID), "already finished")
+
+	timed, err := service.SubmitJob(session.ID, "message", "slow task", JobOptions{Timeout: 100 * time.Millisecond})
+	require.NoError(t, err)
+	require.NoError(t, service.WaitForJob(timed.ID, 5*time.Second))
+	info, err = service.GetJobInfo(timed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusTimeout, info.Status)
+}
+
+func TestClaudeCodeService_StreamJSONJob(t *testing.T) {
+	service := setupTestClaudeCodeService(t)
+
+	session, err := service.CreateSession(InitOptions{Verbose: true})
+	require.NoError(t, err)
+
+	job, err := service.SubmitJob(session.ID, "message", "edit the app", JobOptions{})
+	require.NoError(t, err)
+	require.NoError(t, service.WaitForJob(job.ID, 5*time.Second))
+
+	info, err := service.GetJobInfo(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusCompleted, info.Status)
+	assert.Equal(t, "Updated src/app.go.", info.Response())
+	assert.Equal(t, "I'll update the file.\n\n[Read] src/app.go\n\n[Edit] src/app.go\n\nUpdated src/app.go.", info.Output)
+	assert.Equal(t, []string{"Read", "Edit"}, info.ToolsUsed)
+	assert.Equal(t, []string{"src/app.go"}, info.FilesModified, "only editing tools modify files")
+
+	// The next turn resumes the Claude conversation started by the first one
+	updated, err := service.GetSession(session.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "fake-session-1", updated.ClaudeSessionID)
+	assert.Equal(t, "Updated src/app.go.", updated.LastResponse)
+	assert.Contains(t, claudeArgs(updated), "--resume")
+
+	next, err := service.SubmitJob(session.ID, "message", "thanks", JobOptions{})
+	require.NoError(t, err)
+	require.NoError(t, service.WaitForJob(next.ID, 5*time.Second))
+	output, err := service.GetJobOutput(next.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Echo: thanks (resumed)", output)
+
+	failed, err := service.SubmitJob(session.ID, "message", "fail now", JobOptions{})
+	require.NoError(t, err)
+	require.NoError(t, service.WaitForJob(failed.ID, 5*time.Second))
+	info, err = service.GetJobInfo(failed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusFailed, info.Status)
+	assert.Equal(t, "Something went wrong", info.Error)
+	updated, err = service.GetSession(session.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "error", updated.Status)
+
+	jobs := service.ListJobs()
+	require.Len(t, jobs, 3)
+	assert.Equal(t, job.ID, jobs[0].ID)
+}
+
+func TestClaudeArgs(t *testing.T) {
+	args := claudeArgs(&ClaudeCodeSession{
+		Model:          "opus",
+		PermissionMode: "plan",
+		Directories:    []string{"./src", "./tests"},
+	})
+	assert.Equal(t, []string{
+		"-p", "--output-format", "stream-json", "--verbose",
+		"--model", "opus", "--permission-mode", "plan", "--add-dir", "./src", "--add-dir", "./tests",
+	}, args)
+
+	service := setupTestClaudeCodeService(t)
+	_, err := service.CreateSession(InitOptions{PermissionMode: "auto"})
+	assert.ErrorContains(t, err, "invalid permission mode")
 }
 
-// setupTestClaudeCodeService creates a service for testing
+// setupTestClaudeCodeService creates a service for testing that runs the fake Claude Code CLI
 func setupTestClaudeCodeService(t *testing.T) *ClaudeCodeService {
 	service := NewClaudeCodeService()
 
-	// Mock Claude Code installation and version checks
-	service.isClaudeCodeInstalled = func() bool { return true }
-	service.getClaudeCodeVersion = func() (string, error) { return "1.5.0", nil }
-	// Mock the StartProcess function to avoid trying to start actual claude process in tests
-	service.StartProcess = func(_ InitOptions) error {
-		// Mock successful process start - start a harmless long-running command for health checks
-		// We use 'sleep' which is available on all systems and won't interfere with tests
-		cmd := exec.Command("sleep", "3600") // Sleep for 1 hour, test cleanup will kill it
-		err := cmd.Start()
-		if err != nil {
-			return err
-		}
-		service.process = cmd
-		return nil
-	}
+	executable, err := filepath.Abs(filepath.Join("..", "..", "test", "fixtures", "claudecode", "claude"))
+	require.NoError(t, err)
+	service.executable = executable
 
 	// Initialize the service
-	err := service.Initialize()
+	err = service.Initialize()
 	require.NoError(t, err)
 
 	// Set up test context
@@ -651,31 +736,31 @@ func TestClaudeCodeService_CICDSkipBehavior(t *testing.T) {
 func TestClaudeCodeService_Integration_ResponseHandling(t *testing.T) {
 	service := setupTestClaudeCodeService(t)
 
-	// Test response handling
-	response := &ClaudeCodeResponse{
-		JobID:         "test-job",
-		Type:          "complete",
-		Content:       "Test response",
-		ToolsUsed:     []string{"Read", "Write"},
-		FilesModified: []string{"test.go"},
-	}
-
-	// Create a job to handle response for
 	session, err := service.CreateSession(InitOptions{})
 	require.NoError(t, err)
 
-	job, err := service.SubmitJob(session.ID, "message", "test", JobOptions{})
-	require.NoError(t, err)
-
-	// Set the job ID to match our test response
+	// A job fed by hand instead of by a Claude Code process
+	job := &ClaudeCodeJob{ID: "test-job", SessionID: session.ID, Status: JobStatusRunning, done: make(chan struct{})}
 	service.mu.Lock()
-	delete(service.jobs, job.ID)
-	job.ID = "test-job"
-	service.jobs["test-job"] = job
+	service.jobs[job.ID] = job
 	service.mu.Unlock()
 
-	// Handle the response
-	service.handleResponse(response)
+	service.handleResponse(&ClaudeCodeResponse{
+		JobID:    "test-job",
+		Type:     "init",
+		Metadata: map[string]interface{}{"session_id": "claude-123"},
+	})
+	service.handleResponse(&ClaudeCodeResponse{
+		JobID:         "test-job",
+		Type:          "tool_use",
+		ToolsUsed:     []string{"Read", "Write"},
+		FilesModified: []string{"test.go"},
+	})
+	service.handleResponse(&ClaudeCodeResponse{
+		JobID:   "test-job",
+		Type:    "complete",
+		Content: "Test response",
+	})
 
 	// Verify the job was updated
 	updatedJob, err := service.GetJob("test-job")
@@ -684,6 +769,52 @@ func TestClaudeCodeService_Integration_ResponseHandling(t *testing.T) {
 	assert.Contains(t, updatedJob.Output.String(), "Test response")
 	assert.NotNil(t, updatedJob.EndedAt)
 	assert.Equal(t, 100.0, updatedJob.Progress)
+	assert.Equal(t, []string{"Read", "Write"}, updatedJob.ToolsUsed)
+	assert.Equal(t, []string{"test.go"}, updatedJob.FilesModified)
+
+	updatedSession, err := service.GetSession(session.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "claude-123", updatedSession.ClaudeSessionID)
+
+	// A complete response may carry the tools and files of the whole turn
+	single := &ClaudeCodeJob{ID: "single-job", SessionID: session.ID, Status: JobStatusRunning, done: make(chan struct{})}
+	service.mu.Lock()
+	service.jobs[single.ID] = single
+	service.mu.Unlock()
+
+	service.handleResponse(&ClaudeCodeResponse{
+		JobID:         "single-job",
+		Type:          "complete",
+		Content:       "Test response",
+		ToolsUsed:     []string{"Read", "Write"},
+		FilesModified: []string{"test.go"},
+	})
+
+	updatedJob, err = service.GetJob("single-job")
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusCompleted, updatedJob.Status)
+	assert.Equal(t, "Test response", updatedJob.Output.String())
+	assert.Equal(t, []string{"Read", "Write"}, updatedJob.ToolsUsed)
+	assert.Equal(t, []string{"test.go"}, updatedJob.FilesModified)
+}
+
+func TestParseStreamEvent(t *testing.T) {
+	responses := parseStreamEvent("job", []byte(`{"type":"assistant","message":{"content":[{"type":"thinking","thinking":"hmm"},{"type":"text","text":"Hi"},{"type":"tool_use","name":"Bash","input":{"command":"go test ./..."}}]},"session_id":"s1"}`), true)
+	require.Len(t, responses, 2)
+	assert.Equal(t, "message", responses[0].Type)
+	assert.Equal(t, "Hi", responses[0].Content)
+	assert.Equal(t, "tool_use", responses[1].Type)
+	assert.Equal(t, "[Bash] go test ./...", responses[1].Content)
+	assert.Empty(t, responses[1].FilesModified)
+	assert.Equal(t, "s1", responses[1].Metadata["session_id"])
+
+	responses = parseStreamEvent("job", []byte(`{"type":"result","subtype":"error_max_turns","is_error":true,"session_id":"s1"}`), false)
+	require.Len(t, responses, 1)
+	assert.Equal(t, "error", responses[0].Type)
+	assert.Equal(t, "error max turns", responses[0].Content)
+
+	assert.Empty(t, parseStreamEvent("job", []byte("not json"), false))
+	assert.Empty(t, parseStreamEvent("job", []byte(`{"type":"user","message":{"content":[]}}`), false))
 }
 
 func TestClaudeCodeService_ConcurrentOperations(t *testing.T) {
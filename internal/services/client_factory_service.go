@@ -57,7 +57,11 @@ func (f *ClientFactoryService) GetClientForProvider(providerCatalogID, apiKey st
 		return nil, fmt.Errorf("provider catalog ID cannot be empty")
 	}
 
-	if apiKey == "" {
+	// OpenAI-compatible endpoints and the mock provider may be keyless; all other providers require an API key
+	compatibleEntry, isCompatible := lookupOpenAICompatibleProvider(providerCatalogID)
+	if isCompatible {
+		providerCatalogID = compatibleEntry.ID
+	} else if apiKey == "" && providerCatalogID != MockProviderCatalogID {
 		return nil, fmt.Errorf("API key cannot be empty for provider catalog ID '%s'", providerCatalogID)
 	}
 
@@ -71,25 +75,54 @@ func (f *ClientFactoryService) GetClientForProvider(providerCatalogID, apiKey st
 	}
 
 	// Create client based on provider catalog ID
-	var client neurotypes.LLMClient
+	client, err := f.createClient(providerCatalogID, apiKey, compatibleEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	// Store client in context cache
+	f.llmClientCtx.StoreClient(clientID, client)
+
+	logger.Debug("Created new provider client", "provider_catalog_id", providerCatalogID, "clientID", clientID)
+	return client, nil
+}
+
+// createClient builds a new LLM client for the provider catalog ID.
+// Natively supported providers have dedicated clients; catalog entries with client_type
+// "openai-compatible" use the OpenAI chat client pointed at the entry's base URL.
+func (f *ClientFactoryService) createClient(providerCatalogID, apiKey string, compatibleEntry *neurotypes.ProviderCatalogEntry) (neurotypes.LLMClient, error) {
 	switch providerCatalogID {
 	case "OAC": // OpenAI Chat Completions
-		client = NewOpenAIClient(apiKey)
+		return NewOpenAIClient(apiKey), nil
 	case "OAR": // OpenAI Reasoning/Responses
-		client = NewOpenAIReasoningClient(apiKey)
+		return NewOpenAIReasoningClient(apiKey), nil
 	case "ANC": // Anthropic Chat
-		client = NewAnthropicClient(apiKey)
+		return NewAnthropicClient(apiKey), nil
 	case "GMC": // Gemini Chat
-		client = NewGeminiClient(apiKey)
-	default:
-		return nil, fmt.Errorf("unsupported provider catalog ID '%s'. Supported catalog IDs: OAC, OAR, ANC, GMC", providerCatalogID)
+		return NewGeminiClient(apiKey), nil
+	case MockProviderCatalogID: // Scripted mock responses
+		return NewMockProviderClient(MockClientOptions{})
 	}
 
-	// Store client in context cache
-	f.llmClientCtx.StoreClient(clientID, client)
+	if compatibleEntry != nil {
+		return NewOpenAICompatibleClient(*compatibleEntry, apiKey), nil
+	}
 
-	logger.Debug("Created new provider client", "provider_catalog_id", providerCatalogID, "clientID", clientID)
-	return client, nil
+	return nil, fmt.Errorf("unsupported provider catalog ID '%s'. Supported catalog IDs: OAC, OAR, ANC, GMC, MOCK, or any openai-compatible provider catalog entry", providerCatalogID)
+}
+
+// lookupOpenAICompatibleProvider returns the provider catalog entry for an OpenAI-compatible
+// endpoint. Returns false for native providers, unknown IDs, or when the catalog is unavailable.
+func lookupOpenAICompatibleProvider(providerCatalogID string) (*neurotypes.ProviderCatalogEntry, bool) {
+	providerCatalogService, err := GetGlobalProviderCatalogService()
+	if err != nil {
+		return nil, false
+	}
+	entry, err := providerCatalogService.GetProviderByID(providerCatalogID)
+	if err != nil || !entry.IsOpenAICompatible() {
+		return nil, false
+	}
+	return &entry, true
 }
 
 // generateClientID creates a unique, secure client ID for the given provider catalog ID and API key.
@@ -120,7 +153,11 @@ func (f *ClientFactoryService) GetClientWithID(providerCatalogID, apiKey string)
 		return nil, "", fmt.Errorf("provider catalog ID cannot be empty")
 	}
 
-	if apiKey == "" {
+	// OpenAI-compatible endpoints and the mock provider may be keyless; all other providers require an API key
+	compatibleEntry, isCompatible := lookupOpenAICompatibleProvider(providerCatalogID)
+	if isCompatible {
+		providerCatalogID = compatibleEntry.ID
+	} else if apiKey == "" && providerCatalogID != MockProviderCatalogID {
 		return nil, "", fmt.Errorf("API key cannot be empty for provider catalog ID '%s'", providerCatalogID)
 	}
 
@@ -134,18 +171,9 @@ func (f *ClientFactoryService) GetClientWithID(providerCatalogID, apiKey string)
 	}
 
 	// Create client based on provider catalog ID
-	var client neurotypes.LLMClient
-	switch providerCatalogID {
-	case "OAC": // OpenAI Chat Completions
-		client = NewOpenAIClient(apiKey)
-	case "OAR": // OpenAI Reasoning/Responses
-		client = NewOpenAIReasoningClient(apiKey)
-	case "ANC": // Anthropic Chat
-		client = NewAnthropicClient(apiKey)
-	case "GMC": // Gemini Chat
-		client = NewGeminiClient(apiKey)
-	default:
-		return nil, "", fmt.Errorf("unsupported provider catalog ID '%s'. Supported catalog IDs: OAC, OAR, ANC, GMC", providerCatalogID)
+	client, err := f.createClient(providerCatalogID, apiKey, compatibleEntry)
+	if err != nil {
+		return nil, "", err
 	}
 
 	// Store client in context cache
@@ -155,6 +183,34 @@ func (f *ClientFactoryService) GetClientWithID(providerCatalogID, apiKey string)
 	return client, clientID, nil
 }
 
+// GetMockClientWithID returns a mock provider client with scripted responses and its client ID.
+// Clients with equal options are shared; the default echo client has the ID "MOCK:empty***".
+func (f *ClientFactoryService) GetMockClientWithID(options MockClientOptions) (neurotypes.LLMClient, string, error) {
+	if !f.initialized {
+		return nil, "", fmt.Errorf("client factory service not initialized")
+	}
+
+	options, err := normalizeMockClientOptions(options)
+	if err != nil {
+		return nil, "", err
+	}
+
+	clientID := f.generateClientID(MockProviderCatalogID, options.Fingerprint())
+	if client, exists := f.llmClientCtx.GetClient(clientID); exists {
+		logger.Debug("Returning cached mock client with ID", "clientID", clientID)
+		return client, clientID, nil
+	}
+
+	client, err := NewMockProviderClient(options)
+	if err != nil {
+		return nil, "", err
+	}
+	f.llmClientCtx.StoreClient(clientID, client)
+
+	logger.Debug("Created new mock client with ID", "clientID", clientID, "mode", options.Mode)
+	return client, clientID, nil
+}
+
 // GetClientByID retrieves a cached LLM client by its client ID.
 // Client ID format: "catalog_id:hashed-api-key" (e.g., "OAC:a1b2c3d4...")
 // This method enables direct O(1) lookup using the client ID as the cache key.
@@ -600,3 +600,41 @@ func TestClientFactoryService_GetClientByID(t *testing.T) {
 		})
 	}
 }
+
+func TestClientFactoryService_OpenAICompatibleProviders(t *testing.T) {
+	oldServiceRegistry := GetGlobalRegistry()
+	SetGlobalRegistry(NewRegistry())
+	context.ResetGlobalContext()
+	t.Cleanup(func() {
+		SetGlobalRegistry(oldServiceRegistry)
+		context.ResetGlobalContext()
+	})
+
+	providerCatalogService := NewProviderCatalogService()
+	require.NoError(t, GetGlobalRegistry().RegisterService(providerCatalogService))
+	require.NoError(t, providerCatalogService.Initialize())
+
+	service := NewClientFactoryService()
+	require.NoError(t, service.Initialize())
+
+	// Keyless endpoints are allowed and the catalog ID is normalized
+	client, clientID, err := service.GetClientWithID("olc", "")
+	require.NoError(t, err)
+	assert.Equal(t, "OLC:empty***", clientID)
+	assert.Equal(t, "ollama", client.GetProviderName())
+	assert.True(t, client.IsConfigured())
+
+	cached, err := service.GetClientByID(clientID)
+	require.NoError(t, err)
+	assert.Same(t, client, cached)
+
+	_, keyedID, err := service.GetClientWithID("VLC", "vllm-secret")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(keyedID, "VLC:"))
+	assert.NotEqual(t, "VLC:empty***", keyedID)
+
+	// Native providers still require an API key
+	_, _, err = service.GetClientWithID("ANC", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "API key cannot be empty")
+}
@@ -0,0 +1,471 @@
+// Package services provides context-window management for long chat sessions.
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"neuroshell/internal/logger"
+	"neuroshell/pkg/neurotypes"
+)
+
+// Context policy configuration names.
+const (
+	ContextPolicyParameter = "context_policy"  // Per-model policy set with \model-new[context_policy=...]
+	ContextWindowParameter = "context_window"  // Per-model override of the catalog context window in tokens
+	ContextPolicyVariable  = "_context_policy" // Global policy used when neither session nor model sets one
+)
+
+// Context policies applied when a session's prompt does not fit the model's context window.
+const (
+	ContextPolicyError           = "error"                  // Fail before sending
+	ContextPolicyTruncateOldest  = "truncate-oldest"        // Drop the oldest messages until the prompt fits
+	ContextPolicyKeepLast        = "keep-system-and-last-N" // Keep the system prompt and the last N messages
+	ContextPolicySummarizeOldest = "summarize-oldest"       // Replace the oldest messages with a model-written summary
+	contextKeepLastPrefix        = "keep-system-and-last-"
+)
+
+// Error codes reported for context policy failures.
+const (
+	ContextExceededCode      = "context_window_exceeded"
+	contextInvalidPolicyCode = "invalid_context_policy"
+	contextSummaryFailedCode = "context_summary_failed"
+)
+
+// contextSummaryPrompt instructs the model when compacting early turns for summarize-oldest.
+const contextSummaryPrompt = "You compact conversations so they can continue in a limited context window. " +
+	"Summarize the conversation you are given, keeping facts, decisions, names, numbers and open questions. " +
+	"Reply with the summary only."
+
+// ContextPolicy is a parsed context policy and where it was configured.
+type ContextPolicy struct {
+	Name     string // One of the ContextPolicy* names, empty when no policy is configured
+	KeepLast int    // Messages kept by keep-system-and-last-N
+	Source   string // "session", "model" or "variable"
+}
+
+// String returns the policy as it is written in configuration, e.g. "keep-system-and-last-6".
+func (p ContextPolicy) String() string {
+	if p.Name == ContextPolicyKeepLast {
+		return contextKeepLastPrefix + strconv.Itoa(p.KeepLast)
+	}
+	return p.Name
+}
+
+// ParseContextPolicy parses a context policy value. An empty value means no policy.
+func ParseContextPolicy(value string) (ContextPolicy, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "":
+		return ContextPolicy{}, nil
+	case ContextPolicyError, ContextPolicyTruncateOldest, ContextPolicySummarizeOldest:
+		return ContextPolicy{Name: value}, nil
+	}
+	if count, found := strings.CutPrefix(value, contextKeepLastPrefix); found {
+		keep, err := strconv.Atoi(count)
+		if err == nil && keep > 0 {
+			return ContextPolicy{Name: ContextPolicyKeepLast, KeepLast: keep}, nil
+		}
+	}
+	return ContextPolicy{}, fmt.Errorf("invalid context policy '%s': must be error, truncate-oldest, keep-system-and-last-N (e.g. keep-system-and-last-10) or summarize-oldest", value)
+}
+
+// tokenEstimator approximates a provider's tokenizer without calling it.
+type tokenEstimator struct {
+	bytesPerToken   float64 // Average UTF-8 bytes per token
+	messageOverhead int     // Tokens added per message for role and formatting
+}
+
+// tokenEstimators holds the estimates per provider. Counting bytes rather than characters keeps
+// estimates conservative for non-Latin scripts, which use more tokens per character.
+var tokenEstimators = map[string]tokenEstimator{
+	"openai":    {bytesPerToken: 4, messageOverhead: 4},
+	"anthropic": {bytesPerToken: 3.5, messageOverhead: 5},
+	"gemini":    {bytesPerToken: 4, messageOverhead: 5},
+}
+
+// defaultTokenEstimator is used for OpenAI-compatible servers and other providers.
+var defaultTokenEstimator = tokenEstimator{bytesPerToken: 3.5, messageOverhead: 4}
+
+// estimatorFor returns the token estimator of a provider.
+func estimatorFor(provider string) tokenEstimator {
+	if estimator, ok := tokenEstimators[strings.ToLower(provider)]; ok {
+		return estimator
+	}
+	return defaultTokenEstimator
+}
+
+// EstimateTokens estimates the number of tokens a provider uses for text.
+func EstimateTokens(provider string, text string) int {
+	if text == "" {
+		return 0
+	}
+	return int(math.Ceil(float64(len(text)) / estimatorFor(provider).bytesPerToken))
+}
+
+// EstimateMessageTokens estimates the tokens of a message, including tool call names and arguments.
+func EstimateMessageTokens(provider string, msg neurotypes.Message) int {
+	tokens := estimatorFor(provider).messageOverhead + EstimateTokens(provider, msg.Content)
+	for _, call := range msg.ToolCalls {
+		tokens += EstimateTokens(provider, call.Name) + EstimateTokens(provider, call.Arguments)
+	}
+	return tokens
+}
+
+// EstimateSessionTokens estimates the prompt tokens of a session: system prompt and all messages.
+func EstimateSessionTokens(provider string, session *neurotypes.ChatSession) int {
+	if session == nil {
+		return 0
+	}
+	tokens := estimatorFor(provider).messageOverhead + EstimateTokens(provider, session.SystemPrompt)
+	for _, msg := range session.Messages {
+		tokens += EstimateMessageTokens(provider, msg)
+	}
+	return tokens
+}
+
+// ContextTokenLimit returns the prompt tokens a model accepts, the context window, and the tokens
+// reserved for output. The window comes from the model's context_window parameter, else its catalog
+// entry. The model's max_tokens (or max_completion_tokens) is reserved for output; without it a
+// quarter of the window is reserved, at most the catalog max_output_tokens.
+// The limit is 0 when the context window is unknown.
+func ContextTokenLimit(model *neurotypes.ModelConfig) (int, int, int) {
+	if model == nil {
+		return 0, 0, 0
+	}
+	entry := lookupModelEntry(model)
+
+	window := 0
+	if value, ok := intParameter(model.Parameters[ContextWindowParameter]); ok && value > 0 {
+		window = value
+	} else if entry != nil {
+		window = entry.ContextWindow
+	}
+	if window <= 0 {
+		return 0, 0, 0
+	}
+
+	reserved := 0
+	for _, name := range []string{"max_tokens", "max_completion_tokens"} {
+		if value, ok := intParameter(model.Parameters[name]); ok && value > 0 {
+			reserved = value
+			break
+		}
+	}
+	if reserved == 0 || reserved >= window {
+		reserved = window / 4
+		if entry != nil && entry.MaxOutputTokens != nil && *entry.MaxOutputTokens > 0 && *entry.MaxOutputTokens < reserved {
+			reserved = *entry.MaxOutputTokens
+		}
+	}
+	return window - reserved, window, reserved
+}
+
+// ContextSummarizer asks a model to summarize messages, extending a previous summary if there is one.
+type ContextSummarizer func(previousSummary string, messages []neurotypes.Message) (string, error)
+
+// ContextFitResult describes the session sent to the model after applying the context policy.
+type ContextFitResult struct {
+	Session            *neurotypes.ChatSession // Session to send; the original session when nothing was changed
+	Policy             ContextPolicy           // Policy in effect, empty when none is configured
+	EstimatedTokens    int                     // Estimated prompt tokens of the full session
+	SentTokens         int                     // Estimated prompt tokens of the session to send
+	TokenLimit         int                     // Prompt tokens the model accepts, 0 when unknown
+	DroppedMessages    int                     // Messages left out of the request
+	SummarizedMessages int                     // Messages replaced by Summary
+	Summary            string                  // Summary added to the system prompt by summarize-oldest
+}
+
+// contextSummary is a cached summary of a session's first messageCount messages.
+type contextSummary struct {
+	messageCount int
+	digest       string
+	text         string
+}
+
+// ContextWindowService keeps chat sessions within the context window of the model they are sent to.
+// Policies act on a copy of the session, so the stored conversation is never changed. Summaries
+// written by summarize-oldest are cached per session and only extended once the prompt overflows again.
+type ContextWindowService struct {
+	initialized bool
+	summaries   map[string]contextSummary
+	mutex       sync.Mutex
+}
+
+// NewContextWindowService creates a new ContextWindowService instance.
+func NewContextWindowService() *ContextWindowService {
+	return &ContextWindowService{
+		initialized: false,
+		summaries:   make(map[string]contextSummary),
+	}
+}
+
+// Name returns the service name "context_window" for registration.
+func (s *ContextWindowService) Name() string {
+	return "context_window"
+}
+
+// Initialize sets up the ContextWindowService for operation.
+func (s *ContextWindowService) Initialize() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.initialized = true
+	logger.Debug("ContextWindowService initialized")
+	return nil
+}
+
+// ResolvePolicy returns the context policy for sending a session to a model.
+// The session's policy takes precedence over the model's context_policy parameter,
+// which takes precedence over ${_context_policy}.
+func (s *ContextWindowService) ResolvePolicy(session *neurotypes.ChatSession, model *neurotypes.ModelConfig) (ContextPolicy, error) {
+	if session != nil && session.ContextPolicy != "" {
+		policy, err := ParseContextPolicy(session.ContextPolicy)
+		if err != nil {
+			return policy, fmt.Errorf("session '%s': %w", session.Name, err)
+		}
+		policy.Source = "session"
+		return policy, nil
+	}
+
+	if model != nil {
+		if value, ok := model.Parameters[ContextPolicyParameter]; ok {
+			policy, err := ParseContextPolicy(fmt.Sprintf("%v", value))
+			if err != nil {
+				return policy, fmt.Errorf("model '%s': %w", model.Name, err)
+			}
+			policy.Source = "model"
+			return policy, nil
+		}
+	}
+
+	if variableService, err := GetGlobalVariableService(); err == nil {
+		if value, err := variableService.Get(ContextPolicyVariable); err == nil && strings.TrimSpace(value) != "" {
+			policy, err := ParseContextPolicy(value)
+			if err != nil {
+				return policy, fmt.Errorf("%s: %w", ContextPolicyVariable, err)
+			}
+			policy.Source = "variable"
+			return policy, nil
+		}
+	}
+
+	return ContextPolicy{}, nil
+}
+
+// Fit applies the context policy when the session does not fit the model's context window.
+// summarize is only called by summarize-oldest. Failures are returned as client errors so they
+// surface like other rejected requests.
+func (s *ContextWindowService) Fit(session *neurotypes.ChatSession, model *neurotypes.ModelConfig, summarize ContextSummarizer) (*ContextFitResult, *neurotypes.LLMError) {
+	result := &ContextFitResult{Session: session}
+	if !s.initialized || session == nil {
+		return result, nil
+	}
+
+	policy, err := s.ResolvePolicy(session, model)
+	if err != nil {
+		return result, &neurotypes.LLMError{Code: contextInvalidPolicyCode, Message: err.Error(), Type: "client_error"}
+	}
+	result.Policy = policy
+
+	provider := ""
+	if model != nil {
+		provider = model.Provider
+	}
+	result.EstimatedTokens = EstimateSessionTokens(provider, session)
+	result.SentTokens = result.EstimatedTokens
+	limit, window, reserved := ContextTokenLimit(model)
+	result.TokenLimit = limit
+
+	if policy.Name == "" || limit <= 0 || result.EstimatedTokens <= limit {
+		return result, nil
+	}
+
+	exceeded := func(tokens int, detail string) *neurotypes.LLMError {
+		return &neurotypes.LLMError{
+			Code: ContextExceededCode,
+			Message: fmt.Sprintf("session '%s' needs about %d prompt tokens but model '%s' accepts %d (%d-token context window, %d reserved for output)%s",
+				session.Name, tokens, model.Name, limit, window, reserved, detail),
+			Type: "client_error",
+		}
+	}
+
+	switch policy.Name {
+	case ContextPolicyTruncateOldest:
+		kept := s.keepNewest(provider, session, session.Messages, limit)
+		s.setMessages(result, provider, session, kept)
+	case ContextPolicyKeepLast:
+		start := max(len(session.Messages)-policy.KeepLast, 0)
+		s.setMessages(result, provider, session, startAtUserTurn(session.Messages[start:]))
+	case ContextPolicySummarizeOldest:
+		if llmErr := s.summarizeOldest(result, provider, session, limit, summarize); llmErr != nil {
+			return result, llmErr
+		}
+	default:
+		return result, exceeded(result.EstimatedTokens, "; set a context policy such as truncate-oldest to send long sessions")
+	}
+
+	if result.SentTokens > limit {
+		return result, exceeded(result.SentTokens, fmt.Sprintf(" even after applying context policy %s", policy))
+	}
+	logger.Debug("Context policy applied", "policy", policy.String(), "session", session.Name,
+		"estimated", result.EstimatedTokens, "sent", result.SentTokens, "dropped", result.DroppedMessages,
+		"summarized", result.SummarizedMessages)
+	return result, nil
+}
+
+// keepNewest returns the newest messages that fit within limit together with the system prompt.
+// At least the last message is kept.
+func (s *ContextWindowService) keepNewest(provider string, session *neurotypes.ChatSession, messages []neurotypes.Message, limit int) []neurotypes.Message {
+	tokens := EstimateSessionTokens(provider, &neurotypes.ChatSession{SystemPrompt: session.SystemPrompt})
+	start := len(messages)
+	for start > 0 {
+		next := EstimateMessageTokens(provider, messages[start-1])
+		if tokens+next > limit && start < len(messages) {
+			break
+		}
+		tokens += next
+		start--
+	}
+	return startAtUserTurn(messages[start:])
+}
+
+// summarizeOldest replaces the oldest messages with a summary in the system prompt.
+// A cached summary is reused while the remaining messages fit; otherwise the summary is extended
+// with older messages until the newest messages take at most half of the limit.
+func (s *ContextWindowService) summarizeOldest(result *ContextFitResult, provider string, session *neurotypes.ChatSession, limit int, summarize ContextSummarizer) *neurotypes.LLMError {
+	s.mutex.Lock()
+	cached, hasCached := s.summaries[session.ID]
+	s.mutex.Unlock()
+	if hasCached && (cached.messageCount >= len(session.Messages) || contextDigest(session.Messages[:cached.messageCount]) != cached.digest) {
+		// Messages covered by the summary were edited or deleted
+		hasCached = false
+	}
+
+	if hasCached {
+		s.setSummary(result, provider, session, cached)
+		if result.SentTokens <= limit {
+			return nil
+		}
+	}
+
+	summarized := 0
+	previous := ""
+	if hasCached {
+		summarized = cached.messageCount
+		previous = cached.text
+	}
+	kept := s.keepNewest(provider, session, session.Messages[summarized:], limit/2)
+	split := len(session.Messages) - len(kept)
+	if split <= summarized {
+		// The newest message alone does not fit
+		return nil
+	}
+	if summarize == nil {
+		return &neurotypes.LLMError{Code: contextSummaryFailedCode, Message: "context policy summarize-oldest needs a model to write the summary", Type: "client_error"}
+	}
+
+	text, err := summarize(previous, session.Messages[summarized:split])
+	if err != nil {
+		return &neurotypes.LLMError{Code: contextSummaryFailedCode, Message: fmt.Sprintf("failed to summarize earlier messages: %s", err), Type: "client_error"}
+	}
+
+	summary := contextSummary{messageCount: split, digest: contextDigest(session.Messages[:split]), text: text}
+	s.mutex.Lock()
+	s.summaries[session.ID] = summary
+	s.mutex.Unlock()
+
+	s.setSummary(result, provider, session, summary)
+	return nil
+}
+
+// setMessages makes the result send the session with only the given messages.
+func (s *ContextWindowService) setMessages(result *ContextFitResult, provider string, session *neurotypes.ChatSession, messages []neurotypes.Message) {
+	fitted := *session
+	fitted.Messages = messages
+	result.Session = &fitted
+	result.DroppedMessages = len(session.Messages) - len(messages)
+	result.SentTokens = EstimateSessionTokens(provider, &fitted)
+}
+
+// setSummary makes the result send the summary in the system prompt followed by the newer messages.
+func (s *ContextWindowService) setSummary(result *ContextFitResult, provider string, session *neurotypes.ChatSession, summary contextSummary) {
+	fitted := *session
+	fitted.SystemPrompt = strings.TrimSpace(session.SystemPrompt + "\n\nSummary of the earlier conversation:\n" + summary.text)
+	fitted.Messages = session.Messages[summary.messageCount:]
+	result.Session = &fitted
+	result.DroppedMessages = summary.messageCount
+	result.SummarizedMessages = summary.messageCount
+	result.Summary = summary.text
+	result.SentTokens = EstimateSessionTokens(provider, &fitted)
+}
+
+// NewContextSummarySession builds the request asking a model to summarize messages of a session.
+// It keeps the session's ID so usage and budgets are counted for the session.
+func NewContextSummarySession(session *neurotypes.ChatSession, previousSummary string, messages []neurotypes.Message) *neurotypes.ChatSession {
+	var transcript strings.Builder
+	if previousSummary != "" {
+		transcript.WriteString("Summary so far:\n")
+		transcript.WriteString(previousSummary)
+		transcript.WriteString("\n\nConversation continues:\n")
+	}
+	for _, msg := range messages {
+		transcript.WriteString(fmt.Sprintf("%s: %s\n", msg.Role, msg.Content))
+	}
+
+	return &neurotypes.ChatSession{
+		ID:           session.ID,
+		Name:         session.Name,
+		SystemPrompt: contextSummaryPrompt,
+		Messages: []neurotypes.Message{
+			{ID: session.ID + "-summary", Role: "user", Content: strings.TrimSpace(transcript.String())},
+		},
+		CreatedAt: session.CreatedAt,
+		UpdatedAt: session.UpdatedAt,
+	}
+}
+
+// startAtUserTurn drops leading messages until the first user message, so the request does not
+// begin with an assistant reply or an orphaned tool result. Messages are kept when none is a user message.
+func startAtUserTurn(messages []neurotypes.Message) []neurotypes.Message {
+	for i, msg := range messages {
+		if msg.Role == "user" {
+			return messages[i:]
+		}
+	}
+	return messages
+}
+
+// contextDigest identifies a list of messages, so cached summaries are dropped when they change.
+func contextDigest(messages []neurotypes.Message) string {
+	hash := sha256.New()
+	for _, msg := range messages {
+		hash.Write([]byte(msg.ID + "\x00" + msg.Role + "\x00" + msg.Content + "\x00"))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// GetContextWindowService retrieves the context window service with proper type casting.
+func (r *Registry) GetContextWindowService() (*ContextWindowService, error) {
+	service, err := r.GetService("context_window")
+	if err != nil {
+		return nil, err
+	}
+
+	contextWindowService, ok := service.(*ContextWindowService)
+	if !ok {
+		return nil, fmt.Errorf("context window service has incorrect type")
+	}
+
+	return contextWindowService, nil
+}
+
+// GetGlobalContextWindowService returns the context window service from the global registry.
+func GetGlobalContextWindowService() (*ContextWindowService, error) {
+	return GetGlobalRegistry().GetContextWindowService()
+}
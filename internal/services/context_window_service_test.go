@@ -0,0 +1,269 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/internal/context"
+	"neuroshell/pkg/neurotypes"
+)
+
+// setupContextWindowTestRegistry registers the services the context window service reads from.
+func setupContextWindowTestRegistry(t *testing.T) (*ContextWindowService, *VariableService) {
+	oldServiceRegistry := GetGlobalRegistry()
+	SetGlobalRegistry(NewRegistry())
+	context.ResetGlobalContext()
+	context.GetGlobalContext().SetTestMode(true)
+
+	t.Cleanup(func() {
+		SetGlobalRegistry(oldServiceRegistry)
+		context.ResetGlobalContext()
+	})
+
+	variableService := NewVariableService()
+	contextWindowService := NewContextWindowService()
+	for _, service := range []neurotypes.Service{variableService, contextWindowService, NewModelCatalogService(), NewProviderCatalogService()} {
+		require.NoError(t, GetGlobalRegistry().RegisterService(service))
+		require.NoError(t, service.Initialize())
+	}
+	return contextWindowService, variableService
+}
+
+// contextTestSession alternates user and assistant messages of 40 bytes (14 estimated OpenAI tokens each).
+func contextTestSession(count int) *neurotypes.ChatSession {
+	session := &neurotypes.ChatSession{ID: "s1", Name: "long", SystemPrompt: "sys"}
+	for i := 0; i < count; i++ {
+		role := "user"
+		if i%2 == 1 {
+			role = "assistant"
+		}
+		session.Messages = append(session.Messages, neurotypes.Message{
+			ID:      fmt.Sprintf("m%d", i),
+			Role:    role,
+			Content: fmt.Sprintf("%-40s", fmt.Sprintf("message %d", i)),
+		})
+	}
+	return session
+}
+
+// contextTestModel has a 100-token window, of which 25 tokens are reserved for output.
+func contextTestModel(policy string) *neurotypes.ModelConfig {
+	parameters := map[string]any{ContextWindowParameter: 100}
+	if policy != "" {
+		parameters[ContextPolicyParameter] = policy
+	}
+	return &neurotypes.ModelConfig{Name: "small", Provider: "openai", Parameters: parameters}
+}
+
+func TestParseContextPolicy(t *testing.T) {
+	for _, value := range []string{"error", "truncate-oldest", "summarize-oldest", " Truncate-Oldest "} {
+		policy, err := ParseContextPolicy(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, strings.ToLower(strings.TrimSpace(value)), policy.String())
+	}
+
+	policy, err := ParseContextPolicy("keep-system-and-last-6")
+	require.NoError(t, err)
+	assert.Equal(t, ContextPolicyKeepLast, policy.Name)
+	assert.Equal(t, 6, policy.KeepLast)
+	assert.Equal(t, "keep-system-and-last-6", policy.String())
+
+	policy, err = ParseContextPolicy("")
+	require.NoError(t, err)
+	assert.Empty(t, policy.Name)
+
+	for _, value := range []string{"drop", "keep-system-and-last-N", "keep-system-and-last-0", "keep-system-and-last--2"} {
+		_, err := ParseContextPolicy(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	text := strings.Repeat("a", 40)
+	assert.Equal(t, 0, EstimateTokens("openai", ""))
+	assert.Equal(t, 10, EstimateTokens("openai", text))
+	assert.Equal(t, 12, EstimateTokens("anthropic", text))
+	assert.Equal(t, 12, EstimateTokens("ollama", text))
+
+	// Multi-byte text counts more tokens per character
+	assert.Greater(t, EstimateTokens("openai", strings.Repeat("語", 40)), EstimateTokens("openai", text))
+
+	msg := neurotypes.Message{Role: "assistant", Content: text, ToolCalls: []neurotypes.ToolCall{{Name: "weather", Arguments: `{"city":"Paris"}`}}}
+	assert.Equal(t, 4+10+2+4, EstimateMessageTokens("openai", msg))
+
+	session := contextTestSession(10)
+	assert.Equal(t, 5+10*14, EstimateSessionTokens("openai", session))
+}
+
+func TestContextTokenLimit(t *testing.T) {
+	setupContextWindowTestRegistry(t)
+
+	limit, window, reserved := ContextTokenLimit(contextTestModel(""))
+	assert.Equal(t, []int{75, 100, 25}, []int{limit, window, reserved})
+
+	// An explicit output limit is reserved as is
+	model := contextTestModel("")
+	model.Parameters["max_tokens"] = 40
+	limit, _, reserved = ContextTokenLimit(model)
+	assert.Equal(t, 60, limit)
+	assert.Equal(t, 40, reserved)
+
+	// The catalog supplies the window and caps the reserve at max_output_tokens
+	limit, window, reserved = ContextTokenLimit(&neurotypes.ModelConfig{Name: "mock", CatalogID: "MOCK"})
+	assert.Equal(t, []int{128000 - 4096, 128000, 4096}, []int{limit, window, reserved})
+
+	limit, _, _ = ContextTokenLimit(&neurotypes.ModelConfig{Name: "custom"})
+	assert.Zero(t, limit, "unknown window")
+}
+
+func TestContextWindowService_ResolvePolicy(t *testing.T) {
+	service, variableService := setupContextWindowTestRegistry(t)
+	session := contextTestSession(2)
+
+	policy, err := service.ResolvePolicy(session, contextTestModel(""))
+	require.NoError(t, err)
+	assert.Empty(t, policy.Name)
+
+	require.NoError(t, variableService.Set(ContextPolicyVariable, "error"))
+	policy, err = service.ResolvePolicy(session, contextTestModel(""))
+	require.NoError(t, err)
+	assert.Equal(t, ContextPolicy{Name: ContextPolicyError, Source: "variable"}, policy)
+
+	policy, err = service.ResolvePolicy(session, contextTestModel("truncate-oldest"))
+	require.NoError(t, err)
+	assert.Equal(t, ContextPolicy{Name: ContextPolicyTruncateOldest, Source: "model"}, policy)
+
+	session.ContextPolicy = "keep-system-and-last-4"
+	policy, err = service.ResolvePolicy(session, contextTestModel("truncate-oldest"))
+	require.NoError(t, err)
+	assert.Equal(t, ContextPolicy{Name: ContextPolicyKeepLast, KeepLast: 4, Source: "session"}, policy)
+
+	session.ContextPolicy = ""
+	require.NoError(t, variableService.Set(ContextPolicyVariable, "shrink"))
+	_, err = service.ResolvePolicy(session, contextTestModel(""))
+	assert.ErrorContains(t, err, ContextPolicyVariable)
+}
+
+func TestContextWindowService_Fit(t *testing.T) {
+	service, _ := setupContextWindowTestRegistry(t)
+	session := contextTestSession(10)
+
+	t.Run("no policy sends the session as is", func(t *testing.T) {
+		result, llmErr := service.Fit(session, contextTestModel(""), nil)
+		require.Nil(t, llmErr)
+		assert.Same(t, session, result.Session)
+		assert.Equal(t, 145, result.EstimatedTokens)
+		assert.Equal(t, 75, result.TokenLimit)
+	})
+
+	t.Run("sessions that fit are not changed", func(t *testing.T) {
+		short := contextTestSession(4)
+		result, llmErr := service.Fit(short, contextTestModel("error"), nil)
+		require.Nil(t, llmErr)
+		assert.Same(t, short, result.Session)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, llmErr := service.Fit(session, contextTestModel("error"), nil)
+		require.NotNil(t, llmErr)
+		assert.Equal(t, ContextExceededCode, llmErr.Code)
+		assert.Equal(t, "client_error", llmErr.Type)
+		assert.Contains(t, llmErr.Message, "about 145 prompt tokens")
+	})
+
+	t.Run("truncate-oldest starts at a user turn", func(t *testing.T) {
+		result, llmErr := service.Fit(session, contextTestModel("truncate-oldest"), nil)
+		require.Nil(t, llmErr)
+		require.Len(t, result.Session.Messages, 4)
+		assert.Equal(t, "m6", result.Session.Messages[0].ID)
+		assert.Equal(t, 6, result.DroppedMessages)
+		assert.Equal(t, 61, result.SentTokens)
+		assert.Len(t, session.Messages, 10, "stored session must not change")
+	})
+
+	t.Run("keep-system-and-last-N", func(t *testing.T) {
+		result, llmErr := service.Fit(session, contextTestModel("keep-system-and-last-4"), nil)
+		require.Nil(t, llmErr)
+		require.Len(t, result.Session.Messages, 4)
+		assert.Equal(t, "sys", result.Session.SystemPrompt)
+		assert.Equal(t, 6, result.DroppedMessages)
+
+		_, llmErr = service.Fit(session, contextTestModel("keep-system-and-last-8"), nil)
+		require.NotNil(t, llmErr)
+		assert.Contains(t, llmErr.Message, "even after applying context policy keep-system-and-last-8")
+	})
+
+	t.Run("a single message larger than the window", func(t *testing.T) {
+		huge := contextTestSession(1)
+		huge.Messages[0].Content = strings.Repeat("x", 1000)
+		_, llmErr := service.Fit(huge, contextTestModel("truncate-oldest"), nil)
+		require.NotNil(t, llmErr)
+		assert.Equal(t, ContextExceededCode, llmErr.Code)
+	})
+}
+
+func TestContextWindowService_SummarizeOldest(t *testing.T) {
+	service, _ := setupContextWindowTestRegistry(t)
+	model := contextTestModel("summarize-oldest")
+
+	var calls []string
+	summarize := func(previous string, messages []neurotypes.Message) (string, error) {
+		calls = append(calls, fmt.Sprintf("%q+%s..%s", previous, messages[0].ID, messages[len(messages)-1].ID))
+		return fmt.Sprintf("S%d", len(calls)), nil
+	}
+
+	session := contextTestSession(10)
+	result, llmErr := service.Fit(session, model, summarize)
+	require.Nil(t, llmErr)
+	assert.Equal(t, []string{`""+m0..m7`}, calls)
+	assert.Equal(t, 8, result.SummarizedMessages)
+	assert.Equal(t, "S1", result.Summary)
+	assert.Equal(t, "sys\n\nSummary of the earlier conversation:\nS1", result.Session.SystemPrompt)
+	require.Len(t, result.Session.Messages, 2)
+	assert.Equal(t, "m8", result.Session.Messages[0].ID)
+
+	// The cached summary is reused while the newer messages fit
+	session = contextTestSession(12)
+	result, llmErr = service.Fit(session, model, summarize)
+	require.Nil(t, llmErr)
+	assert.Len(t, calls, 1)
+	assert.Equal(t, "S1", result.Summary)
+	assert.Len(t, result.Session.Messages, 4)
+
+	// Once they overflow again the summary is extended
+	session = contextTestSession(14)
+	result, llmErr = service.Fit(session, model, summarize)
+	require.Nil(t, llmErr)
+	assert.Equal(t, `"S1"+m8..m11`, calls[1])
+	assert.Equal(t, 12, result.SummarizedMessages)
+
+	// Editing a summarized message starts over
+	session.Messages[0].Content = "edited"
+	_, llmErr = service.Fit(session, model, summarize)
+	require.Nil(t, llmErr)
+	assert.Equal(t, `""+m0..m11`, calls[2])
+
+	// Summary failures fail the call
+	failing := func(string, []neurotypes.Message) (string, error) { return "", fmt.Errorf("rate limited") }
+	other := contextTestSession(10)
+	other.ID = "s2"
+	_, llmErr = service.Fit(other, model, failing)
+	require.NotNil(t, llmErr)
+	assert.Equal(t, "context_summary_failed", llmErr.Code)
+	assert.Contains(t, llmErr.Message, "rate limited")
+}
+
+func TestNewContextSummarySession(t *testing.T) {
+	session := contextTestSession(2)
+	request := NewContextSummarySession(session, "Earlier summary", session.Messages)
+	assert.Equal(t, session.ID, request.ID)
+	require.Len(t, request.Messages, 1)
+	assert.Equal(t, "user", request.Messages[0].Role)
+	assert.Contains(t, request.Messages[0].Content, "Summary so far:\nEarlier summary")
+	assert.Contains(t, request.Messages[0].Content, "assistant: message 1")
+	assert.NotEqual(t, session.SystemPrompt, request.SystemPrompt)
+}
@@ -15,10 +15,14 @@ import (
 
 // DebugTransportService provides HTTP request/response capture for all LLM clients.
 // This service is always enabled and captures network traffic for debugging purposes.
+// When a cassette is configured, traffic is also recorded to it or replayed from it.
 type DebugTransportService struct {
 	capturedData string
 	initialized  bool
 	mutex        sync.RWMutex
+	cassettePath string
+	cassetteMode CassetteMode
+	cassette     *cassetteState
 }
 
 // NewDebugTransportService creates a new DebugTransportService instance.
@@ -39,8 +43,16 @@ func (d *DebugTransportService) Initialize() error {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
-	d.initialized = true
 	d.capturedData = ""
+	d.cassette = nil
+	if d.cassettePath != "" {
+		cassette, err := loadCassette(d.cassettePath, d.cassetteMode)
+		if err != nil {
+			return err
+		}
+		d.cassette = cassette
+	}
+	d.initialized = true
 
 	logger.ServiceOperation("debug_transport", "initialize", "completed")
 	return nil
@@ -54,12 +66,33 @@ func (d *DebugTransportService) CreateTransport() http.RoundTripper {
 		return http.DefaultTransport
 	}
 
+	base := http.DefaultTransport
+	if d.cassette != nil {
+		base = &cassetteTransport{base: base, state: d.cassette}
+	}
+
 	return &debugTransport{
-		base:    http.DefaultTransport,
+		base:    base,
 		service: d,
 	}
 }
 
+// SetCassette configures a cassette file to record to or replay from.
+// It takes effect when the service is initialized; an empty path disables cassettes.
+func (d *DebugTransportService) SetCassette(path string, mode CassetteMode) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.cassettePath = path
+	d.cassetteMode = mode
+}
+
+// HasCassette reports whether LLM traffic is recorded to or replayed from a cassette.
+func (d *DebugTransportService) HasCassette() bool {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	return d.cassettePath != ""
+}
+
 // GetCapturedData returns the captured HTTP debug data as JSON string.
 func (d *DebugTransportService) GetCapturedData() string {
 	d.mutex.RLock()
@@ -108,6 +141,18 @@ func (dt *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 		return resp, err
 	}
 
+	// Streaming responses must reach the client incrementally, so capture them as they are read
+	if isEventStream(resp) {
+		resp.Body = &streamCaptureBody{
+			body:         resp.Body,
+			transport:    dt,
+			requestData:  requestData,
+			responseData: dt.captureResponseHeaders(resp),
+			startTime:    startTime,
+		}
+		return resp, nil
+	}
+
 	// Capture response data
 	responseData, captureErr := dt.captureResponse(resp)
 	if captureErr != nil {
@@ -156,13 +201,18 @@ func (dt *debugTransport) captureRequest(req *http.Request) (map[string]interfac
 	return requestData, nil
 }
 
-// captureResponse captures HTTP response data.
-func (dt *debugTransport) captureResponse(resp *http.Response) (map[string]interface{}, error) {
-	responseData := map[string]interface{}{
+// captureResponseHeaders captures HTTP response status and headers without touching the body.
+func (dt *debugTransport) captureResponseHeaders(resp *http.Response) map[string]interface{} {
+	return map[string]interface{}{
 		"status_code": resp.StatusCode,
 		"status":      resp.Status,
 		"headers":     dt.sanitizeHeaders(resp.Header),
 	}
+}
+
+// captureResponse captures HTTP response data.
+func (dt *debugTransport) captureResponse(resp *http.Response) (map[string]interface{}, error) {
+	responseData := dt.captureResponseHeaders(resp)
 
 	// Capture response body if present
 	if resp.Body != nil {
@@ -235,17 +285,58 @@ func (dt *debugTransport) storeDebugData(requestData, responseData map[string]in
 	logger.Debug("Debug data captured", "data_length", len(jsonData))
 }
 
+// isEventStream reports whether the response is a server-sent event stream.
+func isEventStream(resp *http.Response) bool {
+	return strings.HasPrefix(strings.ToLower(resp.Header.Get("Content-Type")), "text/event-stream")
+}
+
+// streamCaptureBody wraps a streaming response body, recording the raw stream as the client reads it.
+// Debug data is stored once the stream is exhausted or closed.
+type streamCaptureBody struct {
+	body         io.ReadCloser
+	transport    *debugTransport
+	requestData  map[string]interface{}
+	responseData map[string]interface{}
+	startTime    time.Time
+	buffer       bytes.Buffer
+	stored       bool
+}
+
+// Read reads from the underlying stream and records the bytes read.
+func (b *streamCaptureBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	if n > 0 {
+		b.buffer.Write(p[:n])
+	}
+	if err == io.EOF {
+		b.store()
+	}
+	return n, err
+}
+
+// Close closes the underlying stream and stores the captured data.
+func (b *streamCaptureBody) Close() error {
+	b.store()
+	return b.body.Close()
+}
+
+// store saves the captured stream as debug data exactly once.
+func (b *streamCaptureBody) store() {
+	if b.stored {
+		return
+	}
+	b.stored = true
+	b.responseData["body"] = b.buffer.String()
+	b.transport.storeDebugData(b.requestData, b.responseData, b.startTime, time.Now())
+}
+
 // sanitizeHeaders removes or masks sensitive headers.
 func (dt *debugTransport) sanitizeHeaders(headers http.Header) map[string]interface{} {
 	sanitized := make(map[string]interface{})
 
 	for name, values := range headers {
-		lowerName := strings.ToLower(name)
-
 		// Mask sensitive headers
-		if strings.Contains(lowerName, "authorization") ||
-			strings.Contains(lowerName, "api-key") ||
-			strings.Contains(lowerName, "token") {
+		if isSensitiveHeader(name) {
 			if len(values) > 0 && len(values[0]) > 10 {
 				// Show first 10 characters and mask the rest
 				masked := values[0][:10] + "***[MASKED]***"
@@ -262,6 +353,14 @@ func (dt *debugTransport) sanitizeHeaders(headers http.Header) map[string]interf
 	return sanitized
 }
 
+// isSensitiveHeader reports whether a header carries credentials.
+func isSensitiveHeader(name string) bool {
+	lowerName := strings.ToLower(name)
+	return strings.Contains(lowerName, "authorization") ||
+		strings.Contains(lowerName, "api-key") ||
+		strings.Contains(lowerName, "token")
+}
+
 // GetGlobalDebugTransportService returns the global debug transport service instance.
 func GetGlobalDebugTransportService() (*DebugTransportService, error) {
 	serviceInterface, err := GetGlobalRegistry().GetService("debug_transport")
@@ -0,0 +1,134 @@
+// Package services provides user-defined script commands for NeuroShell.
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	neuroshellcontext "neuroshell/internal/context"
+	"neuroshell/internal/logger"
+	"neuroshell/pkg/neurotypes"
+)
+
+// definitionNamePattern matches names accepted for commands defined with \def.
+var definitionNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+
+// DefinitionService manages script commands defined at runtime with \def name … \enddef.
+// Definitions are resolved after builtin and stdlib commands and listed by \help and autocomplete.
+type DefinitionService struct {
+	initialized bool
+	definitions map[string]neurotypes.CommandDefinition
+	mutex       sync.RWMutex
+}
+
+// NewDefinitionService creates a new DefinitionService instance.
+func NewDefinitionService() *DefinitionService {
+	return &DefinitionService{
+		initialized: false,
+		definitions: make(map[string]neurotypes.CommandDefinition),
+	}
+}
+
+// Name returns the service name "definition" for registration.
+func (d *DefinitionService) Name() string {
+	return "definition"
+}
+
+// Initialize sets up the DefinitionService for operation.
+func (d *DefinitionService) Initialize() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.initialized = true
+	logger.Debug("DefinitionService initialized")
+	return nil
+}
+
+// Define registers a command from the lines of its body, replacing any existing definition with the
+// same name. The %% comments before the first command of the body become its help, see ParseScriptHelp.
+func (d *DefinitionService) Define(name string, lines []string) (neurotypes.CommandDefinition, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if !d.initialized {
+		return neurotypes.CommandDefinition{}, fmt.Errorf("definition service not initialized")
+	}
+
+	if !definitionNamePattern.MatchString(name) {
+		return neurotypes.CommandDefinition{}, fmt.Errorf("invalid command name '%s': start with a letter and use letters, digits, '_' or '-'", name)
+	}
+
+	help := ParseScriptHelp(name, lines)
+	if help.Description == "" {
+		help.Description = "User-defined command"
+	}
+	help.Notes = append(help.Notes, "Defined with \\def; the message is available as ${_1} and named options as variables")
+
+	definition := neurotypes.CommandDefinition{
+		Name:   name,
+		Help:   help,
+		Script: strings.Join(lines, "\n"),
+	}
+	d.definitions[name] = definition
+
+	// Register help so the command shows in \help and autocomplete
+	if neuroCtx, ok := neuroshellcontext.GetGlobalContext().(*neuroshellcontext.NeuroContext); ok {
+		neuroCtx.RegisterCommandHelp(definition.Help)
+	}
+
+	logger.Debug("Command defined", "name", name, "lines", len(lines))
+	return definition, nil
+}
+
+// Get returns the definition with the given name.
+func (d *DefinitionService) Get(name string) (neurotypes.CommandDefinition, bool) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	definition, exists := d.definitions[name]
+	return definition, exists
+}
+
+// Exists reports whether a command with the given name was defined with \def.
+func (d *DefinitionService) Exists(name string) bool {
+	_, exists := d.Get(name)
+	return exists
+}
+
+// List returns all definitions sorted by name.
+func (d *DefinitionService) List() []neurotypes.CommandDefinition {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	definitions := make([]neurotypes.CommandDefinition, 0, len(d.definitions))
+	for _, definition := range d.definitions {
+		definitions = append(definitions, definition)
+	}
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].Name < definitions[j].Name
+	})
+	return definitions
+}
+
+// GetDefinitionService retrieves the definition service from the global registry.
+func (r *Registry) GetDefinitionService() (*DefinitionService, error) {
+	service, err := r.GetService("definition")
+	if err != nil {
+		return nil, err
+	}
+
+	definitionService, ok := service.(*DefinitionService)
+	if !ok {
+		return nil, fmt.Errorf("definition service has incorrect type")
+	}
+
+	return definitionService, nil
+}
+
+// GetGlobalDefinitionService returns the definition service from the global registry.
+func GetGlobalDefinitionService() (*DefinitionService, error) {
+	return GetGlobalRegistry().GetDefinitionService()
+}
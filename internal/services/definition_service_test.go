@@ -0,0 +1,71 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	neuroshellcontext "neuroshell/internal/context"
+)
+
+func TestDefinitionService_Basic(t *testing.T) {
+	service := NewDefinitionService()
+	assert.Equal(t, "definition", service.Name())
+
+	_, err := service.Define("greet", []string{"\\echo hi"})
+	assert.Error(t, err, "define should fail before initialization")
+
+	require.NoError(t, service.Initialize())
+}
+
+func TestDefinitionService_DefineGetList(t *testing.T) {
+	ctx := neuroshellcontext.New()
+	neuroshellcontext.SetGlobalContext(ctx)
+	defer neuroshellcontext.ResetGlobalContext()
+
+	service := NewDefinitionService()
+	require.NoError(t, service.Initialize())
+
+	_, err := service.Define("zeta", []string{"\\echo z"})
+	require.NoError(t, err)
+	_, err = service.Define("alpha", []string{"%% First letter", "\\echo a"})
+	require.NoError(t, err)
+
+	definitions := service.List()
+	require.Len(t, definitions, 2)
+	assert.Equal(t, "alpha", definitions[0].Name)
+	assert.Equal(t, "zeta", definitions[1].Name)
+
+	definition, exists := service.Get("alpha")
+	require.True(t, exists)
+	assert.Equal(t, "%% First letter\n\\echo a", definition.Script)
+	assert.True(t, service.Exists("zeta"))
+	assert.False(t, service.Exists("missing"))
+
+	// Help is registered for \help and autocomplete
+	helpInfo, exists := ctx.GetCommandHelpInfo("alpha")
+	require.True(t, exists)
+	assert.Equal(t, "First letter", helpInfo.Description)
+	assert.Equal(t, "\\alpha", helpInfo.Usage)
+	assert.True(t, ctx.IsCommandRegistered("zeta"))
+
+	// Defining again replaces the command and its help
+	_, err = service.Define("alpha", []string{"%% Replaced", "\\echo b"})
+	require.NoError(t, err)
+	definition, _ = service.Get("alpha")
+	assert.Equal(t, "Replaced", definition.Help.Description)
+	helpInfo, _ = ctx.GetCommandHelpInfo("alpha")
+	assert.Equal(t, "Replaced", helpInfo.Description)
+	assert.Len(t, service.List(), 2)
+}
+
+func TestDefinitionService_Define_InvalidName(t *testing.T) {
+	service := NewDefinitionService()
+	require.NoError(t, service.Initialize())
+
+	for _, name := range []string{"", "has space", "_hidden", "1st", "dots.not.allowed", "path/cmd"} {
+		_, err := service.Define(name, []string{"\\echo hi"})
+		assert.Error(t, err, "name %q should be rejected", name)
+	}
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -145,12 +146,8 @@ func (c *GeminiClient) SendStructuredCompletion(session *neurotypes.ChatSession,
 		return &neurotypes.StructuredLLMResponse{
 			TextContent:    "",
 			ThinkingBlocks: []neurotypes.ThinkingBlock{},
-			Error: &neurotypes.LLMError{
-				Code:    "api_request_failed",
-				Message: err.Error(),
-				Type:    "api_error",
-			},
-			Metadata: map[string]interface{}{"provider": "gemini", "model": modelConfig.BaseModel},
+			Error:          newProviderLLMError(err.Error(), err),
+			Metadata:       map[string]interface{}{"provider": "gemini", "model": modelConfig.BaseModel},
 		}
 	}
 
@@ -175,13 +172,263 @@ func (c *GeminiClient) SendStructuredCompletion(session *neurotypes.ChatSession,
 		TextContent:    textContent,
 		ThinkingBlocks: thinkingBlocks,
 		Error:          nil, // No error in successful case
-		Metadata:       map[string]interface{}{"provider": "gemini", "model": modelConfig.BaseModel},
+		Metadata:       geminiMetadata(modelConfig.BaseModel, geminiUsage(result.UsageMetadata), result),
 	}
 
 	logger.Debug("Gemini structured response received", "content_length", len(textContent), "thinking_blocks", len(thinkingBlocks))
 	return structuredResponse
 }
 
+// SendStructuredCompletionWithTools sends a request to Gemini advertising the given tools as function declarations.
+// Function calls requested by the model are returned in the ToolCalls field of the structured response.
+// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+func (c *GeminiClient) SendStructuredCompletionWithTools(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig, tools []neurotypes.ToolDefinition) *neurotypes.StructuredLLMResponse {
+	logger.Debug("Gemini SendStructuredCompletionWithTools starting", "model", modelConfig.BaseModel, "tools", len(tools))
+
+	if err := c.initializeClientIfNeeded(); err != nil {
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error: &neurotypes.LLMError{
+				Code:    "api_request_failed",
+				Message: fmt.Sprintf("failed to initialize Gemini client: %s", err.Error()),
+				Type:    "api_error",
+			},
+			Metadata: map[string]interface{}{"provider": "gemini", "model": modelConfig.BaseModel},
+		}
+	}
+
+	contents := c.convertMessagesToGemini(session)
+	config := c.buildGenerationConfig(modelConfig, session)
+	config.Tools = geminiTools(tools)
+
+	result, err := c.client.Models.GenerateContent(context.Background(), modelConfig.BaseModel, contents, config)
+	if err != nil {
+		logger.Error("Gemini request failed", "error", err)
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error:          newProviderLLMError(fmt.Sprintf("gemini request failed: %s", err.Error()), err),
+			Metadata:       map[string]interface{}{"provider": "gemini", "model": modelConfig.BaseModel},
+		}
+	}
+
+	textContent, thinkingBlocks := c.processGeminiResponseStructured(result)
+	toolCalls := extractGeminiToolCalls(result)
+	if textContent == "" && len(thinkingBlocks) == 0 && len(toolCalls) == 0 {
+		logger.Error("No content in Gemini structured response")
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error: &neurotypes.LLMError{
+				Code:    "empty_response",
+				Message: "no content in response",
+				Type:    "response_error",
+			},
+			Metadata: map[string]interface{}{"provider": "gemini", "model": modelConfig.BaseModel},
+		}
+	}
+
+	logger.Debug("Gemini tool calling response received", "content_length", len(textContent), "tool_calls", len(toolCalls))
+	return &neurotypes.StructuredLLMResponse{
+		TextContent:    textContent,
+		ThinkingBlocks: thinkingBlocks,
+		ToolCalls:      toolCalls,
+		Error:          nil,
+		Metadata:       geminiMetadata(modelConfig.BaseModel, geminiUsage(result.UsageMetadata), result),
+	}
+}
+
+// SendStructuredCompletionWithSchema sends a request whose answer is constrained to a JSON schema
+// through Gemini's JSON response MIME type and response JSON schema.
+// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+func (c *GeminiClient) SendStructuredCompletionWithSchema(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig, schema *neurotypes.ResponseSchema) *neurotypes.StructuredLLMResponse {
+	logger.Debug("Gemini SendStructuredCompletionWithSchema starting", "model", modelConfig.BaseModel, "schema", schema.Name)
+
+	if err := c.initializeClientIfNeeded(); err != nil {
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error: &neurotypes.LLMError{
+				Code:    "api_request_failed",
+				Message: fmt.Sprintf("failed to initialize Gemini client: %s", err.Error()),
+				Type:    "api_error",
+			},
+			Metadata: map[string]interface{}{"provider": "gemini", "model": modelConfig.BaseModel},
+		}
+	}
+
+	contents := c.convertMessagesToGemini(session)
+	config := c.buildGenerationConfig(modelConfig, session)
+	config.ResponseMIMEType = "application/json"
+	config.ResponseJsonSchema = schema.Schema
+
+	result, err := c.client.Models.GenerateContent(context.Background(), modelConfig.BaseModel, contents, config)
+	if err != nil {
+		logger.Error("Gemini request failed", "error", err)
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error:          newProviderLLMError(fmt.Sprintf("gemini request failed: %s", err.Error()), err),
+			Metadata:       map[string]interface{}{"provider": "gemini", "model": modelConfig.BaseModel},
+		}
+	}
+
+	textContent, thinkingBlocks := c.processGeminiResponseStructured(result)
+	if textContent == "" {
+		logger.Error("No content in Gemini structured output response")
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: thinkingBlocks,
+			Error: &neurotypes.LLMError{
+				Code:    "empty_response",
+				Message: "no content in response",
+				Type:    "response_error",
+			},
+			Metadata: map[string]interface{}{"provider": "gemini", "model": modelConfig.BaseModel},
+		}
+	}
+
+	logger.Debug("Gemini structured output response received", "content_length", len(textContent))
+	return &neurotypes.StructuredLLMResponse{
+		TextContent:    textContent,
+		ThinkingBlocks: thinkingBlocks,
+		Error:          nil,
+		Metadata:       geminiMetadata(modelConfig.BaseModel, geminiUsage(result.UsageMetadata), result),
+	}
+}
+
+// StreamStructuredCompletion sends a streaming request to Gemini.
+// Text and thought deltas are delivered to onChunk as they arrive; consecutive thought fragments
+// are merged into a single thinking block in the returned structured response.
+// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+func (c *GeminiClient) StreamStructuredCompletion(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig, onChunk neurotypes.StreamHandler) *neurotypes.StructuredLLMResponse {
+	logger.Debug("Gemini StreamStructuredCompletion starting", "model", modelConfig.BaseModel)
+
+	if err := c.initializeClientIfNeeded(); err != nil {
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error: &neurotypes.LLMError{
+				Code:    "api_request_failed",
+				Message: fmt.Sprintf("failed to initialize Gemini client: %s", err.Error()),
+				Type:    "api_error",
+			},
+			Metadata: map[string]interface{}{"provider": "gemini", "model": modelConfig.BaseModel},
+		}
+	}
+
+	contents := c.convertMessagesToGemini(session)
+	config := c.buildGenerationConfig(modelConfig, session)
+
+	var textContent strings.Builder
+	var thinkingBlocks []neurotypes.ThinkingBlock
+	var usage *neurotypes.TokenUsage
+	var last *genai.GenerateContentResponse
+	lastWasThought := false
+
+	for result, err := range c.client.Models.GenerateContentStream(context.Background(), modelConfig.BaseModel, contents, config) {
+		if err != nil {
+			logger.Error("Gemini streaming request failed", "error", err)
+			return &neurotypes.StructuredLLMResponse{
+				TextContent:    "",
+				ThinkingBlocks: []neurotypes.ThinkingBlock{},
+				Error:          newProviderLLMError(fmt.Sprintf("gemini request failed: %s", err.Error()), err),
+				Metadata:       map[string]interface{}{"provider": "gemini", "model": modelConfig.BaseModel},
+			}
+		}
+
+		// Usage counts are cumulative, so the last reported value covers the whole response
+		last = result
+		if chunkUsage := geminiUsage(result.UsageMetadata); chunkUsage != nil {
+			usage = chunkUsage
+		}
+
+		for _, candidate := range result.Candidates {
+			if candidate.Content == nil {
+				continue
+			}
+			for _, part := range candidate.Content.Parts {
+				if part.Text == "" {
+					continue
+				}
+
+				if part.Thought {
+					if lastWasThought && len(thinkingBlocks) > 0 {
+						thinkingBlocks[len(thinkingBlocks)-1].Content += part.Text
+					} else {
+						thinkingBlocks = append(thinkingBlocks, neurotypes.ThinkingBlock{
+							Content:  part.Text,
+							Provider: "gemini",
+							Type:     "thinking",
+						})
+					}
+					lastWasThought = true
+					if onChunk != nil {
+						onChunk(neurotypes.StreamChunk{Type: "thinking", Content: part.Text, Provider: "gemini"})
+					}
+					continue
+				}
+
+				lastWasThought = false
+				textContent.WriteString(part.Text)
+				if onChunk != nil {
+					onChunk(neurotypes.StreamChunk{Type: "text", Content: part.Text, Provider: "gemini"})
+				}
+			}
+		}
+	}
+
+	if textContent.Len() == 0 && len(thinkingBlocks) == 0 {
+		logger.Error("No content in Gemini streamed response")
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error: &neurotypes.LLMError{
+				Code:    "empty_response",
+				Message: "no content in response",
+				Type:    "response_error",
+			},
+			Metadata: map[string]interface{}{"provider": "gemini", "model": modelConfig.BaseModel},
+		}
+	}
+
+	logger.Debug("Gemini streamed response received", "content_length", textContent.Len(), "thinking_blocks", len(thinkingBlocks))
+	return &neurotypes.StructuredLLMResponse{
+		TextContent:    textContent.String(),
+		ThinkingBlocks: thinkingBlocks,
+		Error:          nil,
+		Metadata:       geminiMetadata(modelConfig.BaseModel, usage, last),
+	}
+}
+
+// geminiMetadata builds response metadata with usage, the finish reason and the response ID.
+func geminiMetadata(model string, usage *neurotypes.TokenUsage, result *genai.GenerateContentResponse) map[string]interface{} {
+	metadata := usageMetadata("gemini", model, usage)
+	if result == nil {
+		return metadata
+	}
+	stopReason := ""
+	if len(result.Candidates) > 0 {
+		stopReason = string(result.Candidates[0].FinishReason)
+	}
+	return withResponseDetails(metadata, stopReason, result.ResponseID)
+}
+
+// geminiUsage normalizes Gemini usage metadata. Gemini reports thought tokens separately from
+// candidate tokens, so both are counted as output. Returns nil when no usage was reported.
+func geminiUsage(usage *genai.GenerateContentResponseUsageMetadata) *neurotypes.TokenUsage {
+	if usage == nil || (usage.PromptTokenCount == 0 && usage.CandidatesTokenCount == 0 && usage.ThoughtsTokenCount == 0) {
+		return nil
+	}
+	return &neurotypes.TokenUsage{
+		InputTokens:    int64(usage.PromptTokenCount) + int64(usage.ToolUsePromptTokenCount),
+		OutputTokens:   int64(usage.CandidatesTokenCount) + int64(usage.ThoughtsTokenCount),
+		ThinkingTokens: int64(usage.ThoughtsTokenCount),
+		CachedTokens:   int64(usage.CachedContentTokenCount),
+	}
+}
+
 // SetDebugTransport sets the HTTP transport for network debugging.
 func (c *GeminiClient) SetDebugTransport(transport http.RoundTripper) {
 	c.debugTransport = transport
@@ -195,11 +442,33 @@ func (c *GeminiClient) SetDebugTransport(transport http.RoundTripper) {
 func (c *GeminiClient) convertMessagesToGemini(session *neurotypes.ChatSession) []*genai.Content {
 	contents := make([]*genai.Content, 0)
 
+	// Gemini function responses are matched by name, so remember which tool each call ID refers to
+	toolNames := make(map[string]string)
+	lastIsToolResults := false
+
 	// Convert conversation messages with proper role mapping
 	for _, msg := range session.Messages {
 		var role string
 		var content string
 
+		if msg.Role == "assistant" && len(msg.ToolCalls) > 0 {
+			contents = append(contents, geminiFunctionCallContent(msg, toolNames))
+			lastIsToolResults = false
+			continue
+		}
+		if msg.Role == "tool" {
+			part := geminiFunctionResponsePart(msg, toolNames[msg.ToolCallID])
+			if lastIsToolResults {
+				last := contents[len(contents)-1]
+				last.Parts = append(last.Parts, part)
+			} else {
+				contents = append(contents, &genai.Content{Parts: []*genai.Part{part}, Role: "user"})
+			}
+			lastIsToolResults = true
+			continue
+		}
+		lastIsToolResults = false
+
 		switch msg.Role {
 		case "user":
 			role = "user"
@@ -235,6 +504,73 @@ func (c *GeminiClient) convertMessagesToGemini(session *neurotypes.ChatSession)
 	return contents
 }
 
+// geminiFunctionCallContent converts an assistant message carrying tool calls into model content with function call parts.
+func geminiFunctionCallContent(msg neurotypes.Message, toolNames map[string]string) *genai.Content {
+	parts := make([]*genai.Part, 0, len(msg.ToolCalls)+1)
+	if msg.Content != "" {
+		parts = append(parts, &genai.Part{Text: msg.Content})
+	}
+	for _, call := range msg.ToolCalls {
+		toolNames[call.ID] = call.Name
+		var args map[string]any
+		if err := json.Unmarshal([]byte(call.Arguments), &args); err != nil {
+			args = map[string]any{}
+		}
+		parts = append(parts, &genai.Part{FunctionCall: &genai.FunctionCall{ID: call.ID, Name: call.Name, Args: args}})
+	}
+	return &genai.Content{Parts: parts, Role: "model"}
+}
+
+// geminiFunctionResponsePart converts a tool result message into a function response part.
+func geminiFunctionResponsePart(msg neurotypes.Message, name string) *genai.Part {
+	return &genai.Part{FunctionResponse: &genai.FunctionResponse{
+		ID:       msg.ToolCallID,
+		Name:     name,
+		Response: map[string]any{"output": msg.Content},
+	}}
+}
+
+// geminiTools converts tool definitions to Gemini function declarations.
+func geminiTools(tools []neurotypes.ToolDefinition) []*genai.Tool {
+	declarations := make([]*genai.FunctionDeclaration, 0, len(tools))
+	for _, tool := range tools {
+		declarations = append(declarations, &genai.FunctionDeclaration{
+			Name:                 tool.Name,
+			Description:          tool.Description,
+			ParametersJsonSchema: tool.Parameters,
+		})
+	}
+	return []*genai.Tool{{FunctionDeclarations: declarations}}
+}
+
+// extractGeminiToolCalls collects function call parts from a Gemini response as tool calls.
+// Gemini may omit call IDs, in which case positional IDs are generated.
+func extractGeminiToolCalls(result *genai.GenerateContentResponse) []neurotypes.ToolCall {
+	var toolCalls []neurotypes.ToolCall
+	for _, candidate := range result.Candidates {
+		if candidate.Content == nil {
+			continue
+		}
+		for _, part := range candidate.Content.Parts {
+			if part.FunctionCall == nil {
+				continue
+			}
+			arguments := "{}"
+			if len(part.FunctionCall.Args) > 0 {
+				if encoded, err := json.Marshal(part.FunctionCall.Args); err == nil {
+					arguments = string(encoded)
+				}
+			}
+			id := part.FunctionCall.ID
+			if id == "" {
+				id = fmt.Sprintf("gemini-call-%d", len(toolCalls)+1)
+			}
+			toolCalls = append(toolCalls, neurotypes.ToolCall{ID: id, Name: part.FunctionCall.Name, Arguments: arguments})
+		}
+	}
+	return toolCalls
+}
+
 // buildGenerationConfig creates a Gemini generation config from NeuroShell model parameters and session.
 func (c *GeminiClient) buildGenerationConfig(modelConfig *neurotypes.ModelConfig, session *neurotypes.ChatSession) *genai.GenerateContentConfig {
 	config := &genai.GenerateContentConfig{}
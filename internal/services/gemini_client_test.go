@@ -700,6 +700,7 @@ func TestGeminiClient_ParameterEdgeCases(t *testing.T) {
 
 func TestGeminiClient_InterfaceCompliance(_ *testing.T) {
 	var _ neurotypes.LLMClient = &GeminiClient{}
+	var _ neurotypes.StructuredOutputLLMClient = &GeminiClient{}
 }
 
 // Test SendStructuredCompletion method
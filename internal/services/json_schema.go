@@ -0,0 +1,431 @@
+// Package services provides JSON schema loading and validation for structured output responses.
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"neuroshell/pkg/neurotypes"
+)
+
+// SchemaViolationType is the LLMError type (and code) reported when a structured output
+// response is not valid JSON or does not conform to the requested schema.
+const SchemaViolationType = "schema_violation"
+
+// maxReportedViolations limits how many schema violations are listed in an error message.
+const maxReportedViolations = 5
+
+var schemaNameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// LoadResponseSchema reads a JSON schema file for structured output.
+// The schema name sent to providers is derived from the file name.
+func LoadResponseSchema(path string) (*neurotypes.ResponseSchema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema file: %w", err)
+	}
+	schema, err := ParseResponseSchema(data, strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema file %s: %w", path, err)
+	}
+	schema.Path = path
+	return schema, nil
+}
+
+// ParseResponseSchema parses a JSON schema document. The root must be an object schema
+// because providers only return JSON objects as structured output.
+func ParseResponseSchema(data []byte, name string) (*neurotypes.ResponseSchema, error) {
+	var document map[string]interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("schema is not a JSON object: %w", err)
+	}
+	if rootType, ok := document["type"]; ok && rootType != "object" {
+		return nil, fmt.Errorf("schema root type must be \"object\", got %v", rootType)
+	}
+
+	name = schemaNameInvalidChars.ReplaceAllString(name, "_")
+	if name == "" || name == "_" {
+		name = "response"
+	}
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return &neurotypes.ResponseSchema{Name: name, Schema: document}, nil
+}
+
+// ParseStructuredOutput decodes the JSON document returned as structured output.
+// Markdown code fences around the document are tolerated.
+func ParseStructuredOutput(text string) (interface{}, error) {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "```") {
+		text = strings.TrimPrefix(text, "```json")
+		text = strings.TrimPrefix(text, "```")
+		text = strings.TrimSuffix(strings.TrimSpace(text), "```")
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		return nil, fmt.Errorf("response is not valid JSON: %w", err)
+	}
+	return value, nil
+}
+
+// ValidateJSONSchema validates a decoded JSON value against a schema and returns all violations.
+// Supported keywords: type, enum, const, properties, required, additionalProperties,
+// minProperties, maxProperties, items, prefixItems, minItems, maxItems, uniqueItems, minLength,
+// maxLength, pattern, minimum, maximum, exclusiveMinimum, exclusiveMaximum, multipleOf, allOf,
+// anyOf, oneOf, not, and local $ref pointers (e.g. "#/$defs/item"). Other keywords are ignored.
+func ValidateJSONSchema(value interface{}, schema map[string]interface{}) []string {
+	validator := &schemaValidator{root: schema}
+	validator.validate(value, schema, "$")
+	return validator.violations
+}
+
+// validateStructuredOutput checks a successful structured output response against its schema
+// and turns invalid responses into schema_violation errors. The response text is kept.
+func validateStructuredOutput(response *neurotypes.StructuredLLMResponse, schema *neurotypes.ResponseSchema) {
+	if response == nil || response.Error != nil {
+		return
+	}
+
+	value, err := ParseStructuredOutput(response.TextContent)
+	var violations []string
+	if err != nil {
+		violations = []string{err.Error()}
+	} else {
+		violations = ValidateJSONSchema(value, schema.Schema)
+	}
+	if len(violations) == 0 {
+		return
+	}
+
+	reported := violations
+	if len(reported) > maxReportedViolations {
+		reported = append(reported[:maxReportedViolations:maxReportedViolations], fmt.Sprintf("... and %d more", len(violations)-maxReportedViolations))
+	}
+	response.Error = &neurotypes.LLMError{
+		Code:    SchemaViolationType,
+		Message: fmt.Sprintf("response does not match schema '%s': %s", schema.Name, strings.Join(reported, "; ")),
+		Type:    SchemaViolationType,
+	}
+}
+
+// schemaValidator collects violations while walking a value and its schema.
+type schemaValidator struct {
+	root       map[string]interface{}
+	violations []string
+	depth      int
+}
+
+func (v *schemaValidator) fail(path, format string, args ...interface{}) {
+	v.violations = append(v.violations, path+": "+fmt.Sprintf(format, args...))
+}
+
+func (v *schemaValidator) validate(value interface{}, schemaValue interface{}, path string) {
+	switch schema := schemaValue.(type) {
+	case bool:
+		if !schema {
+			v.fail(path, "no value is allowed here")
+		}
+		return
+	case map[string]interface{}:
+		v.depth++
+		defer func() { v.depth-- }()
+		if v.depth > 64 {
+			v.fail(path, "schema nesting too deep (recursive $ref?)")
+			return
+		}
+		v.validateObjectSchema(value, schema, path)
+	}
+}
+
+func (v *schemaValidator) validateObjectSchema(value interface{}, schema map[string]interface{}, path string) {
+	if ref, ok := schema["$ref"].(string); ok {
+		target, err := v.resolveRef(ref)
+		if err != nil {
+			v.fail(path, "%s", err.Error())
+		} else {
+			v.validate(value, target, path)
+		}
+	}
+
+	if typeValue, ok := schema["type"]; ok && !matchesSchemaType(value, typeValue) {
+		v.fail(path, "expected %s, got %s", describeSchemaType(typeValue), jsonTypeName(value))
+		return
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, allowed := range enum {
+			if reflect.DeepEqual(value, allowed) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.fail(path, "value %s is not one of %s", compactJSON(value), compactJSON(enum))
+		}
+	}
+	if constValue, ok := schema["const"]; ok && !reflect.DeepEqual(value, constValue) {
+		v.fail(path, "value %s is not %s", compactJSON(value), compactJSON(constValue))
+	}
+
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		v.validateObject(typed, schema, path)
+	case []interface{}:
+		v.validateArray(typed, schema, path)
+	case string:
+		v.validateString(typed, schema, path)
+	case float64:
+		v.validateNumber(typed, schema, path)
+	}
+
+	v.validateCombinators(value, schema, path)
+}
+
+func (v *schemaValidator) validateObject(object map[string]interface{}, schema map[string]interface{}, path string) {
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, name := range required {
+			if key, ok := name.(string); ok {
+				if _, exists := object[key]; !exists {
+					v.fail(path, "missing required property '%s'", key)
+				}
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	for _, key := range sortedKeys(object) {
+		propertyPath := path + "." + key
+		if propertySchema, ok := properties[key]; ok {
+			v.validate(object[key], propertySchema, propertyPath)
+			continue
+		}
+		if additional, ok := schema["additionalProperties"]; ok {
+			if allowed, isBool := additional.(bool); isBool && !allowed {
+				v.fail(path, "property '%s' is not allowed", key)
+				continue
+			}
+			v.validate(object[key], additional, propertyPath)
+		}
+	}
+
+	if limit, ok := schemaNumber(schema, "minProperties"); ok && float64(len(object)) < limit {
+		v.fail(path, "expected at least %v properties, got %d", limit, len(object))
+	}
+	if limit, ok := schemaNumber(schema, "maxProperties"); ok && float64(len(object)) > limit {
+		v.fail(path, "expected at most %v properties, got %d", limit, len(object))
+	}
+}
+
+func (v *schemaValidator) validateArray(array []interface{}, schema map[string]interface{}, path string) {
+	prefixItems, _ := schema["prefixItems"].([]interface{})
+	for i, item := range array {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		if i < len(prefixItems) {
+			v.validate(item, prefixItems[i], itemPath)
+		} else if items, ok := schema["items"]; ok {
+			v.validate(item, items, itemPath)
+		}
+	}
+
+	if limit, ok := schemaNumber(schema, "minItems"); ok && float64(len(array)) < limit {
+		v.fail(path, "expected at least %v items, got %d", limit, len(array))
+	}
+	if limit, ok := schemaNumber(schema, "maxItems"); ok && float64(len(array)) > limit {
+		v.fail(path, "expected at most %v items, got %d", limit, len(array))
+	}
+	if unique, _ := schema["uniqueItems"].(bool); unique {
+		for i := range array {
+			for j := i + 1; j < len(array); j++ {
+				if reflect.DeepEqual(array[i], array[j]) {
+					v.fail(path, "items %d and %d are equal", i, j)
+				}
+			}
+		}
+	}
+}
+
+func (v *schemaValidator) validateString(text string, schema map[string]interface{}, path string) {
+	length := float64(utf8.RuneCountInString(text))
+	if limit, ok := schemaNumber(schema, "minLength"); ok && length < limit {
+		v.fail(path, "expected at least %v characters, got %v", limit, length)
+	}
+	if limit, ok := schemaNumber(schema, "maxLength"); ok && length > limit {
+		v.fail(path, "expected at most %v characters, got %v", limit, length)
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			v.fail(path, "invalid pattern %q in schema: %v", pattern, err)
+		} else if !re.MatchString(text) {
+			v.fail(path, "%q does not match pattern %q", text, pattern)
+		}
+	}
+}
+
+func (v *schemaValidator) validateNumber(number float64, schema map[string]interface{}, path string) {
+	if limit, ok := schemaNumber(schema, "minimum"); ok && number < limit {
+		v.fail(path, "%v is less than the minimum %v", number, limit)
+	}
+	if limit, ok := schemaNumber(schema, "maximum"); ok && number > limit {
+		v.fail(path, "%v is greater than the maximum %v", number, limit)
+	}
+	if limit, ok := schemaNumber(schema, "exclusiveMinimum"); ok && number <= limit {
+		v.fail(path, "%v must be greater than %v", number, limit)
+	}
+	if limit, ok := schemaNumber(schema, "exclusiveMaximum"); ok && number >= limit {
+		v.fail(path, "%v must be less than %v", number, limit)
+	}
+	if divisor, ok := schemaNumber(schema, "multipleOf"); ok && divisor > 0 {
+		if quotient := number / divisor; math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+			v.fail(path, "%v is not a multiple of %v", number, divisor)
+		}
+	}
+}
+
+func (v *schemaValidator) validateCombinators(value interface{}, schema map[string]interface{}, path string) {
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		for _, subschema := range allOf {
+			v.validate(value, subschema, path)
+		}
+	}
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok {
+		if v.countMatches(value, anyOf, path) == 0 {
+			v.fail(path, "value does not match any of the anyOf schemas")
+		}
+	}
+	if oneOf, ok := schema["oneOf"].([]interface{}); ok {
+		if matches := v.countMatches(value, oneOf, path); matches != 1 {
+			v.fail(path, "value must match exactly one oneOf schema, matched %d", matches)
+		}
+	}
+	if not, ok := schema["not"]; ok && v.countMatches(value, []interface{}{not}, path) == 1 {
+		v.fail(path, "value must not match the 'not' schema")
+	}
+}
+
+// countMatches returns how many of the subschemas the value satisfies without recording violations.
+func (v *schemaValidator) countMatches(value interface{}, subschemas []interface{}, path string) int {
+	matches := 0
+	for _, subschema := range subschemas {
+		probe := &schemaValidator{root: v.root, depth: v.depth}
+		probe.validate(value, subschema, path)
+		if len(probe.violations) == 0 {
+			matches++
+		}
+	}
+	return matches
+}
+
+// resolveRef resolves a local JSON pointer reference such as "#/$defs/item".
+func (v *schemaValidator) resolveRef(ref string) (interface{}, error) {
+	if ref == "#" {
+		return v.root, nil
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("unsupported $ref %q (only local references are supported)", ref)
+	}
+
+	var current interface{} = v.root
+	for _, token := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unresolvable $ref %q", ref)
+		}
+		if current, ok = object[token]; !ok {
+			return nil, fmt.Errorf("unresolvable $ref %q", ref)
+		}
+	}
+	return current, nil
+}
+
+// matchesSchemaType reports whether a value has the type (or one of the types) named in a schema.
+func matchesSchemaType(value interface{}, typeValue interface{}) bool {
+	switch typed := typeValue.(type) {
+	case string:
+		return matchesJSONType(value, typed)
+	case []interface{}:
+		for _, candidate := range typed {
+			if name, ok := candidate.(string); ok && matchesJSONType(value, name) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func matchesJSONType(value interface{}, typeName string) bool {
+	switch typeName {
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number)
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	default:
+		return jsonTypeName(value) == typeName
+	}
+}
+
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func describeSchemaType(typeValue interface{}) string {
+	if types, ok := typeValue.([]interface{}); ok {
+		names := make([]string, 0, len(types))
+		for _, name := range types {
+			names = append(names, fmt.Sprintf("%v", name))
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprintf("%v", typeValue)
+}
+
+func schemaNumber(schema map[string]interface{}, keyword string) (float64, bool) {
+	number, ok := schema[keyword].(float64)
+	return number, ok
+}
+
+func compactJSON(value interface{}) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(encoded)
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
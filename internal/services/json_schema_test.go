@@ -0,0 +1,179 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/pkg/neurotypes"
+)
+
+const personSchemaJSON = `{
+	"type": "object",
+	"properties": {
+		"name": {"type": "string", "minLength": 1},
+		"age": {"type": "integer", "minimum": 0},
+		"role": {"enum": ["admin", "user"]},
+		"tags": {"type": "array", "items": {"type": "string"}, "uniqueItems": true},
+		"address": {"$ref": "#/$defs/address"}
+	},
+	"required": ["name", "age"],
+	"additionalProperties": false,
+	"$defs": {
+		"address": {
+			"type": "object",
+			"properties": {"city": {"type": "string"}},
+			"required": ["city"]
+		}
+	}
+}`
+
+func mustParseSchema(t *testing.T) *neurotypes.ResponseSchema {
+	schema, err := ParseResponseSchema([]byte(personSchemaJSON), "person")
+	require.NoError(t, err)
+	return schema
+}
+
+func TestParseResponseSchema(t *testing.T) {
+	schema := mustParseSchema(t)
+	assert.Equal(t, "person", schema.Name)
+	assert.Equal(t, "object", schema.Schema["type"])
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"invalid JSON", `{"type":`, "schema is not a JSON object"},
+		{"array root", `[1, 2]`, "schema is not a JSON object"},
+		{"non-object type", `{"type": "string"}`, "schema root type must be \"object\""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseResponseSchema([]byte(tt.data), "bad")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestParseResponseSchema_SanitizesName(t *testing.T) {
+	schema, err := ParseResponseSchema([]byte(`{"type": "object"}`), "my schema.v2")
+	require.NoError(t, err)
+	assert.Equal(t, "my_schema_v2", schema.Name)
+
+	schema, err = ParseResponseSchema([]byte(`{"type": "object"}`), "")
+	require.NoError(t, err)
+	assert.Equal(t, "response", schema.Name)
+}
+
+func TestLoadResponseSchema(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "person.json")
+	require.NoError(t, os.WriteFile(path, []byte(personSchemaJSON), 0644))
+
+	schema, err := LoadResponseSchema(path)
+	require.NoError(t, err)
+	assert.Equal(t, "person", schema.Name)
+	assert.Equal(t, path, schema.Path)
+
+	_, err = LoadResponseSchema(filepath.Join(dir, "missing.json"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read schema file")
+}
+
+func TestParseStructuredOutput(t *testing.T) {
+	value, err := ParseStructuredOutput("```json\n{\"name\": \"Ada\"}\n```")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "Ada"}, value)
+
+	_, err = ParseStructuredOutput("not json")
+	assert.Error(t, err)
+}
+
+func TestValidateJSONSchema(t *testing.T) {
+	schema := mustParseSchema(t)
+
+	tests := []struct {
+		name     string
+		document string
+		want     []string
+	}{
+		{"valid", `{"name": "Ada", "age": 36, "tags": ["math"], "address": {"city": "London"}}`, nil},
+		{"missing required", `{"name": "Ada"}`, []string{"$: missing required property 'age'"}},
+		{"wrong type", `{"name": "Ada", "age": 36.5}`, []string{"$.age:"}},
+		{"below minimum", `{"name": "Ada", "age": -1}`, []string{"$.age:"}},
+		{"enum", `{"name": "Ada", "age": 1, "role": "root"}`, []string{"$.role:"}},
+		{"additional property", `{"name": "Ada", "age": 1, "extra": true}`, []string{"extra"}},
+		{"duplicate items", `{"name": "Ada", "age": 1, "tags": ["a", "a"]}`, []string{"$.tags:"}},
+		{"ref", `{"name": "Ada", "age": 1, "address": {}}`, []string{"$.address: missing required property 'city'"}},
+		{"empty string", `{"name": "", "age": 1}`, []string{"$.name:"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := ParseStructuredOutput(tt.document)
+			require.NoError(t, err)
+			violations := ValidateJSONSchema(value, schema.Schema)
+			if tt.want == nil {
+				assert.Empty(t, violations)
+				return
+			}
+			require.Len(t, violations, len(tt.want), "violations: %v", violations)
+			for i, want := range tt.want {
+				assert.Contains(t, violations[i], want)
+			}
+		})
+	}
+}
+
+func TestValidateStructuredOutput(t *testing.T) {
+	schema := mustParseSchema(t)
+
+	response := &neurotypes.StructuredLLMResponse{TextContent: `{"name": "Ada", "age": 36}`}
+	validateStructuredOutput(response, schema)
+	assert.Nil(t, response.Error)
+
+	response = &neurotypes.StructuredLLMResponse{TextContent: `{"name": "Ada"}`}
+	validateStructuredOutput(response, schema)
+	require.NotNil(t, response.Error)
+	assert.Equal(t, SchemaViolationType, response.Error.Type)
+	assert.Contains(t, response.Error.Message, "response does not match schema 'person'")
+
+	response = &neurotypes.StructuredLLMResponse{TextContent: "Sure, here is the JSON"}
+	validateStructuredOutput(response, schema)
+	require.NotNil(t, response.Error)
+	assert.Equal(t, SchemaViolationType, response.Error.Type)
+}
+
+func TestMockLLMService_SendStructuredCompletionWithSchema(t *testing.T) {
+	service := NewMockLLMService()
+	require.NoError(t, service.Initialize())
+	schema := mustParseSchema(t)
+	model := &neurotypes.ModelConfig{Provider: "openai", BaseModel: "gpt-4"}
+
+	session := &neurotypes.ChatSession{Messages: []neurotypes.Message{{Role: "user", Content: "Describe Ada"}}}
+	response := service.SendStructuredCompletionWithSchema(NewMockLLMClient(), session, model, schema)
+	require.Nil(t, response.Error)
+	value, err := ParseStructuredOutput(response.TextContent)
+	require.NoError(t, err)
+	assert.Empty(t, ValidateJSONSchema(value, schema.Schema))
+
+	session.Messages[0].Content = "trigger schema violation"
+	response = service.SendStructuredCompletionWithSchema(NewMockLLMClient(), session, model, schema)
+	require.NotNil(t, response.Error)
+	assert.Equal(t, SchemaViolationType, response.Error.Type)
+}
+
+func TestLLMService_SendStructuredCompletionWithSchema_UnsupportedClient(t *testing.T) {
+	service := NewLLMService()
+	require.NoError(t, service.Initialize())
+	session := &neurotypes.ChatSession{Messages: []neurotypes.Message{{Role: "user", Content: "Hi"}}}
+	model := &neurotypes.ModelConfig{Provider: "openai", BaseModel: "gpt-4"}
+
+	response := service.SendStructuredCompletionWithSchema(NewMockLLMClient(), session, model, mustParseSchema(t))
+	require.NotNil(t, response.Error)
+	assert.Equal(t, "structured_output_unsupported", response.Error.Code)
+}
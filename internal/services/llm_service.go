@@ -1,8 +1,11 @@
 package services
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
 	"strings"
+	"time"
 
 	"neuroshell/internal/logger"
 	"neuroshell/pkg/neurotypes"
@@ -71,10 +74,157 @@ func (s *LLMService) SendCompletion(client neurotypes.LLMClient, session *neurot
 
 // SendStructuredCompletion sends a chat completion request using the provided client and returns structured response.
 // This separates thinking/reasoning content from regular text for proper rendering control.
+// Requests that would exceed a spending budget fail with a "budget_exceeded" error before being sent,
+// and rate limit or server errors are retried with backoff according to the model's retry policy.
 // All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
 func (s *LLMService) SendStructuredCompletion(client neurotypes.LLMClient, session *neurotypes.ChatSession, model *neurotypes.ModelConfig) *neurotypes.StructuredLLMResponse {
 	logger.ServiceOperation("llm", "send_structured_completion", "starting")
 
+	if errResponse := s.validateStructuredRequest(client); errResponse != nil {
+		return errResponse
+	}
+
+	if errResponse := checkBudget(session, model); errResponse != nil {
+		return errResponse
+	}
+
+	logger.Debug("Sending structured completion request", "provider", client.GetProviderName(), "model", model.BaseModel, "messages", len(session.Messages))
+
+	// Send the structured completion request using the client with session as-is
+	response := sendWithRetry(model, func() *neurotypes.StructuredLLMResponse {
+		return client.SendStructuredCompletion(session, model)
+	}, nil)
+
+	logger.Debug("Structured completion request completed", "text_length", len(response.TextContent), "thinking_blocks", len(response.ThinkingBlocks))
+	logger.ServiceOperation("llm", "send_structured_completion", "completed")
+	return response
+}
+
+// StreamStructuredCompletion sends a chat completion request and delivers text and thinking deltas to onChunk.
+// Clients implementing neurotypes.StreamingLLMClient stream natively; other clients fall back to a
+// blocking call whose thinking blocks and text are delivered as whole chunks once available.
+// Failed requests are only retried while no chunk has been delivered yet.
+// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+func (s *LLMService) StreamStructuredCompletion(client neurotypes.LLMClient, session *neurotypes.ChatSession, model *neurotypes.ModelConfig, onChunk neurotypes.StreamHandler) *neurotypes.StructuredLLMResponse {
+	logger.ServiceOperation("llm", "stream_structured_completion", "starting")
+
+	if errResponse := s.validateStructuredRequest(client); errResponse != nil {
+		return errResponse
+	}
+
+	if errResponse := checkBudget(session, model); errResponse != nil {
+		return errResponse
+	}
+
+	if onChunk == nil {
+		onChunk = func(neurotypes.StreamChunk) {}
+	}
+
+	logger.Debug("Sending streaming completion request", "provider", client.GetProviderName(), "model", model.BaseModel, "messages", len(session.Messages))
+
+	// Once output has been shown a retry would repeat it, so track whether anything was delivered
+	delivered := false
+	trackedOnChunk := func(chunk neurotypes.StreamChunk) {
+		delivered = true
+		onChunk(chunk)
+	}
+
+	streamingClient, supportsStreaming := client.(neurotypes.StreamingLLMClient)
+	if !supportsStreaming {
+		logger.Debug("Client does not support streaming, falling back to blocking call", "provider", client.GetProviderName())
+	}
+	response := sendWithRetry(model, func() *neurotypes.StructuredLLMResponse {
+		if supportsStreaming {
+			return streamingClient.StreamStructuredCompletion(session, model, trackedOnChunk)
+		}
+		blockingResponse := client.SendStructuredCompletion(session, model)
+		emitStructuredResponseChunks(blockingResponse, trackedOnChunk)
+		return blockingResponse
+	}, func() bool { return !delivered })
+
+	logger.Debug("Streaming completion request completed", "text_length", len(response.TextContent), "thinking_blocks", len(response.ThinkingBlocks))
+	logger.ServiceOperation("llm", "stream_structured_completion", "completed")
+	return response
+}
+
+// SendStructuredCompletionWithTools sends a chat completion request advertising the given tools.
+// Clients that do not implement neurotypes.ToolCallingLLMClient receive a plain structured request.
+// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+func (s *LLMService) SendStructuredCompletionWithTools(client neurotypes.LLMClient, session *neurotypes.ChatSession, model *neurotypes.ModelConfig, tools []neurotypes.ToolDefinition) *neurotypes.StructuredLLMResponse {
+	logger.ServiceOperation("llm", "send_structured_completion_with_tools", "starting")
+
+	if errResponse := s.validateStructuredRequest(client); errResponse != nil {
+		return errResponse
+	}
+
+	if errResponse := checkBudget(session, model); errResponse != nil {
+		return errResponse
+	}
+
+	toolClient, ok := client.(neurotypes.ToolCallingLLMClient)
+	if !ok || len(tools) == 0 {
+		logger.Debug("Sending request without tools", "provider", client.GetProviderName(), "supports_tools", ok, "tools", len(tools))
+		return sendWithRetry(model, func() *neurotypes.StructuredLLMResponse {
+			return client.SendStructuredCompletion(session, model)
+		}, nil)
+	}
+
+	logger.Debug("Sending tool calling request", "provider", client.GetProviderName(), "model", model.BaseModel, "messages", len(session.Messages), "tools", len(tools))
+
+	response := sendWithRetry(model, func() *neurotypes.StructuredLLMResponse {
+		return toolClient.SendStructuredCompletionWithTools(session, model, tools)
+	}, nil)
+
+	logger.Debug("Tool calling request completed", "text_length", len(response.TextContent), "tool_calls", len(response.ToolCalls))
+	logger.ServiceOperation("llm", "send_structured_completion_with_tools", "completed")
+	return response
+}
+
+// SendStructuredCompletionWithSchema sends a chat completion request constrained to a JSON schema
+// using the provider's native structured output feature, then validates the returned document
+// locally. Invalid documents are reported as "schema_violation" errors and are not retried.
+// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+func (s *LLMService) SendStructuredCompletionWithSchema(client neurotypes.LLMClient, session *neurotypes.ChatSession, model *neurotypes.ModelConfig, schema *neurotypes.ResponseSchema) *neurotypes.StructuredLLMResponse {
+	logger.ServiceOperation("llm", "send_structured_completion_with_schema", "starting")
+
+	if errResponse := s.validateStructuredRequest(client); errResponse != nil {
+		return errResponse
+	}
+
+	schemaClient, ok := client.(neurotypes.StructuredOutputLLMClient)
+	if !ok {
+		logger.Error("Client does not support structured output", "provider", client.GetProviderName())
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error: &neurotypes.LLMError{
+				Code:    "structured_output_unsupported",
+				Message: fmt.Sprintf("provider '%s' does not support structured output", client.GetProviderName()),
+				Type:    "client_error",
+			},
+			Metadata: map[string]interface{}{"service": "llm", "provider": client.GetProviderName()},
+		}
+	}
+
+	if errResponse := checkBudget(session, model); errResponse != nil {
+		return errResponse
+	}
+
+	logger.Debug("Sending structured output request", "provider", client.GetProviderName(), "model", model.BaseModel, "schema", schema.Name)
+
+	response := sendWithRetry(model, func() *neurotypes.StructuredLLMResponse {
+		return schemaClient.SendStructuredCompletionWithSchema(session, model, schema)
+	}, nil)
+	validateStructuredOutput(response, schema)
+
+	logger.Debug("Structured output request completed", "text_length", len(response.TextContent), "valid", response.Error == nil)
+	logger.ServiceOperation("llm", "send_structured_completion_with_schema", "completed")
+	return response
+}
+
+// validateStructuredRequest checks service and client readiness for structured requests.
+// Returns nil when the request can proceed, or an error response describing the problem.
+func (s *LLMService) validateStructuredRequest(client neurotypes.LLMClient) *neurotypes.StructuredLLMResponse {
 	if !s.initialized {
 		logger.Error("LLM service not initialized")
 		return &neurotypes.StructuredLLMResponse{
@@ -117,20 +267,36 @@ func (s *LLMService) SendStructuredCompletion(client neurotypes.LLMClient, sessi
 		}
 	}
 
-	logger.Debug("Sending structured completion request", "provider", client.GetProviderName(), "model", model.BaseModel, "messages", len(session.Messages))
+	return nil
+}
 
-	// Send the structured completion request using the client with session as-is
-	response := client.SendStructuredCompletion(session, model)
+// emitStructuredResponseChunks delivers a complete structured response as stream chunks.
+// Thinking blocks are emitted first, followed by the text content, mirroring provider order.
+func emitStructuredResponseChunks(response *neurotypes.StructuredLLMResponse, onChunk neurotypes.StreamHandler) {
+	if response == nil || onChunk == nil {
+		return
+	}
 
-	logger.Debug("Structured completion request completed", "text_length", len(response.TextContent), "thinking_blocks", len(response.ThinkingBlocks))
-	logger.ServiceOperation("llm", "send_structured_completion", "completed")
-	return response
+	for _, block := range response.ThinkingBlocks {
+		if block.Content == "" {
+			continue
+		}
+		onChunk(neurotypes.StreamChunk{Type: "thinking", Content: block.Content, Provider: block.Provider})
+	}
+
+	if response.TextContent != "" {
+		provider, _ := response.Metadata["provider"].(string)
+		onChunk(neurotypes.StreamChunk{Type: "text", Content: response.TextContent, Provider: provider})
+	}
 }
 
 // MockLLMService provides a mock implementation of LLMService for testing
 type MockLLMService struct {
-	initialized bool
-	responses   map[string]string // model -> response mapping
+	initialized   bool
+	responses     map[string]string // model -> response mapping
+	toolCallCount int               // number of mock tool calls issued, used for call IDs
+	attempts      map[string]int    // attempts per transient error message, used to fail then recover
+	requestCount  int               // number of mock replies, used for request IDs
 }
 
 // NewMockLLMService creates a new MockLLMService instance
@@ -196,7 +362,7 @@ func (m *MockLLMService) SendCompletionWithDebug(_ neurotypes.LLMClient, session
 
 // SendStructuredCompletion mocks sending a structured completion request
 // All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
-func (m *MockLLMService) SendStructuredCompletion(_ neurotypes.LLMClient, session *neurotypes.ChatSession, model *neurotypes.ModelConfig) *neurotypes.StructuredLLMResponse {
+func (m *MockLLMService) SendStructuredCompletion(client neurotypes.LLMClient, session *neurotypes.ChatSession, model *neurotypes.ModelConfig) *neurotypes.StructuredLLMResponse {
 	if !m.initialized {
 		return &neurotypes.StructuredLLMResponse{
 			TextContent:    "",
@@ -210,6 +376,21 @@ func (m *MockLLMService) SendStructuredCompletion(_ neurotypes.LLMClient, sessio
 		}
 	}
 
+	if errResponse := checkBudget(session, model); errResponse != nil {
+		return errResponse
+	}
+
+	return sendWithRetry(model, func() *neurotypes.StructuredLLMResponse {
+		// Scripted mock provider clients are deterministic, so test mode uses their responses
+		if mockClient, ok := client.(*MockProviderClient); ok {
+			return mockClient.SendStructuredCompletion(session, model)
+		}
+		return m.sendStructuredCompletion(session, model)
+	}, nil)
+}
+
+// sendStructuredCompletion produces a single mock response attempt.
+func (m *MockLLMService) sendStructuredCompletion(session *neurotypes.ChatSession, model *neurotypes.ModelConfig) *neurotypes.StructuredLLMResponse {
 	// Create a mock response with message count and last message info for debugging
 	messageCount := len(session.Messages)
 	lastMessage := "no messages"
@@ -255,6 +436,36 @@ func (m *MockLLMService) SendStructuredCompletion(_ neurotypes.LLMClient, sessio
 		}
 	}
 
+	// Trigger a transient failure: a rate limit with Retry-After, then an overloaded server, then success
+	if strings.Contains(lastMessageLower, "trigger transient error") {
+		if m.attempts == nil {
+			m.attempts = make(map[string]int)
+		}
+		key := session.ID + "/" + lastMessage
+		m.attempts[key]++
+		switch m.attempts[key] {
+		case 1:
+			return &neurotypes.StructuredLLMResponse{
+				TextContent:    "",
+				ThinkingBlocks: []neurotypes.ThinkingBlock{},
+				Error: &neurotypes.LLMError{
+					Code:       "rate_limit_exceeded",
+					Message:    "Mock rate limit exceeded for testing retries",
+					Type:       "rate_limit",
+					RetryAfter: 3 * time.Second,
+				},
+				Metadata: map[string]interface{}{"service": "mock_llm", "error_triggered": true},
+			}
+		case 2:
+			return mockServerErrorResponse()
+		}
+	}
+
+	// Trigger a server error on every attempt
+	if strings.Contains(lastMessageLower, "trigger server error") {
+		return mockServerErrorResponse()
+	}
+
 	// Trigger client error
 	if strings.Contains(lastMessageLower, "trigger client error") {
 		return &neurotypes.StructuredLLMResponse{
@@ -304,12 +515,222 @@ func (m *MockLLMService) SendStructuredCompletion(_ neurotypes.LLMClient, sessio
 		TextContent:    textContent,
 		ThinkingBlocks: thinkingBlocks,
 		Error:          nil, // No error in successful case
-		Metadata:       map[string]interface{}{"service": "mock_llm", "provider": provider, "model": model.BaseModel},
+		Metadata:       usageMetadata(provider, model.BaseModel, mockTokenUsage(session, textContent, thinkingBlocks)),
 	}
+	structuredResponse.Metadata["service"] = "mock_llm"
+	m.requestCount++
+	withResponseDetails(structuredResponse.Metadata, "end_turn", fmt.Sprintf("mock-request-%d", m.requestCount))
 
 	return structuredResponse
 }
 
+// mockServerErrorResponse returns the overloaded server error used by the mock retry triggers.
+func mockServerErrorResponse() *neurotypes.StructuredLLMResponse {
+	return &neurotypes.StructuredLLMResponse{
+		TextContent:    "",
+		ThinkingBlocks: []neurotypes.ThinkingBlock{},
+		Error: &neurotypes.LLMError{
+			Code:    "server_error_529",
+			Message: "Mock provider overloaded for testing retries",
+			Type:    "server_error",
+		},
+		Metadata: map[string]interface{}{"service": "mock_llm", "error_triggered": true},
+	}
+}
+
+// mockTokenUsage produces deterministic token counts for mock responses by counting words,
+// so usage accounting can be exercised in tests without a provider.
+func mockTokenUsage(session *neurotypes.ChatSession, textContent string, thinkingBlocks []neurotypes.ThinkingBlock) *neurotypes.TokenUsage {
+	input := len(strings.Fields(session.SystemPrompt))
+	for _, msg := range session.Messages {
+		input += len(strings.Fields(msg.Content))
+	}
+	thinking := 0
+	for _, block := range thinkingBlocks {
+		thinking += len(strings.Fields(block.Content))
+	}
+	output := len(strings.Fields(textContent)) + thinking
+
+	return &neurotypes.TokenUsage{
+		InputTokens:    int64(input),
+		OutputTokens:   int64(output),
+		ThinkingTokens: int64(thinking),
+	}
+}
+
+// StreamStructuredCompletion mocks a streaming request by emitting the mock structured response as chunks.
+// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+func (m *MockLLMService) StreamStructuredCompletion(client neurotypes.LLMClient, session *neurotypes.ChatSession, model *neurotypes.ModelConfig, onChunk neurotypes.StreamHandler) *neurotypes.StructuredLLMResponse {
+	response := m.SendStructuredCompletion(client, session, model)
+	emitStructuredResponseChunks(response, onChunk)
+	return response
+}
+
+// SendStructuredCompletionWithTools mocks a tool calling request.
+// When tools are available and the last message is a user message containing "trigger tool call",
+// the first tool is requested with empty arguments; once a tool result is present a final answer
+// summarizing the results is returned.
+// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+func (m *MockLLMService) SendStructuredCompletionWithTools(client neurotypes.LLMClient, session *neurotypes.ChatSession, model *neurotypes.ModelConfig, tools []neurotypes.ToolDefinition) *neurotypes.StructuredLLMResponse {
+	if len(tools) == 0 || session == nil || len(session.Messages) == 0 {
+		return m.SendStructuredCompletion(client, session, model)
+	}
+
+	if errResponse := checkBudget(session, model); errResponse != nil {
+		return errResponse
+	}
+
+	lastMessage := session.Messages[len(session.Messages)-1]
+	switch {
+	case lastMessage.Role == "tool":
+		// Collect the trailing tool results into a final answer
+		var results []string
+		for i := len(session.Messages) - 1; i >= 0 && session.Messages[i].Role == "tool"; i-- {
+			results = append([]string{session.Messages[i].Content}, results...)
+		}
+		textContent := fmt.Sprintf("This is a mocking reply using tool results: %s", strings.Join(results, "; "))
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    textContent,
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error:          nil,
+			Metadata:       usageMetadata("mock", model.BaseModel, mockTokenUsage(session, textContent, nil)),
+		}
+	case lastMessage.Role == "user" && strings.Contains(strings.ToLower(lastMessage.Content), "trigger tool call"):
+		m.toolCallCount++
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			ToolCalls: []neurotypes.ToolCall{
+				{ID: fmt.Sprintf("mock-call-%d", m.toolCallCount), Name: tools[0].Name, Arguments: mockToolArguments(tools[0])},
+			},
+			Error:    nil,
+			Metadata: usageMetadata("mock", model.BaseModel, mockTokenUsage(session, "", nil)),
+		}
+	default:
+		return m.SendStructuredCompletion(client, session, model)
+	}
+}
+
+// SendStructuredCompletionWithSchema mocks a structured output request by generating a document
+// that conforms to the schema, which is then validated like a real response.
+// A last message containing "trigger schema violation" returns an empty object instead.
+// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+func (m *MockLLMService) SendStructuredCompletionWithSchema(client neurotypes.LLMClient, session *neurotypes.ChatSession, model *neurotypes.ModelConfig, schema *neurotypes.ResponseSchema) *neurotypes.StructuredLLMResponse {
+	response := m.SendStructuredCompletion(client, session, model)
+	if response.Error != nil {
+		return response
+	}
+
+	var document interface{} = map[string]interface{}{}
+	if len(session.Messages) == 0 || !strings.Contains(strings.ToLower(session.Messages[len(session.Messages)-1].Content), "trigger schema violation") {
+		document = mockSchemaValue(schema.Schema, schema.Schema, "response", 0)
+	}
+	response.TextContent = compactJSON(document)
+	response.ThinkingBlocks = []neurotypes.ThinkingBlock{}
+	validateStructuredOutput(response, schema)
+	return response
+}
+
+// mockSchemaValue builds a deterministic value satisfying common schema constraints:
+// the first enum or const value, minimum numbers and lengths, and every declared property.
+func mockSchemaValue(schemaValue interface{}, root map[string]interface{}, name string, depth int) interface{} {
+	schema, ok := schemaValue.(map[string]interface{})
+	if !ok || depth > 8 {
+		return nil
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		if target, err := (&schemaValidator{root: root}).resolveRef(ref); err == nil {
+			return mockSchemaValue(target, root, name, depth+1)
+		}
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		return enum[0]
+	}
+	if constValue, ok := schema["const"]; ok {
+		return constValue
+	}
+	for _, keyword := range []string{"anyOf", "oneOf", "allOf"} {
+		if subschemas, ok := schema[keyword].([]interface{}); ok && len(subschemas) > 0 {
+			return mockSchemaValue(subschemas[0], root, name, depth+1)
+		}
+	}
+
+	schemaType, _ := schema["type"].(string)
+	if types, ok := schema["type"].([]interface{}); ok && len(types) > 0 {
+		schemaType, _ = types[0].(string)
+	}
+	if schemaType == "" {
+		if _, hasProperties := schema["properties"]; hasProperties {
+			schemaType = "object"
+		}
+	}
+
+	switch schemaType {
+	case "object":
+		object := make(map[string]interface{})
+		properties, _ := schema["properties"].(map[string]interface{})
+		for property, propertySchema := range properties {
+			object[property] = mockSchemaValue(propertySchema, root, property, depth+1)
+		}
+		return object
+	case "array":
+		count := 1
+		if minItems, ok := schemaNumber(schema, "minItems"); ok && int(minItems) > count {
+			count = int(minItems)
+		}
+		items := make([]interface{}, 0, count)
+		for i := 0; i < count; i++ {
+			items = append(items, mockSchemaValue(schema["items"], root, name, depth+1))
+		}
+		return items
+	case "integer", "number":
+		if minimum, ok := schemaNumber(schema, "minimum"); ok {
+			return math.Ceil(minimum)
+		}
+		if minimum, ok := schemaNumber(schema, "exclusiveMinimum"); ok {
+			return math.Floor(minimum) + 1
+		}
+		return float64(1)
+	case "boolean":
+		return true
+	case "null":
+		return nil
+	default:
+		value := "mock_" + name
+		if minLength, ok := schemaNumber(schema, "minLength"); ok && len(value) < int(minLength) {
+			value += strings.Repeat("x", int(minLength)-len(value))
+		}
+		return value
+	}
+}
+
+// mockToolArguments builds deterministic arguments for every property in a tool's parameter schema.
+func mockToolArguments(tool neurotypes.ToolDefinition) string {
+	arguments := make(map[string]interface{})
+	if properties, ok := tool.Parameters["properties"].(map[string]interface{}); ok {
+		for name, property := range properties {
+			propertyType := ""
+			if propertyMap, ok := property.(map[string]interface{}); ok {
+				propertyType, _ = propertyMap["type"].(string)
+			}
+			switch propertyType {
+			case "integer", "number":
+				arguments[name] = 1
+			case "boolean":
+				arguments[name] = true
+			default:
+				arguments[name] = "mock_" + name
+			}
+		}
+	}
+
+	encoded, err := json.Marshal(arguments)
+	if err != nil {
+		return "{}"
+	}
+	return string(encoded)
+}
+
 // SetMockResponse sets a mock response for a specific model
 func (m *MockLLMService) SetMockResponse(model, response string) {
 	m.responses[model] = response
@@ -311,3 +311,105 @@ func TestLLMService_SendStructuredCompletion_NotInitialized(t *testing.T) {
 	assert.Contains(t, response.Error.Message, "llm service not initialized")
 	assert.Equal(t, "service_error", response.Error.Type)
 }
+
+// Test LLMService StreamStructuredCompletion falls back to whole chunks for non-streaming clients
+func TestLLMService_StreamStructuredCompletion_Fallback(t *testing.T) {
+	service := NewLLMService()
+	err := service.Initialize()
+	require.NoError(t, err)
+
+	client := NewMockLLMClient()
+	session := &neurotypes.ChatSession{
+		ID:       "test-session",
+		Name:     "test",
+		Messages: []neurotypes.Message{{Role: "user", Content: "Hello"}},
+	}
+
+	modelConfig := &neurotypes.ModelConfig{
+		BaseModel: "gpt-4",
+		Provider:  "openai",
+	}
+
+	var chunks []neurotypes.StreamChunk
+	response := service.StreamStructuredCompletion(client, session, modelConfig, func(chunk neurotypes.StreamChunk) {
+		chunks = append(chunks, chunk)
+	})
+
+	require.NotNil(t, response)
+	assert.Nil(t, response.Error)
+	assert.Equal(t, "This is a mock LLM response.", response.TextContent)
+	require.Len(t, chunks, 2)
+	assert.Equal(t, "thinking", chunks[0].Type)
+	assert.Equal(t, "This is mock thinking content for testing.", chunks[0].Content)
+	assert.Equal(t, "text", chunks[1].Type)
+	assert.Equal(t, "This is a mock LLM response.", chunks[1].Content)
+	assert.Equal(t, "mock", chunks[1].Provider)
+}
+
+// MockStreamingLLMClient extends MockLLMClient with native streaming support
+type MockStreamingLLMClient struct {
+	MockLLMClient
+	deltas []string
+}
+
+func (m *MockStreamingLLMClient) StreamStructuredCompletion(_ *neurotypes.ChatSession, _ *neurotypes.ModelConfig, onChunk neurotypes.StreamHandler) *neurotypes.StructuredLLMResponse {
+	var text string
+	for _, delta := range m.deltas {
+		onChunk(neurotypes.StreamChunk{Type: "text", Content: delta, Provider: "mock"})
+		text += delta
+	}
+	return &neurotypes.StructuredLLMResponse{
+		TextContent:    text,
+		ThinkingBlocks: []neurotypes.ThinkingBlock{},
+		Metadata:       map[string]interface{}{"provider": "mock", "model": "test"},
+	}
+}
+
+func TestLLMService_StreamStructuredCompletion_StreamingClient(t *testing.T) {
+	service := NewLLMService()
+	err := service.Initialize()
+	require.NoError(t, err)
+
+	client := &MockStreamingLLMClient{
+		MockLLMClient: *NewMockLLMClient(),
+		deltas:        []string{"Hel", "lo ", "world"},
+	}
+	session := &neurotypes.ChatSession{
+		ID:       "test-session",
+		Name:     "test",
+		Messages: []neurotypes.Message{{Role: "user", Content: "Hello"}},
+	}
+	modelConfig := &neurotypes.ModelConfig{BaseModel: "test"}
+
+	var received []string
+	response := service.StreamStructuredCompletion(client, session, modelConfig, func(chunk neurotypes.StreamChunk) {
+		received = append(received, chunk.Content)
+	})
+
+	require.NotNil(t, response)
+	assert.Nil(t, response.Error)
+	assert.Equal(t, "Hello world", response.TextContent)
+	assert.Equal(t, []string{"Hel", "lo ", "world"}, received)
+}
+
+func TestLLMService_StreamStructuredCompletion_NotInitialized(t *testing.T) {
+	service := NewLLMService()
+
+	client := NewMockLLMClient()
+	session := &neurotypes.ChatSession{
+		ID:       "test-session",
+		Name:     "test",
+		Messages: []neurotypes.Message{{Role: "user", Content: "Hello"}},
+	}
+	modelConfig := &neurotypes.ModelConfig{BaseModel: "gpt-4"}
+
+	called := false
+	response := service.StreamStructuredCompletion(client, session, modelConfig, func(_ neurotypes.StreamChunk) {
+		called = true
+	})
+
+	require.NotNil(t, response)
+	require.NotNil(t, response.Error)
+	assert.Equal(t, "service_not_initialized", response.Error.Code)
+	assert.False(t, called)
+}
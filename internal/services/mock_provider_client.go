@@ -0,0 +1,307 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"neuroshell/pkg/neurotypes"
+)
+
+// Mock provider identifiers.
+const (
+	MockProviderCatalogID = "MOCK" // Provider catalog ID of the built-in mock provider
+	MockClientType        = "mock" // client_type of the mock provider catalog entry
+)
+
+// Mock response modes.
+const (
+	MockModeEcho    = "echo"    // Reply with the last user message
+	MockModeReverse = "reverse" // Reply with the last user message reversed
+	MockModeFixed   = "fixed"   // Reply with a fixed response
+	MockModeRules   = "rules"   // Reply with the first regex rule matching the last user message
+)
+
+// MockClientOptions configures the scripted responses of a MockProviderClient.
+type MockClientOptions struct {
+	Mode       string // echo, reverse, fixed or rules; inferred from Response and RulesFile when empty
+	Response   string // Response returned in fixed mode
+	RulesFile  string // YAML file of regex rules used in rules mode
+	Thinking   string // Thinking block content added to every successful response
+	Error      string // Error to inject: rate_limit, server_error or api_error
+	ErrorEvery int    // Inject Error on every Nth call (1 = every call)
+}
+
+// MockRule maps a regular expression on the last user message to a scripted response.
+// Response and Thinking may reference capture groups as $1 or ${name}.
+type MockRule struct {
+	Match    string `yaml:"match"`
+	Response string `yaml:"response"`
+	Thinking string `yaml:"thinking"`
+	Error    string `yaml:"error"`
+	pattern  *regexp.Regexp
+}
+
+// MockRulesFile is the structure of a mock rules YAML file.
+// Rules are tried in order; Default is used when none matches.
+type MockRulesFile struct {
+	Rules   []MockRule `yaml:"rules"`
+	Default *string    `yaml:"default"`
+}
+
+// mockErrors maps injectable error names to the errors real providers report,
+// so retries and error handling behave as they would against a provider.
+var mockErrors = map[string]neurotypes.LLMError{
+	"rate_limit":   {Code: "rate_limit_exceeded", Message: "Mock rate limit exceeded", Type: "rate_limit"},
+	"server_error": {Code: "server_error_503", Message: "Mock provider unavailable", Type: "server_error"},
+	"api_error":    {Code: "api_request_failed", Message: "Mock API request failed", Type: "api_error"},
+}
+
+// MockProviderClient implements the LLMClient interface with scripted responses,
+// so workflows can be tested without API keys or network access.
+type MockProviderClient struct {
+	options MockClientOptions
+	rules   MockRulesFile
+	calls   int
+	mutex   sync.Mutex
+}
+
+// NewMockProviderClient creates a mock client, loading and compiling the rules file if one is given.
+func NewMockProviderClient(options MockClientOptions) (*MockProviderClient, error) {
+	options, err := normalizeMockClientOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &MockProviderClient{options: options}
+	if options.Mode == MockModeRules {
+		rules, err := loadMockRules(options.RulesFile)
+		if err != nil {
+			return nil, err
+		}
+		client.rules = rules
+	}
+	return client, nil
+}
+
+// normalizeMockClientOptions infers the mode and validates the options.
+func normalizeMockClientOptions(options MockClientOptions) (MockClientOptions, error) {
+	options.Mode = strings.ToLower(strings.TrimSpace(options.Mode))
+	if options.Mode == "" {
+		switch {
+		case options.RulesFile != "":
+			options.Mode = MockModeRules
+		case options.Response != "":
+			options.Mode = MockModeFixed
+		default:
+			options.Mode = MockModeEcho
+		}
+	}
+
+	switch options.Mode {
+	case MockModeEcho, MockModeReverse:
+	case MockModeFixed:
+		if options.Response == "" {
+			return options, fmt.Errorf("mock mode 'fixed' requires a response")
+		}
+	case MockModeRules:
+		if options.RulesFile == "" {
+			return options, fmt.Errorf("mock mode 'rules' requires a rules file")
+		}
+	default:
+		return options, fmt.Errorf("invalid mock mode '%s': must be echo, reverse, fixed or rules", options.Mode)
+	}
+
+	if options.Error != "" {
+		if _, ok := mockErrors[options.Error]; !ok {
+			return options, fmt.Errorf("invalid mock error '%s': must be rate_limit, server_error or api_error", options.Error)
+		}
+		if options.ErrorEvery == 0 {
+			options.ErrorEvery = 1
+		}
+	}
+	if options.ErrorEvery < 0 {
+		return options, fmt.Errorf("mock error interval must be positive, got %d", options.ErrorEvery)
+	}
+	return options, nil
+}
+
+// loadMockRules reads and compiles a mock rules YAML file.
+func loadMockRules(path string) (MockRulesFile, error) {
+	var rules MockRulesFile
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return rules, fmt.Errorf("failed to read mock rules: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return rules, fmt.Errorf("failed to parse mock rules %s: %w", path, err)
+	}
+	for i := range rules.Rules {
+		pattern, err := regexp.Compile(rules.Rules[i].Match)
+		if err != nil {
+			return rules, fmt.Errorf("invalid pattern in mock rule %d of %s: %w", i+1, path, err)
+		}
+		rules.Rules[i].pattern = pattern
+		if errName := rules.Rules[i].Error; errName != "" {
+			if _, ok := mockErrors[errName]; !ok {
+				return rules, fmt.Errorf("invalid error '%s' in mock rule %d of %s", errName, i+1, path)
+			}
+		}
+	}
+	return rules, nil
+}
+
+// Fingerprint identifies normalized options, so equal options share a cached client.
+// It is empty for the default echo client.
+func (o MockClientOptions) Fingerprint() string {
+	if o == (MockClientOptions{Mode: MockModeEcho}) {
+		return ""
+	}
+	return fmt.Sprintf("%s\x00%s\x00%s\x00%s\x00%s\x00%d", o.Mode, o.Response, o.RulesFile, o.Thinking, o.Error, o.ErrorEvery)
+}
+
+// Options returns the normalized options of the client.
+func (c *MockProviderClient) Options() MockClientOptions {
+	return c.options
+}
+
+// GetProviderName returns "mock".
+func (c *MockProviderClient) GetProviderName() string {
+	return MockClientType
+}
+
+// IsConfigured always returns true since the mock provider needs no credentials.
+func (c *MockProviderClient) IsConfigured() bool {
+	return true
+}
+
+// SetDebugTransport is a no-op: the mock provider makes no HTTP requests.
+func (c *MockProviderClient) SetDebugTransport(_ http.RoundTripper) {}
+
+// SendChatCompletion returns the scripted response text.
+func (c *MockProviderClient) SendChatCompletion(session *neurotypes.ChatSession, model *neurotypes.ModelConfig) (string, error) {
+	response := c.SendStructuredCompletion(session, model)
+	if response.Error != nil {
+		return "", fmt.Errorf("%s: %s", response.Error.Code, response.Error.Message)
+	}
+	return response.TextContent, nil
+}
+
+// SendStructuredCompletion returns the scripted response for the last user message.
+// Injected errors are reported in the Error field like provider errors, so they are retried
+// and surfaced through the normal error handling.
+// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+func (c *MockProviderClient) SendStructuredCompletion(session *neurotypes.ChatSession, model *neurotypes.ModelConfig) *neurotypes.StructuredLLMResponse {
+	c.mutex.Lock()
+	c.calls++
+	call := c.calls
+	c.mutex.Unlock()
+
+	if c.options.Error != "" && call%c.options.ErrorEvery == 0 {
+		return mockErrorResponse(c.options.Error, call)
+	}
+
+	input := lastUserMessage(session)
+	text, thinking, errName, err := c.scriptedResponse(input)
+	if err != nil {
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error: &neurotypes.LLMError{
+				Code:    "no_matching_rule",
+				Message: err.Error(),
+				Type:    "client_error",
+			},
+			Metadata: map[string]interface{}{"provider": MockClientType},
+		}
+	}
+	if errName != "" {
+		return mockErrorResponse(errName, call)
+	}
+
+	thinkingBlocks := []neurotypes.ThinkingBlock{}
+	if thinking != "" {
+		thinkingBlocks = append(thinkingBlocks, neurotypes.ThinkingBlock{Content: thinking, Provider: MockClientType, Type: "thinking"})
+	}
+
+	baseModel := ""
+	if model != nil {
+		baseModel = model.BaseModel
+	}
+	return &neurotypes.StructuredLLMResponse{
+		TextContent:    text,
+		ThinkingBlocks: thinkingBlocks,
+		Error:          nil,
+		Metadata:       withResponseDetails(usageMetadata(MockClientType, baseModel, mockTokenUsage(session, text, thinkingBlocks)), "end_turn", fmt.Sprintf("mock-request-%d", call)),
+	}
+}
+
+// SendStructuredCompletionWithSchema returns the scripted response as the JSON document,
+// so rules can exercise both valid documents and schema violations.
+// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+func (c *MockProviderClient) SendStructuredCompletionWithSchema(session *neurotypes.ChatSession, model *neurotypes.ModelConfig, _ *neurotypes.ResponseSchema) *neurotypes.StructuredLLMResponse {
+	return c.SendStructuredCompletion(session, model)
+}
+
+// scriptedResponse returns the response text, thinking content and injected error name for an input.
+func (c *MockProviderClient) scriptedResponse(input string) (string, string, string, error) {
+	switch c.options.Mode {
+	case MockModeReverse:
+		runes := []rune(input)
+		for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+			runes[i], runes[j] = runes[j], runes[i]
+		}
+		return string(runes), c.options.Thinking, "", nil
+	case MockModeFixed:
+		return c.options.Response, c.options.Thinking, "", nil
+	case MockModeRules:
+		for _, rule := range c.rules.Rules {
+			match := rule.pattern.FindStringSubmatchIndex(input)
+			if match == nil {
+				continue
+			}
+			text := string(rule.pattern.ExpandString(nil, rule.Response, input, match))
+			thinking := c.options.Thinking
+			if rule.Thinking != "" {
+				thinking = string(rule.pattern.ExpandString(nil, rule.Thinking, input, match))
+			}
+			return text, thinking, rule.Error, nil
+		}
+		if c.rules.Default != nil {
+			return *c.rules.Default, c.options.Thinking, "", nil
+		}
+		return "", "", "", fmt.Errorf("no mock rule in %s matches '%s'", c.options.RulesFile, input)
+	default:
+		return input, c.options.Thinking, "", nil
+	}
+}
+
+// mockErrorResponse builds the response for an injected error.
+func mockErrorResponse(name string, call int) *neurotypes.StructuredLLMResponse {
+	llmErr := mockErrors[name]
+	llmErr.Message = fmt.Sprintf("%s (call %d)", llmErr.Message, call)
+	return &neurotypes.StructuredLLMResponse{
+		TextContent:    "",
+		ThinkingBlocks: []neurotypes.ThinkingBlock{},
+		Error:          &llmErr,
+		Metadata:       map[string]interface{}{"provider": MockClientType, "error_triggered": true},
+	}
+}
+
+// lastUserMessage returns the content of the most recent user message in the session.
+func lastUserMessage(session *neurotypes.ChatSession) string {
+	if session == nil {
+		return ""
+	}
+	for i := len(session.Messages) - 1; i >= 0; i-- {
+		if session.Messages[i].Role == "user" {
+			return session.Messages[i].Content
+		}
+	}
+	return ""
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/pkg/neurotypes"
+)
+
+func mockSession(messages ...string) *neurotypes.ChatSession {
+	session := &neurotypes.ChatSession{ID: "mock-session"}
+	for _, content := range messages {
+		session.Messages = append(session.Messages, neurotypes.Message{Role: "user", Content: content})
+	}
+	return session
+}
+
+func writeMockRules(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestMockProviderClient_Modes(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  MockClientOptions
+		mode     string
+		expected string
+	}{
+		{name: "default echoes", options: MockClientOptions{}, mode: MockModeEcho, expected: "Hello, mock"},
+		{name: "reverse", options: MockClientOptions{Mode: "Reverse"}, mode: MockModeReverse, expected: "kcom ,olleH"},
+		{name: "fixed inferred from response", options: MockClientOptions{Response: "Done."}, mode: MockModeFixed, expected: "Done."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewMockProviderClient(tt.options)
+			require.NoError(t, err)
+			assert.Equal(t, tt.mode, client.Options().Mode)
+			assert.Equal(t, "mock", client.GetProviderName())
+			assert.True(t, client.IsConfigured())
+
+			response := client.SendStructuredCompletion(mockSession("ignored", "Hello, mock"), &neurotypes.ModelConfig{BaseModel: "mock"})
+			require.Nil(t, response.Error)
+			assert.Equal(t, tt.expected, response.TextContent)
+			assert.Empty(t, response.ThinkingBlocks)
+		})
+	}
+}
+
+func TestMockProviderClient_InvalidOptions(t *testing.T) {
+	_, err := NewMockProviderClient(MockClientOptions{Mode: "fixed"})
+	assert.ErrorContains(t, err, "requires a response")
+
+	_, err = NewMockProviderClient(MockClientOptions{Mode: "shout"})
+	assert.ErrorContains(t, err, "invalid mock mode")
+
+	_, err = NewMockProviderClient(MockClientOptions{Error: "timeout"})
+	assert.ErrorContains(t, err, "invalid mock error")
+
+	_, err = NewMockProviderClient(MockClientOptions{RulesFile: filepath.Join(t.TempDir(), "missing.yaml")})
+	assert.ErrorContains(t, err, "failed to read mock rules")
+
+	_, err = NewMockProviderClient(MockClientOptions{RulesFile: writeMockRules(t, "rules:\n  - match: \"(\"\n")})
+	assert.ErrorContains(t, err, "invalid pattern in mock rule 1")
+}
+
+func TestMockProviderClient_Rules(t *testing.T) {
+	path := writeMockRules(t, `rules:
+  - match: "(?i)weather in (?P<city>\\w+)"
+    response: "It is sunny in ${city}."
+    thinking: "Looking up $1"
+  - match: "^fail"
+    error: server_error
+default: "I don't know."
+`)
+	client, err := NewMockProviderClient(MockClientOptions{RulesFile: path, Thinking: "Default thinking"})
+	require.NoError(t, err)
+	assert.Equal(t, MockModeRules, client.Options().Mode)
+
+	response := client.SendStructuredCompletion(mockSession("What's the weather in Paris?"), nil)
+	require.Nil(t, response.Error)
+	assert.Equal(t, "It is sunny in Paris.", response.TextContent)
+	require.Len(t, response.ThinkingBlocks, 1)
+	assert.Equal(t, "Looking up Paris", response.ThinkingBlocks[0].Content)
+	assert.Equal(t, "mock", response.ThinkingBlocks[0].Provider)
+
+	response = client.SendStructuredCompletion(mockSession("fail please"), nil)
+	require.NotNil(t, response.Error)
+	assert.Equal(t, "server_error", response.Error.Type)
+
+	response = client.SendStructuredCompletion(mockSession("Something else"), nil)
+	require.Nil(t, response.Error)
+	assert.Equal(t, "I don't know.", response.TextContent)
+	require.Len(t, response.ThinkingBlocks, 1)
+	assert.Equal(t, "Default thinking", response.ThinkingBlocks[0].Content)
+}
+
+func TestMockProviderClient_RulesWithoutDefault(t *testing.T) {
+	client, err := NewMockProviderClient(MockClientOptions{RulesFile: writeMockRules(t, "rules:\n  - match: hello\n    response: hi\n")})
+	require.NoError(t, err)
+
+	response := client.SendStructuredCompletion(mockSession("goodbye"), nil)
+	require.NotNil(t, response.Error)
+	assert.Equal(t, "no_matching_rule", response.Error.Code)
+	assert.Equal(t, "client_error", response.Error.Type)
+}
+
+func TestMockProviderClient_ErrorEvery(t *testing.T) {
+	client, err := NewMockProviderClient(MockClientOptions{Error: "rate_limit", ErrorEvery: 3})
+	require.NoError(t, err)
+
+	var failures []int
+	for call := 1; call <= 6; call++ {
+		response := client.SendStructuredCompletion(mockSession("ping"), nil)
+		if response.Error != nil {
+			assert.Equal(t, "rate_limit", response.Error.Type)
+			assert.Equal(t, "rate_limit_exceeded", response.Error.Code)
+			failures = append(failures, call)
+			continue
+		}
+		assert.Equal(t, "ping", response.TextContent)
+	}
+	assert.Equal(t, []int{3, 6}, failures)
+
+	_, err = client.SendChatCompletion(mockSession("ping"), nil)
+	assert.NoError(t, err)
+}
+
+func TestMockProviderClient_UsageMetadata(t *testing.T) {
+	client, err := NewMockProviderClient(MockClientOptions{})
+	require.NoError(t, err)
+
+	response := client.SendStructuredCompletion(mockSession(strings.Repeat("word ", 20)), &neurotypes.ModelConfig{BaseModel: "mock"})
+	require.Nil(t, response.Error)
+	assert.Equal(t, "mock", response.Metadata["provider"])
+	usage, ok := UsageFromMetadata(response.Metadata)
+	require.True(t, ok)
+	assert.Positive(t, usage.InputTokens)
+	assert.Positive(t, usage.OutputTokens)
+}
+
+func TestClientFactoryService_GetMockClientWithID(t *testing.T) {
+	factory := NewClientFactoryService()
+	require.NoError(t, factory.Initialize())
+
+	defaultClient, defaultID, err := factory.GetMockClientWithID(MockClientOptions{})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(defaultID, "MOCK:"))
+
+	// The catalog path without options yields the same default client
+	catalogClient, catalogID, err := factory.GetClientWithID(MockProviderCatalogID, "")
+	require.NoError(t, err)
+	assert.Equal(t, defaultID, catalogID)
+	assert.Same(t, defaultClient, catalogClient)
+
+	fixedClient, fixedID, err := factory.GetMockClientWithID(MockClientOptions{Response: "Done."})
+	require.NoError(t, err)
+	assert.NotEqual(t, defaultID, fixedID)
+
+	again, againID, err := factory.GetMockClientWithID(MockClientOptions{Mode: "fixed", Response: "Done."})
+	require.NoError(t, err)
+	assert.Equal(t, fixedID, againID)
+	assert.Same(t, fixedClient, again)
+
+	_, _, err = factory.GetMockClientWithID(MockClientOptions{Mode: "fixed"})
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"neuroshell/internal/data/embedded"
+	"neuroshell/internal/logger"
 	"neuroshell/pkg/neurotypes"
 
 	"gopkg.in/yaml.v3"
@@ -15,6 +16,8 @@ import (
 // from different LLM providers (OpenAI, Anthropic).
 type ModelCatalogService struct {
 	initialized bool
+	catalog     []neurotypes.ModelCatalogEntry // Embedded entries merged with user and project entries at initialization
+	warnings    []string                       // Problems found in user and project catalog files
 }
 
 // NewModelCatalogService creates a new ModelCatalogService instance.
@@ -30,17 +33,50 @@ func (m *ModelCatalogService) Name() string {
 }
 
 // Initialize sets up the ModelCatalogService for operation.
+// User and project catalogs are read once here; problems with their files are kept as warnings
+// (see LoadWarnings) instead of failing later lookups.
 func (m *ModelCatalogService) Initialize() error {
+	catalog, err := m.loadEmbeddedModels()
+	if err != nil {
+		return err
+	}
+
+	// Merge user and project catalogs; their entries override embedded ones with the same ID
+	id := func(e neurotypes.ModelCatalogEntry) string { return e.ID }
+	source := func(e *neurotypes.ModelCatalogEntry) *neurotypes.CatalogSource { return &e.Source }
+	layers, warnings := loadCustomCatalogLayers(ModelCatalogDir, m.loadModelFile, id, source)
+	allowProjectOverrides := projectCatalogOverridesAllowed()
+	for _, layer := range layers {
+		var mergeWarnings []string
+		catalog, mergeWarnings = mergeCatalogEntries(catalog, layer, id, source, allowProjectOverrides)
+		warnings = append(warnings, mergeWarnings...)
+	}
+	for _, warning := range warnings {
+		logger.Warn("Model catalog", "warning", warning)
+	}
+
+	m.catalog = catalog
+	m.warnings = warnings
 	m.initialized = true
 	return nil
 }
 
+// LoadWarnings returns the problems found while loading user and project model catalog files.
+func (m *ModelCatalogService) LoadWarnings() []string {
+	return append([]string(nil), m.warnings...)
+}
+
 // GetModelCatalog returns the complete model catalog from all providers.
 func (m *ModelCatalogService) GetModelCatalog() ([]neurotypes.ModelCatalogEntry, error) {
 	if !m.initialized {
 		return nil, fmt.Errorf("model catalog service not initialized")
 	}
 
+	return append([]neurotypes.ModelCatalogEntry(nil), m.catalog...), nil
+}
+
+// loadEmbeddedModels loads the model catalog shipped with NeuroShell.
+func (m *ModelCatalogService) loadEmbeddedModels() ([]neurotypes.ModelCatalogEntry, error) {
 	var allModels []neurotypes.ModelCatalogEntry
 
 	// Load OpenAI models
@@ -184,10 +220,45 @@ func (m *ModelCatalogService) GetModelCatalog() ([]neurotypes.ModelCatalogEntry,
 	}
 	allModels = append(allModels, gemini25FlashLiteModel)
 
+	// Load local OpenAI-compatible server models
+	ollamaLocalModel, err := m.loadModelFile(embedded.OllamaLocalModelData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Ollama local model: %w", err)
+	}
+	allModels = append(allModels, ollamaLocalModel)
+
+	vllmLocalModel, err := m.loadModelFile(embedded.VLLMLocalModelData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load vLLM local model: %w", err)
+	}
+	allModels = append(allModels, vllmLocalModel)
+
+	llamaCppLocalModel, err := m.loadModelFile(embedded.LlamaCppLocalModelData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load llama.cpp local model: %w", err)
+	}
+	allModels = append(allModels, llamaCppLocalModel)
+
+	lmStudioLocalModel, err := m.loadModelFile(embedded.LMStudioLocalModelData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load LM Studio local model: %w", err)
+	}
+	allModels = append(allModels, lmStudioLocalModel)
+
+	// Load the scripted mock model used for testing without API keys
+	mockModel, err := m.loadModelFile(embedded.MockModelData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load mock model: %w", err)
+	}
+	allModels = append(allModels, mockModel)
+
 	// Validate that all model IDs are unique (case-insensitive)
 	if err := m.validateUniqueIDs(allModels); err != nil {
 		return nil, fmt.Errorf("model catalog validation failed: %w", err)
 	}
+	for i := range allModels {
+		allModels[i].Source = neurotypes.CatalogSource{Kind: neurotypes.CatalogSourceEmbedded}
+	}
 
 	return allModels, nil
 }
@@ -321,7 +392,7 @@ func (m *ModelCatalogService) normalizeID(id string) string {
 	return strings.ToUpper(id)
 }
 
-// loadModelFile loads and parses an individual model file from embedded YAML data.
+// loadModelFile loads and parses an individual model file from YAML data.
 func (m *ModelCatalogService) loadModelFile(data []byte) (neurotypes.ModelCatalogEntry, error) {
 	var modelFile neurotypes.ModelCatalogFile
 
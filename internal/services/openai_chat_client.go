@@ -10,6 +10,7 @@ import (
 
 	"github.com/openai/openai-go"
 	"github.com/openai/openai-go/option"
+	"github.com/openai/openai-go/shared"
 )
 
 // OpenAIClient implements the LLMClient interface for OpenAI's API.
@@ -17,6 +18,9 @@ import (
 // all OpenAI-specific communication logic.
 type OpenAIClient struct {
 	apiKey         string
+	provider       string            // Provider name reported in responses
+	baseURL        string            // Custom base URL for OpenAI-compatible endpoints (empty for OpenAI)
+	headers        map[string]string // Extra HTTP headers sent with every request
 	client         *openai.Client
 	debugTransport http.RoundTripper
 }
@@ -25,19 +29,34 @@ type OpenAIClient struct {
 // The actual OpenAI client is created only when the first request is made.
 func NewOpenAIClient(apiKey string) *OpenAIClient {
 	return &OpenAIClient{
-		apiKey: apiKey,
-		client: nil, // Will be initialized lazily
+		apiKey:   apiKey,
+		provider: "openai",
+		client:   nil, // Will be initialized lazily
+	}
+}
+
+// NewOpenAICompatibleClient creates a chat client for an OpenAI-compatible server such as
+// Ollama, vLLM, llama.cpp server or LM Studio, using the base URL and headers of its
+// provider catalog entry. The API key is optional since local servers usually do not need one.
+func NewOpenAICompatibleClient(entry neurotypes.ProviderCatalogEntry, apiKey string) *OpenAIClient {
+	return &OpenAIClient{
+		apiKey:   apiKey,
+		provider: entry.Provider,
+		baseURL:  entry.BaseURL,
+		headers:  entry.Headers,
+		client:   nil, // Will be initialized lazily
 	}
 }
 
 // GetProviderName returns the provider name for this client.
 func (c *OpenAIClient) GetProviderName() string {
-	return "openai"
+	return c.provider
 }
 
-// IsConfigured returns true if the client has a valid API key.
+// IsConfigured returns true if the client has a valid API key,
+// or a base URL for OpenAI-compatible endpoints that may not require a key.
 func (c *OpenAIClient) IsConfigured() bool {
-	return c.apiKey != ""
+	return c.apiKey != "" || c.baseURL != ""
 }
 
 // SetDebugTransport sets the HTTP transport for network debugging.
@@ -53,20 +72,35 @@ func (c *OpenAIClient) initializeClientIfNeeded() error {
 		return nil // Already initialized
 	}
 
-	if c.apiKey == "" {
+	if !c.IsConfigured() {
 		return fmt.Errorf("OpenAI API key not configured")
 	}
 
 	// Create OpenAI client with API key and optional debug transport
 	var options []option.RequestOption
-	options = append(options, option.WithAPIKey(c.apiKey))
+	if c.apiKey != "" {
+		options = append(options, option.WithAPIKey(c.apiKey))
+	}
+	if c.baseURL != "" {
+		options = append(options, option.WithBaseURL(c.baseURL))
+		// Never forward OpenAI credentials picked up from the environment to other servers
+		if c.apiKey == "" {
+			options = append(options, option.WithHeaderDel("Authorization"))
+		}
+		options = append(options, option.WithHeaderDel("OpenAI-Organization"), option.WithHeaderDel("OpenAI-Project"))
+	}
+	for name, value := range c.headers {
+		options = append(options, option.WithHeader(name, value))
+	}
+	// Retries are handled by the LLM service's retry policy
+	options = append(options, option.WithMaxRetries(0))
 
 	if c.debugTransport != nil {
 		httpClient := &http.Client{Transport: c.debugTransport}
 		options = append(options, option.WithHTTPClient(httpClient))
-		logger.Debug("OpenAI client initialized with debug transport", "provider", "openai")
+		logger.Debug("OpenAI client initialized with debug transport", "provider", c.provider, "base_url", c.baseURL)
 	} else {
-		logger.Debug("OpenAI client initialized", "provider", "openai")
+		logger.Debug("OpenAI client initialized", "provider", c.provider, "base_url", c.baseURL)
 	}
 
 	client := openai.NewClient(options...)
@@ -84,26 +118,7 @@ func (c *OpenAIClient) SendChatCompletion(session *neurotypes.ChatSession, model
 		return "", fmt.Errorf("failed to initialize OpenAI client: %w", err)
 	}
 
-	// Convert session messages to OpenAI format
-	messages := c.convertMessagesToOpenAI(session)
-	logger.Debug("Messages converted", "message_count", len(messages))
-
-	// Add system prompt if present
-	if session.SystemPrompt != "" {
-		systemMsg := openai.SystemMessage(session.SystemPrompt)
-		messages = append([]openai.ChatCompletionMessageParamUnion{systemMsg}, messages...)
-		logger.Debug("System prompt added", "system_prompt", session.SystemPrompt)
-	}
-
-	// Build completion parameters
-	params := openai.ChatCompletionNewParams{
-		Model:    openai.ChatModel(modelConfig.BaseModel),
-		Messages: messages,
-	}
-	logger.Debug("Completion parameters built", "model", modelConfig.BaseModel, "message_count", len(messages))
-
-	// Apply model parameters if available
-	c.applyModelParameters(&params, modelConfig)
+	params := c.buildCompletionParams(session, modelConfig)
 
 	// Send request
 	logger.Debug("Sending OpenAI request", "model", modelConfig.BaseModel)
@@ -131,26 +146,95 @@ func (c *OpenAIClient) SendChatCompletion(session *neurotypes.ChatSession, model
 
 // SendStructuredCompletion sends a chat completion request to OpenAI and returns structured response.
 // Since regular OpenAI models don't have native thinking content, this returns regular text with no thinking blocks.
+// Token usage reported by the API is included in the response metadata.
 // All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
 func (c *OpenAIClient) SendStructuredCompletion(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig) *neurotypes.StructuredLLMResponse {
 	logger.Debug("OpenAI SendStructuredCompletion starting", "model", modelConfig.BaseModel)
 
-	// Use regular completion since OpenAI chat models don't have native thinking content
-	textContent, err := c.SendChatCompletion(session, modelConfig)
+	if err := c.initializeClientIfNeeded(); err != nil {
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error: &neurotypes.LLMError{
+				Code:    "api_request_failed",
+				Message: fmt.Sprintf("failed to initialize OpenAI client: %s", err.Error()),
+				Type:    "api_error",
+			},
+			Metadata: map[string]interface{}{"provider": c.provider, "model": modelConfig.BaseModel},
+		}
+	}
+
+	params := c.buildCompletionParams(session, modelConfig)
+
+	logger.Debug("Sending OpenAI request", "model", modelConfig.BaseModel)
+	completion, err := c.client.Chat.Completions.New(context.Background(), params)
 	if err != nil {
+		logger.Error("OpenAI request failed", "error", err)
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error:          newProviderLLMError(fmt.Sprintf("openai request failed: %s", err.Error()), err),
+			Metadata:       map[string]interface{}{"provider": c.provider, "model": modelConfig.BaseModel},
+		}
+	}
+
+	// Regular models don't provide thinking blocks, so the completion maps directly to the response
+	return openAICompletionResponse(c.provider, completion, modelConfig)
+}
+
+// StreamStructuredCompletion sends a streaming chat completion request to OpenAI.
+// Content deltas are delivered to onChunk as they arrive; the accumulated completion is returned
+// as a structured response identical to SendStructuredCompletion.
+// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+func (c *OpenAIClient) StreamStructuredCompletion(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig, onChunk neurotypes.StreamHandler) *neurotypes.StructuredLLMResponse {
+	logger.Debug("OpenAI StreamStructuredCompletion starting", "model", modelConfig.BaseModel)
+
+	if err := c.initializeClientIfNeeded(); err != nil {
 		return &neurotypes.StructuredLLMResponse{
 			TextContent:    "",
 			ThinkingBlocks: []neurotypes.ThinkingBlock{},
 			Error: &neurotypes.LLMError{
 				Code:    "api_request_failed",
-				Message: err.Error(),
+				Message: fmt.Sprintf("failed to initialize OpenAI client: %s", err.Error()),
 				Type:    "api_error",
 			},
-			Metadata: map[string]interface{}{"provider": "openai", "model": modelConfig.BaseModel},
+			Metadata: map[string]interface{}{"provider": c.provider, "model": modelConfig.BaseModel},
+		}
+	}
+
+	params := c.buildCompletionParams(session, modelConfig)
+	// Usage is only sent in a final chunk when explicitly requested
+	params.StreamOptions = openai.ChatCompletionStreamOptionsParam{IncludeUsage: openai.Bool(true)}
+
+	logger.Debug("Sending OpenAI streaming request", "model", modelConfig.BaseModel)
+	stream := c.client.Chat.Completions.NewStreaming(context.Background(), params)
+	defer func() { _ = stream.Close() }()
+
+	acc := openai.ChatCompletionAccumulator{}
+	for stream.Next() {
+		chunk := stream.Current()
+		acc.AddChunk(chunk)
+
+		if onChunk != nil && len(chunk.Choices) > 0 && chunk.Choices[0].Delta.Content != "" {
+			onChunk(neurotypes.StreamChunk{Type: "text", Content: chunk.Choices[0].Delta.Content, Provider: c.provider})
 		}
 	}
 
-	// Check for empty response
+	if err := stream.Err(); err != nil {
+		logger.Error("OpenAI streaming request failed", "error", err)
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error:          newProviderLLMError(fmt.Sprintf("openai request failed: %s", err.Error()), err),
+			Metadata:       map[string]interface{}{"provider": c.provider, "model": modelConfig.BaseModel},
+		}
+	}
+
+	var textContent string
+	if len(acc.Choices) > 0 {
+		textContent = acc.Choices[0].Message.Content
+	}
+
 	if textContent == "" {
 		return &neurotypes.StructuredLLMResponse{
 			TextContent:    "",
@@ -160,20 +244,118 @@ func (c *OpenAIClient) SendStructuredCompletion(session *neurotypes.ChatSession,
 				Message: "no content returned",
 				Type:    "response_error",
 			},
-			Metadata: map[string]interface{}{"provider": "openai", "model": modelConfig.BaseModel},
+			Metadata: map[string]interface{}{"provider": c.provider, "model": modelConfig.BaseModel},
 		}
 	}
 
-	// Create structured response with no thinking blocks (regular models don't provide thinking blocks)
-	structuredResponse := &neurotypes.StructuredLLMResponse{
+	logger.Debug("OpenAI streamed response received", "content_length", len(textContent))
+	return &neurotypes.StructuredLLMResponse{
 		TextContent:    textContent,
-		ThinkingBlocks: []neurotypes.ThinkingBlock{}, // Empty - regular models don't provide thinking blocks
-		Error:          nil,                          // No error in successful case
-		Metadata:       map[string]interface{}{"provider": "openai", "model": modelConfig.BaseModel},
+		ThinkingBlocks: []neurotypes.ThinkingBlock{},
+		Error:          nil,
+		Metadata:       openAIChatMetadata(c.provider, modelConfig.BaseModel, &acc.ChatCompletion),
+	}
+}
+
+// SendStructuredCompletionWithTools sends a chat completion request to OpenAI advertising the given tools.
+// Tool calls requested by the model are returned in the ToolCalls field of the structured response.
+// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+func (c *OpenAIClient) SendStructuredCompletionWithTools(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig, tools []neurotypes.ToolDefinition) *neurotypes.StructuredLLMResponse {
+	logger.Debug("OpenAI SendStructuredCompletionWithTools starting", "model", modelConfig.BaseModel, "tools", len(tools))
+
+	if err := c.initializeClientIfNeeded(); err != nil {
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error: &neurotypes.LLMError{
+				Code:    "api_request_failed",
+				Message: fmt.Sprintf("failed to initialize OpenAI client: %s", err.Error()),
+				Type:    "api_error",
+			},
+			Metadata: map[string]interface{}{"provider": c.provider, "model": modelConfig.BaseModel},
+		}
+	}
+
+	params := c.buildCompletionParams(session, modelConfig)
+	params.Tools = openAIToolParams(tools)
+
+	logger.Debug("Sending OpenAI tool calling request", "model", modelConfig.BaseModel)
+	completion, err := c.client.Chat.Completions.New(context.Background(), params)
+	if err != nil {
+		logger.Error("OpenAI request failed", "error", err)
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error:          newProviderLLMError(fmt.Sprintf("openai request failed: %s", err.Error()), err),
+			Metadata:       map[string]interface{}{"provider": c.provider, "model": modelConfig.BaseModel},
+		}
 	}
 
-	logger.Debug("OpenAI structured response created", "content_length", len(textContent), "thinking_blocks", 0)
-	return structuredResponse
+	return openAICompletionResponse(c.provider, completion, modelConfig)
+}
+
+// SendStructuredCompletionWithSchema sends a chat completion request whose answer is constrained to
+// a JSON schema through the response_format parameter. OpenAI-compatible servers receive the same
+// parameter; the answer is validated locally by the LLM service.
+// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+func (c *OpenAIClient) SendStructuredCompletionWithSchema(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig, schema *neurotypes.ResponseSchema) *neurotypes.StructuredLLMResponse {
+	logger.Debug("OpenAI SendStructuredCompletionWithSchema starting", "model", modelConfig.BaseModel, "schema", schema.Name)
+
+	if err := c.initializeClientIfNeeded(); err != nil {
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error: &neurotypes.LLMError{
+				Code:    "api_request_failed",
+				Message: fmt.Sprintf("failed to initialize OpenAI client: %s", err.Error()),
+				Type:    "api_error",
+			},
+			Metadata: map[string]interface{}{"provider": c.provider, "model": modelConfig.BaseModel},
+		}
+	}
+
+	params := c.buildCompletionParams(session, modelConfig)
+	params.ResponseFormat = openAIResponseFormat(schema)
+
+	logger.Debug("Sending OpenAI structured output request", "model", modelConfig.BaseModel)
+	completion, err := c.client.Chat.Completions.New(context.Background(), params)
+	if err != nil {
+		logger.Error("OpenAI request failed", "error", err)
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error:          newProviderLLMError(fmt.Sprintf("openai request failed: %s", err.Error()), err),
+			Metadata:       map[string]interface{}{"provider": c.provider, "model": modelConfig.BaseModel},
+		}
+	}
+
+	return openAICompletionResponse(c.provider, completion, modelConfig)
+}
+
+// buildCompletionParams converts a session and model configuration into OpenAI chat completion parameters.
+func (c *OpenAIClient) buildCompletionParams(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig) openai.ChatCompletionNewParams {
+	// Convert session messages to OpenAI format
+	messages := c.convertMessagesToOpenAI(session)
+	logger.Debug("Messages converted", "message_count", len(messages))
+
+	// Add system prompt if present
+	if session.SystemPrompt != "" {
+		systemMsg := openai.SystemMessage(session.SystemPrompt)
+		messages = append([]openai.ChatCompletionMessageParamUnion{systemMsg}, messages...)
+		logger.Debug("System prompt added", "system_prompt", session.SystemPrompt)
+	}
+
+	// Build completion parameters
+	params := openai.ChatCompletionNewParams{
+		Model:    openai.ChatModel(modelConfig.BaseModel),
+		Messages: messages,
+	}
+	logger.Debug("Completion parameters built", "model", modelConfig.BaseModel, "message_count", len(messages))
+
+	// Apply model parameters if available
+	c.applyModelParameters(&params, modelConfig)
+
+	return params
 }
 
 // convertMessagesToOpenAI converts NeuroShell messages to OpenAI format.
@@ -185,9 +367,11 @@ func (c *OpenAIClient) convertMessagesToOpenAI(session *neurotypes.ChatSession)
 		case "user":
 			messages = append(messages, openai.UserMessage(msg.Content))
 		case "assistant":
-			messages = append(messages, openai.AssistantMessage(msg.Content))
+			messages = append(messages, openAIAssistantMessage(msg))
 		case "system":
 			messages = append(messages, openai.SystemMessage(msg.Content))
+		case "tool":
+			messages = append(messages, openai.ToolMessage(msg.Content, msg.ToolCallID))
 		default:
 			// Skip unknown roles
 			continue
@@ -197,6 +381,127 @@ func (c *OpenAIClient) convertMessagesToOpenAI(session *neurotypes.ChatSession)
 	return messages
 }
 
+// openAIAssistantMessage converts an assistant message, including any tool calls it carries.
+func openAIAssistantMessage(msg neurotypes.Message) openai.ChatCompletionMessageParamUnion {
+	if len(msg.ToolCalls) == 0 {
+		return openai.AssistantMessage(msg.Content)
+	}
+
+	assistant := openai.ChatCompletionAssistantMessageParam{}
+	if msg.Content != "" {
+		assistant.Content.OfString = openai.String(msg.Content)
+	}
+	for _, call := range msg.ToolCalls {
+		assistant.ToolCalls = append(assistant.ToolCalls, openai.ChatCompletionMessageToolCallParam{
+			ID: call.ID,
+			Function: openai.ChatCompletionMessageToolCallFunctionParam{
+				Name:      call.Name,
+				Arguments: call.Arguments,
+			},
+		})
+	}
+	return openai.ChatCompletionMessageParamUnion{OfAssistant: &assistant}
+}
+
+// openAIToolParams converts tool definitions to OpenAI function tool parameters.
+func openAIToolParams(tools []neurotypes.ToolDefinition) []openai.ChatCompletionToolParam {
+	params := make([]openai.ChatCompletionToolParam, 0, len(tools))
+	for _, tool := range tools {
+		function := openai.FunctionDefinitionParam{
+			Name:       tool.Name,
+			Parameters: openai.FunctionParameters(tool.Parameters),
+		}
+		if tool.Description != "" {
+			function.Description = openai.String(tool.Description)
+		}
+		params = append(params, openai.ChatCompletionToolParam{Function: function})
+	}
+	return params
+}
+
+// openAIResponseFormat converts a response schema to the chat completions json_schema response format.
+func openAIResponseFormat(schema *neurotypes.ResponseSchema) openai.ChatCompletionNewParamsResponseFormatUnion {
+	return openai.ChatCompletionNewParamsResponseFormatUnion{
+		OfJSONSchema: &shared.ResponseFormatJSONSchemaParam{
+			JSONSchema: shared.ResponseFormatJSONSchemaJSONSchemaParam{
+				Name:   schema.Name,
+				Schema: schema.Schema,
+			},
+		},
+	}
+}
+
+// openAICompletionResponse converts a chat completion, including any tool calls and token usage, into a structured response.
+func openAICompletionResponse(provider string, completion *openai.ChatCompletion, modelConfig *neurotypes.ModelConfig) *neurotypes.StructuredLLMResponse {
+	if len(completion.Choices) == 0 {
+		logger.Error("No response choices returned")
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error: &neurotypes.LLMError{
+				Code:    "empty_response",
+				Message: "no response choices returned",
+				Type:    "response_error",
+			},
+			Metadata: map[string]interface{}{"provider": provider, "model": modelConfig.BaseModel},
+		}
+	}
+
+	message := completion.Choices[0].Message
+	var toolCalls []neurotypes.ToolCall
+	for _, call := range message.ToolCalls {
+		toolCalls = append(toolCalls, neurotypes.ToolCall{
+			ID:        call.ID,
+			Name:      call.Function.Name,
+			Arguments: call.Function.Arguments,
+		})
+	}
+
+	if message.Content == "" && len(toolCalls) == 0 {
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error: &neurotypes.LLMError{
+				Code:    "empty_response",
+				Message: "no content returned",
+				Type:    "response_error",
+			},
+			Metadata: map[string]interface{}{"provider": provider, "model": modelConfig.BaseModel},
+		}
+	}
+
+	logger.Debug("OpenAI completion response received", "content_length", len(message.Content), "tool_calls", len(toolCalls))
+	return &neurotypes.StructuredLLMResponse{
+		TextContent:    message.Content,
+		ThinkingBlocks: []neurotypes.ThinkingBlock{},
+		ToolCalls:      toolCalls,
+		Error:          nil,
+		Metadata:       openAIChatMetadata(provider, modelConfig.BaseModel, completion),
+	}
+}
+
+// openAIChatMetadata builds response metadata with usage, the finish reason and the completion ID.
+func openAIChatMetadata(provider string, model string, completion *openai.ChatCompletion) map[string]interface{} {
+	stopReason := ""
+	if len(completion.Choices) > 0 {
+		stopReason = completion.Choices[0].FinishReason
+	}
+	return withResponseDetails(usageMetadata(provider, model, openAIChatUsage(completion.Usage)), stopReason, completion.ID)
+}
+
+// openAIChatUsage normalizes chat completion usage. Returns nil when the API reported no usage.
+func openAIChatUsage(usage openai.CompletionUsage) *neurotypes.TokenUsage {
+	if usage.PromptTokens == 0 && usage.CompletionTokens == 0 {
+		return nil
+	}
+	return &neurotypes.TokenUsage{
+		InputTokens:    usage.PromptTokens,
+		OutputTokens:   usage.CompletionTokens,
+		ThinkingTokens: usage.CompletionTokensDetails.ReasoningTokens,
+		CachedTokens:   usage.PromptTokensDetails.CachedTokens,
+	}
+}
+
 // applyModelParameters applies model configuration parameters to the OpenAI request.
 func (c *OpenAIClient) applyModelParameters(params *openai.ChatCompletionNewParams, modelConfig *neurotypes.ModelConfig) {
 	if modelConfig.Parameters == nil {
@@ -1,6 +1,10 @@
 package services
 
 import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/openai/openai-go"
@@ -393,6 +397,16 @@ func TestOpenAIClient_MessageRoleConversion(t *testing.T) {
 // TestOpenAIClient_InterfaceCompliance verifies that OpenAIClient implements LLMClient interface
 func TestOpenAIClient_InterfaceCompliance(_ *testing.T) {
 	var _ neurotypes.LLMClient = &OpenAIClient{}
+	var _ neurotypes.StructuredOutputLLMClient = &OpenAIClient{}
+}
+
+func TestOpenAIResponseFormat(t *testing.T) {
+	schema := &neurotypes.ResponseSchema{Name: "person", Schema: map[string]interface{}{"type": "object"}}
+
+	format := openAIResponseFormat(schema)
+	require.NotNil(t, format.OfJSONSchema)
+	assert.Equal(t, "person", format.OfJSONSchema.JSONSchema.Name)
+	assert.Equal(t, schema.Schema, format.OfJSONSchema.JSONSchema.Schema)
 }
 
 func TestOpenAIClient_LazyInitialization(t *testing.T) {
@@ -571,3 +585,133 @@ func TestOpenAIClient_ParameterApplicationEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+// sseTransport is a fake RoundTripper that answers every request with a server-sent event stream
+type sseTransport struct {
+	events string
+}
+
+func (s *sseTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+		Body:       io.NopCloser(strings.NewReader(s.events)),
+		Request:    req,
+	}, nil
+}
+
+func TestOpenAIClient_StreamStructuredCompletion(t *testing.T) {
+	debugService := NewDebugTransportService()
+	require.NoError(t, debugService.Initialize())
+
+	events := `data: {"id":"c1","object":"chat.completion.chunk","created":1,"model":"gpt-4o","choices":[{"index":0,"delta":{"role":"assistant","content":"Hel"},"finish_reason":null}]}
+
+data: {"id":"c1","object":"chat.completion.chunk","created":1,"model":"gpt-4o","choices":[{"index":0,"delta":{"content":"lo!"},"finish_reason":"stop"}]}
+
+data: [DONE]
+
+`
+	client := NewOpenAIClient("test-key")
+	client.SetDebugTransport(&debugTransport{base: &sseTransport{events: events}, service: debugService})
+
+	session := &neurotypes.ChatSession{
+		ID:       "test-session",
+		Messages: []neurotypes.Message{{Role: "user", Content: "Hi"}},
+	}
+	modelConfig := &neurotypes.ModelConfig{BaseModel: "gpt-4o"}
+
+	var deltas []string
+	response := client.StreamStructuredCompletion(session, modelConfig, func(chunk neurotypes.StreamChunk) {
+		assert.Equal(t, "text", chunk.Type)
+		assert.Equal(t, "openai", chunk.Provider)
+		deltas = append(deltas, chunk.Content)
+	})
+
+	require.NotNil(t, response)
+	require.Nil(t, response.Error)
+	assert.Equal(t, []string{"Hel", "lo!"}, deltas)
+	assert.Equal(t, "Hello!", response.TextContent)
+	assert.Empty(t, response.ThinkingBlocks)
+
+	// The debug transport records the raw stream once it has been consumed
+	assert.Contains(t, debugService.GetCapturedData(), "chat.completion.chunk")
+}
+
+func TestOpenAIClient_ToolMessageConversion(t *testing.T) {
+	client := NewOpenAIClient("test-api-key")
+
+	session := &neurotypes.ChatSession{
+		Messages: []neurotypes.Message{
+			{Role: "user", Content: "Weather in Paris?"},
+			{Role: "assistant", ToolCalls: []neurotypes.ToolCall{
+				{ID: "call-1", Name: "weather", Arguments: `{"city":"Paris"}`},
+			}},
+			{Role: "tool", ToolCallID: "call-1", Content: "Sunny"},
+		},
+	}
+
+	messages := client.convertMessagesToOpenAI(session)
+	require.Len(t, messages, 3)
+
+	require.NotNil(t, messages[1].OfAssistant)
+	require.Len(t, messages[1].OfAssistant.ToolCalls, 1)
+	assert.Equal(t, "call-1", messages[1].OfAssistant.ToolCalls[0].ID)
+	assert.Equal(t, "weather", messages[1].OfAssistant.ToolCalls[0].Function.Name)
+
+	require.NotNil(t, messages[2].OfTool)
+	assert.Equal(t, "call-1", messages[2].OfTool.ToolCallID)
+}
+
+func TestOpenAIToolParams(t *testing.T) {
+	params := openAIToolParams([]neurotypes.ToolDefinition{
+		{Name: "weather", Description: "Get the weather", Parameters: map[string]interface{}{"type": "object"}},
+		{Name: "get_time", Parameters: map[string]interface{}{"type": "object"}},
+	})
+
+	require.Len(t, params, 2)
+	assert.Equal(t, "weather", params[0].Function.Name)
+	assert.Equal(t, "Get the weather", params[0].Function.Description.Value)
+	assert.Equal(t, "object", params[0].Function.Parameters["type"])
+	assert.False(t, params[1].Function.Description.Valid())
+}
+
+func TestOpenAICompatibleClient_UsesCatalogBaseURLAndHeaders(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "sk-real-openai-key")
+
+	var requestPath string
+	var requestHeaders http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.Path
+		requestHeaders = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"id":"c1","object":"chat.completion","model":"llama3.2","choices":[{"index":0,"finish_reason":"stop","message":{"role":"assistant","content":"Hello from local"}}],"usage":{"prompt_tokens":3,"completion_tokens":4,"total_tokens":7}}`)
+	}))
+	defer server.Close()
+
+	entry := neurotypes.ProviderCatalogEntry{
+		ID:         "LOCAL",
+		Provider:   "ollama",
+		BaseURL:    server.URL + "/v1",
+		ClientType: "openai-compatible",
+		Headers:    map[string]string{"X-Team": "secure"},
+	}
+	client := NewOpenAICompatibleClient(entry, "")
+	assert.Equal(t, "ollama", client.GetProviderName())
+	assert.True(t, client.IsConfigured(), "keyless compatible clients are configured by their base URL")
+
+	session := &neurotypes.ChatSession{Messages: []neurotypes.Message{{Role: "user", Content: "Hi"}}}
+	response := client.SendStructuredCompletion(session, &neurotypes.ModelConfig{BaseModel: "llama3.2"})
+	require.Nil(t, response.Error)
+	assert.Equal(t, "Hello from local", response.TextContent)
+	assert.Equal(t, "ollama", response.Metadata["provider"])
+
+	assert.Equal(t, "/v1/chat/completions", requestPath)
+	assert.Equal(t, "secure", requestHeaders.Get("X-Team"))
+	assert.Empty(t, requestHeaders.Get("Authorization"), "OpenAI credentials must not be sent to other servers")
+
+	keyed := NewOpenAICompatibleClient(entry, "local-secret")
+	response = keyed.SendStructuredCompletion(session, &neurotypes.ModelConfig{BaseModel: "llama3.2"})
+	require.Nil(t, response.Error)
+	assert.Equal(t, "Bearer local-secret", requestHeaders.Get("Authorization"))
+}
@@ -64,6 +64,8 @@ func (c *OpenAIReasoningClient) initializeClientIfNeeded() error {
 	// Create OpenAI client with API key and optional debug transport
 	var options []option.RequestOption
 	options = append(options, option.WithAPIKey(c.apiKey))
+	// Retries are handled by the LLM service's retry policy
+	options = append(options, option.WithMaxRetries(0))
 
 	if c.debugTransport != nil {
 		httpClient := &http.Client{Transport: c.debugTransport}
@@ -100,7 +102,7 @@ func (c *OpenAIReasoningClient) SendChatCompletion(session *neurotypes.ChatSessi
 }
 
 // SendStructuredCompletion sends a chat completion request to OpenAI and returns structured response.
-// This method reuses SendChatCompletion logic and post-processes the response to separate reasoning blocks.
+// This method uses the same routing as SendChatCompletion, separates reasoning blocks and keeps token usage in the metadata.
 // All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
 func (c *OpenAIReasoningClient) SendStructuredCompletion(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig) *neurotypes.StructuredLLMResponse {
 	logger.Debug("OpenAI SendStructuredCompletion starting", "model", modelConfig.BaseModel)
@@ -111,48 +113,60 @@ func (c *OpenAIReasoningClient) SendStructuredCompletion(session *neurotypes.Cha
 
 	if isReasoningModel {
 		// For reasoning models, get raw response and extract thinking blocks separately
-		return c.sendStructuredReasoningCompletion(session, modelConfig)
+		return c.sendStructuredReasoningCompletion(session, modelConfig, nil)
 	}
 
-	// For regular models, use SendChatCompletion and wrap in structured format
-	textContent, err := c.SendChatCompletion(session, modelConfig)
-	if err != nil {
+	// For regular models, use the chat completions endpoint and keep its token usage
+	return c.sendStructuredChatCompletion(session, modelConfig, nil)
+}
+
+// SendStructuredCompletionWithSchema sends a request whose answer is constrained to a JSON schema.
+// Reasoning mode uses the /responses text format; chat mode uses the response_format parameter.
+// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+func (c *OpenAIReasoningClient) SendStructuredCompletionWithSchema(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig, schema *neurotypes.ResponseSchema) *neurotypes.StructuredLLMResponse {
+	logger.Debug("OpenAI SendStructuredCompletionWithSchema starting", "model", modelConfig.BaseModel, "schema", schema.Name)
+
+	if c.isReasoningModel(modelConfig) {
+		return c.sendStructuredReasoningCompletion(session, modelConfig, schema)
+	}
+	return c.sendStructuredChatCompletion(session, modelConfig, schema)
+}
+
+// sendStructuredChatCompletion handles structured chat completions via /chat/completions endpoint.
+// When schema is set, the answer is constrained to it through the response_format parameter.
+// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+func (c *OpenAIReasoningClient) sendStructuredChatCompletion(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig, schema *neurotypes.ResponseSchema) *neurotypes.StructuredLLMResponse {
+	if err := c.initializeClientIfNeeded(); err != nil {
 		return &neurotypes.StructuredLLMResponse{
 			TextContent:    "",
 			ThinkingBlocks: []neurotypes.ThinkingBlock{},
 			Error: &neurotypes.LLMError{
 				Code:    "api_request_failed",
-				Message: err.Error(),
+				Message: fmt.Sprintf("failed to initialize OpenAI client: %s", err.Error()),
 				Type:    "api_error",
 			},
 			Metadata: map[string]interface{}{"provider": "openai", "model": modelConfig.BaseModel},
 		}
 	}
 
-	// Check for empty response
-	if textContent == "" {
+	params := c.buildChatParams(session, modelConfig)
+	if schema != nil {
+		params.ResponseFormat = openAIResponseFormat(schema)
+	}
+
+	logger.Debug("Sending OpenAI chat completion request", "model", modelConfig.BaseModel)
+	completion, err := c.client.Chat.Completions.New(context.Background(), params)
+	if err != nil {
+		logger.Error("OpenAI chat completion request failed", "error", err)
 		return &neurotypes.StructuredLLMResponse{
 			TextContent:    "",
 			ThinkingBlocks: []neurotypes.ThinkingBlock{},
-			Error: &neurotypes.LLMError{
-				Code:    "empty_response",
-				Message: "no content returned",
-				Type:    "response_error",
-			},
-			Metadata: map[string]interface{}{"provider": "openai", "model": modelConfig.BaseModel},
+			Error:          newProviderLLMError(fmt.Sprintf("openai chat completion request failed: %s", err.Error()), err),
+			Metadata:       map[string]interface{}{"provider": "openai", "model": modelConfig.BaseModel},
 		}
 	}
 
-	// Create structured response with no thinking blocks (regular models don't provide reasoning)
-	structuredResponse := &neurotypes.StructuredLLMResponse{
-		TextContent:    textContent,
-		ThinkingBlocks: []neurotypes.ThinkingBlock{}, // Empty - regular models don't provide reasoning blocks
-		Error:          nil,                          // No error in successful case
-		Metadata:       map[string]interface{}{"provider": "openai", "model": modelConfig.BaseModel},
-	}
-
-	logger.Debug("OpenAI structured chat completion response created", "content_length", len(textContent), "thinking_blocks", 0)
-	return structuredResponse
+	return openAICompletionResponse("openai", completion, modelConfig)
 }
 
 // isReasoningModel determines if a model should use reasoning mode based on explicit parameters.
@@ -171,26 +185,7 @@ func (c *OpenAIReasoningClient) isReasoningModel(modelConfig *neurotypes.ModelCo
 
 // sendChatCompletion handles regular chat completions via /chat/completions endpoint.
 func (c *OpenAIReasoningClient) sendChatCompletion(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig) (string, error) {
-	// Convert session messages to OpenAI format
-	messages := c.convertMessagesToOpenAI(session)
-	logger.Debug("Messages converted for chat completion", "message_count", len(messages))
-
-	// Add system prompt if present
-	if session.SystemPrompt != "" {
-		systemMsg := openai.SystemMessage(session.SystemPrompt)
-		messages = append([]openai.ChatCompletionMessageParamUnion{systemMsg}, messages...)
-		logger.Debug("System prompt added to chat completion", "system_prompt", session.SystemPrompt)
-	}
-
-	// Build completion parameters
-	params := openai.ChatCompletionNewParams{
-		Model:    openai.ChatModel(modelConfig.BaseModel),
-		Messages: messages,
-	}
-	logger.Debug("Chat completion parameters built", "model", modelConfig.BaseModel, "message_count", len(messages))
-
-	// Apply model parameters if available
-	c.applyChatParameters(&params, modelConfig)
+	params := c.buildChatParams(session, modelConfig)
 
 	// Send request
 	logger.Debug("Sending OpenAI chat completion request", "model", modelConfig.BaseModel)
@@ -220,7 +215,7 @@ func (c *OpenAIReasoningClient) sendChatCompletion(session *neurotypes.ChatSessi
 // Returns ONLY the clean text content without any formatting or reasoning summaries.
 func (c *OpenAIReasoningClient) sendReasoningCompletion(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig) (string, error) {
 	// Use shared request logic to get raw response
-	response, err := c.sendReasoningCompletionRequest(session, modelConfig)
+	response, err := c.sendReasoningCompletionRequest(session, modelConfig, nil)
 	if err != nil {
 		return "", err
 	}
@@ -264,26 +259,26 @@ func (c *OpenAIReasoningClient) sendReasoningCompletion(session *neurotypes.Chat
 }
 
 // sendReasoningCompletionRequest handles the core reasoning request logic shared by both SendChatCompletion and SendStructuredCompletion.
+// When schema is set, the answer is constrained to it through the text format parameter.
 // Returns the raw OpenAI responses response for processing.
-func (c *OpenAIReasoningClient) sendReasoningCompletionRequest(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig) (*responses.Response, error) {
+func (c *OpenAIReasoningClient) sendReasoningCompletionRequest(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig, schema *neurotypes.ResponseSchema) (*responses.Response, error) {
 	// Initialize client if needed
 	if err := c.initializeClientIfNeeded(); err != nil {
 		return nil, fmt.Errorf("failed to initialize OpenAI client: %w", err)
 	}
 
-	// Convert session messages to responses API format
-	input := c.convertSessionToReasoningInput(session)
-	logger.Debug("Messages converted for reasoning completion")
-
-	// Build reasoning parameters
-	params := responses.ResponseNewParams{
-		Model: shared.ResponsesModel(modelConfig.BaseModel),
-		Input: input,
+	params := c.buildReasoningParams(session, modelConfig)
+	if schema != nil {
+		params.Text = responses.ResponseTextConfigParam{
+			Format: responses.ResponseFormatTextConfigUnionParam{
+				OfJSONSchema: &responses.ResponseFormatTextJSONSchemaConfigParam{
+					Name:   schema.Name,
+					Schema: schema.Schema,
+				},
+			},
+		}
 	}
 
-	// Apply reasoning-specific parameters
-	c.applyReasoningParameters(&params, modelConfig)
-
 	// Send request to /responses endpoint
 	logger.Debug("Sending OpenAI reasoning completion request", "model", modelConfig.BaseModel)
 	response, err := c.client.Responses.New(context.Background(), params)
@@ -304,22 +299,24 @@ func (c *OpenAIReasoningClient) sendReasoningCompletionRequest(session *neurotyp
 // sendStructuredReasoningCompletion handles structured reasoning completions via /responses endpoint.
 // This reuses the core request logic and separates reasoning summaries from response content.
 // All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
-func (c *OpenAIReasoningClient) sendStructuredReasoningCompletion(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig) *neurotypes.StructuredLLMResponse {
+func (c *OpenAIReasoningClient) sendStructuredReasoningCompletion(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig, schema *neurotypes.ResponseSchema) *neurotypes.StructuredLLMResponse {
 	// Use shared request logic to get raw response
-	response, err := c.sendReasoningCompletionRequest(session, modelConfig)
+	response, err := c.sendReasoningCompletionRequest(session, modelConfig, schema)
 	if err != nil {
 		return &neurotypes.StructuredLLMResponse{
 			TextContent:    "",
 			ThinkingBlocks: []neurotypes.ThinkingBlock{},
-			Error: &neurotypes.LLMError{
-				Code:    "api_request_failed",
-				Message: err.Error(),
-				Type:    "api_error",
-			},
-			Metadata: map[string]interface{}{"provider": "openai", "model": modelConfig.BaseModel},
+			Error:          newProviderLLMError(err.Error(), err),
+			Metadata:       map[string]interface{}{"provider": "openai", "model": modelConfig.BaseModel},
 		}
 	}
 
+	return c.buildStructuredReasoningResponse(response, modelConfig)
+}
+
+// buildStructuredReasoningResponse converts a complete /responses result into a structured response,
+// separating reasoning summaries from message content.
+func (c *OpenAIReasoningClient) buildStructuredReasoningResponse(response *responses.Response, modelConfig *neurotypes.ModelConfig) *neurotypes.StructuredLLMResponse {
 	// Process output items: separate reasoning summaries and message content
 	var textContent string
 	var thinkingBlocks []neurotypes.ThinkingBlock
@@ -367,6 +364,12 @@ func (c *OpenAIReasoningClient) sendStructuredReasoningCompletion(session *neuro
 		"created_at":  response.CreatedAt,
 		"status":      response.Status,
 	}
+	withResponseDetails(metadata, string(response.Status), response.ID)
+	if usage := openAIResponsesUsage(response.Usage); usage != nil {
+		for key, value := range usageMetadata("openai", modelConfig.BaseModel, usage) {
+			metadata[key] = value
+		}
+	}
 
 	// Check for truly empty response (no text content AND no thinking blocks)
 	if textContent == "" && len(thinkingBlocks) == 0 {
@@ -395,6 +398,248 @@ func (c *OpenAIReasoningClient) sendStructuredReasoningCompletion(session *neuro
 	return structuredResponse
 }
 
+// StreamStructuredCompletion sends a streaming request to OpenAI using the same routing as SendStructuredCompletion.
+// Reasoning summary deltas are delivered as thinking chunks and message deltas as text chunks.
+// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+func (c *OpenAIReasoningClient) StreamStructuredCompletion(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig, onChunk neurotypes.StreamHandler) *neurotypes.StructuredLLMResponse {
+	logger.Debug("OpenAI StreamStructuredCompletion starting", "model", modelConfig.BaseModel)
+
+	if onChunk == nil {
+		onChunk = func(neurotypes.StreamChunk) {}
+	}
+
+	if err := c.initializeClientIfNeeded(); err != nil {
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error: &neurotypes.LLMError{
+				Code:    "api_request_failed",
+				Message: fmt.Sprintf("failed to initialize OpenAI client: %s", err.Error()),
+				Type:    "api_error",
+			},
+			Metadata: map[string]interface{}{"provider": "openai", "model": modelConfig.BaseModel},
+		}
+	}
+
+	if c.isReasoningModel(modelConfig) {
+		return c.streamStructuredReasoningCompletion(session, modelConfig, onChunk)
+	}
+	return c.streamStructuredChatCompletion(session, modelConfig, onChunk)
+}
+
+// SendStructuredCompletionWithTools sends a chat completion request advertising the given tools.
+// Tool calling uses the /chat/completions endpoint; reasoning mode requests are sent without tools.
+// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+func (c *OpenAIReasoningClient) SendStructuredCompletionWithTools(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig, tools []neurotypes.ToolDefinition) *neurotypes.StructuredLLMResponse {
+	logger.Debug("OpenAI SendStructuredCompletionWithTools starting", "model", modelConfig.BaseModel, "tools", len(tools))
+
+	if c.isReasoningModel(modelConfig) {
+		logger.Debug("Reasoning mode does not support tools, sending without tools", "model", modelConfig.BaseModel)
+		return c.SendStructuredCompletion(session, modelConfig)
+	}
+
+	if err := c.initializeClientIfNeeded(); err != nil {
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error: &neurotypes.LLMError{
+				Code:    "api_request_failed",
+				Message: fmt.Sprintf("failed to initialize OpenAI client: %s", err.Error()),
+				Type:    "api_error",
+			},
+			Metadata: map[string]interface{}{"provider": "openai", "model": modelConfig.BaseModel},
+		}
+	}
+
+	params := c.buildChatParams(session, modelConfig)
+	params.Tools = openAIToolParams(tools)
+
+	logger.Debug("Sending OpenAI chat completion tool calling request", "model", modelConfig.BaseModel)
+	completion, err := c.client.Chat.Completions.New(context.Background(), params)
+	if err != nil {
+		logger.Error("OpenAI chat completion request failed", "error", err)
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error:          newProviderLLMError(fmt.Sprintf("openai chat completion request failed: %s", err.Error()), err),
+			Metadata:       map[string]interface{}{"provider": "openai", "model": modelConfig.BaseModel},
+		}
+	}
+
+	return openAICompletionResponse("openai", completion, modelConfig)
+}
+
+// streamStructuredChatCompletion streams a regular chat completion via /chat/completions endpoint.
+func (c *OpenAIReasoningClient) streamStructuredChatCompletion(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig, onChunk neurotypes.StreamHandler) *neurotypes.StructuredLLMResponse {
+	params := c.buildChatParams(session, modelConfig)
+	// Usage is only sent in a final chunk when explicitly requested
+	params.StreamOptions = openai.ChatCompletionStreamOptionsParam{IncludeUsage: openai.Bool(true)}
+
+	logger.Debug("Sending OpenAI chat completion streaming request", "model", modelConfig.BaseModel)
+	stream := c.client.Chat.Completions.NewStreaming(context.Background(), params)
+	defer func() { _ = stream.Close() }()
+
+	acc := openai.ChatCompletionAccumulator{}
+	for stream.Next() {
+		chunk := stream.Current()
+		acc.AddChunk(chunk)
+
+		if len(chunk.Choices) > 0 && chunk.Choices[0].Delta.Content != "" {
+			onChunk(neurotypes.StreamChunk{Type: "text", Content: chunk.Choices[0].Delta.Content, Provider: "openai"})
+		}
+	}
+
+	if err := stream.Err(); err != nil {
+		logger.Error("OpenAI chat completion streaming request failed", "error", err)
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error:          newProviderLLMError(fmt.Sprintf("openai chat completion request failed: %s", err.Error()), err),
+			Metadata:       map[string]interface{}{"provider": "openai", "model": modelConfig.BaseModel},
+		}
+	}
+
+	var textContent string
+	if len(acc.Choices) > 0 {
+		textContent = acc.Choices[0].Message.Content
+	}
+
+	if textContent == "" {
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error: &neurotypes.LLMError{
+				Code:    "empty_response",
+				Message: "no content returned",
+				Type:    "response_error",
+			},
+			Metadata: map[string]interface{}{"provider": "openai", "model": modelConfig.BaseModel},
+		}
+	}
+
+	logger.Debug("OpenAI streamed chat completion received", "content_length", len(textContent))
+	return &neurotypes.StructuredLLMResponse{
+		TextContent:    textContent,
+		ThinkingBlocks: []neurotypes.ThinkingBlock{},
+		Error:          nil,
+		Metadata:       openAIChatMetadata("openai", modelConfig.BaseModel, &acc.ChatCompletion),
+	}
+}
+
+// openAIResponsesUsage normalizes /responses usage. Returns nil when the API reported no usage.
+func openAIResponsesUsage(usage responses.ResponseUsage) *neurotypes.TokenUsage {
+	if usage.InputTokens == 0 && usage.OutputTokens == 0 {
+		return nil
+	}
+	return &neurotypes.TokenUsage{
+		InputTokens:    usage.InputTokens,
+		OutputTokens:   usage.OutputTokens,
+		ThinkingTokens: usage.OutputTokensDetails.ReasoningTokens,
+		CachedTokens:   usage.InputTokensDetails.CachedTokens,
+	}
+}
+
+// streamStructuredReasoningCompletion streams a reasoning completion via /responses endpoint.
+// The final response.completed event carries the full output, which is processed like a blocking response.
+func (c *OpenAIReasoningClient) streamStructuredReasoningCompletion(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig, onChunk neurotypes.StreamHandler) *neurotypes.StructuredLLMResponse {
+	params := c.buildReasoningParams(session, modelConfig)
+
+	logger.Debug("Sending OpenAI reasoning streaming request", "model", modelConfig.BaseModel)
+	stream := c.client.Responses.NewStreaming(context.Background(), params)
+	defer func() { _ = stream.Close() }()
+
+	var completed *responses.Response
+	var streamErr *neurotypes.LLMError
+	for stream.Next() {
+		event := stream.Current()
+		switch event.Type {
+		case "response.output_text.delta":
+			onChunk(neurotypes.StreamChunk{Type: "text", Content: event.Delta.OfString, Provider: "openai"})
+		case "response.reasoning_summary_text.delta":
+			onChunk(neurotypes.StreamChunk{Type: "thinking", Content: event.Delta.OfString, Provider: "openai"})
+		case "response.completed":
+			response := event.Response
+			completed = &response
+		case "response.failed":
+			message := "reasoning response failed"
+			if event.Response.Error.Message != "" {
+				message = event.Response.Error.Message
+			}
+			streamErr = &neurotypes.LLMError{Code: "api_request_failed", Message: message, Type: "api_error"}
+		case "error":
+			streamErr = &neurotypes.LLMError{Code: "api_request_failed", Message: event.Message, Type: "api_error"}
+		}
+	}
+
+	if err := stream.Err(); err != nil {
+		logger.Error("OpenAI reasoning streaming request failed", "error", err)
+		streamErr = newProviderLLMError(fmt.Sprintf("openai reasoning completion request failed: %s", err.Error()), err)
+	}
+
+	if streamErr == nil && (completed == nil || len(completed.Output) == 0) {
+		streamErr = &neurotypes.LLMError{
+			Code:    "api_request_failed",
+			Message: "openai reasoning completion request failed: no response output items returned",
+			Type:    "api_error",
+		}
+	}
+
+	if streamErr != nil {
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error:          streamErr,
+			Metadata:       map[string]interface{}{"provider": "openai", "model": modelConfig.BaseModel},
+		}
+	}
+
+	return c.buildStructuredReasoningResponse(completed, modelConfig)
+}
+
+// buildChatParams converts a session and model configuration into chat completion parameters.
+func (c *OpenAIReasoningClient) buildChatParams(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig) openai.ChatCompletionNewParams {
+	// Convert session messages to OpenAI format
+	messages := c.convertMessagesToOpenAI(session)
+	logger.Debug("Messages converted for chat completion", "message_count", len(messages))
+
+	// Add system prompt if present
+	if session.SystemPrompt != "" {
+		systemMsg := openai.SystemMessage(session.SystemPrompt)
+		messages = append([]openai.ChatCompletionMessageParamUnion{systemMsg}, messages...)
+		logger.Debug("System prompt added to chat completion", "system_prompt", session.SystemPrompt)
+	}
+
+	// Build completion parameters
+	params := openai.ChatCompletionNewParams{
+		Model:    openai.ChatModel(modelConfig.BaseModel),
+		Messages: messages,
+	}
+	logger.Debug("Chat completion parameters built", "model", modelConfig.BaseModel, "message_count", len(messages))
+
+	// Apply model parameters if available
+	c.applyChatParameters(&params, modelConfig)
+
+	return params
+}
+
+// buildReasoningParams converts a session and model configuration into /responses parameters.
+func (c *OpenAIReasoningClient) buildReasoningParams(session *neurotypes.ChatSession, modelConfig *neurotypes.ModelConfig) responses.ResponseNewParams {
+	// Convert session messages to responses API format
+	input := c.convertSessionToReasoningInput(session)
+	logger.Debug("Messages converted for reasoning completion")
+
+	// Build reasoning parameters
+	params := responses.ResponseNewParams{
+		Model: shared.ResponsesModel(modelConfig.BaseModel),
+		Input: input,
+	}
+
+	// Apply reasoning-specific parameters
+	c.applyReasoningParameters(&params, modelConfig)
+
+	return params
+}
+
 // convertMessagesToOpenAI converts NeuroShell messages to OpenAI chat completion format.
 func (c *OpenAIReasoningClient) convertMessagesToOpenAI(session *neurotypes.ChatSession) []openai.ChatCompletionMessageParamUnion {
 	messages := make([]openai.ChatCompletionMessageParamUnion, 0, len(session.Messages))
@@ -404,9 +649,11 @@ func (c *OpenAIReasoningClient) convertMessagesToOpenAI(session *neurotypes.Chat
 		case "user":
 			messages = append(messages, openai.UserMessage(msg.Content))
 		case "assistant":
-			messages = append(messages, openai.AssistantMessage(msg.Content))
+			messages = append(messages, openAIAssistantMessage(msg))
 		case "system":
 			messages = append(messages, openai.SystemMessage(msg.Content))
+		case "tool":
+			messages = append(messages, openai.ToolMessage(msg.Content, msg.ToolCallID))
 		default:
 			// Skip unknown roles
 			continue
@@ -378,6 +378,7 @@ func TestOpenAIReasoningClient_applyReasoningParameters(t *testing.T) {
 
 func TestOpenAIReasoningClient_InterfaceCompliance(_ *testing.T) {
 	var _ neurotypes.LLMClient = (*OpenAIReasoningClient)(nil)
+	var _ neurotypes.StructuredOutputLLMClient = (*OpenAIReasoningClient)(nil)
 }
 
 func TestOpenAIReasoningClient_LazyInitialization(t *testing.T) {
@@ -55,6 +55,34 @@ func (p *ParameterValidatorService) ValidateParameters(args map[string]string, p
 			continue
 		}
 
+		// max_retries configures the retry policy and applies to every model
+		if paramName == MaxRetriesParameter {
+			retries, err := ParseMaxRetries(paramValue)
+			if err != nil {
+				return nil, fmt.Errorf("parameter '%s': %w", paramName, err)
+			}
+			result[paramName] = retries
+			continue
+		}
+
+		// context_policy and context_window configure context window management for every model
+		if paramName == ContextPolicyParameter {
+			policy, err := ParseContextPolicy(paramValue)
+			if err != nil {
+				return nil, fmt.Errorf("parameter '%s': %w", paramName, err)
+			}
+			result[paramName] = policy.String()
+			continue
+		}
+		if paramName == ContextWindowParameter {
+			window, err := strconv.Atoi(strings.TrimSpace(paramValue))
+			if err != nil || window <= 0 {
+				return nil, fmt.Errorf("parameter '%s': must be a positive integer", paramName)
+			}
+			result[paramName] = window
+			continue
+		}
+
 		paramDef, exists := paramDefMap[paramName]
 		if !exists {
 			return nil, fmt.Errorf("unknown parameter '%s'", paramName)
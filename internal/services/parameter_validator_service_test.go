@@ -759,3 +759,17 @@ func TestParameterValidatorService_ValidateParameters_EdgeCases(t *testing.T) {
 
 // Interface compliance check
 var _ neurotypes.Service = (*ParameterValidatorService)(nil)
+
+func TestParameterValidatorService_ValidateParameters_MaxRetries(t *testing.T) {
+	service := NewParameterValidatorService()
+	require.NoError(t, service.Initialize())
+
+	// max_retries is accepted for every model without a catalog definition
+	result, err := service.ValidateParameters(map[string]string{"max_retries": "5"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 5, result["max_retries"])
+
+	_, err = service.ValidateParameters(map[string]string{"max_retries": "-1"}, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "max_retries")
+}
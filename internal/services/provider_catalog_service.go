@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"neuroshell/internal/data/embedded"
+	"neuroshell/internal/logger"
 	"neuroshell/pkg/neurotypes"
 
 	"gopkg.in/yaml.v3"
@@ -12,9 +13,12 @@ import (
 
 // ProviderCatalogService provides provider catalog operations for NeuroShell.
 // It handles loading and searching through embedded YAML provider catalogs
-// from different LLM providers (OpenAI, Anthropic, Moonshot, OpenRouter).
+// from different LLM providers (OpenAI, Anthropic, Gemini) and local OpenAI-compatible servers
+// (Ollama, vLLM, llama.cpp, LM Studio).
 type ProviderCatalogService struct {
 	initialized bool
+	catalog     []neurotypes.ProviderCatalogEntry // Embedded entries merged with user and project entries at initialization
+	warnings    []string                          // Problems found in user and project catalog files
 }
 
 // NewProviderCatalogService creates a new ProviderCatalogService instance.
@@ -30,17 +34,50 @@ func (p *ProviderCatalogService) Name() string {
 }
 
 // Initialize sets up the ProviderCatalogService for operation.
+// User and project catalogs are read once here; problems with their files are kept as warnings
+// (see LoadWarnings) instead of failing later lookups.
 func (p *ProviderCatalogService) Initialize() error {
+	catalog, err := p.loadEmbeddedProviders()
+	if err != nil {
+		return err
+	}
+
+	// Merge user and project catalogs; their entries override embedded ones with the same ID
+	id := func(e neurotypes.ProviderCatalogEntry) string { return e.ID }
+	source := func(e *neurotypes.ProviderCatalogEntry) *neurotypes.CatalogSource { return &e.Source }
+	layers, warnings := loadCustomCatalogLayers(ProviderCatalogDir, p.loadProviderFile, id, source)
+	allowProjectOverrides := projectCatalogOverridesAllowed()
+	for _, layer := range layers {
+		var mergeWarnings []string
+		catalog, mergeWarnings = mergeCatalogEntries(catalog, layer, id, source, allowProjectOverrides)
+		warnings = append(warnings, mergeWarnings...)
+	}
+	for _, warning := range warnings {
+		logger.Warn("Provider catalog", "warning", warning)
+	}
+
+	p.catalog = catalog
+	p.warnings = warnings
 	p.initialized = true
 	return nil
 }
 
+// LoadWarnings returns the problems found while loading user and project provider catalog files.
+func (p *ProviderCatalogService) LoadWarnings() []string {
+	return append([]string(nil), p.warnings...)
+}
+
 // GetProviderCatalog returns the complete provider catalog from all providers.
 func (p *ProviderCatalogService) GetProviderCatalog() ([]neurotypes.ProviderCatalogEntry, error) {
 	if !p.initialized {
 		return nil, fmt.Errorf("provider catalog service not initialized")
 	}
 
+	return append([]neurotypes.ProviderCatalogEntry(nil), p.catalog...), nil
+}
+
+// loadEmbeddedProviders loads the provider catalog shipped with NeuroShell.
+func (p *ProviderCatalogService) loadEmbeddedProviders() ([]neurotypes.ProviderCatalogEntry, error) {
 	var allProviders []neurotypes.ProviderCatalogEntry
 
 	// Load OpenAI providers
@@ -71,10 +108,45 @@ func (p *ProviderCatalogService) GetProviderCatalog() ([]neurotypes.ProviderCata
 	}
 	allProviders = append(allProviders, geminiChat)
 
+	// Load local and self-hosted OpenAI-compatible providers
+	ollamaChat, err := p.loadProviderFile(embedded.OllamaChatProviderData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Ollama chat provider: %w", err)
+	}
+	allProviders = append(allProviders, ollamaChat)
+
+	vllmChat, err := p.loadProviderFile(embedded.VLLMChatProviderData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load vLLM chat provider: %w", err)
+	}
+	allProviders = append(allProviders, vllmChat)
+
+	llamaCppChat, err := p.loadProviderFile(embedded.LlamaCppChatProviderData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load llama.cpp chat provider: %w", err)
+	}
+	allProviders = append(allProviders, llamaCppChat)
+
+	lmStudioChat, err := p.loadProviderFile(embedded.LMStudioChatProviderData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load LM Studio chat provider: %w", err)
+	}
+	allProviders = append(allProviders, lmStudioChat)
+
+	// Load the scripted mock provider used for testing without API keys
+	mockProvider, err := p.loadProviderFile(embedded.MockProviderData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load mock provider: %w", err)
+	}
+	allProviders = append(allProviders, mockProvider)
+
 	// Validate that all provider IDs are unique (case-insensitive)
 	if err := p.validateUniqueIDs(allProviders); err != nil {
 		return nil, fmt.Errorf("provider catalog validation failed: %w", err)
 	}
+	for i := range allProviders {
+		allProviders[i].Source = neurotypes.CatalogSource{Kind: neurotypes.CatalogSourceEmbedded}
+	}
 
 	return allProviders, nil
 }
@@ -132,7 +204,7 @@ func (p *ProviderCatalogService) SearchProviderCatalog(query string) ([]neurotyp
 
 // GetSupportedProviders returns a list of supported provider names.
 func (p *ProviderCatalogService) GetSupportedProviders() []string {
-	return []string{"openai", "anthropic", "gemini"}
+	return []string{"openai", "anthropic", "gemini", "ollama", "vllm", "llamacpp", "lmstudio", "mock"}
 }
 
 // GetValidCatalogIDs returns a list of all valid provider catalog IDs dynamically.
@@ -194,12 +266,18 @@ func (p *ProviderCatalogService) validateUniqueIDs(providers []neurotypes.Provid
 	return nil
 }
 
+// IsOpenAICompatible reports whether a provider catalog ID names an OpenAI-compatible endpoint.
+func (p *ProviderCatalogService) IsOpenAICompatible(id string) bool {
+	provider, err := p.GetProviderByID(id)
+	return err == nil && provider.IsOpenAICompatible()
+}
+
 // normalizeID converts an ID to uppercase for case-insensitive comparison.
 func (p *ProviderCatalogService) normalizeID(id string) string {
 	return strings.ToUpper(id)
 }
 
-// loadProviderFile loads and parses an individual provider file from embedded YAML data.
+// loadProviderFile loads and parses an individual provider file from YAML data.
 func (p *ProviderCatalogService) loadProviderFile(data []byte) (neurotypes.ProviderCatalogEntry, error) {
 	var providerFile neurotypes.ProviderCatalogFile
 
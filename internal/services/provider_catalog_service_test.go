@@ -62,7 +62,7 @@ func TestProviderCatalogService_GetProviderCatalog(t *testing.T) {
 		require.NotNil(t, providers)
 
 		// Verify we get providers from all expected providers
-		assert.Equal(t, 4, len(providers), "Should have exactly 4 providers")
+		assert.Equal(t, 9, len(providers), "Should have exactly 9 providers")
 
 		// Check that we have providers from all expected providers
 		providerNames := make(map[string]bool)
@@ -71,7 +71,9 @@ func TestProviderCatalogService_GetProviderCatalog(t *testing.T) {
 			assert.NotEmpty(t, provider.ID, "Provider ID should not be empty")
 			assert.NotEmpty(t, provider.Provider, "Provider name should not be empty")
 			assert.NotEmpty(t, provider.DisplayName, "Display name should not be empty")
-			assert.NotEmpty(t, provider.BaseURL, "Base URL should not be empty")
+			if provider.ClientType != MockClientType {
+				assert.NotEmpty(t, provider.BaseURL, "Base URL should not be empty")
+			}
 			assert.NotEmpty(t, provider.ClientType, "Client type should not be empty")
 			assert.NotEmpty(t, provider.Description, "Description should not be empty")
 
@@ -124,7 +126,7 @@ func TestProviderCatalogService_GetProvidersByProvider(t *testing.T) {
 		provider := providers[0]
 		assert.Equal(t, "ANC", provider.ID)
 		assert.Equal(t, "anthropic", provider.Provider)
-		assert.Equal(t, "openai-compatible", provider.ClientType)
+		assert.Equal(t, "anthropic", provider.ClientType)
 		assert.Contains(t, provider.BaseURL, "api.anthropic.com")
 		assert.NotEmpty(t, provider.Headers, "Anthropic should have headers")
 		assert.Equal(t, "2023-06-01", provider.Headers["anthropic-version"])
@@ -280,7 +282,7 @@ func TestProviderCatalogService_SearchProviderCatalog(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, providers)
 		// Should return all providers since empty query matches everything
-		assert.Equal(t, 4, len(providers), "Empty search should return all providers")
+		assert.Equal(t, 9, len(providers), "Empty search should return all providers")
 	})
 }
 
@@ -293,7 +295,12 @@ func TestProviderCatalogService_GetSupportedProviders(t *testing.T) {
 	assert.Contains(t, providers, "openai")
 	assert.Contains(t, providers, "anthropic")
 	assert.Contains(t, providers, "gemini")
-	assert.Equal(t, 3, len(providers), "Should have exactly 3 supported providers")
+	assert.Contains(t, providers, "ollama")
+	assert.Contains(t, providers, "vllm")
+	assert.Contains(t, providers, "llamacpp")
+	assert.Contains(t, providers, "lmstudio")
+	assert.Contains(t, providers, "mock")
+	assert.Equal(t, 8, len(providers), "Should have exactly 8 supported providers")
 }
 
 func TestProviderCatalogService_GetProviderByID(t *testing.T) {
@@ -470,7 +477,7 @@ func TestProviderCatalogService_IDValidationIntegration(t *testing.T) {
 	t.Run("real catalog has unique IDs", func(t *testing.T) {
 		providers, err := service.GetProviderCatalog()
 		require.NoError(t, err)
-		assert.Equal(t, 4, len(providers), "Should have 4 providers in catalog")
+		assert.Equal(t, 9, len(providers), "Should have 9 providers in catalog")
 
 		// Verify all providers have IDs
 		for _, provider := range providers {
@@ -521,17 +528,23 @@ func TestProviderCatalogService_IDValidationIntegration(t *testing.T) {
 			assert.NotEmpty(t, provider.ID, "Provider should have ID")
 			assert.NotEmpty(t, provider.Provider, "Provider should have provider name")
 			assert.NotEmpty(t, provider.DisplayName, "Provider should have display name")
-			assert.NotEmpty(t, provider.BaseURL, "Provider should have base URL")
 			assert.NotEmpty(t, provider.ClientType, "Provider should have client type")
 			assert.NotEmpty(t, provider.Description, "Provider should have description")
 			assert.NotEmpty(t, provider.ImplementationNotes, "Provider should have implementation notes")
 
 			// Validate client types
-			validClientTypes := []string{"openai", "openai_reasoning", "openai-compatible", "gemini"}
+			validClientTypes := []string{"openai", "openai_reasoning", "anthropic", "openai-compatible", "gemini", "mock"}
 			assert.Contains(t, validClientTypes, provider.ClientType, "Provider should have valid client type")
 
-			// Validate base URLs start with https
-			assert.True(t, strings.HasPrefix(provider.BaseURL, "https://"), "Provider base URL should use HTTPS")
+			// Validate base URLs: hosted APIs use https, local OpenAI-compatible servers default to localhost,
+			// and the mock provider makes no requests
+			if provider.ClientType == MockClientType {
+				assert.Empty(t, provider.BaseURL, "Mock provider should not have a base URL")
+			} else if provider.IsOpenAICompatible() {
+				assert.True(t, strings.HasPrefix(provider.BaseURL, "http://localhost:"), "Local provider base URL should point to localhost")
+			} else {
+				assert.True(t, strings.HasPrefix(provider.BaseURL, "https://"), "Provider base URL should use HTTPS")
+			}
 
 			// Validate implementation notes values
 			validImplementationNotes := []string{"Natively supported by NeuroShell", "Uses OpenAI-compatible API", "Used for models with reasoning_tokens: true"}
@@ -0,0 +1,248 @@
+// Package services provides retry with exponential backoff for transient LLM provider errors.
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/openai/openai-go"
+	"google.golang.org/genai"
+
+	neuroshellcontext "neuroshell/internal/context"
+	"neuroshell/internal/logger"
+	"neuroshell/pkg/neurotypes"
+)
+
+// Retry configuration names and limits.
+const (
+	MaxRetriesParameter = "max_retries" // Per-model retry count set with \model-new[max_retries=N]
+	RetryVariable       = "_llm_retry"  // Global retry count, or "off" to disable retries
+	defaultMaxRetries   = 2
+	maxAllowedRetries   = 10
+	retryBaseDelay      = time.Second
+	retryMaxDelay       = 30 * time.Second
+	retryAfterLimit     = 2 * time.Minute
+)
+
+// Metadata keys used to report retries in StructuredLLMResponse.Metadata.
+const (
+	RetryCountKey  = "retry_count"
+	RetryWaitMsKey = "retry_wait_ms"
+)
+
+// retrySleep waits between attempts; replaced in tests to avoid real delays.
+var retrySleep = time.Sleep
+
+// RetryPolicy controls how often and how long failed LLM requests are retried.
+type RetryPolicy struct {
+	MaxRetries int           // Retries after the first attempt; 0 disables retrying
+	BaseDelay  time.Duration // Delay before the first retry, doubled for each further retry
+	MaxDelay   time.Duration // Upper bound for the backoff delay
+	Jitter     bool          // Randomize delays so parallel scripts do not retry in lockstep
+}
+
+// ResolveRetryPolicy returns the retry policy for a model.
+// The model's max_retries parameter takes precedence over ${_llm_retry}, which takes
+// precedence over the default of 2 retries. Test mode disables jitter for reproducible output.
+func ResolveRetryPolicy(model *neurotypes.ModelConfig) (RetryPolicy, error) {
+	policy := RetryPolicy{
+		MaxRetries: defaultMaxRetries,
+		BaseDelay:  retryBaseDelay,
+		MaxDelay:   retryMaxDelay,
+		Jitter:     true,
+	}
+	if ctx := neuroshellcontext.GetGlobalContext(); ctx != nil && ctx.IsTestMode() {
+		policy.Jitter = false
+	}
+
+	if model != nil {
+		if value, ok := model.Parameters[MaxRetriesParameter]; ok {
+			retries, err := ParseMaxRetries(fmt.Sprintf("%v", value))
+			if err != nil {
+				return policy, fmt.Errorf("invalid %s for model '%s': %w", MaxRetriesParameter, model.Name, err)
+			}
+			policy.MaxRetries = retries
+			return policy, nil
+		}
+	}
+
+	if variableService, err := GetGlobalVariableService(); err == nil {
+		if value, err := variableService.Get(RetryVariable); err == nil && strings.TrimSpace(value) != "" {
+			retries, err := ParseMaxRetries(value)
+			if err != nil {
+				return policy, fmt.Errorf("invalid %s value: %w", RetryVariable, err)
+			}
+			policy.MaxRetries = retries
+		}
+	}
+
+	return policy, nil
+}
+
+// ParseMaxRetries parses a retry count. "off"/"false" disable retries and "on"/"true" select the default.
+func ParseMaxRetries(value string) (int, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "off", "false", "no":
+		return 0, nil
+	case "on", "true", "yes":
+		return defaultMaxRetries, nil
+	}
+
+	retries, err := strconv.Atoi(value)
+	if err != nil || retries < 0 || retries > maxAllowedRetries {
+		return 0, fmt.Errorf("'%s' is not a retry count between 0 and %d", value, maxAllowedRetries)
+	}
+	return retries, nil
+}
+
+// Delay returns the wait before the given retry (0-based).
+// A Retry-After value from the provider is honored; otherwise the delay grows exponentially.
+func (p RetryPolicy) Delay(retry int, llmErr *neurotypes.LLMError) time.Duration {
+	if llmErr != nil && llmErr.RetryAfter > 0 {
+		return min(llmErr.RetryAfter, retryAfterLimit)
+	}
+
+	delay := p.BaseDelay
+	for i := 0; i < retry && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, p.MaxDelay)
+
+	if p.Jitter && delay > 0 {
+		// Equal jitter: keep half of the delay and randomize the rest
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+	}
+	return delay
+}
+
+// IsRetryableLLMError reports whether a failed request may succeed when sent again.
+func IsRetryableLLMError(llmErr *neurotypes.LLMError) bool {
+	return llmErr != nil && (llmErr.Type == "rate_limit" || llmErr.Type == "server_error")
+}
+
+// sendWithRetry performs a request and retries it according to the model's retry policy.
+// canRetry, when set, can veto a retry (for example after streamed output was already shown).
+// The number of retries and the total wait are added to the response metadata.
+func sendWithRetry(model *neurotypes.ModelConfig, send func() *neurotypes.StructuredLLMResponse, canRetry func() bool) *neurotypes.StructuredLLMResponse {
+	policy, err := ResolveRetryPolicy(model)
+	if err != nil {
+		return &neurotypes.StructuredLLMResponse{
+			TextContent:    "",
+			ThinkingBlocks: []neurotypes.ThinkingBlock{},
+			Error: &neurotypes.LLMError{
+				Code:    "invalid_retry_policy",
+				Message: err.Error(),
+				Type:    "client_error",
+			},
+			Metadata: map[string]interface{}{"service": "llm"},
+		}
+	}
+
+	start := time.Now()
+	retries := 0
+	var waited time.Duration
+	response := send()
+	for response != nil && IsRetryableLLMError(response.Error) && retries < policy.MaxRetries {
+		if canRetry != nil && !canRetry() {
+			break
+		}
+		delay := policy.Delay(retries, response.Error)
+		logger.Debug("Retrying LLM request", "attempt", retries+2, "error_type", response.Error.Type, "delay", delay)
+		if ctx := neuroshellcontext.GetGlobalContext(); ctx == nil || !ctx.IsTestMode() {
+			retrySleep(delay)
+		}
+		retries++
+		waited += delay
+		response = send()
+	}
+
+	if response != nil {
+		if response.Metadata == nil {
+			response.Metadata = make(map[string]interface{})
+		}
+		response.Metadata[RetryCountKey] = retries
+		response.Metadata[RetryWaitMsKey] = waited.Milliseconds()
+		// Latency is left out in test mode so recorded output stays deterministic
+		if ctx := neuroshellcontext.GetGlobalContext(); ctx == nil || !ctx.IsTestMode() {
+			response.Metadata[neurotypes.ResponseLatencyMsKey] = time.Since(start).Milliseconds()
+		}
+	}
+	return response
+}
+
+// RetryStatsFromMetadata returns the retries and total wait recorded in response metadata.
+func RetryStatsFromMetadata(metadata map[string]interface{}) (int, time.Duration) {
+	if metadata == nil {
+		return 0, 0
+	}
+	return int(metadataInt(metadata, RetryCountKey)), time.Duration(metadataInt(metadata, RetryWaitMsKey)) * time.Millisecond
+}
+
+// newProviderLLMError builds the error for a failed provider request, classifying HTTP status codes
+// so transient failures can be retried: 429 is "rate_limit" and 5xx (including 529 overloaded) is
+// "server_error". Other failures keep the generic "api_error" type.
+func newProviderLLMError(message string, err error) *neurotypes.LLMError {
+	llmErr := &neurotypes.LLMError{
+		Code:    "api_request_failed",
+		Message: message,
+		Type:    "api_error",
+	}
+
+	statusCode := 0
+	var header http.Header
+	var anthropicErr *anthropic.Error
+	var openaiErr *openai.Error
+	var geminiErr genai.APIError
+	switch {
+	case errors.As(err, &anthropicErr):
+		statusCode = anthropicErr.StatusCode
+		if anthropicErr.Response != nil {
+			header = anthropicErr.Response.Header
+		}
+	case errors.As(err, &openaiErr):
+		statusCode = openaiErr.StatusCode
+		if openaiErr.Response != nil {
+			header = openaiErr.Response.Header
+		}
+	case errors.As(err, &geminiErr):
+		statusCode = geminiErr.Code
+	}
+
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		llmErr.Code = "rate_limit_exceeded"
+		llmErr.Type = "rate_limit"
+	case statusCode >= 500:
+		llmErr.Code = fmt.Sprintf("server_error_%d", statusCode)
+		llmErr.Type = "server_error"
+	}
+	if header != nil {
+		llmErr.RetryAfter = parseRetryAfter(header.Get("Retry-After"), time.Now())
+	}
+	return llmErr
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds * float64(time.Second))
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genai"
+
+	"neuroshell/internal/context"
+	"neuroshell/pkg/neurotypes"
+)
+
+// FlakyLLMClient fails with the given errors before returning a successful response.
+type FlakyLLMClient struct {
+	MockLLMClient
+	failures []*neurotypes.LLMError
+	calls    int
+}
+
+func (f *FlakyLLMClient) SendStructuredCompletion(session *neurotypes.ChatSession, model *neurotypes.ModelConfig) *neurotypes.StructuredLLMResponse {
+	f.calls++
+	if f.calls <= len(f.failures) {
+		return &neurotypes.StructuredLLMResponse{Error: f.failures[f.calls-1]}
+	}
+	return f.MockLLMClient.SendStructuredCompletion(session, model)
+}
+
+// setupRetryTestRegistry records delays instead of sleeping and registers a variable service.
+func setupRetryTestRegistry(t *testing.T) (*VariableService, *[]time.Duration) {
+	oldServiceRegistry := GetGlobalRegistry()
+	SetGlobalRegistry(NewRegistry())
+	context.ResetGlobalContext()
+
+	var delays []time.Duration
+	oldSleep := retrySleep
+	retrySleep = func(d time.Duration) { delays = append(delays, d) }
+
+	t.Cleanup(func() {
+		retrySleep = oldSleep
+		SetGlobalRegistry(oldServiceRegistry)
+		context.ResetGlobalContext()
+	})
+
+	variableService := NewVariableService()
+	require.NoError(t, GetGlobalRegistry().RegisterService(variableService))
+	require.NoError(t, variableService.Initialize())
+	return variableService, &delays
+}
+
+func TestParseMaxRetries(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected int
+		wantErr  bool
+	}{
+		{"3", 3, false},
+		{" 0 ", 0, false},
+		{"off", 0, false},
+		{"true", defaultMaxRetries, false},
+		{"-1", 0, true},
+		{"11", 0, true},
+		{"many", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			retries, err := ParseMaxRetries(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, retries)
+		})
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 5, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	assert.Equal(t, time.Second, policy.Delay(0, nil))
+	assert.Equal(t, 2*time.Second, policy.Delay(1, nil))
+	assert.Equal(t, 4*time.Second, policy.Delay(2, nil))
+	assert.Equal(t, 5*time.Second, policy.Delay(3, nil), "delay is capped")
+	assert.Equal(t, 7*time.Second, policy.Delay(0, &neurotypes.LLMError{RetryAfter: 7 * time.Second}), "Retry-After is honored")
+	assert.Equal(t, retryAfterLimit, policy.Delay(0, &neurotypes.LLMError{RetryAfter: time.Hour}))
+
+	policy.Jitter = true
+	for i := 0; i < 20; i++ {
+		delay := policy.Delay(2, nil)
+		assert.GreaterOrEqual(t, delay, 2*time.Second)
+		assert.LessOrEqual(t, delay, 4*time.Second)
+	}
+}
+
+func TestResolveRetryPolicy(t *testing.T) {
+	variableService, _ := setupRetryTestRegistry(t)
+
+	policy, err := ResolveRetryPolicy(nil)
+	require.NoError(t, err)
+	assert.Equal(t, defaultMaxRetries, policy.MaxRetries)
+
+	require.NoError(t, variableService.Set(RetryVariable, "4"))
+	policy, err = ResolveRetryPolicy(&neurotypes.ModelConfig{Name: "m"})
+	require.NoError(t, err)
+	assert.Equal(t, 4, policy.MaxRetries)
+
+	// The model parameter wins over the variable, whether stored as int or loaded from JSON
+	for _, value := range []any{1, float64(1), "1"} {
+		policy, err = ResolveRetryPolicy(&neurotypes.ModelConfig{Name: "m", Parameters: map[string]any{MaxRetriesParameter: value}})
+		require.NoError(t, err)
+		assert.Equal(t, 1, policy.MaxRetries)
+	}
+
+	require.NoError(t, variableService.Set(RetryVariable, "lots"))
+	_, err = ResolveRetryPolicy(nil)
+	assert.Error(t, err)
+}
+
+func TestLLMService_RetriesTransientErrors(t *testing.T) {
+	_, delays := setupRetryTestRegistry(t)
+
+	service := NewLLMService()
+	require.NoError(t, service.Initialize())
+
+	client := &FlakyLLMClient{
+		MockLLMClient: *NewMockLLMClient(),
+		failures: []*neurotypes.LLMError{
+			{Code: "rate_limit_exceeded", Type: "rate_limit", RetryAfter: 3 * time.Second},
+			{Code: "server_error_529", Type: "server_error"},
+		},
+	}
+	session := &neurotypes.ChatSession{Messages: []neurotypes.Message{{Role: "user", Content: "Hello"}}}
+
+	response := service.SendStructuredCompletion(client, session, &neurotypes.ModelConfig{BaseModel: "test"})
+	require.NotNil(t, response)
+	assert.Nil(t, response.Error)
+	assert.Equal(t, 3, client.calls)
+	require.Len(t, *delays, 2)
+	assert.Equal(t, 3*time.Second, (*delays)[0])
+
+	retries, waited := RetryStatsFromMetadata(response.Metadata)
+	assert.Equal(t, 2, retries)
+	assert.InDelta(t, float64(3*time.Second+(*delays)[1]), float64(waited), float64(time.Millisecond))
+}
+
+func TestLLMService_RetryLimitAndNonRetryableErrors(t *testing.T) {
+	variableService, _ := setupRetryTestRegistry(t)
+	require.NoError(t, variableService.Set(RetryVariable, "1"))
+
+	service := NewLLMService()
+	require.NoError(t, service.Initialize())
+	session := &neurotypes.ChatSession{Messages: []neurotypes.Message{{Role: "user", Content: "Hello"}}}
+	model := &neurotypes.ModelConfig{BaseModel: "test"}
+
+	serverError := &neurotypes.LLMError{Code: "server_error_503", Type: "server_error"}
+	client := &FlakyLLMClient{MockLLMClient: *NewMockLLMClient(), failures: []*neurotypes.LLMError{serverError, serverError, serverError}}
+	response := service.SendStructuredCompletion(client, session, model)
+	assert.Equal(t, serverError, response.Error)
+	assert.Equal(t, 2, client.calls, "one attempt plus one retry")
+
+	apiError := &neurotypes.LLMError{Code: "api_request_failed", Type: "api_error"}
+	client = &FlakyLLMClient{MockLLMClient: *NewMockLLMClient(), failures: []*neurotypes.LLMError{apiError}}
+	response = service.SendStructuredCompletion(client, session, model)
+	assert.Equal(t, apiError, response.Error)
+	assert.Equal(t, 1, client.calls, "non-transient errors are not retried")
+}
+
+// PartialStreamingLLMClient streams a delta and then fails with a transient error.
+type PartialStreamingLLMClient struct {
+	MockLLMClient
+	calls int
+}
+
+func (p *PartialStreamingLLMClient) StreamStructuredCompletion(_ *neurotypes.ChatSession, _ *neurotypes.ModelConfig, onChunk neurotypes.StreamHandler) *neurotypes.StructuredLLMResponse {
+	p.calls++
+	onChunk(neurotypes.StreamChunk{Type: "text", Content: "partial", Provider: "mock"})
+	return &neurotypes.StructuredLLMResponse{TextContent: "partial", Error: &neurotypes.LLMError{Type: "server_error"}}
+}
+
+func TestLLMService_StreamDoesNotRetryAfterOutput(t *testing.T) {
+	setupRetryTestRegistry(t)
+
+	service := NewLLMService()
+	require.NoError(t, service.Initialize())
+
+	client := &PartialStreamingLLMClient{MockLLMClient: *NewMockLLMClient()}
+	session := &neurotypes.ChatSession{Messages: []neurotypes.Message{{Role: "user", Content: "Hello"}}}
+
+	var chunks int
+	response := service.StreamStructuredCompletion(client, session, &neurotypes.ModelConfig{BaseModel: "test"}, func(neurotypes.StreamChunk) { chunks++ })
+	require.NotNil(t, response.Error)
+	assert.Equal(t, 1, client.calls)
+	assert.Equal(t, 1, chunks)
+}
+
+func TestNewProviderLLMError(t *testing.T) {
+	header := http.Header{}
+	header.Set("Retry-After", "12")
+	rateLimited := &anthropic.Error{StatusCode: http.StatusTooManyRequests, Response: &http.Response{StatusCode: http.StatusTooManyRequests, Header: header}}
+	llmErr := newProviderLLMError("anthropic request failed", fmt.Errorf("wrapped: %w", rateLimited))
+	assert.Equal(t, "rate_limit", llmErr.Type)
+	assert.Equal(t, "rate_limit_exceeded", llmErr.Code)
+	assert.Equal(t, 12*time.Second, llmErr.RetryAfter)
+	assert.Equal(t, "anthropic request failed", llmErr.Message)
+
+	overloaded := &anthropic.Error{StatusCode: 529, Response: &http.Response{StatusCode: 529}}
+	llmErr = newProviderLLMError("overloaded", overloaded)
+	assert.Equal(t, "server_error", llmErr.Type)
+	assert.Equal(t, "server_error_529", llmErr.Code)
+	assert.Zero(t, llmErr.RetryAfter)
+
+	badRequest := &openai.Error{StatusCode: http.StatusBadRequest, Response: &http.Response{StatusCode: http.StatusBadRequest}}
+	assert.Equal(t, "api_error", newProviderLLMError("bad", badRequest).Type)
+
+	assert.Equal(t, "server_error", newProviderLLMError("gemini", genai.APIError{Code: 503}).Type)
+	assert.Equal(t, "api_error", newProviderLLMError("network", fmt.Errorf("dial tcp: no such host")).Type)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 5*time.Second, parseRetryAfter("5", now))
+	assert.Equal(t, 1500*time.Millisecond, parseRetryAfter("1.5", now))
+	assert.Equal(t, 30*time.Second, parseRetryAfter(now.Add(30*time.Second).Format(http.TimeFormat), now))
+	assert.Zero(t, parseRetryAfter("", now))
+	assert.Zero(t, parseRetryAfter("soon", now))
+	assert.Zero(t, parseRetryAfter(now.Add(-time.Minute).Format(http.TimeFormat), now))
+}
@@ -0,0 +1,105 @@
+// Package services provides help parsing for script commands.
+package services
+
+import (
+	"strings"
+
+	"neuroshell/pkg/neurotypes"
+)
+
+// ParseScriptHelp builds help information from the header of a script: the %% comments before
+// its first command. "Description:", "Usage:", "Example:", "Option:" and "Note:" comments fill the
+// matching fields, as in the stdlib scripts. Without a "Description:" comment the first other
+// comment is the description, and any further comments become notes. Usage defaults to \name.
+//
+// Options are written as "name - description (type, default: value)" or "(type, required)";
+// examples as "command - description".
+func ParseScriptHelp(name string, lines []string) neurotypes.HelpInfo {
+	help := neurotypes.HelpInfo{
+		Command:   name,
+		ParseMode: neurotypes.ParseModeKeyValue,
+	}
+
+	var plain []string
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if !strings.HasPrefix(trimmed, "%%") {
+			break
+		}
+		text := strings.TrimSpace(strings.TrimPrefix(trimmed, "%%"))
+
+		switch {
+		case text == "":
+			continue
+		case strings.HasPrefix(text, "Description:") && help.Description == "":
+			help.Description = strings.TrimSpace(strings.TrimPrefix(text, "Description:"))
+		case strings.HasPrefix(text, "Usage:") && help.Usage == "":
+			help.Usage = strings.TrimSpace(strings.TrimPrefix(text, "Usage:"))
+		case strings.HasPrefix(text, "Example:"):
+			help.Examples = append(help.Examples, parseScriptHelpExample(strings.TrimPrefix(text, "Example:")))
+		case strings.HasPrefix(text, "Option:"):
+			help.Options = append(help.Options, parseScriptHelpOption(strings.TrimPrefix(text, "Option:")))
+		case strings.HasPrefix(text, "Note:"):
+			help.Notes = append(help.Notes, strings.TrimSpace(strings.TrimPrefix(text, "Note:")))
+		default:
+			plain = append(plain, text)
+		}
+	}
+
+	if help.Description == "" && len(plain) > 0 {
+		help.Description = plain[0]
+		plain = plain[1:]
+	}
+	help.Notes = append(help.Notes, plain...)
+	if help.Usage == "" {
+		help.Usage = "\\" + name
+	}
+	return help
+}
+
+// parseScriptHelpExample parses "command - description".
+func parseScriptHelpExample(text string) neurotypes.HelpExample {
+	text = strings.TrimSpace(text)
+	if idx := strings.LastIndex(text, " - "); idx != -1 {
+		return neurotypes.HelpExample{
+			Command:     strings.TrimSpace(text[:idx]),
+			Description: strings.TrimSpace(text[idx+3:]),
+		}
+	}
+	return neurotypes.HelpExample{Command: text}
+}
+
+// parseScriptHelpOption parses "name - description (type, default: value)".
+func parseScriptHelpOption(text string) neurotypes.HelpOption {
+	text = strings.TrimSpace(text)
+	name, description, _ := strings.Cut(text, " - ")
+	option := neurotypes.HelpOption{
+		Name:        strings.TrimSpace(name),
+		Description: strings.TrimSpace(description),
+		Type:        "string",
+	}
+
+	// Optional trailing "(type, default: value)" or "(type, required)"
+	if open := strings.LastIndex(option.Description, "("); open != -1 && strings.HasSuffix(option.Description, ")") {
+		details := option.Description[open+1 : len(option.Description)-1]
+		option.Description = strings.TrimSpace(option.Description[:open])
+		for i, part := range strings.Split(details, ",") {
+			part = strings.TrimSpace(part)
+			switch {
+			case part == "required":
+				option.Required = true
+			case strings.HasPrefix(part, "default:"):
+				option.Default = strings.TrimSpace(strings.TrimPrefix(part, "default:"))
+			case i == 0 && part != "":
+				option.Type = part
+			}
+		}
+	}
+	if option.Default == "none" {
+		option.Default = ""
+	}
+	return option
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/pkg/neurotypes"
+)
+
+func TestParseScriptHelp(t *testing.T) {
+	tests := []struct {
+		name        string
+		lines       []string
+		description string
+		usage       string
+		notes       []string
+	}{
+		{
+			name:        "no comments",
+			lines:       []string{"\\echo hi"},
+			description: "",
+			usage:       "\\cmd",
+		},
+		{
+			name:        "first comment is the description",
+			lines:       []string{"%% Say hello", "%%", "%% Usage: \\cmd[name=who]", "%% Sets ${greeting}", "\\echo hi", "%% Not help"},
+			description: "Say hello",
+			usage:       "\\cmd[name=who]",
+			notes:       []string{"Sets ${greeting}"},
+		},
+		{
+			name:        "explicit description after a plain comment",
+			lines:       []string{"", "%% Reviews things", "%% Description: Explicit", "%% Note: Read only"},
+			description: "Explicit",
+			usage:       "\\cmd",
+			notes:       []string{"Read only", "Reviews things"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			help := ParseScriptHelp("cmd", tt.lines)
+			assert.Equal(t, "cmd", help.Command)
+			assert.Equal(t, neurotypes.ParseModeKeyValue, help.ParseMode)
+			assert.Equal(t, tt.description, help.Description)
+			assert.Equal(t, tt.usage, help.Usage)
+			assert.Equal(t, tt.notes, help.Notes)
+		})
+	}
+}
+
+func TestParseScriptHelp_ExamplesAndOptions(t *testing.T) {
+	help := ParseScriptHelp("enhanced-echo", []string{
+		"%% Description: Enhanced echo command with additional formatting options",
+		"%% Usage: \\enhanced-echo [style=color] [prefix=text] <message>",
+		"%% Example: \\enhanced-echo[style=blue,prefix=INFO] System ready - shows colored prefixed message",
+		"%% Example: \\enhanced-echo plain",
+		"%% Option: style - Text color (string, default: none)",
+		"%% Option: count - How many times (int, default: 1)",
+		"%% Option: target - File to write (string, required)",
+		"%% Option: raw",
+	})
+
+	assert.Equal(t, "Enhanced echo command with additional formatting options", help.Description)
+	require.Len(t, help.Examples, 2)
+	assert.Equal(t, neurotypes.HelpExample{Command: "\\enhanced-echo[style=blue,prefix=INFO] System ready", Description: "shows colored prefixed message"}, help.Examples[0])
+	assert.Equal(t, neurotypes.HelpExample{Command: "\\enhanced-echo plain"}, help.Examples[1])
+
+	assert.Equal(t, []neurotypes.HelpOption{
+		{Name: "style", Description: "Text color", Type: "string"},
+		{Name: "count", Description: "How many times", Type: "int", Default: "1"},
+		{Name: "target", Description: "File to write", Type: "string", Required: true},
+		{Name: "raw", Type: "string"},
+	}, help.Options)
+}
@@ -0,0 +1,215 @@
+// Package services provides the search path for script commands invoked by bare name.
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	neuroshellcontext "neuroshell/internal/context"
+	"neuroshell/internal/data/embedded"
+	"neuroshell/internal/logger"
+	"neuroshell/pkg/neurotypes"
+)
+
+// ScriptLibraryDir is the name of the script command directory, both in the user config directory
+// and in the project directory.
+const ScriptLibraryDir = "lib"
+
+// NeuroPathEnv names the environment variable listing extra script command directories, separated
+// like PATH. They are searched before the user and project directories.
+const NeuroPathEnv = "NEURO_PATH"
+
+// ScriptLibrarySourceEnv is the source of directories listed in NEURO_PATH; the user and project
+// directories use neurotypes.CatalogSourceUser and CatalogSourceProject.
+const ScriptLibrarySourceEnv = "NEURO_PATH"
+
+// ScriptLibraryEntry is a directory of the script command search path.
+type ScriptLibraryEntry struct {
+	Path   string // Directory searched for <name>.neuro
+	Source string // NEURO_PATH, user or project
+}
+
+// ScriptLibraryService finds script commands invoked by bare name, so \review-pr runs
+// review-pr.neuro from the first directory of the search path that has it. The search path is
+// $NEURO_PATH, then ~/.config/neuroshell/lib, then ./.neuro/lib; user scripts come before project
+// scripts so a cloned repository cannot replace a user's commands.
+type ScriptLibraryService struct {
+	initialized bool
+	registered  map[string]bool // Commands whose help was registered from a library script
+	mutex       sync.Mutex
+}
+
+// NewScriptLibraryService creates a new ScriptLibraryService instance.
+func NewScriptLibraryService() *ScriptLibraryService {
+	return &ScriptLibraryService{
+		initialized: false,
+		registered:  make(map[string]bool),
+	}
+}
+
+// Name returns the service name "script_library" for registration.
+func (s *ScriptLibraryService) Name() string {
+	return "script_library"
+}
+
+// Initialize registers help for the scripts found in the search path, so they show in \help and
+// autocomplete. Scripts added later are still found, and registered when first run.
+func (s *ScriptLibraryService) Initialize() error {
+	s.initialized = true
+
+	for _, name := range s.List() {
+		path, found := s.Find(name)
+		if !found {
+			continue
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			logger.Debug("Skipping unreadable library script", "path", path, "error", err)
+			continue
+		}
+		s.RegisterHelp(name, path, string(content))
+	}
+
+	logger.Debug("ScriptLibraryService initialized")
+	return nil
+}
+
+// SearchPath returns the directories searched for script commands, in order.
+// Directories that do not exist are included; they are skipped when searching.
+func (s *ScriptLibraryService) SearchPath() []ScriptLibraryEntry {
+	ctx := neuroshellcontext.GetGlobalContext()
+	if ctx == nil {
+		return nil
+	}
+
+	var entries []ScriptLibraryEntry
+	seen := make(map[string]bool)
+	add := func(path, source string) {
+		if path == "" {
+			return
+		}
+		path = filepath.Clean(path)
+		if seen[path] {
+			return
+		}
+		seen[path] = true
+		entries = append(entries, ScriptLibraryEntry{Path: path, Source: source})
+	}
+
+	for _, dir := range filepath.SplitList(ctx.GetEnv(NeuroPathEnv)) {
+		add(strings.TrimSpace(dir), ScriptLibrarySourceEnv)
+	}
+	if configDir, err := ctx.GetUserConfigDir(); err == nil {
+		add(filepath.Join(configDir, ScriptLibraryDir), neurotypes.CatalogSourceUser)
+	}
+	if workDir, err := ctx.GetWorkingDir(); err == nil {
+		add(filepath.Join(workDir, ProjectConfigDir, ScriptLibraryDir), neurotypes.CatalogSourceProject)
+	}
+	return entries
+}
+
+// Find returns the path of the script for a command name from the first directory that has it.
+func (s *ScriptLibraryService) Find(name string) (string, bool) {
+	paths := s.FindAll(name)
+	if len(paths) == 0 {
+		return "", false
+	}
+	return paths[0], true
+}
+
+// FindAll returns the paths of every script for a command name in search path order.
+// Only the first one runs; the others are shadowed by it.
+func (s *ScriptLibraryService) FindAll(name string) []string {
+	if !definitionNamePattern.MatchString(name) {
+		return nil
+	}
+
+	var paths []string
+	for _, entry := range s.SearchPath() {
+		path := filepath.Join(entry.Path, name+".neuro")
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// List returns the sorted names of all scripts in the search path.
+// Scripts whose names start with '_' or are not valid command names are left out.
+func (s *ScriptLibraryService) List() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, entry := range s.SearchPath() {
+		files, err := os.ReadDir(entry.Path)
+		if err != nil {
+			continue
+		}
+		for _, file := range files {
+			name, isScript := strings.CutSuffix(file.Name(), ".neuro")
+			if file.IsDir() || !isScript || seen[name] || !definitionNamePattern.MatchString(name) {
+				continue
+			}
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RegisterHelp registers help parsed from a library script's header for \help and autocomplete,
+// unless a builtin, stdlib or \def command of the same name takes precedence over the script.
+func (s *ScriptLibraryService) RegisterHelp(name, path, content string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	neuroCtx, ok := neuroshellcontext.GetGlobalContext().(*neuroshellcontext.NeuroContext)
+	if !ok {
+		return
+	}
+	if neuroCtx.IsCommandRegistered(name) && !s.registered[name] {
+		return
+	}
+	if embedded.NewStdlibLoader().ScriptExists(name) {
+		return
+	}
+
+	help := ParseScriptHelp(name, strings.Split(content, "\n"))
+	if help.Description == "" {
+		help.Description = "Script command"
+	}
+	help.Notes = append(help.Notes, fmt.Sprintf("Runs %s", path))
+	neuroCtx.RegisterCommandHelp(help)
+	s.registered[name] = true
+}
+
+// IsLibraryCommand reports whether the help of a command was registered from a library script.
+func (s *ScriptLibraryService) IsLibraryCommand(name string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.registered[name]
+}
+
+// GetScriptLibraryService retrieves the script library service from the global registry.
+func (r *Registry) GetScriptLibraryService() (*ScriptLibraryService, error) {
+	service, err := r.GetService("script_library")
+	if err != nil {
+		return nil, err
+	}
+
+	libraryService, ok := service.(*ScriptLibraryService)
+	if !ok {
+		return nil, fmt.Errorf("script library service has incorrect type")
+	}
+
+	return libraryService, nil
+}
+
+// GetGlobalScriptLibraryService returns the script library service from the global registry.
+func GetGlobalScriptLibraryService() (*ScriptLibraryService, error) {
+	return GetGlobalRegistry().GetScriptLibraryService()
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	neuroshellcontext "neuroshell/internal/context"
+	"neuroshell/pkg/neurotypes"
+)
+
+// setupScriptLibraryTest sets a test mode context with NEURO_PATH listing the given directories.
+func setupScriptLibraryTest(t *testing.T, dirs ...string) *neuroshellcontext.NeuroContext {
+	ctx := neuroshellcontext.New()
+	ctx.SetTestMode(true)
+	ctx.SetTestEnvOverride(NeuroPathEnv, strings.Join(dirs, string(os.PathListSeparator)))
+	neuroshellcontext.SetGlobalContext(ctx)
+	t.Cleanup(neuroshellcontext.ResetGlobalContext)
+	return ctx
+}
+
+func writeLibraryScript(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name+".neuro")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestScriptLibraryService_SearchPath(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+	ctx := setupScriptLibraryTest(t, first, second)
+
+	service := NewScriptLibraryService()
+	assert.Equal(t, "script_library", service.Name())
+
+	configDir, err := ctx.GetUserConfigDir()
+	require.NoError(t, err)
+	workDir, err := ctx.GetWorkingDir()
+	require.NoError(t, err)
+
+	assert.Equal(t, []ScriptLibraryEntry{
+		{Path: first, Source: ScriptLibrarySourceEnv},
+		{Path: second, Source: ScriptLibrarySourceEnv},
+		{Path: filepath.Join(configDir, ScriptLibraryDir), Source: neurotypes.CatalogSourceUser},
+		{Path: filepath.Join(workDir, ProjectConfigDir, ScriptLibraryDir), Source: neurotypes.CatalogSourceProject},
+	}, service.SearchPath())
+}
+
+func TestScriptLibraryService_FindAndShadowing(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+	setupScriptLibraryTest(t, first, second)
+
+	firstPath := writeLibraryScript(t, first, "review-pr", "\\echo first")
+	secondPath := writeLibraryScript(t, second, "review-pr", "\\echo second")
+	onlySecond := writeLibraryScript(t, second, "deploy", "\\echo deploy")
+	writeLibraryScript(t, second, "_helper", "\\echo hidden")
+	require.NoError(t, os.Mkdir(filepath.Join(second, "dir.neuro"), 0755))
+
+	service := NewScriptLibraryService()
+
+	path, found := service.Find("review-pr")
+	require.True(t, found)
+	assert.Equal(t, firstPath, path)
+	assert.Equal(t, []string{firstPath, secondPath}, service.FindAll("review-pr"))
+
+	path, found = service.Find("deploy")
+	require.True(t, found)
+	assert.Equal(t, onlySecond, path)
+
+	_, found = service.Find("missing")
+	assert.False(t, found)
+	_, found = service.Find("dir")
+	assert.False(t, found, "directories are not scripts")
+	assert.Empty(t, service.FindAll("../review-pr"), "names must be command names")
+	assert.Empty(t, service.FindAll("_helper"))
+
+	assert.Equal(t, []string{"deploy", "review-pr"}, service.List())
+}
+
+func TestScriptLibraryService_RegisterHelp(t *testing.T) {
+	dir := t.TempDir()
+	ctx := setupScriptLibraryTest(t, dir)
+
+	path := writeLibraryScript(t, dir, "review-pr", "%% Description: Review a pull request\n%% Usage: \\review-pr[pr=N]\n\\echo ${pr}\n")
+	writeLibraryScript(t, dir, "plain", "\\echo plain\n")
+
+	service := NewScriptLibraryService()
+	require.NoError(t, service.Initialize())
+
+	helpInfo, exists := ctx.GetCommandHelpInfo("review-pr")
+	require.True(t, exists)
+	assert.Equal(t, "Review a pull request", helpInfo.Description)
+	assert.Equal(t, "\\review-pr[pr=N]", helpInfo.Usage)
+	assert.Equal(t, []string{"Runs " + path}, helpInfo.Notes)
+	assert.True(t, service.IsLibraryCommand("review-pr"))
+
+	helpInfo, exists = ctx.GetCommandHelpInfo("plain")
+	require.True(t, exists)
+	assert.Equal(t, "Script command", helpInfo.Description)
+
+	// Help of other commands is not replaced
+	ctx.RegisterCommandHelp(neurotypes.HelpInfo{Command: "taken", Description: "Defined elsewhere"})
+	service.RegisterHelp("taken", filepath.Join(dir, "taken.neuro"), "%% Library version\n")
+	helpInfo, _ = ctx.GetCommandHelpInfo("taken")
+	assert.Equal(t, "Defined elsewhere", helpInfo.Description)
+	assert.False(t, service.IsLibraryCommand("taken"))
+
+	// Library help is refreshed when the script changes
+	service.RegisterHelp("plain", filepath.Join(dir, "plain.neuro"), "%% Now described\n\\echo plain\n")
+	helpInfo, _ = ctx.GetCommandHelpInfo("plain")
+	assert.Equal(t, "Now described", helpInfo.Description)
+}
@@ -0,0 +1,301 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	neuroshellcontext "neuroshell/internal/context"
+	"neuroshell/internal/logger"
+	"neuroshell/pkg/neurotypes"
+)
+
+// Values of the _session_autoload variable.
+const (
+	SessionAutoloadOff  = "false"
+	SessionAutoloadFull = "true"
+	SessionAutoloadLazy = "lazy"
+)
+
+// SavedSession describes a session file in the sessions directory.
+type SavedSession struct {
+	ID      string
+	Name    string
+	Path    string
+	ModTime time.Time
+}
+
+// SessionsDir returns the directory \session-save and autosave write sessions to.
+func (c *ChatSessionService) SessionsDir() (string, error) {
+	configDir, err := neuroshellcontext.GetGlobalContext().GetUserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get config directory: %w", err)
+	}
+	return filepath.Join(configDir, "sessions"), nil
+}
+
+// ParseSessionAutoload normalizes a _session_autoload value to true, lazy or false.
+func ParseSessionAutoload(value string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "false", "0", "no", "off":
+		return SessionAutoloadOff, nil
+	case "true", "1", "yes", "on":
+		return SessionAutoloadFull, nil
+	case "lazy":
+		return SessionAutoloadLazy, nil
+	default:
+		return "", fmt.Errorf("invalid session autoload mode '%s'. Valid modes: true, lazy, false", value)
+	}
+}
+
+// AutoloadSessions loads the saved sessions as configured by _session_autoload.
+// It is called once at shell startup, after .neurorc, and returns the sessions it added.
+func (c *ChatSessionService) AutoloadSessions() ([]*neurotypes.ChatSession, error) {
+	if !c.initialized {
+		return nil, fmt.Errorf("chat session service not initialized")
+	}
+
+	value, _ := neuroshellcontext.GetGlobalContext().GetVariable("_session_autoload")
+	mode, err := ParseSessionAutoload(value)
+	if err != nil {
+		return nil, err
+	}
+	if mode == SessionAutoloadOff {
+		return nil, nil
+	}
+	return c.LoadSavedSessions("", mode == SessionAutoloadLazy)
+}
+
+// ListSavedSessions returns the sessions in the sessions directory, sorted by file name.
+// Only the ID and name at the start of each file are read.
+func (c *ChatSessionService) ListSavedSessions() ([]SavedSession, error) {
+	sessionsDir, err := c.SessionsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := filepath.Glob(filepath.Join(sessionsDir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions directory: %w", err)
+	}
+	sort.Strings(paths)
+
+	saved := make([]SavedSession, 0, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		id, name, err := readSessionHeader(path)
+		if err != nil {
+			logger.Debug("Skipping unreadable saved session", "path", path, "error", err)
+			continue
+		}
+		saved = append(saved, SavedSession{ID: id, Name: name, Path: path, ModTime: info.ModTime()})
+	}
+	return saved, nil
+}
+
+// LoadSavedSessions loads saved sessions into memory, keeping their IDs so later saves update
+// the same files. With an identifier only the matching session is loaded (exact ID or name first,
+// then a unique prefix of either). With lazy only the name and ID are indexed; the rest of the
+// session is read the first time it is looked up. Sessions already in memory are skipped, and
+// names that are taken get a version suffix. No session is activated.
+func (c *ChatSessionService) LoadSavedSessions(identifier string, lazy bool) ([]*neurotypes.ChatSession, error) {
+	if !c.initialized {
+		return nil, fmt.Errorf("chat session service not initialized")
+	}
+
+	saved, err := c.ListSavedSessions()
+	if err != nil {
+		return nil, err
+	}
+	if identifier != "" {
+		match, err := matchSavedSession(saved, identifier)
+		if err != nil {
+			return nil, err
+		}
+		saved = []SavedSession{match}
+	}
+
+	ctx := neuroshellcontext.GetGlobalContext()
+	sessions := ctx.GetChatSessions()
+	nameToID := ctx.GetSessionNameToID()
+
+	var loaded []*neurotypes.ChatSession
+	for _, entry := range saved {
+		if _, exists := sessions[entry.ID]; exists {
+			continue
+		}
+
+		session := &neurotypes.ChatSession{
+			ID:           entry.ID,
+			Name:         entry.Name,
+			CreatedAt:    entry.ModTime,
+			UpdatedAt:    entry.ModTime,
+			UnloadedFrom: entry.Path,
+		}
+		if !lazy {
+			if err := loadSessionBody(session); err != nil {
+				logger.Error("Failed to load saved session", "path", entry.Path, "error", err)
+				continue
+			}
+		}
+		session.IsActive = false
+		session.Name = c.generateAvailableName(session.Name)
+
+		sessions[session.ID] = session
+		nameToID[session.Name] = session.ID
+		ctx.SetChatSessions(sessions)
+		ctx.SetSessionNameToID(nameToID)
+		loaded = append(loaded, session)
+	}
+
+	logger.Debug("Loaded saved sessions", "count", len(loaded), "lazy", lazy)
+	return loaded, nil
+}
+
+// PurgeSavedSessions deletes saved session files that were last written more than olderThan ago.
+// Sessions in memory are not affected. With dryRun nothing is deleted.
+func (c *ChatSessionService) PurgeSavedSessions(olderThan time.Duration, dryRun bool) ([]SavedSession, error) {
+	if !c.initialized {
+		return nil, fmt.Errorf("chat session service not initialized")
+	}
+
+	saved, err := c.ListSavedSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	var purged []SavedSession
+	for _, entry := range saved {
+		if entry.ModTime.After(cutoff) {
+			continue
+		}
+		if !dryRun {
+			if err := os.Remove(entry.Path); err != nil {
+				return purged, fmt.Errorf("failed to delete %s: %w", entry.Path, err)
+			}
+		}
+		purged = append(purged, entry)
+	}
+	return purged, nil
+}
+
+// matchSavedSession finds the saved session with the given ID or name, or a unique prefix of either.
+func matchSavedSession(saved []SavedSession, identifier string) (SavedSession, error) {
+	for _, entry := range saved {
+		if entry.ID == identifier || entry.Name == identifier {
+			return entry, nil
+		}
+	}
+
+	var matches []SavedSession
+	for _, entry := range saved {
+		if strings.HasPrefix(entry.ID, identifier) || strings.HasPrefix(strings.ToLower(entry.Name), strings.ToLower(identifier)) {
+			matches = append(matches, entry)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return SavedSession{}, fmt.Errorf("no saved session found for '%s'", identifier)
+	case 1:
+		return matches[0], nil
+	default:
+		names := make([]string, 0, len(matches))
+		for _, match := range matches {
+			names = append(names, match.Name)
+		}
+		return SavedSession{}, fmt.Errorf("multiple saved sessions match '%s': %s", identifier, strings.Join(names, ", "))
+	}
+}
+
+// readSessionHeader reads the ID and name of a saved session without decoding its messages.
+// Both are written before the messages, so only the start of the file is read.
+func readSessionHeader(path string) (string, string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", "", err
+	}
+	defer func() { _ = file.Close() }()
+
+	decoder := json.NewDecoder(file)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return "", "", fmt.Errorf("not a session file")
+	}
+
+	var id, name string
+	for decoder.More() && (id == "" || name == "") {
+		key, err := decoder.Token()
+		if err != nil {
+			return "", "", err
+		}
+		switch key {
+		case "id":
+			err = decoder.Decode(&id)
+		case "name":
+			err = decoder.Decode(&name)
+		default:
+			var skipped json.RawMessage
+			err = decoder.Decode(&skipped)
+		}
+		if err != nil {
+			return "", "", err
+		}
+	}
+	if id == "" {
+		return "", "", fmt.Errorf("session file has no id")
+	}
+	return id, name, nil
+}
+
+// loadSessionBody reads a lazily loaded session from its file. The in-memory name and active
+// state are kept, since the name may have been versioned when the session was indexed.
+func loadSessionBody(session *neurotypes.ChatSession) error {
+	if session.UnloadedFrom == "" {
+		return nil
+	}
+
+	stored, err := readSessionFile(session.UnloadedFrom)
+	if err != nil {
+		return err
+	}
+	if stored.ID != session.ID {
+		return fmt.Errorf("saved session %s has ID %s, expected %s", session.UnloadedFrom, stored.ID, session.ID)
+	}
+
+	stored.Name = session.Name
+	stored.IsActive = session.IsActive
+	*session = *stored
+	return nil
+}
+
+// readSessionFile reads a session written by \session-save.
+func readSessionFile(path string) (*neurotypes.ChatSession, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read saved session: %w", err)
+	}
+	var stored neurotypes.ChatSession
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("failed to parse saved session %s: %w", path, err)
+	}
+	return &stored, nil
+}
+
+// ensureLoaded reads the body of a lazily loaded session before it is used.
+func (c *ChatSessionService) ensureLoaded(session *neurotypes.ChatSession) (*neurotypes.ChatSession, error) {
+	if session.UnloadedFrom == "" {
+		return session, nil
+	}
+	if err := loadSessionBody(session); err != nil {
+		return nil, fmt.Errorf("failed to load session '%s': %w", session.Name, err)
+	}
+	logger.Debug("Loaded lazily indexed session", "session_id", session.ID, "session_name", session.Name)
+	return session, nil
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	neuroshellcontext "neuroshell/internal/context"
+	"neuroshell/internal/testutils"
+	"neuroshell/pkg/neurotypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSessionAutoload(t *testing.T) {
+	for value, expected := range map[string]string{"": "false", "off": "false", "TRUE": "true", "1": "true", " lazy ": "lazy"} {
+		mode, err := ParseSessionAutoload(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, expected, mode, value)
+	}
+	_, err := ParseSessionAutoload("sometimes")
+	assert.ErrorContains(t, err, "invalid session autoload mode")
+}
+
+func TestReadSessionHeader(t *testing.T) {
+	data, err := json.MarshalIndent(&neurotypes.ChatSession{
+		ID:       "550e8400-e29b-41d4-a716-446655440000",
+		Name:     "work",
+		Messages: []neurotypes.Message{{ID: "m1", Role: "user", Content: "Hello"}},
+	}, "", "  ")
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "session.json")
+	require.NoError(t, os.WriteFile(path, data, 0644))
+
+	id, name, err := readSessionHeader(path)
+	require.NoError(t, err)
+	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", id)
+	assert.Equal(t, "work", name)
+
+	require.NoError(t, os.WriteFile(path, []byte(`[{"id": "x"}]`), 0644))
+	_, _, err = readSessionHeader(path)
+	assert.Error(t, err)
+	require.NoError(t, os.WriteFile(path, []byte(`{"name": "no id"}`), 0644))
+	_, _, err = readSessionHeader(path)
+	assert.ErrorContains(t, err, "no id")
+}
+
+func TestMatchSavedSession(t *testing.T) {
+	saved := []SavedSession{
+		{ID: "aaaa1111", Name: "work"},
+		{ID: "bbbb2222", Name: "workshop"},
+		{ID: "cccc3333", Name: "notes"},
+	}
+
+	match, err := matchSavedSession(saved, "work")
+	require.NoError(t, err)
+	assert.Equal(t, "aaaa1111", match.ID, "exact names win over prefixes")
+	match, err = matchSavedSession(saved, "bbbb")
+	require.NoError(t, err)
+	assert.Equal(t, "workshop", match.Name)
+	match, err = matchSavedSession(saved, "NOT")
+	require.NoError(t, err)
+	assert.Equal(t, "notes", match.Name)
+
+	_, err = matchSavedSession(saved, "wor")
+	assert.ErrorContains(t, err, "multiple saved sessions match")
+	_, err = matchSavedSession(saved, "zzz")
+	assert.ErrorContains(t, err, "no saved session found")
+}
+
+func TestChatSessionService_LazySessionLoadedOnLookup(t *testing.T) {
+	testutils.ResetTestCounters()
+	ctx := neuroshellcontext.NewTestContext()
+
+	service := NewChatSessionService()
+	require.NoError(t, service.Initialize())
+
+	stored := &neurotypes.ChatSession{
+		ID:           "550e8400-e29b-41d4-a716-446655440000",
+		Name:         "work",
+		SystemPrompt: "Be brief",
+		Messages:     []neurotypes.Message{{ID: "m1", Role: "user", Content: "Hello"}},
+	}
+	data, err := json.Marshal(stored)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), stored.ID+".json")
+	require.NoError(t, os.WriteFile(path, data, 0644))
+
+	// A lazily indexed session whose name was versioned because "work" was taken
+	stub := &neurotypes.ChatSession{ID: stored.ID, Name: "work:v1", UnloadedFrom: path}
+	sessions := ctx.GetChatSessions()
+	sessions[stub.ID] = stub
+	ctx.SetChatSessions(sessions)
+	nameToID := ctx.GetSessionNameToID()
+	nameToID[stub.Name] = stub.ID
+	ctx.SetSessionNameToID(nameToID)
+
+	session, err := service.FindSessionByPrefixWithContext("work:", ctx)
+	require.NoError(t, err)
+	assert.Same(t, stub, session, "the session is loaded in place")
+	assert.Empty(t, session.UnloadedFrom)
+	assert.Equal(t, "work:v1", session.Name)
+	assert.Equal(t, "Be brief", session.SystemPrompt)
+	require.Len(t, session.Messages, 1)
+
+	// A file that no longer matches the index is reported instead of replacing the session
+	require.NoError(t, os.WriteFile(path, []byte(`{"id": "other"}`), 0644))
+	stub.UnloadedFrom = path
+	_, err = service.GetSessionWithContext(stub.ID, ctx)
+	assert.ErrorContains(t, err, "expected "+stub.ID)
+}
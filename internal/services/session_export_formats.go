@@ -0,0 +1,291 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"neuroshell/pkg/neurotypes"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Fine-tuning record shapes supported by ExportSessionToJSONL.
+const (
+	FinetuneShapeOpenAI    = "openai"
+	FinetuneShapeAnthropic = "anthropic"
+)
+
+// ExportSessionToMarkdown exports a session by ID to a readable Markdown transcript.
+// Thinking blocks are written as collapsible sections.
+func (c *ChatSessionService) ExportSessionToMarkdown(sessionID, filePath string, includeSystem bool) error {
+	session, err := c.sessionForExport(sessionID)
+	if err != nil {
+		return err
+	}
+	return writeExportFile(filePath, []byte(FormatSessionMarkdown(session, includeSystem)), false)
+}
+
+// ExportSessionToHTML exports a session by ID to a standalone HTML page styled with the theme's colors.
+func (c *ChatSessionService) ExportSessionToHTML(sessionID, filePath string, includeSystem bool, theme *Theme) error {
+	session, err := c.sessionForExport(sessionID)
+	if err != nil {
+		return err
+	}
+	return writeExportFile(filePath, []byte(FormatSessionHTML(session, includeSystem, theme)), false)
+}
+
+// ExportSessionToJSONL writes a session by ID as one fine-tuning record in the given shape.
+// With appendRecord the record is added to the end of an existing file, so curated sessions
+// can be collected into a single training set.
+func (c *ChatSessionService) ExportSessionToJSONL(sessionID, filePath, shape string, includeSystem, appendRecord bool) error {
+	session, err := c.sessionForExport(sessionID)
+	if err != nil {
+		return err
+	}
+	record, err := FormatFinetuneRecord(session, shape, includeSystem)
+	if err != nil {
+		return err
+	}
+	return writeExportFile(filePath, append(record, '\n'), appendRecord)
+}
+
+// sessionForExport returns the session to export, checking that the service is ready.
+func (c *ChatSessionService) sessionForExport(sessionID string) (*neurotypes.ChatSession, error) {
+	if !c.initialized {
+		return nil, fmt.Errorf("chat session service not initialized")
+	}
+	session, err := c.GetSession(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	return session, nil
+}
+
+// writeExportFile writes export data, creating parent directories as needed.
+func writeExportFile(filePath string, data []byte, appendData bool) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create parent directory: %w", err)
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if appendData {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	file, err := os.OpenFile(filePath, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open export file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	if _, err := file.Write(data); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	return nil
+}
+
+// FormatSessionMarkdown renders the active path of a session as a Markdown transcript.
+func FormatSessionMarkdown(session *neurotypes.ChatSession, includeSystem bool) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", session.Name)
+	fmt.Fprintf(&sb, "- Session ID: %s\n", session.ID)
+	fmt.Fprintf(&sb, "- Created: %s\n", session.CreatedAt.Format("2006-01-02 15:04:05"))
+	if session.ActiveBranch != "" {
+		fmt.Fprintf(&sb, "- Branch: %s\n", session.ActiveBranch)
+	}
+	fmt.Fprintf(&sb, "- Messages: %d\n", len(session.Messages))
+
+	if includeSystem && session.SystemPrompt != "" {
+		fmt.Fprintf(&sb, "\n## System\n\n%s\n", session.SystemPrompt)
+	}
+
+	for _, msg := range session.Messages {
+		fmt.Fprintf(&sb, "\n## %s\n\n", exportRoleTitle(msg))
+		for _, block := range exportThinkingBlocks(msg) {
+			fmt.Fprintf(&sb, "<details>\n<summary>Thinking</summary>\n\n%s\n\n</details>\n\n", block.Content)
+		}
+		for _, call := range msg.ToolCalls {
+			fmt.Fprintf(&sb, "Tool call `%s`: `%s`\n\n", call.Name, call.Arguments)
+		}
+		if msg.Content != "" {
+			fmt.Fprintf(&sb, "%s\n", msg.Content)
+		}
+	}
+	return sb.String()
+}
+
+// FormatSessionHTML renders the active path of a session as a standalone HTML page.
+// Role colors come from the theme; themes without colors fall back to neutral defaults.
+func FormatSessionHTML(session *neurotypes.ChatSession, includeSystem bool, theme *Theme) string {
+	if theme == nil {
+		theme = &Theme{}
+	}
+	colors := map[string]string{
+		"system":    htmlColor(theme.Warning.GetForeground(), "#9a6700"),
+		"user":      htmlColor(theme.Keyword.GetForeground(), "#0969da"),
+		"assistant": htmlColor(theme.Success.GetForeground(), "#1f883d"),
+		"tool":      htmlColor(theme.Variable.GetForeground(), "#6f42c1"),
+		"thinking":  htmlColor(theme.Info.GetForeground(), "#57606a"),
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&sb, "<title>%s</title>\n", html.EscapeString(session.Name))
+	sb.WriteString("<style>\n")
+	sb.WriteString("body { font-family: -apple-system, \"Segoe UI\", Helvetica, Arial, sans-serif; max-width: 52rem; margin: 2rem auto; padding: 0 1rem; color: #1f2328; }\n")
+	sb.WriteString(".message { border-left: 4px solid; padding: 0.25rem 1rem; margin: 1rem 0; }\n")
+	sb.WriteString(".role { font-weight: bold; margin: 0.5rem 0; }\n")
+	sb.WriteString(".content { white-space: pre-wrap; }\n")
+	sb.WriteString(".meta { color: #57606a; font-size: 0.9rem; }\n")
+	for _, role := range []string{"system", "user", "assistant", "tool"} {
+		fmt.Fprintf(&sb, ".%s { border-color: %s; } .%s .role { color: %s; }\n", role, colors[role], role, colors[role])
+	}
+	fmt.Fprintf(&sb, "details { color: %s; margin: 0.5rem 0; } details .content { font-style: italic; }\n", colors["thinking"])
+	sb.WriteString("</style>\n</head>\n<body>\n")
+
+	fmt.Fprintf(&sb, "<h1>%s</h1>\n", html.EscapeString(session.Name))
+	meta := fmt.Sprintf("Session %s, created %s, %d messages", session.ID, session.CreatedAt.Format("2006-01-02 15:04:05"), len(session.Messages))
+	if session.ActiveBranch != "" {
+		meta += fmt.Sprintf(", branch %s", session.ActiveBranch)
+	}
+	fmt.Fprintf(&sb, "<p class=\"meta\">%s</p>\n", html.EscapeString(meta))
+
+	if includeSystem && session.SystemPrompt != "" {
+		fmt.Fprintf(&sb, "<div class=\"message system\">\n<div class=\"role\">System</div>\n<div class=\"content\">%s</div>\n</div>\n",
+			html.EscapeString(session.SystemPrompt))
+	}
+
+	for _, msg := range session.Messages {
+		role := msg.Role
+		switch role {
+		case "system", "user", "assistant", "tool":
+		default:
+			role = "user"
+		}
+		fmt.Fprintf(&sb, "<div class=\"message %s\">\n<div class=\"role\">%s</div>\n", role, html.EscapeString(exportRoleTitle(msg)))
+		for _, block := range exportThinkingBlocks(msg) {
+			fmt.Fprintf(&sb, "<details>\n<summary>Thinking</summary>\n<div class=\"content\">%s</div>\n</details>\n", html.EscapeString(block.Content))
+		}
+		for _, call := range msg.ToolCalls {
+			fmt.Fprintf(&sb, "<div class=\"meta\">Tool call <code>%s</code>: <code>%s</code></div>\n",
+				html.EscapeString(call.Name), html.EscapeString(call.Arguments))
+		}
+		if msg.Content != "" {
+			fmt.Fprintf(&sb, "<div class=\"content\">%s</div>\n", html.EscapeString(msg.Content))
+		}
+		sb.WriteString("</div>\n")
+	}
+
+	sb.WriteString("</body>\n</html>\n")
+	return sb.String()
+}
+
+// finetuneMessage is one chat turn of a fine-tuning record.
+type finetuneMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// openAIFinetuneRecord is the chat fine-tuning record shape used by OpenAI.
+type openAIFinetuneRecord struct {
+	Messages []finetuneMessage `json:"messages"`
+}
+
+// anthropicFinetuneRecord is the fine-tuning record shape used for Claude models, with the system prompt kept separate.
+type anthropicFinetuneRecord struct {
+	System   string            `json:"system,omitempty"`
+	Messages []finetuneMessage `json:"messages"`
+}
+
+// FormatFinetuneRecord renders the user and assistant turns of a session as a single-line
+// fine-tuning record in the openai or anthropic shape. Tool turns and thinking are left out.
+func FormatFinetuneRecord(session *neurotypes.ChatSession, shape string, includeSystem bool) ([]byte, error) {
+	var turns []finetuneMessage
+	hasAssistant := false
+	for _, msg := range session.Messages {
+		if msg.Role != "user" && msg.Role != "assistant" {
+			continue
+		}
+		if msg.Role == "assistant" {
+			if len(msg.ToolCalls) > 0 {
+				continue
+			}
+			hasAssistant = true
+		}
+		turns = append(turns, finetuneMessage{Role: msg.Role, Content: msg.Content})
+	}
+	if !hasAssistant {
+		return nil, fmt.Errorf("session '%s' has no assistant messages to train on", session.Name)
+	}
+
+	system := ""
+	if includeSystem {
+		system = session.SystemPrompt
+	}
+
+	var record any
+	switch shape {
+	case "", FinetuneShapeOpenAI:
+		messages := turns
+		if system != "" {
+			messages = append([]finetuneMessage{{Role: "system", Content: system}}, turns...)
+		}
+		record = openAIFinetuneRecord{Messages: messages}
+	case FinetuneShapeAnthropic:
+		if turns[0].Role != "user" {
+			return nil, fmt.Errorf("session '%s' must start with a user message for the anthropic shape", session.Name)
+		}
+		record = anthropicFinetuneRecord{System: system, Messages: turns}
+	default:
+		return nil, fmt.Errorf("unsupported fine-tuning shape '%s'. Supported shapes: %s, %s", shape, FinetuneShapeOpenAI, FinetuneShapeAnthropic)
+	}
+
+	// Training text is kept verbatim, so <, > and & are not escaped
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(record); err != nil {
+		return nil, fmt.Errorf("failed to marshal fine-tuning record: %w", err)
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// exportRoleTitle returns the heading for a message, naming the model for assistant messages.
+func exportRoleTitle(msg neurotypes.Message) string {
+	title := msg.Role
+	if title != "" {
+		title = strings.ToUpper(title[:1]) + title[1:]
+	}
+	if msg.Role == "assistant" && msg.Metadata != nil && msg.Metadata.Model != "" {
+		title += fmt.Sprintf(" (%s)", msg.Metadata.Model)
+	}
+	return title
+}
+
+// exportThinkingBlocks returns the thinking blocks stored with a message.
+func exportThinkingBlocks(msg neurotypes.Message) []neurotypes.ThinkingBlock {
+	if msg.Metadata == nil {
+		return nil
+	}
+	return msg.Metadata.ThinkingBlocks
+}
+
+// htmlColor converts a theme color to a CSS color. Adaptive colors use their light variant,
+// matching the light page background; missing colors use the fallback.
+func htmlColor(color lipgloss.TerminalColor, fallback string) string {
+	switch c := color.(type) {
+	case lipgloss.Color:
+		if c != "" {
+			return string(c)
+		}
+	case lipgloss.AdaptiveColor:
+		if c.Light != "" {
+			return c.Light
+		}
+	}
+	return fallback
+}
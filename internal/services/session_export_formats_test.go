@@ -0,0 +1,107 @@
+package services
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"neuroshell/pkg/neurotypes"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportFormatsTestSession() *neurotypes.ChatSession {
+	return &neurotypes.ChatSession{
+		ID:           "session-1",
+		Name:         "design",
+		SystemPrompt: "You are terse",
+		CreatedAt:    time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Messages: []neurotypes.Message{
+			{Role: "user", Content: "Is <b> bold?"},
+			{Role: "assistant", Content: "Yes", Metadata: &neurotypes.MessageMetadata{
+				Model:          "claude",
+				ThinkingBlocks: []neurotypes.ThinkingBlock{{Content: "HTML question", Provider: "anthropic", Type: "thinking"}},
+			}},
+		},
+	}
+}
+
+func TestFormatSessionMarkdown(t *testing.T) {
+	session := exportFormatsTestSession()
+
+	markdown := FormatSessionMarkdown(session, true)
+	assert.Contains(t, markdown, "# design\n")
+	assert.Contains(t, markdown, "- Created: 2025-01-02 03:04:05\n")
+	assert.Contains(t, markdown, "## System\n\nYou are terse\n")
+	assert.Contains(t, markdown, "## User\n\nIs <b> bold?\n")
+	assert.Contains(t, markdown, "## Assistant (claude)\n\n<details>\n<summary>Thinking</summary>\n\nHTML question\n\n</details>\n\nYes\n")
+
+	assert.NotContains(t, FormatSessionMarkdown(session, false), "You are terse")
+}
+
+func TestFormatSessionHTML(t *testing.T) {
+	session := exportFormatsTestSession()
+	theme := &Theme{
+		Keyword: lipgloss.NewStyle().Foreground(lipgloss.Color("#111111")),
+		Success: lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#222222", Dark: "#333333"}),
+	}
+
+	page := FormatSessionHTML(session, true, theme)
+	assert.True(t, strings.HasPrefix(page, "<!DOCTYPE html>"))
+	assert.Contains(t, page, ".user { border-color: #111111; }")
+	assert.Contains(t, page, ".assistant { border-color: #222222; }")
+	assert.Contains(t, page, ".system { border-color: #9a6700; }", "missing theme colors use the default")
+	assert.Contains(t, page, "Is &lt;b&gt; bold?")
+	assert.Contains(t, page, "<summary>Thinking</summary>")
+	assert.Contains(t, page, "You are terse")
+
+	page = FormatSessionHTML(session, false, nil)
+	assert.NotContains(t, page, "You are terse")
+	assert.Contains(t, page, ".user { border-color: #0969da; }")
+}
+
+func TestFormatFinetuneRecord(t *testing.T) {
+	session := exportFormatsTestSession()
+
+	record, err := FormatFinetuneRecord(session, FinetuneShapeOpenAI, true)
+	require.NoError(t, err)
+	assert.Equal(t, `{"messages":[{"role":"system","content":"You are terse"},{"role":"user","content":"Is <b> bold?"},{"role":"assistant","content":"Yes"}]}`, string(record))
+
+	record, err = FormatFinetuneRecord(session, FinetuneShapeAnthropic, true)
+	require.NoError(t, err)
+	var anthropicRecord anthropicFinetuneRecord
+	require.NoError(t, json.Unmarshal(record, &anthropicRecord))
+	assert.Equal(t, "You are terse", anthropicRecord.System)
+	assert.Len(t, anthropicRecord.Messages, 2)
+
+	record, err = FormatFinetuneRecord(session, FinetuneShapeAnthropic, false)
+	require.NoError(t, err)
+	assert.NotContains(t, string(record), "system")
+
+	_, err = FormatFinetuneRecord(session, "bedrock", true)
+	assert.Error(t, err)
+
+	session.Messages = session.Messages[:1]
+	_, err = FormatFinetuneRecord(session, FinetuneShapeOpenAI, true)
+	assert.ErrorContains(t, err, "no assistant messages")
+}
+
+func TestWriteExportFile_Append(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "train.jsonl")
+
+	require.NoError(t, writeExportFile(path, []byte("one\n"), true))
+	require.NoError(t, writeExportFile(path, []byte("two\n"), true))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "one\ntwo\n", string(data))
+
+	require.NoError(t, writeExportFile(path, []byte("three\n"), false))
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "three\n", string(data))
+}
@@ -0,0 +1,439 @@
+package services
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	neuroshellcontext "neuroshell/internal/context"
+	"neuroshell/internal/logger"
+	"neuroshell/internal/testutils"
+	"neuroshell/pkg/neurotypes"
+)
+
+// Conversation formats supported by ImportConversations.
+const (
+	ImportFormatChatGPT        = "chatgpt"
+	ImportFormatClaude         = "claude"
+	ImportFormatOpenAIMessages = "openai-messages"
+)
+
+// ImportConversations imports the conversations of a ChatGPT or Claude data export, or a raw
+// OpenAI/Anthropic messages array, creating one session per conversation. Conversation titles
+// become session names, versioned when they are taken. Only conversations whose title contains
+// match (case-insensitive) are imported when match is set. The last imported session becomes active.
+func (c *ChatSessionService) ImportConversations(filePath, format, match string) ([]*neurotypes.ChatSession, error) {
+	ctx := neuroshellcontext.GetGlobalContext()
+	return c.ImportConversationsWithContext(filePath, format, match, ctx)
+}
+
+// ImportConversationsWithContext imports conversations from a web-UI export using provided context.
+func (c *ChatSessionService) ImportConversationsWithContext(filePath, format, match string, ctx neurotypes.Context) ([]*neurotypes.ChatSession, error) {
+	if !c.initialized {
+		return nil, fmt.Errorf("chat session service not initialized")
+	}
+
+	data, err := readConversationsFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var conversations []*neurotypes.ChatSession
+	switch format {
+	case ImportFormatChatGPT:
+		conversations, err = ParseChatGPTConversations(data)
+	case ImportFormatClaude:
+		conversations, err = ParseClaudeConversations(data)
+	case ImportFormatOpenAIMessages:
+		var conversation *neurotypes.ChatSession
+		conversation, err = ParseMessagesArray(data)
+		if conversation != nil {
+			conversation.Name = strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+			conversations = []*neurotypes.ChatSession{conversation}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported conversation format '%s'. Supported formats: %s, %s, %s",
+			format, ImportFormatChatGPT, ImportFormatClaude, ImportFormatOpenAIMessages)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if match != "" {
+		filtered := conversations[:0]
+		for _, conversation := range conversations {
+			if strings.Contains(strings.ToLower(conversation.Name), strings.ToLower(match)) {
+				filtered = append(filtered, conversation)
+			}
+		}
+		conversations = filtered
+	}
+	if len(conversations) == 0 {
+		return nil, fmt.Errorf("no conversations with messages found in %s", filePath)
+	}
+
+	sessions := ctx.GetChatSessions()
+	nameToID := ctx.GetSessionNameToID()
+	if activeID := ctx.GetActiveSessionID(); activeID != "" {
+		if prevSession, exists := sessions[activeID]; exists {
+			prevSession.IsActive = false
+		}
+	}
+
+	imported := make([]*neurotypes.ChatSession, 0, len(conversations))
+	for _, conversation := range conversations {
+		fillImportedMessages(conversation, ctx)
+		session, err := c.reconstructImportedSessionWithContext(conversation, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reconstruct session: %w", err)
+		}
+		if name := importedSessionName(conversation.Name); name != "" {
+			session.Name = c.generateAvailableName(name)
+		}
+		session.IsActive = false
+
+		// Each session is registered before the next name is chosen, so duplicate titles are versioned
+		sessions[session.ID] = session
+		nameToID[session.Name] = session.ID
+		ctx.SetChatSessions(sessions)
+		ctx.SetSessionNameToID(nameToID)
+		imported = append(imported, session)
+	}
+
+	last := imported[len(imported)-1]
+	last.IsActive = true
+	ctx.SetActiveSessionID(last.ID)
+
+	logger.Debug("Imported conversations", "file", filePath, "format", format, "count", len(imported))
+	return imported, nil
+}
+
+// importedSessionName turns a conversation title into a valid session name, or "" for the default name.
+func importedSessionName(title string) string {
+	title = strings.Join(strings.Fields(title), " ")
+	if runes := []rune(title); len(runes) > 64 {
+		title = strings.TrimSpace(string(runes[:64]))
+	}
+	return title
+}
+
+// readConversationsFile reads an export file. Zip archives are searched for conversations.json,
+// which is where both ChatGPT and Claude data exports keep the conversations.
+func readConversationsFile(filePath string) ([]byte, error) {
+	if !strings.EqualFold(filepath.Ext(filePath), ".zip") {
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		return data, nil
+	}
+
+	archive, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer func() { _ = archive.Close() }()
+
+	for _, file := range archive.File {
+		if filepath.Base(file.Name) != "conversations.json" {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s in archive: %w", file.Name, err)
+		}
+		defer func() { _ = reader.Close() }()
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s in archive: %w", file.Name, err)
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("archive %s does not contain conversations.json", filePath)
+}
+
+// chatGPTConversation is one conversation of a ChatGPT conversations.json export.
+// Messages form a tree (edits and regenerations branch it); current_node is the leaf that was shown.
+type chatGPTConversation struct {
+	Title       string                 `json:"title"`
+	CreateTime  float64                `json:"create_time"`
+	CurrentNode string                 `json:"current_node"`
+	Mapping     map[string]chatGPTNode `json:"mapping"`
+}
+
+type chatGPTNode struct {
+	ID       string          `json:"id"`
+	Message  *chatGPTMessage `json:"message"`
+	Parent   string          `json:"parent"`
+	Children []string        `json:"children"`
+}
+
+type chatGPTMessage struct {
+	ID         string   `json:"id"`
+	CreateTime *float64 `json:"create_time"`
+	Recipient  string   `json:"recipient"`
+	Author     struct {
+		Role string `json:"role"`
+	} `json:"author"`
+	Content struct {
+		ContentType string `json:"content_type"`
+		Parts       []any  `json:"parts"`
+		Text        string `json:"text"`
+	} `json:"content"`
+	Metadata struct {
+		Hidden bool `json:"is_visually_hidden_from_conversation"`
+	} `json:"metadata"`
+}
+
+// ParseChatGPTConversations parses a ChatGPT conversations.json export.
+// Each conversation follows the branch that was last shown; tool traffic and hidden messages are left out.
+func ParseChatGPTConversations(data []byte) ([]*neurotypes.ChatSession, error) {
+	var export []chatGPTConversation
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("failed to parse ChatGPT export: %w", err)
+	}
+
+	var conversations []*neurotypes.ChatSession
+	for _, conversation := range export {
+		session := &neurotypes.ChatSession{
+			Name:      conversation.Title,
+			CreatedAt: unixSecondsTime(conversation.CreateTime),
+		}
+		for _, node := range chatGPTPath(conversation) {
+			msg := node.Message
+			if msg == nil || msg.Metadata.Hidden || (msg.Recipient != "" && msg.Recipient != "all") {
+				continue
+			}
+			text := chatGPTText(msg)
+			if text == "" {
+				continue
+			}
+			switch msg.Author.Role {
+			case "system":
+				session.SystemPrompt = text
+			case "user", "assistant":
+				timestamp := session.CreatedAt
+				if msg.CreateTime != nil {
+					timestamp = unixSecondsTime(*msg.CreateTime)
+				}
+				session.Messages = append(session.Messages, neurotypes.Message{
+					ID: msg.ID, Role: msg.Author.Role, Content: text, Timestamp: timestamp,
+				})
+			}
+		}
+		if len(session.Messages) > 0 {
+			conversations = append(conversations, session)
+		}
+	}
+	return conversations, nil
+}
+
+// chatGPTPath returns the nodes from the root to the current node of a conversation.
+// Without a current node, the latest child is followed from the root.
+func chatGPTPath(conversation chatGPTConversation) []chatGPTNode {
+	nodeID := conversation.CurrentNode
+	if _, exists := conversation.Mapping[nodeID]; !exists {
+		nodeID = ""
+		ids := make([]string, 0, len(conversation.Mapping))
+		for id := range conversation.Mapping {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			if conversation.Mapping[id].Parent == "" {
+				nodeID = id
+				break
+			}
+		}
+		for nodeID != "" && len(conversation.Mapping[nodeID].Children) > 0 {
+			children := conversation.Mapping[nodeID].Children
+			nodeID = children[len(children)-1]
+		}
+	}
+
+	var path []chatGPTNode
+	seen := make(map[string]bool)
+	for nodeID != "" && !seen[nodeID] {
+		node, exists := conversation.Mapping[nodeID]
+		if !exists {
+			break
+		}
+		seen[nodeID] = true
+		path = append(path, node)
+		nodeID = node.Parent
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// chatGPTText joins the text parts of a ChatGPT message; images and other attachments are skipped.
+func chatGPTText(msg *chatGPTMessage) string {
+	var parts []string
+	for _, part := range msg.Content.Parts {
+		if text, ok := part.(string); ok && strings.TrimSpace(text) != "" {
+			parts = append(parts, text)
+		}
+	}
+	if len(parts) == 0 {
+		return strings.TrimSpace(msg.Content.Text)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// claudeConversation is one conversation of a Claude data export.
+type claudeConversation struct {
+	UUID         string          `json:"uuid"`
+	Name         string          `json:"name"`
+	CreatedAt    time.Time       `json:"created_at"`
+	ChatMessages []claudeMessage `json:"chat_messages"`
+}
+
+type claudeMessage struct {
+	UUID      string          `json:"uuid"`
+	Text      string          `json:"text"`
+	Sender    string          `json:"sender"`
+	CreatedAt time.Time       `json:"created_at"`
+	Content   json.RawMessage `json:"content"`
+}
+
+// ParseClaudeConversations parses the conversations.json of a Claude data export.
+func ParseClaudeConversations(data []byte) ([]*neurotypes.ChatSession, error) {
+	var export []claudeConversation
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("failed to parse Claude export: %w", err)
+	}
+
+	var conversations []*neurotypes.ChatSession
+	for _, conversation := range export {
+		session := &neurotypes.ChatSession{
+			Name:      conversation.Name,
+			CreatedAt: conversation.CreatedAt,
+		}
+		for _, msg := range conversation.ChatMessages {
+			role := msg.Sender
+			if role == "human" {
+				role = "user"
+			}
+			if role != "user" && role != "assistant" {
+				continue
+			}
+			text := strings.TrimSpace(msg.Text)
+			if text == "" {
+				text = contentText(msg.Content)
+			}
+			if text == "" {
+				continue
+			}
+			session.Messages = append(session.Messages, neurotypes.Message{
+				ID: msg.UUID, Role: role, Content: text, Timestamp: msg.CreatedAt,
+			})
+		}
+		if len(session.Messages) > 0 {
+			conversations = append(conversations, session)
+		}
+	}
+	return conversations, nil
+}
+
+// rawMessage is one message of an OpenAI or Anthropic messages array.
+type rawMessage struct {
+	Role    string          `json:"role"`
+	Content json.RawMessage `json:"content"`
+}
+
+// ParseMessagesArray parses a raw OpenAI or Anthropic messages array into one conversation.
+// Both a bare array and an object with "messages" (and Anthropic's top-level "system") are accepted.
+// System and developer messages become the system prompt; tool traffic is left out.
+func ParseMessagesArray(data []byte) (*neurotypes.ChatSession, error) {
+	var request struct {
+		System   json.RawMessage `json:"system"`
+		Messages []rawMessage    `json:"messages"`
+	}
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		if err := json.Unmarshal(data, &request.Messages); err != nil {
+			return nil, fmt.Errorf("failed to parse messages array: %w", err)
+		}
+	} else if err := json.Unmarshal(data, &request); err != nil {
+		return nil, fmt.Errorf("failed to parse messages array: %w", err)
+	}
+
+	session := &neurotypes.ChatSession{}
+	var systemPrompts []string
+	if system := contentText(request.System); system != "" {
+		systemPrompts = append(systemPrompts, system)
+	}
+	for _, msg := range request.Messages {
+		text := contentText(msg.Content)
+		if text == "" {
+			continue
+		}
+		switch msg.Role {
+		case "system", "developer":
+			systemPrompts = append(systemPrompts, text)
+		case "user", "assistant":
+			session.Messages = append(session.Messages, neurotypes.Message{Role: msg.Role, Content: text})
+		}
+	}
+	if len(session.Messages) == 0 {
+		return nil, fmt.Errorf("no user or assistant messages found")
+	}
+	session.SystemPrompt = strings.Join(systemPrompts, "\n\n")
+	return session, nil
+}
+
+// contentText returns the text of message content that is either a string or an array of
+// content blocks; only text blocks are kept.
+func contentText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return strings.TrimSpace(text)
+	}
+
+	var blocks []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(raw, &blocks); err != nil {
+		return ""
+	}
+	var parts []string
+	for _, block := range blocks {
+		if (block.Type == "text" || block.Type == "input_text" || block.Type == "output_text") && strings.TrimSpace(block.Text) != "" {
+			parts = append(parts, block.Text)
+		}
+	}
+	return strings.TrimSpace(strings.Join(parts, "\n"))
+}
+
+// unixSecondsTime converts fractional Unix seconds to a UTC time.
+func unixSecondsTime(seconds float64) time.Time {
+	if seconds <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(int64(math.Round(seconds * 1000))).UTC()
+}
+
+// fillImportedMessages gives messages without an ID or timestamp a fresh ID and the import time.
+func fillImportedMessages(session *neurotypes.ChatSession, ctx neurotypes.Context) {
+	now := testutils.GetCurrentTime(ctx)
+	for i := range session.Messages {
+		if session.Messages[i].ID == "" {
+			session.Messages[i].ID = testutils.GenerateUUID(ctx)
+		}
+		if session.Messages[i].Timestamp.IsZero() {
+			session.Messages[i].Timestamp = now
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	neuroshellcontext "neuroshell/internal/context"
+	"neuroshell/internal/testutils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func importFixture(t *testing.T, name string) []byte {
+	data, err := os.ReadFile(filepath.Join("..", "..", "test", "fixtures", "import", name))
+	require.NoError(t, err)
+	return data
+}
+
+func TestParseChatGPTConversations(t *testing.T) {
+	conversations, err := ParseChatGPTConversations(importFixture(t, "chatgpt-conversations.json"))
+	require.NoError(t, err)
+	require.Len(t, conversations, 2, "conversations without messages are skipped")
+
+	regex := conversations[0]
+	assert.Equal(t, "Regex help", regex.Name)
+	assert.Equal(t, "", regex.SystemPrompt, "hidden system messages are skipped")
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 500000000, time.UTC), regex.CreatedAt)
+	require.Len(t, regex.Messages, 2)
+	assert.Equal(t, "user", regex.Messages[0].Role)
+	assert.Equal(t, "Use \\d+ to match one or more digits.", regex.Messages[1].Content, "the current branch is followed")
+	assert.Equal(t, "a2", regex.Messages[1].ID)
+
+	trip := conversations[1]
+	require.Len(t, trip.Messages, 2, "tool calls are skipped")
+	assert.Equal(t, "Mild and cloudy.", trip.Messages[1].Content)
+
+	_, err = ParseChatGPTConversations([]byte(`{"not": "an array"}`))
+	assert.Error(t, err)
+}
+
+func TestParseClaudeConversations(t *testing.T) {
+	conversations, err := ParseClaudeConversations(importFixture(t, "claude-conversations.json"))
+	require.NoError(t, err)
+	require.Len(t, conversations, 2)
+
+	assert.Equal(t, "API design", conversations[0].Name)
+	require.Len(t, conversations[0].Messages, 2)
+	assert.Equal(t, "user", conversations[0].Messages[0].Role)
+	assert.Equal(t, "Yes, for public APIs.", conversations[0].Messages[1].Content, "content blocks are used when text is empty")
+	assert.Equal(t, time.Date(2025, 1, 1, 10, 0, 5, 0, time.UTC), conversations[0].Messages[1].Timestamp)
+}
+
+func TestParseMessagesArray(t *testing.T) {
+	session, err := ParseMessagesArray(importFixture(t, "anthropic-messages.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "You are a code reviewer.", session.SystemPrompt)
+	require.Len(t, session.Messages, 2)
+	assert.Equal(t, "Review: x := 1", session.Messages[0].Content)
+
+	session, err = ParseMessagesArray([]byte(`[
+		{"role": "developer", "content": "Be brief."},
+		{"role": "user", "content": [{"type": "text", "text": "Hi"}, {"type": "image_url", "image_url": {"url": "x"}}]},
+		{"role": "assistant", "content": null, "tool_calls": [{"id": "1"}]},
+		{"role": "tool", "tool_call_id": "1", "content": "result"},
+		{"role": "assistant", "content": "Hello"}
+	]`))
+	require.NoError(t, err)
+	assert.Equal(t, "Be brief.", session.SystemPrompt)
+	require.Len(t, session.Messages, 2)
+	assert.Equal(t, "Hi", session.Messages[0].Content)
+	assert.Equal(t, "Hello", session.Messages[1].Content)
+
+	_, err = ParseMessagesArray([]byte(`[]`))
+	assert.Error(t, err)
+}
+
+func TestChatSessionService_ImportConversations(t *testing.T) {
+	testutils.ResetTestCounters()
+	ctx := neuroshellcontext.NewTestContext()
+
+	service := NewChatSessionService()
+	require.NoError(t, service.Initialize())
+	existing, err := service.CreateSession("work", "", "")
+	require.NoError(t, err)
+
+	// Claude exports are zip archives with conversations.json inside
+	archivePath := filepath.Join(t.TempDir(), "claude-export.zip")
+	archiveFile, err := os.Create(archivePath)
+	require.NoError(t, err)
+	writer := zip.NewWriter(archiveFile)
+	entry, err := writer.Create("data/conversations.json")
+	require.NoError(t, err)
+	_, err = entry.Write(importFixture(t, "claude-conversations.json"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	require.NoError(t, archiveFile.Close())
+
+	imported, err := service.ImportConversationsWithContext(archivePath, ImportFormatClaude, "", ctx)
+	require.NoError(t, err)
+	require.Len(t, imported, 2)
+	assert.Equal(t, "API design", imported[0].Name)
+	assert.Equal(t, "API design:v1", imported[1].Name, "duplicate titles are versioned")
+	assert.Equal(t, "m1", imported[0].Messages[0].ID)
+	assert.False(t, existing.IsActive)
+	assert.False(t, imported[0].IsActive)
+	assert.True(t, imported[1].IsActive)
+	assert.Equal(t, imported[1].ID, ctx.GetActiveSessionID())
+
+	session, err := service.GetSessionByName("API design:v1")
+	require.NoError(t, err)
+	assert.Equal(t, imported[1].ID, session.ID)
+
+	imported, err = service.ImportConversationsWithContext(filepath.Join("..", "..", "test", "fixtures", "import", "chatgpt-conversations.json"), ImportFormatChatGPT, "TRIP", ctx)
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	assert.Equal(t, "Trip planning", imported[0].Name)
+
+	imported, err = service.ImportConversationsWithContext(filepath.Join("..", "..", "test", "fixtures", "import", "anthropic-messages.json"), ImportFormatOpenAIMessages, "", ctx)
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	assert.Equal(t, "anthropic-messages", imported[0].Name)
+	assert.NotEmpty(t, imported[0].Messages[0].ID, "messages without IDs get one")
+
+	_, err = service.ImportConversationsWithContext(archivePath, ImportFormatClaude, "no such title", ctx)
+	assert.ErrorContains(t, err, "no conversations")
+	_, err = service.ImportConversationsWithContext(archivePath, "bard", "", ctx)
+	assert.ErrorContains(t, err, "unsupported conversation format")
+}
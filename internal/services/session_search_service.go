@@ -0,0 +1,604 @@
+// Package services provides full-text search across chat sessions for NeuroShell.
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	neuroshellcontext "neuroshell/internal/context"
+	"neuroshell/internal/logger"
+	"neuroshell/pkg/neurotypes"
+)
+
+// BM25 parameters used to rank search hits.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// searchIndexVersion is bumped whenever the on-disk index layout or tokenization changes.
+const searchIndexVersion = 1
+
+// snippetWidth is the approximate number of characters shown around the first match of a hit.
+const snippetWidth = 120
+
+// SearchOptions controls a session search.
+type SearchOptions struct {
+	Regex bool   // Treat the query as a regular expression instead of ranked keywords
+	Role  string // Only search messages with this role (user or assistant); empty searches both
+	Limit int    // Maximum number of hits; zero or less returns every hit
+}
+
+// SearchHit is one message that matched a session search.
+type SearchHit struct {
+	SessionID    string
+	SessionName  string
+	MessageIndex int // 1-based position in the session, as shown by \session-show
+	Role         string
+	Score        float64
+	Snippet      string   // Whitespace-collapsed excerpt around the first match
+	Highlights   [][2]int // Byte ranges of the matches within Snippet
+}
+
+// searchDocument is one indexed message.
+type searchDocument struct {
+	Index   int    `json:"index"`
+	Role    string `json:"role"`
+	Content string `json:"content"`
+	Length  int    `json:"length"`
+}
+
+// indexedSession holds the indexed messages of one session. Stamp identifies the indexed
+// version of the session, so unchanged sessions are not indexed again.
+type indexedSession struct {
+	Name      string            `json:"name"`
+	Stamp     string            `json:"stamp"`
+	Documents []*searchDocument `json:"documents"`
+}
+
+// searchIndexFile is the on-disk layout of the index. Postings map each term to the
+// documents containing it ("sessionID#index") and the term frequency in each.
+type searchIndexFile struct {
+	Version  int                        `json:"version"`
+	Sessions map[string]*indexedSession `json:"sessions"`
+	Postings map[string]map[string]int  `json:"postings"`
+}
+
+// SessionSearchService keeps a BM25-ranked inverted index over the messages of every in-memory
+// and saved session. The index is stored under the config directory and updated one session at a
+// time: when a session changes (TriggerAutoSave) and, for anything missed, before each search.
+type SessionSearchService struct {
+	initialized bool
+	loaded      bool
+	index       searchIndexFile
+	totalLength int
+	mutex       sync.Mutex
+}
+
+// NewSessionSearchService creates a new SessionSearchService instance.
+func NewSessionSearchService() *SessionSearchService {
+	return &SessionSearchService{
+		initialized: false,
+	}
+}
+
+// Name returns the service name "session_search" for registration.
+func (s *SessionSearchService) Name() string {
+	return "session_search"
+}
+
+// Initialize sets up the SessionSearchService for operation. The index is read on first use.
+func (s *SessionSearchService) Initialize() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.initialized = true
+	s.loaded = false
+	logger.Debug("SessionSearchService initialized")
+	return nil
+}
+
+// IndexSession updates the index for one in-memory session and writes the index to disk.
+// Errors are logged, since indexing must never interrupt the command that changed the session.
+func (s *SessionSearchService) IndexSession(session *neurotypes.ChatSession) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.initialized || session == nil || session.UnloadedFrom != "" {
+		return
+	}
+	s.loadIndex()
+
+	stamp := memoryStamp(session)
+	if existing, exists := s.index.Sessions[session.ID]; exists && existing.Stamp == stamp {
+		return
+	}
+	s.indexSession(session, stamp)
+	if err := s.saveIndex(); err != nil {
+		logger.Debug("Failed to save session search index", "error", err)
+	}
+}
+
+// Search finds the messages matching query in every in-memory and saved session.
+// Keyword queries are ranked with BM25; regex queries are ranked by their number of matches.
+// Hits are sorted best first, and within equal scores by session name and message index.
+func (s *SessionSearchService) Search(query string, options SearchOptions) ([]SearchHit, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.initialized {
+		return nil, fmt.Errorf("session search service not initialized")
+	}
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("search query cannot be empty")
+	}
+	if options.Role != "" && options.Role != "user" && options.Role != "assistant" {
+		return nil, fmt.Errorf("invalid role '%s'. Valid roles: user, assistant", options.Role)
+	}
+
+	var pattern *regexp.Regexp
+	if options.Regex {
+		var err error
+		if pattern, err = regexp.Compile(query); err != nil {
+			return nil, fmt.Errorf("invalid regular expression: %w", err)
+		}
+	}
+
+	s.loadIndex()
+	if err := s.refresh(); err != nil {
+		return nil, err
+	}
+
+	var hits []SearchHit
+	if pattern != nil {
+		hits = s.searchRegex(pattern, options.Role)
+	} else {
+		hits = s.searchTerms(query, options.Role)
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if hits[i].SessionName != hits[j].SessionName {
+			return hits[i].SessionName < hits[j].SessionName
+		}
+		return hits[i].MessageIndex < hits[j].MessageIndex
+	})
+	if options.Limit > 0 && len(hits) > options.Limit {
+		hits = hits[:options.Limit]
+	}
+	return hits, nil
+}
+
+// searchTerms ranks the documents containing any query term with BM25.
+func (s *SessionSearchService) searchTerms(query, role string) []SearchHit {
+	terms := uniqueTerms(query)
+	documents := s.documents()
+	if len(documents) == 0 || len(terms) == 0 {
+		return nil
+	}
+
+	count := float64(len(documents))
+	averageLength := float64(s.totalLength) / count
+	if averageLength == 0 {
+		averageLength = 1
+	}
+
+	scores := make(map[string]float64)
+	for _, term := range terms {
+		postings := s.index.Postings[term]
+		frequency := float64(len(postings))
+		idf := math.Log(1 + (count-frequency+0.5)/(frequency+0.5))
+		for key, termFrequency := range postings {
+			document, exists := documents[key]
+			if !exists || (role != "" && document.Role != role) {
+				continue
+			}
+			tf := float64(termFrequency)
+			norm := 1 - bm25B + bm25B*float64(document.Length)/averageLength
+			scores[key] += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+		}
+	}
+
+	termSet := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		termSet[term] = true
+	}
+	hits := make([]SearchHit, 0, len(scores))
+	for key, score := range scores {
+		sessionID, document := splitDocumentKey(key), documents[key]
+		flat := strings.Join(strings.Fields(document.Content), " ")
+		hit := s.newHit(sessionID, document, score)
+		hit.Snippet, hit.Highlights = buildSnippet(flat, termMatches(flat, termSet))
+		hits = append(hits, hit)
+	}
+	return hits
+}
+
+// searchRegex scans every document for the pattern.
+func (s *SessionSearchService) searchRegex(pattern *regexp.Regexp, role string) []SearchHit {
+	var hits []SearchHit
+	for sessionID, session := range s.index.Sessions {
+		for _, document := range session.Documents {
+			if role != "" && document.Role != role {
+				continue
+			}
+			flat := strings.Join(strings.Fields(document.Content), " ")
+			var matches [][2]int
+			for _, match := range pattern.FindAllStringIndex(flat, -1) {
+				if match[1] > match[0] {
+					matches = append(matches, [2]int{match[0], match[1]})
+				}
+			}
+			if len(matches) == 0 {
+				continue
+			}
+			hit := s.newHit(sessionID, document, float64(len(matches)))
+			hit.Snippet, hit.Highlights = buildSnippet(flat, matches)
+			hits = append(hits, hit)
+		}
+	}
+	return hits
+}
+
+func (s *SessionSearchService) newHit(sessionID string, document *searchDocument, score float64) SearchHit {
+	return SearchHit{
+		SessionID:    sessionID,
+		SessionName:  s.index.Sessions[sessionID].Name,
+		MessageIndex: document.Index,
+		Role:         document.Role,
+		Score:        score,
+	}
+}
+
+// documents returns every indexed document by key.
+func (s *SessionSearchService) documents() map[string]*searchDocument {
+	documents := make(map[string]*searchDocument)
+	for sessionID, session := range s.index.Sessions {
+		for _, document := range session.Documents {
+			documents[documentKey(sessionID, document.Index)] = document
+		}
+	}
+	return documents
+}
+
+// refresh brings the index up to date with the sessions in memory and the saved session files.
+// Sessions that are gone from both are dropped; the index is written only when something changed.
+func (s *SessionSearchService) refresh() error {
+	chatService, err := GetGlobalChatSessionService()
+	if err != nil {
+		return fmt.Errorf("chat session service not available: %w", err)
+	}
+
+	type source struct {
+		session *neurotypes.ChatSession // In-memory session, nil when only saved
+		path    string                  // Saved file read when the session is not in memory
+		name    string
+		stamp   string
+	}
+	sources := make(map[string]source)
+	for _, session := range chatService.ListSessions() {
+		if session.UnloadedFrom == "" {
+			sources[session.ID] = source{session: session, name: session.Name, stamp: memoryStamp(session)}
+		} else if stamp, err := fileStamp(session.UnloadedFrom); err == nil {
+			sources[session.ID] = source{path: session.UnloadedFrom, name: session.Name, stamp: stamp}
+		}
+	}
+	saved, err := chatService.ListSavedSessions()
+	if err != nil {
+		return err
+	}
+	for _, entry := range saved {
+		if _, exists := sources[entry.ID]; exists {
+			continue
+		}
+		if stamp, err := fileStamp(entry.Path); err == nil {
+			sources[entry.ID] = source{path: entry.Path, name: entry.Name, stamp: stamp}
+		}
+	}
+
+	changed := false
+	for sessionID := range s.index.Sessions {
+		if _, exists := sources[sessionID]; !exists {
+			s.removeSession(sessionID)
+			changed = true
+		}
+	}
+	for sessionID, src := range sources {
+		existing, exists := s.index.Sessions[sessionID]
+		if exists && existing.Stamp == src.stamp {
+			// Renames and versioned names do not change the indexed messages
+			existing.Name = src.name
+			continue
+		}
+		session := src.session
+		if session == nil {
+			stored, err := readSessionFile(src.path)
+			if err != nil {
+				logger.Debug("Skipping unreadable session in search index", "path", src.path, "error", err)
+				continue
+			}
+			session = stored
+		}
+		s.indexSession(session, src.stamp)
+		s.index.Sessions[sessionID].Name = src.name
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	if err := s.saveIndex(); err != nil {
+		logger.Debug("Failed to save session search index", "error", err)
+	}
+	return nil
+}
+
+// indexSession replaces the indexed messages of a session.
+func (s *SessionSearchService) indexSession(session *neurotypes.ChatSession, stamp string) {
+	s.removeSession(session.ID)
+
+	indexed := &indexedSession{Name: session.Name, Stamp: stamp}
+	for i, message := range session.Messages {
+		if (message.Role != "user" && message.Role != "assistant") || strings.TrimSpace(message.Content) == "" {
+			continue
+		}
+		terms := searchTerms(message.Content)
+		document := &searchDocument{Index: i + 1, Role: message.Role, Content: message.Content, Length: len(terms)}
+		indexed.Documents = append(indexed.Documents, document)
+
+		key := documentKey(session.ID, document.Index)
+		for _, term := range terms {
+			if s.index.Postings[term] == nil {
+				s.index.Postings[term] = make(map[string]int)
+			}
+			s.index.Postings[term][key]++
+		}
+		s.totalLength += document.Length
+	}
+	s.index.Sessions[session.ID] = indexed
+}
+
+// removeSession drops a session and its postings from the index.
+func (s *SessionSearchService) removeSession(sessionID string) {
+	indexed, exists := s.index.Sessions[sessionID]
+	if !exists {
+		return
+	}
+	for _, document := range indexed.Documents {
+		key := documentKey(sessionID, document.Index)
+		for _, term := range uniqueTerms(document.Content) {
+			delete(s.index.Postings[term], key)
+			if len(s.index.Postings[term]) == 0 {
+				delete(s.index.Postings, term)
+			}
+		}
+		s.totalLength -= document.Length
+	}
+	delete(s.index.Sessions, sessionID)
+}
+
+// indexPath returns where the index is stored.
+func (s *SessionSearchService) indexPath() (string, error) {
+	configDir, err := neuroshellcontext.GetGlobalContext().GetUserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get config directory: %w", err)
+	}
+	return filepath.Join(configDir, "search", "sessions.json"), nil
+}
+
+// loadIndex reads the index from disk once. A missing, unreadable or outdated index starts
+// empty and is rebuilt by the next refresh.
+func (s *SessionSearchService) loadIndex() {
+	if s.loaded {
+		return
+	}
+	s.loaded = true
+	s.index = searchIndexFile{
+		Version:  searchIndexVersion,
+		Sessions: make(map[string]*indexedSession),
+		Postings: make(map[string]map[string]int),
+	}
+	s.totalLength = 0
+
+	path, err := s.indexPath()
+	if err != nil {
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	var stored searchIndexFile
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Version != searchIndexVersion || stored.Sessions == nil || stored.Postings == nil {
+		logger.Debug("Rebuilding session search index", "path", path)
+		return
+	}
+	s.index = stored
+	for _, session := range stored.Sessions {
+		for _, document := range session.Documents {
+			s.totalLength += document.Length
+		}
+	}
+}
+
+// saveIndex writes the index to disk.
+func (s *SessionSearchService) saveIndex() error {
+	path, err := s.indexPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(&s.index)
+	if err != nil {
+		return fmt.Errorf("failed to marshal search index: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create search index directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write search index: %w", err)
+	}
+	return nil
+}
+
+// searchTerms splits text into lowercase words and folds simple plurals, so "retries" finds "retry".
+func searchTerms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = foldTerm(word)
+	}
+	return words
+}
+
+// uniqueTerms returns the distinct search terms of text in order of appearance.
+func uniqueTerms(text string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, term := range searchTerms(text) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+func foldTerm(word string) string {
+	switch {
+	case len(word) > 4 && strings.HasSuffix(word, "ies"):
+		return word[:len(word)-3] + "y"
+	case len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss"):
+		return word[:len(word)-1]
+	default:
+		return word
+	}
+}
+
+// termMatches returns the byte ranges of the words in text whose term is in terms.
+func termMatches(text string, terms map[string]bool) [][2]int {
+	var matches [][2]int
+	start := -1
+	for i, r := range text + " " {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case isWord && start < 0:
+			start = i
+		case !isWord && start >= 0:
+			if terms[foldTerm(strings.ToLower(text[start:i]))] {
+				matches = append(matches, [2]int{start, i})
+			}
+			start = -1
+		}
+	}
+	return matches
+}
+
+// buildSnippet cuts an excerpt of text around the first match and moves the matches into it.
+func buildSnippet(text string, matches [][2]int) (string, [][2]int) {
+	start, end := 0, len(text)
+	if len(text) > snippetWidth {
+		first, firstEnd := 0, 0
+		if len(matches) > 0 {
+			first, firstEnd = matches[0][0], matches[0][1]
+		}
+		start = max(0, first-snippetWidth/3)
+		end = min(len(text), start+snippetWidth)
+		start = max(0, min(start, end-snippetWidth))
+
+		// Cut at word boundaries without splitting runes
+		if start > 0 {
+			if space := strings.IndexByte(text[start:first], ' '); space >= 0 {
+				start += space + 1
+			}
+		}
+		if end < len(text) {
+			if space := strings.LastIndexByte(text[start:end], ' '); space > 0 && start+space >= firstEnd {
+				end = start + space
+			}
+		}
+		for start < len(text) && !utf8.RuneStart(text[start]) {
+			start++
+		}
+		for end < len(text) && !utf8.RuneStart(text[end]) {
+			end++
+		}
+	}
+
+	prefix, suffix := "", ""
+	if start > 0 {
+		prefix = "..."
+	}
+	if end < len(text) {
+		suffix = "..."
+	}
+
+	var highlights [][2]int
+	for _, match := range matches {
+		if match[0] >= start && match[1] <= end {
+			highlights = append(highlights, [2]int{match[0] - start + len(prefix), match[1] - start + len(prefix)})
+		}
+	}
+	return prefix + text[start:end] + suffix, highlights
+}
+
+// memoryStamp identifies the content of an in-memory session.
+func memoryStamp(session *neurotypes.ChatSession) string {
+	hash := fnv.New64a()
+	for _, message := range session.Messages {
+		_, _ = hash.Write([]byte(message.Role))
+		_, _ = hash.Write([]byte{0})
+		_, _ = hash.Write([]byte(message.Content))
+		_, _ = hash.Write([]byte{0})
+	}
+	return fmt.Sprintf("memory:%d:%x", len(session.Messages), hash.Sum64())
+}
+
+// fileStamp identifies the version of a saved session file without reading it.
+func fileStamp(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("file:%d:%d", info.ModTime().UnixNano(), info.Size()), nil
+}
+
+func documentKey(sessionID string, index int) string {
+	return fmt.Sprintf("%s#%d", sessionID, index)
+}
+
+func splitDocumentKey(key string) string {
+	return key[:strings.LastIndexByte(key, '#')]
+}
+
+// GetSessionSearchService retrieves the session search service from the global registry.
+func (r *Registry) GetSessionSearchService() (*SessionSearchService, error) {
+	service, err := r.GetService("session_search")
+	if err != nil {
+		return nil, err
+	}
+
+	searchService, ok := service.(*SessionSearchService)
+	if !ok {
+		return nil, fmt.Errorf("session search service has incorrect type")
+	}
+
+	return searchService, nil
+}
+
+// GetGlobalSessionSearchService returns the session search service from the global registry.
+func GetGlobalSessionSearchService() (*SessionSearchService, error) {
+	return GetGlobalRegistry().GetSessionSearchService()
+}
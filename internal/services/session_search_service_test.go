@@ -0,0 +1,129 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	neuroshellcontext "neuroshell/internal/context"
+	"neuroshell/internal/testutils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSessionSearchTest(t *testing.T) (*ChatSessionService, *SessionSearchService) {
+	testutils.ResetTestCounters()
+	ctx := neuroshellcontext.NewTestContext()
+
+	oldRegistry := GetGlobalRegistry()
+	SetGlobalRegistry(NewRegistry())
+	t.Cleanup(func() {
+		SetGlobalRegistry(oldRegistry)
+		neuroshellcontext.ResetGlobalContext()
+	})
+	neuroshellcontext.SetGlobalContext(ctx)
+
+	chatService := NewChatSessionService()
+	searchService := NewSessionSearchService()
+	require.NoError(t, GetGlobalRegistry().RegisterService(chatService))
+	require.NoError(t, GetGlobalRegistry().RegisterService(searchService))
+	require.NoError(t, GetGlobalRegistry().InitializeAll())
+
+	// In test mode the index lives under /tmp/neuroshell-test-config; start from an empty one
+	indexPath, err := searchService.indexPath()
+	require.NoError(t, err)
+	_ = os.RemoveAll(filepath.Dir(indexPath))
+	t.Cleanup(func() { _ = os.RemoveAll(filepath.Dir(indexPath)) })
+
+	return chatService, searchService
+}
+
+func TestSearchTerms(t *testing.T) {
+	assert.Equal(t, []string{"retry", "logic", "for", "the", "api", "v2", "class"}, searchTerms("Retries, LOGIC for the APIs (v2) class"))
+	assert.Equal(t, []string{"retry", "logic"}, uniqueTerms("retry logic, retry"))
+}
+
+func TestBuildSnippet(t *testing.T) {
+	snippet, highlights := buildSnippet("use retry logic", termMatches("use retry logic", map[string]bool{"retry": true}))
+	assert.Equal(t, "use retry logic", snippet)
+	assert.Equal(t, [][2]int{{4, 9}}, highlights)
+
+	long := "Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. " +
+		"Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat. Retry here please. " +
+		"Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur."
+	snippet, highlights = buildSnippet(long, termMatches(long, map[string]bool{"retry": true}))
+	assert.True(t, len(snippet) <= snippetWidth+6)
+	assert.Contains(t, snippet, "Retry here")
+	assert.Regexp(t, `^\.\.\.\S`, snippet, "the start is cut at a word boundary")
+	assert.Regexp(t, `\S\.\.\.$`, snippet)
+	require.Len(t, highlights, 1)
+	assert.Equal(t, "Retry", snippet[highlights[0][0]:highlights[0][1]])
+}
+
+func TestSessionSearchService_Search(t *testing.T) {
+	chatService, searchService := setupSessionSearchTest(t)
+
+	api, err := chatService.CreateSession("api", "", "How should we handle timeouts?")
+	require.NoError(t, err)
+	require.NoError(t, chatService.AddMessage(api.ID, "assistant", "Use retry logic with backoff. Retries should be capped."))
+	notes, err := chatService.CreateSession("notes", "", "Groceries: milk, eggs")
+	require.NoError(t, err)
+	require.NoError(t, chatService.AddMessage(notes.ID, "assistant", "Noted, one retry."))
+
+	hits, err := searchService.Search("retry logic", SearchOptions{Limit: 20})
+	require.NoError(t, err)
+	require.Len(t, hits, 2)
+	assert.Equal(t, "api", hits[0].SessionName, "messages matching more terms rank first")
+	assert.Equal(t, 2, hits[0].MessageIndex)
+	assert.Equal(t, "assistant", hits[0].Role)
+	assert.Greater(t, hits[0].Score, hits[1].Score)
+	require.Len(t, hits[0].Highlights, 3)
+	assert.Equal(t, "Retries", hits[0].Snippet[hits[0].Highlights[2][0]:hits[0].Highlights[2][1]])
+
+	hits, err = searchService.Search("retry", SearchOptions{Role: "user"})
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+
+	hits, err = searchService.Search(`back\w+`, SearchOptions{Regex: true})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, "backoff", hits[0].Snippet[hits[0].Highlights[0][0]:hits[0].Highlights[0][1]])
+
+	// Changes are picked up incrementally, and deleted sessions drop out of the index
+	chatService.TriggerAutoSave(notes.ID)
+	require.NoError(t, chatService.AddMessage(notes.ID, "user", "Add retry logic to the shopping list"))
+	require.NoError(t, chatService.DeleteSession(api.ID))
+	hits, err = searchService.Search("logic", SearchOptions{})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, "notes", hits[0].SessionName)
+	assert.Equal(t, 3, hits[0].MessageIndex)
+
+	_, err = searchService.Search("  ", SearchOptions{})
+	assert.ErrorContains(t, err, "cannot be empty")
+	_, err = searchService.Search("x", SearchOptions{Role: "tool"})
+	assert.ErrorContains(t, err, "invalid role")
+	_, err = searchService.Search("(", SearchOptions{Regex: true})
+	assert.ErrorContains(t, err, "invalid regular expression")
+}
+
+func TestSessionSearchService_IndexPersists(t *testing.T) {
+	chatService, searchService := setupSessionSearchTest(t)
+
+	session, err := chatService.CreateSession("persisted", "", "Exponential backoff with jitter")
+	require.NoError(t, err)
+	searchService.IndexSession(session)
+
+	// A new service reads the stored index; its postings survive the round trip
+	reloaded := NewSessionSearchService()
+	require.NoError(t, reloaded.Initialize())
+	reloaded.loadIndex()
+	require.Contains(t, reloaded.index.Sessions, session.ID)
+	assert.Equal(t, map[string]int{documentKey(session.ID, 1): 1}, reloaded.index.Postings["jitter"])
+	assert.Equal(t, 4, reloaded.totalLength)
+
+	reloaded.removeSession(session.ID)
+	assert.NotContains(t, reloaded.index.Postings, "jitter")
+	assert.Equal(t, 0, reloaded.totalLength)
+}
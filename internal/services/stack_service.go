@@ -201,3 +201,53 @@ func (ss *StackService) GetCurrentSilentDepth() int {
 	}
 	return ss.stackCtx.GetCurrentSilentDepth()
 }
+
+// Loop block support methods
+
+// PushLoopBoundary registers a new innermost loop
+func (ss *StackService) PushLoopBoundary(loop neuroshellcontext.LoopBlockContext) {
+	if !ss.initialized {
+		return
+	}
+	ss.stackCtx.PushLoopBoundary(loop)
+}
+
+// PopLoopBoundary removes the most recent loop block context
+func (ss *StackService) PopLoopBoundary() {
+	if !ss.initialized {
+		return
+	}
+	ss.stackCtx.PopLoopBoundary()
+}
+
+// IsInLoopBlock returns true if currently inside a loop
+func (ss *StackService) IsInLoopBlock() bool {
+	if !ss.initialized {
+		return false
+	}
+	return ss.stackCtx.IsInLoopBlock()
+}
+
+// GetCurrentLoopID returns the ID of the innermost loop
+func (ss *StackService) GetCurrentLoopID() string {
+	if !ss.initialized {
+		return ""
+	}
+	return ss.stackCtx.GetCurrentLoopID()
+}
+
+// GetCurrentLoopDepth returns the current loop nesting depth
+func (ss *StackService) GetCurrentLoopDepth() int {
+	if !ss.initialized {
+		return 0
+	}
+	return ss.stackCtx.GetCurrentLoopDepth()
+}
+
+// NextLoopPass counts a new pass of the innermost loop and returns a copy of its context
+func (ss *StackService) NextLoopPass(loopID string) (neuroshellcontext.LoopBlockContext, bool) {
+	if !ss.initialized {
+		return neuroshellcontext.LoopBlockContext{}, false
+	}
+	return ss.stackCtx.NextLoopPass(loopID)
+}
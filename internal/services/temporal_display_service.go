@@ -22,6 +22,7 @@ type TemporalDisplayService struct {
 type Display struct {
 	id        string
 	stopCh    chan struct{}
+	doneCh    chan struct{} // Closed once the display goroutine has cleaned up
 	ticker    *time.Ticker
 	startTime time.Time
 	condition func(elapsed time.Duration) bool
@@ -94,6 +95,7 @@ func (t *TemporalDisplayService) StartCustomDisplay(id string, condition func(ti
 	display := &Display{
 		id:        id,
 		stopCh:    make(chan struct{}),
+		doneCh:    make(chan struct{}),
 		ticker:    time.NewTicker(100 * time.Millisecond), // Update every 100ms for responsive timers
 		startTime: time.Now(),
 		condition: condition,
@@ -129,6 +131,26 @@ func (t *TemporalDisplayService) Stop(id string) error {
 	return nil
 }
 
+// StopAndWait stops a specific temporal display and blocks until its line has been cleared.
+// Use this before writing to the console directly so cleanup cannot erase the new output.
+func (t *TemporalDisplayService) StopAndWait(id string) error {
+	if !t.initialized {
+		return fmt.Errorf("temporal display service not initialized")
+	}
+
+	t.mu.Lock()
+	display, exists := t.activeDisplays[id]
+	if !exists {
+		t.mu.Unlock()
+		return fmt.Errorf("display with id '%s' not found", id)
+	}
+	t.stopDisplayUnsafe(display)
+	t.mu.Unlock()
+
+	<-display.doneCh
+	return nil
+}
+
 // StopAll stops and cleans up all active temporal displays.
 func (t *TemporalDisplayService) StopAll() error {
 	if !t.initialized {
@@ -161,6 +183,7 @@ func (t *TemporalDisplayService) IsActive(id string) bool {
 // runDisplay runs the main display loop for a temporal display in its own goroutine.
 func (t *TemporalDisplayService) runDisplay(display *Display) {
 	defer func() {
+		defer close(display.doneCh)
 		display.ticker.Stop()
 		t.cleanupDisplay(display)
 
@@ -434,3 +434,29 @@ func TestTemporalDisplayService_ServiceRegistration(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, "temporal-display", temporalService.Name())
 }
+
+// Test StopAndWait blocks until the display has been cleaned up
+func TestTemporalDisplayService_StopAndWait(t *testing.T) {
+	service := NewTemporalDisplayService()
+	err := service.Initialize()
+	require.NoError(t, err)
+
+	condition := func(_ time.Duration) bool { return false }
+	renderer := func(_ time.Duration) string { return "waiting" }
+
+	err = service.StartCustomDisplay("wait-test", condition, renderer)
+	require.NoError(t, err)
+
+	err = service.StopAndWait("wait-test")
+	assert.NoError(t, err)
+	assert.False(t, service.IsActive("wait-test"))
+
+	service.mu.RLock()
+	_, exists := service.activeDisplays["wait-test"]
+	service.mu.RUnlock()
+	assert.False(t, exists)
+
+	// Stopping an unknown display reports an error
+	err = service.StopAndWait("wait-test")
+	assert.Error(t, err)
+}
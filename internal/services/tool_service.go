@@ -0,0 +1,393 @@
+// Package services provides tool registry and tool loop management for NeuroShell.
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"neuroshell/internal/logger"
+	"neuroshell/pkg/neurotypes"
+)
+
+// toolNamePattern matches tool names accepted by all supported providers.
+var toolNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// ToolLoop tracks a tool calling exchange between the model and .neuro tool scripts.
+// The exchanged messages are kept outside the session so \llm-call stays free of session mutations.
+type ToolLoop struct {
+	ID        string                  // Loop identifier passed between \llm-call and \tool-call
+	SessionID string                  // Session the loop belongs to
+	Messages  []neurotypes.Message    // Assistant tool call messages and tool result messages
+	Calls     []neurotypes.ToolCall   // All tool calls requested during the loop
+	Results   []neurotypes.ToolResult // Results collected from tool scripts
+	Rounds    int                     // Number of model responses that requested tools
+	sequence  int                     // Order in which the loop was started
+}
+
+// ToolService manages tools backed by .neuro scripts and the state of active tool loops.
+type ToolService struct {
+	initialized bool
+	tools       map[string]neurotypes.ToolDefinition
+	loops       map[string]*ToolLoop
+	loopCounter int
+	mutex       sync.RWMutex
+}
+
+// NewToolService creates a new ToolService instance.
+func NewToolService() *ToolService {
+	return &ToolService{
+		initialized: false,
+		tools:       make(map[string]neurotypes.ToolDefinition),
+		loops:       make(map[string]*ToolLoop),
+	}
+}
+
+// Name returns the service name "tool" for registration.
+func (t *ToolService) Name() string {
+	return "tool"
+}
+
+// Initialize sets up the ToolService for operation.
+func (t *ToolService) Initialize() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.initialized = true
+	logger.Debug("ToolService initialized")
+	return nil
+}
+
+// Define registers a tool, replacing any existing tool with the same name.
+func (t *ToolService) Define(tool neurotypes.ToolDefinition) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if !t.initialized {
+		return fmt.Errorf("tool service not initialized")
+	}
+
+	if !toolNamePattern.MatchString(tool.Name) {
+		return fmt.Errorf("invalid tool name '%s': use 1-64 letters, digits, '_' or '-'", tool.Name)
+	}
+	if tool.Parameters == nil {
+		tool.Parameters = defaultToolParameters()
+	}
+
+	t.tools[tool.Name] = tool
+	logger.Debug("Tool defined", "name", tool.Name, "script", tool.ScriptPath)
+	return nil
+}
+
+// Get returns the tool with the given name.
+func (t *ToolService) Get(name string) (neurotypes.ToolDefinition, error) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if !t.initialized {
+		return neurotypes.ToolDefinition{}, fmt.Errorf("tool service not initialized")
+	}
+
+	tool, exists := t.tools[name]
+	if !exists {
+		return neurotypes.ToolDefinition{}, fmt.Errorf("tool '%s' not found", name)
+	}
+	return tool, nil
+}
+
+// List returns all defined tools sorted by name.
+func (t *ToolService) List() []neurotypes.ToolDefinition {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	tools := make([]neurotypes.ToolDefinition, 0, len(t.tools))
+	for _, tool := range t.tools {
+		tools = append(tools, tool)
+	}
+	sort.Slice(tools, func(i, j int) bool {
+		return tools[i].Name < tools[j].Name
+	})
+	return tools
+}
+
+// Remove deletes the tool with the given name.
+func (t *ToolService) Remove(name string) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if !t.initialized {
+		return fmt.Errorf("tool service not initialized")
+	}
+
+	if _, exists := t.tools[name]; !exists {
+		return fmt.Errorf("tool '%s' not found", name)
+	}
+	delete(t.tools, name)
+	return nil
+}
+
+// LoadToolScript reads a .neuro script and builds a tool definition from its %% header.
+// The header may contain a "%% Description:" line and a "%% Parameters:" line followed by
+// a JSON schema spread over subsequent %% lines. The tool name defaults to the file name.
+func (t *ToolService) LoadToolScript(path string) (neurotypes.ToolDefinition, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return neurotypes.ToolDefinition{}, fmt.Errorf("failed to read tool script '%s': %w", path, err)
+	}
+
+	description, parameters, err := ParseToolHeader(string(content))
+	if err != nil {
+		return neurotypes.ToolDefinition{}, fmt.Errorf("invalid tool script '%s': %w", path, err)
+	}
+
+	return neurotypes.ToolDefinition{
+		Name:        strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		Description: description,
+		Parameters:  parameters,
+		ScriptPath:  filepath.Clean(path),
+	}, nil
+}
+
+// ParseToolHeader extracts the description and JSON schema parameters from a tool script header.
+// Scripts without a parameter block accept an empty object.
+func ParseToolHeader(content string) (string, map[string]interface{}, error) {
+	var description string
+	var schemaLines []string
+	inSchema := false
+	depth := 0
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "%%") {
+			if inSchema {
+				return "", nil, fmt.Errorf("unterminated parameters block")
+			}
+			continue
+		}
+		text := strings.TrimSpace(strings.TrimPrefix(trimmed, "%%"))
+
+		if inSchema {
+			schemaLines = append(schemaLines, text)
+			depth += strings.Count(text, "{") - strings.Count(text, "}")
+			if depth <= 0 {
+				inSchema = false
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(text, "Description:") && description == "":
+			description = strings.TrimSpace(strings.TrimPrefix(text, "Description:"))
+		case strings.HasPrefix(text, "Parameters:") && schemaLines == nil:
+			rest := strings.TrimSpace(strings.TrimPrefix(text, "Parameters:"))
+			schemaLines = []string{}
+			if rest != "" {
+				schemaLines = append(schemaLines, rest)
+				depth = strings.Count(rest, "{") - strings.Count(rest, "}")
+			}
+			inSchema = rest == "" || depth > 0
+		}
+	}
+
+	if inSchema {
+		return "", nil, fmt.Errorf("unterminated parameters block")
+	}
+
+	if len(schemaLines) == 0 {
+		return description, defaultToolParameters(), nil
+	}
+
+	var parameters map[string]interface{}
+	if err := json.Unmarshal([]byte(strings.Join(schemaLines, "\n")), &parameters); err != nil {
+		return "", nil, fmt.Errorf("parameters must be a JSON schema object: %w", err)
+	}
+	if schemaType, ok := parameters["type"]; ok && schemaType != "object" {
+		return "", nil, fmt.Errorf("parameters schema type must be \"object\"")
+	}
+	parameters["type"] = "object"
+	if _, ok := parameters["properties"]; !ok {
+		parameters["properties"] = map[string]interface{}{}
+	}
+
+	return description, parameters, nil
+}
+
+// defaultToolParameters returns the schema used for tools that take no arguments.
+func defaultToolParameters() map[string]interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	}
+}
+
+// StartLoop creates a new tool loop for the given session.
+func (t *ToolService) StartLoop(sessionID string) *ToolLoop {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.loopCounter++
+	loop := &ToolLoop{
+		ID:        fmt.Sprintf("tool_loop_%d", t.loopCounter),
+		SessionID: sessionID,
+		sequence:  t.loopCounter,
+	}
+	t.loops[loop.ID] = loop
+	return loop
+}
+
+// GetLoop returns the active tool loop with the given ID.
+func (t *ToolService) GetLoop(id string) (*ToolLoop, error) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	loop, exists := t.loops[id]
+	if !exists {
+		return nil, fmt.Errorf("tool loop '%s' not found", id)
+	}
+	return loop, nil
+}
+
+// RecordToolCalls appends an assistant message requesting the given tool calls to the loop.
+// Thinking blocks that came with the request are kept on the message so they can be sent back
+// with the tool results, as providers with extended thinking require.
+func (t *ToolService) RecordToolCalls(id string, content string, calls []neurotypes.ToolCall, thinkingBlocks []neurotypes.ThinkingBlock) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	loop, exists := t.loops[id]
+	if !exists {
+		return fmt.Errorf("tool loop '%s' not found", id)
+	}
+
+	loop.Rounds++
+	loop.Calls = append(loop.Calls, calls...)
+	message := neurotypes.Message{
+		ID:        fmt.Sprintf("%s-assistant-%d", id, loop.Rounds),
+		Role:      "assistant",
+		Content:   content,
+		Timestamp: time.Now(),
+		ToolCalls: calls,
+	}
+	if len(thinkingBlocks) > 0 {
+		message.Metadata = &neurotypes.MessageMetadata{ThinkingBlocks: thinkingBlocks}
+	}
+	loop.Messages = append(loop.Messages, message)
+	return nil
+}
+
+// RecordToolResult appends a tool result message to the loop.
+func (t *ToolService) RecordToolResult(id string, result neurotypes.ToolResult) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	loop, exists := t.loops[id]
+	if !exists {
+		return fmt.Errorf("tool loop '%s' not found", id)
+	}
+
+	content := result.Content
+	if result.IsError {
+		content = "Error: " + content
+	}
+
+	loop.Results = append(loop.Results, result)
+	loop.Messages = append(loop.Messages, neurotypes.Message{
+		ID:         fmt.Sprintf("%s-tool-%s", id, result.CallID),
+		Role:       "tool",
+		Content:    content,
+		Timestamp:  time.Now(),
+		ToolCallID: result.CallID,
+	})
+	return nil
+}
+
+// FindToolCall returns the tool call with the given ID from the loop.
+func (t *ToolService) FindToolCall(id string, callID string) (neurotypes.ToolCall, error) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	loop, exists := t.loops[id]
+	if !exists {
+		return neurotypes.ToolCall{}, fmt.Errorf("tool loop '%s' not found", id)
+	}
+	for _, call := range loop.Calls {
+		if call.ID == callID {
+			return call, nil
+		}
+	}
+	return neurotypes.ToolCall{}, fmt.Errorf("tool call '%s' not found in loop '%s'", callID, id)
+}
+
+// EndLoop removes a tool loop once the model has produced a final answer or the loop was abandoned.
+// Ending a loop that no longer exists does nothing.
+func (t *ToolService) EndLoop(id string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	delete(t.loops, id)
+}
+
+// LoopMark returns a mark of the loops started so far, for use with EndLoopsSince.
+func (t *ToolService) LoopMark() int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.loopCounter
+}
+
+// EndLoopsSince removes the loops started after the given mark that are still active.
+// It releases loops whose commands were abandoned because an error stopped execution.
+func (t *ToolService) EndLoopsSince(mark int) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for id, loop := range t.loops {
+		if loop.sequence > mark {
+			delete(t.loops, id)
+		}
+	}
+}
+
+// BuildLoopSession returns a copy of the session with the loop's messages appended.
+// The original session is left untouched.
+func (t *ToolService) BuildLoopSession(session *neurotypes.ChatSession, id string) (*neurotypes.ChatSession, error) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	loop, exists := t.loops[id]
+	if !exists {
+		return nil, fmt.Errorf("tool loop '%s' not found", id)
+	}
+
+	working := *session
+	working.Messages = make([]neurotypes.Message, 0, len(session.Messages)+len(loop.Messages))
+	working.Messages = append(working.Messages, session.Messages...)
+	working.Messages = append(working.Messages, loop.Messages...)
+	return &working, nil
+}
+
+// GetToolService retrieves the tool service with proper type casting.
+func (r *Registry) GetToolService() (*ToolService, error) {
+	service, err := r.GetService("tool")
+	if err != nil {
+		return nil, err
+	}
+
+	toolService, ok := service.(*ToolService)
+	if !ok {
+		return nil, fmt.Errorf("tool service has incorrect type")
+	}
+
+	return toolService, nil
+}
+
+// GetGlobalToolService returns the tool service from the global registry.
+func GetGlobalToolService() (*ToolService, error) {
+	return GetGlobalRegistry().GetToolService()
+}
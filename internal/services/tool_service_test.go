@@ -0,0 +1,211 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuroshell/pkg/neurotypes"
+)
+
+func TestToolService_Basic(t *testing.T) {
+	service := NewToolService()
+	assert.Equal(t, "tool", service.Name())
+
+	err := service.Define(neurotypes.ToolDefinition{Name: "weather"})
+	assert.Error(t, err, "define should fail before initialization")
+
+	require.NoError(t, service.Initialize())
+}
+
+func TestToolService_DefineListRemove(t *testing.T) {
+	service := NewToolService()
+	require.NoError(t, service.Initialize())
+
+	require.NoError(t, service.Define(neurotypes.ToolDefinition{Name: "zeta", ScriptPath: "zeta.neuro"}))
+	require.NoError(t, service.Define(neurotypes.ToolDefinition{Name: "alpha", ScriptPath: "alpha.neuro"}))
+
+	tools := service.List()
+	require.Len(t, tools, 2)
+	assert.Equal(t, "alpha", tools[0].Name)
+	assert.Equal(t, "zeta", tools[1].Name)
+	assert.Equal(t, "object", tools[0].Parameters["type"], "missing parameters should default to an empty object schema")
+
+	tool, err := service.Get("zeta")
+	require.NoError(t, err)
+	assert.Equal(t, "zeta.neuro", tool.ScriptPath)
+
+	require.NoError(t, service.Remove("zeta"))
+	_, err = service.Get("zeta")
+	assert.Error(t, err)
+	assert.Error(t, service.Remove("zeta"))
+	assert.Len(t, service.List(), 1)
+}
+
+func TestToolService_Define_InvalidName(t *testing.T) {
+	service := NewToolService()
+	require.NoError(t, service.Initialize())
+
+	tests := []string{"", "has space", "dots.not.allowed", string(make([]byte, 65))}
+	for _, name := range tests {
+		err := service.Define(neurotypes.ToolDefinition{Name: name})
+		assert.Error(t, err, "name %q should be rejected", name)
+	}
+}
+
+func TestParseToolHeader(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		description  string
+		expectError  bool
+		expectedKeys []string
+	}{
+		{
+			name: "description and multi-line schema",
+			content: `%% Description: Get the weather for a city
+%% Parameters:
+%% {
+%%   "type": "object",
+%%   "properties": {"city": {"type": "string"}},
+%%   "required": ["city"]
+%% }
+\echo Sunny in ${city}`,
+			description:  "Get the weather for a city",
+			expectedKeys: []string{"city"},
+		},
+		{
+			name:         "inline schema",
+			content:      "%% Parameters: {\"properties\": {\"n\": {\"type\": \"integer\"}}}\n\\echo ${n}",
+			expectedKeys: []string{"n"},
+		},
+		{
+			name:         "no header",
+			content:      "\\echo hello",
+			expectedKeys: []string{},
+		},
+		{
+			name:        "invalid json",
+			content:     "%% Parameters: {not json}\n",
+			expectError: true,
+		},
+		{
+			name:        "non-object schema",
+			content:     "%% Parameters: {\"type\": \"string\"}\n",
+			expectError: true,
+		},
+		{
+			name:        "unterminated block",
+			content:     "%% Parameters:\n%% {\n%%   \"type\": \"object\"\n\\echo oops",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			description, parameters, err := ParseToolHeader(tt.content)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.description, description)
+			assert.Equal(t, "object", parameters["type"])
+
+			properties, ok := parameters["properties"].(map[string]interface{})
+			require.True(t, ok)
+			assert.Len(t, properties, len(tt.expectedKeys))
+			for _, key := range tt.expectedKeys {
+				assert.Contains(t, properties, key)
+			}
+		})
+	}
+}
+
+func TestToolService_LoadToolScript(t *testing.T) {
+	service := NewToolService()
+	require.NoError(t, service.Initialize())
+
+	path := filepath.Join(t.TempDir(), "get_time.neuro")
+	require.NoError(t, os.WriteFile(path, []byte("%% Description: Current time\n\\echo noon\n"), 0644))
+
+	tool, err := service.LoadToolScript(path)
+	require.NoError(t, err)
+	assert.Equal(t, "get_time", tool.Name)
+	assert.Equal(t, "Current time", tool.Description)
+	assert.Equal(t, path, tool.ScriptPath)
+
+	_, err = service.LoadToolScript(filepath.Join(t.TempDir(), "missing.neuro"))
+	assert.Error(t, err)
+}
+
+func TestToolService_Loop(t *testing.T) {
+	service := NewToolService()
+	require.NoError(t, service.Initialize())
+
+	session := &neurotypes.ChatSession{
+		ID:       "session-1",
+		Messages: []neurotypes.Message{{ID: "m1", Role: "user", Content: "What's the weather?"}},
+	}
+
+	loop := service.StartLoop(session.ID)
+	assert.Equal(t, "tool_loop_1", loop.ID)
+
+	calls := []neurotypes.ToolCall{{ID: "call-1", Name: "weather", Arguments: `{"city":"Paris"}`}}
+	thinking := []neurotypes.ThinkingBlock{{Content: "Check the weather", Provider: "anthropic", Type: "thinking", Signature: "sig"}}
+	require.NoError(t, service.RecordToolCalls(loop.ID, "", calls, thinking))
+
+	call, err := service.FindToolCall(loop.ID, "call-1")
+	require.NoError(t, err)
+	assert.Equal(t, "weather", call.Name)
+	_, err = service.FindToolCall(loop.ID, "call-2")
+	assert.Error(t, err)
+
+	require.NoError(t, service.RecordToolResult(loop.ID, neurotypes.ToolResult{CallID: "call-1", Name: "weather", Content: "boom", IsError: true}))
+
+	working, err := service.BuildLoopSession(session, loop.ID)
+	require.NoError(t, err)
+	require.Len(t, working.Messages, 3)
+	assert.Equal(t, "assistant", working.Messages[1].Role)
+	assert.Len(t, working.Messages[1].ToolCalls, 1)
+	require.NotNil(t, working.Messages[1].Metadata)
+	assert.Equal(t, thinking, working.Messages[1].Metadata.ThinkingBlocks)
+	assert.Equal(t, "tool", working.Messages[2].Role)
+	assert.Equal(t, "call-1", working.Messages[2].ToolCallID)
+	assert.Equal(t, "Error: boom", working.Messages[2].Content)
+	assert.Len(t, session.Messages, 1, "original session must not be modified")
+
+	current, err := service.GetLoop(loop.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, current.Rounds)
+
+	service.EndLoop(loop.ID)
+	_, err = service.GetLoop(loop.ID)
+	assert.Error(t, err)
+	assert.Error(t, service.RecordToolResult(loop.ID, neurotypes.ToolResult{CallID: "call-1"}))
+}
+
+func TestToolService_EndLoopsSince(t *testing.T) {
+	service := NewToolService()
+	require.NoError(t, service.Initialize())
+
+	outer := service.StartLoop("session-1")
+	mark := service.LoopMark()
+	inner := service.StartLoop("session-1")
+	ended := service.StartLoop("session-2")
+	service.EndLoop(ended.ID)
+
+	// Only loops started after the mark are released
+	service.EndLoopsSince(mark)
+	_, err := service.GetLoop(inner.ID)
+	assert.Error(t, err)
+	_, err = service.GetLoop(outer.ID)
+	assert.NoError(t, err)
+
+	service.EndLoopsSince(0)
+	_, err = service.GetLoop(outer.ID)
+	assert.Error(t, err)
+}
@@ -0,0 +1,237 @@
+// Package services provides token usage and cost accounting for NeuroShell.
+package services
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"neuroshell/internal/logger"
+	"neuroshell/pkg/neurotypes"
+)
+
+// UsageService keeps running token usage and cost totals per chat session and per model.
+// Totals live for the lifetime of the shell process and can be reset with Reset.
+type UsageService struct {
+	initialized bool
+	sessions    map[string]*neurotypes.UsageTotals
+	models      map[string]*neurotypes.UsageTotals
+	overall     neurotypes.UsageTotals
+	mutex       sync.RWMutex
+}
+
+// NewUsageService creates a new UsageService instance.
+func NewUsageService() *UsageService {
+	return &UsageService{
+		initialized: false,
+		sessions:    make(map[string]*neurotypes.UsageTotals),
+		models:      make(map[string]*neurotypes.UsageTotals),
+	}
+}
+
+// Name returns the service name "usage" for registration.
+func (u *UsageService) Name() string {
+	return "usage"
+}
+
+// Initialize sets up the UsageService for operation.
+func (u *UsageService) Initialize() error {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	u.initialized = true
+	logger.Debug("UsageService initialized")
+	return nil
+}
+
+// Record adds the usage of a single request to the session, model and overall totals.
+func (u *UsageService) Record(sessionID string, modelName string, usage neurotypes.TokenUsage, cost float64, priced bool) error {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if !u.initialized {
+		return fmt.Errorf("usage service not initialized")
+	}
+
+	if sessionID != "" {
+		if u.sessions[sessionID] == nil {
+			u.sessions[sessionID] = &neurotypes.UsageTotals{}
+		}
+		u.sessions[sessionID].Add(usage, cost, priced)
+	}
+	if modelName != "" {
+		if u.models[modelName] == nil {
+			u.models[modelName] = &neurotypes.UsageTotals{}
+		}
+		u.models[modelName].Add(usage, cost, priced)
+	}
+	u.overall.Add(usage, cost, priced)
+
+	logger.Debug("Usage recorded", "session", sessionID, "model", modelName,
+		"input_tokens", usage.InputTokens, "output_tokens", usage.OutputTokens, "cost_usd", cost)
+	return nil
+}
+
+// GetSessionTotals returns the totals recorded for a session.
+func (u *UsageService) GetSessionTotals(sessionID string) neurotypes.UsageTotals {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+
+	if totals, exists := u.sessions[sessionID]; exists {
+		return *totals
+	}
+	return neurotypes.UsageTotals{}
+}
+
+// GetModelTotals returns the totals recorded for a model.
+func (u *UsageService) GetModelTotals(modelName string) neurotypes.UsageTotals {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+
+	if totals, exists := u.models[modelName]; exists {
+		return *totals
+	}
+	return neurotypes.UsageTotals{}
+}
+
+// GetOverallTotals returns the totals across all sessions and models.
+func (u *UsageService) GetOverallTotals() neurotypes.UsageTotals {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+
+	return u.overall
+}
+
+// ListSessionIDs returns the IDs of all sessions with recorded usage, sorted.
+func (u *UsageService) ListSessionIDs() []string {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+
+	return sortedUsageKeys(u.sessions)
+}
+
+// ListModelNames returns the names of all models with recorded usage, sorted.
+func (u *UsageService) ListModelNames() []string {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+
+	return sortedUsageKeys(u.models)
+}
+
+// Reset clears all recorded totals.
+func (u *UsageService) Reset() {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	u.sessions = make(map[string]*neurotypes.UsageTotals)
+	u.models = make(map[string]*neurotypes.UsageTotals)
+	u.overall = neurotypes.UsageTotals{}
+}
+
+// sortedUsageKeys returns the keys of a totals map in sorted order.
+func sortedUsageKeys(totals map[string]*neurotypes.UsageTotals) []string {
+	keys := make([]string, 0, len(totals))
+	for key := range totals {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// CalculateCost returns the USD cost of a request from catalog pricing.
+// The second return value is false when no pricing is available.
+func CalculateCost(usage neurotypes.TokenUsage, pricing *neurotypes.ModelPricing) (float64, bool) {
+	if pricing == nil || (pricing.InputPerMToken == 0 && pricing.OutputPerMToken == 0) {
+		return 0, false
+	}
+	cost := float64(usage.InputTokens)*pricing.InputPerMToken/1_000_000 +
+		float64(usage.OutputTokens)*pricing.OutputPerMToken/1_000_000
+	return cost, true
+}
+
+// usageMetadata builds response metadata for a provider, including token usage when reported.
+func usageMetadata(provider string, model string, usage *neurotypes.TokenUsage) map[string]interface{} {
+	metadata := map[string]interface{}{"provider": provider, "model": model}
+	if usage != nil {
+		metadata[neurotypes.UsageInputTokensKey] = usage.InputTokens
+		metadata[neurotypes.UsageOutputTokensKey] = usage.OutputTokens
+		metadata[neurotypes.UsageThinkingTokensKey] = usage.ThinkingTokens
+		metadata[neurotypes.UsageCachedTokensKey] = usage.CachedTokens
+	}
+	return metadata
+}
+
+// withResponseDetails adds the stop reason and provider request ID to response metadata when reported.
+func withResponseDetails(metadata map[string]interface{}, stopReason string, requestID string) map[string]interface{} {
+	if stopReason != "" {
+		metadata[neurotypes.ResponseStopReasonKey] = stopReason
+	}
+	if requestID != "" {
+		metadata[neurotypes.ResponseRequestIDKey] = requestID
+	}
+	return metadata
+}
+
+// ResponseDetailsFromMetadata returns the stop reason, provider request ID and latency recorded in response metadata.
+func ResponseDetailsFromMetadata(metadata map[string]interface{}) (stopReason string, requestID string, latencyMs int64) {
+	if metadata == nil {
+		return "", "", 0
+	}
+	stopReason, _ = metadata[neurotypes.ResponseStopReasonKey].(string)
+	requestID, _ = metadata[neurotypes.ResponseRequestIDKey].(string)
+	return stopReason, requestID, metadataInt(metadata, neurotypes.ResponseLatencyMsKey)
+}
+
+// UsageFromMetadata extracts token usage from structured response metadata.
+// The second return value is false when the response carries no usage information.
+func UsageFromMetadata(metadata map[string]interface{}) (neurotypes.TokenUsage, bool) {
+	if metadata == nil {
+		return neurotypes.TokenUsage{}, false
+	}
+	if _, exists := metadata[neurotypes.UsageInputTokensKey]; !exists {
+		return neurotypes.TokenUsage{}, false
+	}
+
+	return neurotypes.TokenUsage{
+		InputTokens:    metadataInt(metadata, neurotypes.UsageInputTokensKey),
+		OutputTokens:   metadataInt(metadata, neurotypes.UsageOutputTokensKey),
+		ThinkingTokens: metadataInt(metadata, neurotypes.UsageThinkingTokensKey),
+		CachedTokens:   metadataInt(metadata, neurotypes.UsageCachedTokensKey),
+	}, true
+}
+
+// metadataInt reads an integer metadata value regardless of its numeric type.
+func metadataInt(metadata map[string]interface{}, key string) int64 {
+	switch value := metadata[key].(type) {
+	case int64:
+		return value
+	case int:
+		return int64(value)
+	case int32:
+		return int64(value)
+	case float64:
+		return int64(value)
+	default:
+		return 0
+	}
+}
+
+// GetUsageService retrieves the usage service with proper type casting.
+func (r *Registry) GetUsageService() (*UsageService, error) {
+	service, err := r.GetService("usage")
+	if err != nil {
+		return nil, err
+	}
+
+	usageService, ok := service.(*UsageService)
+	if !ok {
+		return nil, fmt.Errorf("usage service has incorrect type")
+	}
+
+	return usageService, nil
+}
+
+// GetGlobalUsageService returns the usage service from the global registry.
+func GetGlobalUsageService() (*UsageService, error) {
+	return GetGlobalRegistry().GetUsageService()
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genai"
+
+	"neuroshell/pkg/neurotypes"
+)
+
+func TestUsageService_Basic(t *testing.T) {
+	service := NewUsageService()
+	assert.Equal(t, "usage", service.Name())
+
+	err := service.Record("s1", "m1", neurotypes.TokenUsage{InputTokens: 1}, 0, false)
+	assert.Error(t, err, "record should fail before initialization")
+
+	require.NoError(t, service.Initialize())
+}
+
+func TestUsageService_RecordTotals(t *testing.T) {
+	service := NewUsageService()
+	require.NoError(t, service.Initialize())
+
+	require.NoError(t, service.Record("s1", "gpt", neurotypes.TokenUsage{InputTokens: 100, OutputTokens: 50, ThinkingTokens: 10, CachedTokens: 20}, 0.5, true))
+	require.NoError(t, service.Record("s1", "claude", neurotypes.TokenUsage{InputTokens: 10, OutputTokens: 5}, 0, false))
+	require.NoError(t, service.Record("s2", "gpt", neurotypes.TokenUsage{InputTokens: 1, OutputTokens: 1}, 0.25, true))
+
+	session := service.GetSessionTotals("s1")
+	assert.Equal(t, 2, session.Requests)
+	assert.Equal(t, 1, session.UnpricedRequests)
+	assert.Equal(t, int64(110), session.Tokens.InputTokens)
+	assert.Equal(t, int64(55), session.Tokens.OutputTokens)
+	assert.Equal(t, int64(10), session.Tokens.ThinkingTokens)
+	assert.Equal(t, int64(20), session.Tokens.CachedTokens)
+	assert.InDelta(t, 0.5, session.CostUSD, 1e-9)
+
+	model := service.GetModelTotals("gpt")
+	assert.Equal(t, 2, model.Requests)
+	assert.InDelta(t, 0.75, model.CostUSD, 1e-9)
+
+	overall := service.GetOverallTotals()
+	assert.Equal(t, 3, overall.Requests)
+	assert.Equal(t, int64(111), overall.Tokens.InputTokens)
+
+	assert.Equal(t, []string{"s1", "s2"}, service.ListSessionIDs())
+	assert.Equal(t, []string{"claude", "gpt"}, service.ListModelNames())
+	assert.Equal(t, 0, service.GetSessionTotals("missing").Requests)
+
+	service.Reset()
+	assert.Equal(t, 0, service.GetOverallTotals().Requests)
+	assert.Empty(t, service.ListSessionIDs())
+	assert.Empty(t, service.ListModelNames())
+}
+
+func TestCalculateCost(t *testing.T) {
+	usage := neurotypes.TokenUsage{InputTokens: 2_000_000, OutputTokens: 500_000}
+
+	cost, priced := CalculateCost(usage, &neurotypes.ModelPricing{InputPerMToken: 1.25, OutputPerMToken: 10})
+	assert.True(t, priced)
+	assert.InDelta(t, 7.5, cost, 1e-9)
+
+	cost, priced = CalculateCost(usage, nil)
+	assert.False(t, priced)
+	assert.Zero(t, cost)
+
+	_, priced = CalculateCost(usage, &neurotypes.ModelPricing{})
+	assert.False(t, priced, "zero pricing is treated as unknown")
+}
+
+func TestUsageMetadataRoundTrip(t *testing.T) {
+	metadata := usageMetadata("openai", "gpt-4", &neurotypes.TokenUsage{InputTokens: 12, OutputTokens: 34, ThinkingTokens: 5, CachedTokens: 6})
+	assert.Equal(t, "openai", metadata["provider"])
+
+	usage, ok := UsageFromMetadata(metadata)
+	require.True(t, ok)
+	assert.Equal(t, neurotypes.TokenUsage{InputTokens: 12, OutputTokens: 34, ThinkingTokens: 5, CachedTokens: 6}, usage)
+
+	// Values decoded from JSON arrive as float64
+	usage, ok = UsageFromMetadata(map[string]interface{}{"input_tokens": float64(7), "output_tokens": float64(8)})
+	require.True(t, ok)
+	assert.Equal(t, int64(7), usage.InputTokens)
+	assert.Equal(t, int64(8), usage.OutputTokens)
+
+	_, ok = UsageFromMetadata(usageMetadata("openai", "gpt-4", nil))
+	assert.False(t, ok)
+	_, ok = UsageFromMetadata(nil)
+	assert.False(t, ok)
+}
+
+func TestProviderUsageNormalization(t *testing.T) {
+	assert.Nil(t, anthropicUsage(anthropic.BetaUsage{}))
+	usage := anthropicUsage(anthropic.BetaUsage{InputTokens: 10, CacheReadInputTokens: 90, CacheCreationInputTokens: 5, OutputTokens: 20})
+	require.NotNil(t, usage)
+	assert.Equal(t, int64(105), usage.InputTokens, "cache reads and writes count as input")
+	assert.Equal(t, int64(90), usage.CachedTokens)
+	assert.Equal(t, int64(20), usage.OutputTokens)
+
+	assert.Nil(t, geminiUsage(nil))
+	usage = geminiUsage(&genai.GenerateContentResponseUsageMetadata{PromptTokenCount: 30, CandidatesTokenCount: 10, ThoughtsTokenCount: 15, CachedContentTokenCount: 4})
+	require.NotNil(t, usage)
+	assert.Equal(t, int64(30), usage.InputTokens)
+	assert.Equal(t, int64(25), usage.OutputTokens, "thought tokens count as output")
+	assert.Equal(t, int64(15), usage.ThinkingTokens)
+	assert.Equal(t, int64(4), usage.CachedTokens)
+
+	assert.Nil(t, openAIChatUsage(openai.CompletionUsage{}))
+	chatUsage := openai.CompletionUsage{PromptTokens: 40, CompletionTokens: 60}
+	chatUsage.CompletionTokensDetails.ReasoningTokens = 25
+	chatUsage.PromptTokensDetails.CachedTokens = 32
+	usage = openAIChatUsage(chatUsage)
+	require.NotNil(t, usage)
+	assert.Equal(t, neurotypes.TokenUsage{InputTokens: 40, OutputTokens: 60, ThinkingTokens: 25, CachedTokens: 32}, *usage)
+}
@@ -85,6 +85,76 @@ func (v *VariableService) SetSystemVariable(name, value string) error {
 	return v.varCtx.SetSystemVariable(name, value)
 }
 
+// PushScriptFrame starts the variable frame of a script invocation and returns its ID.
+// The parameter variables (_0, _1, _* and _@) are set and named options become local variables;
+// PopScriptFrame drops them and restores the caller's parameter variables.
+func (v *VariableService) PushScriptFrame(name string, parameters, options map[string]string) (string, error) {
+	if !v.initialized {
+		return "", fmt.Errorf("variable service not initialized")
+	}
+
+	if v.varCtx == nil {
+		return "", fmt.Errorf("variable subcontext not available")
+	}
+
+	return v.varCtx.PushScriptFrame(name, parameters, options), nil
+}
+
+// PopScriptFrame ends the innermost variable frame if it has the given ID.
+func (v *VariableService) PopScriptFrame(id string) bool {
+	if !v.initialized || v.varCtx == nil {
+		return false
+	}
+	return v.varCtx.PopScriptFrame(id)
+}
+
+// UnwindScriptFrames ends variable frames until only depth remain, as when a script stops on an error.
+func (v *VariableService) UnwindScriptFrames(depth int) {
+	if !v.initialized || v.varCtx == nil {
+		return
+	}
+	for v.varCtx.GetScriptFrameDepth() > depth {
+		if !v.varCtx.PopScriptFrame(v.varCtx.GetCurrentScriptFrameID()) {
+			return
+		}
+	}
+}
+
+// GetScriptFrameDepth returns the number of running script invocations.
+func (v *VariableService) GetScriptFrameDepth() int {
+	if !v.initialized || v.varCtx == nil {
+		return 0
+	}
+	return v.varCtx.GetScriptFrameDepth()
+}
+
+// SetLocal sets a variable local to the running script, hiding any global variable of that name
+// until the script returns.
+func (v *VariableService) SetLocal(name, value string) error {
+	if !v.initialized {
+		return fmt.Errorf("variable service not initialized")
+	}
+
+	if v.varCtx == nil {
+		return fmt.Errorf("variable subcontext not available")
+	}
+
+	return v.varCtx.SetLocalVariable(name, value)
+}
+
+// Export sets a variable in the scope of the running script's caller.
+func (v *VariableService) Export(name, value string) error {
+	if !v.initialized {
+		return fmt.Errorf("variable service not initialized")
+	}
+
+	if v.varCtx == nil {
+		return fmt.Errorf("variable subcontext not available")
+	}
+
+	return v.varCtx.ExportVariable(name, value)
+}
+
 // InterpolateString processes ${var} replacements in a string using the variable subcontext
 func (v *VariableService) InterpolateString(text string) (string, error) {
 	if !v.initialized {
@@ -5,13 +5,15 @@ package shell
 import (
 	"strings"
 
-	_ "neuroshell/internal/commands/assert"   // Import assert commands (init functions)
-	_ "neuroshell/internal/commands/builtin"  // Import for side effects (init functions)
-	_ "neuroshell/internal/commands/llm"      // Import llm commands (init functions)
-	_ "neuroshell/internal/commands/model"    // Import model commands (init functions)
-	_ "neuroshell/internal/commands/provider" // Import provider commands (init functions)
-	_ "neuroshell/internal/commands/render"   // Import render commands (init functions)
-	_ "neuroshell/internal/commands/session"  // Import session commands (init functions)
+	_ "neuroshell/internal/commands/assert"     // Import assert commands (init functions)
+	_ "neuroshell/internal/commands/builtin"    // Import for side effects (init functions)
+	_ "neuroshell/internal/commands/claudecode" // Import claude code commands (init functions)
+	_ "neuroshell/internal/commands/llm"        // Import llm commands (init functions)
+	_ "neuroshell/internal/commands/model"      // Import model commands (init functions)
+	_ "neuroshell/internal/commands/provider"   // Import provider commands (init functions)
+	_ "neuroshell/internal/commands/render"     // Import render commands (init functions)
+	_ "neuroshell/internal/commands/session"    // Import session commands (init functions)
+	_ "neuroshell/internal/commands/tool"       // Import tool commands (init functions)
 	"neuroshell/internal/context"
 	"neuroshell/internal/logger"
 	"neuroshell/internal/services"
@@ -23,6 +25,12 @@ import (
 // PromptUpdateCallback is called after command execution to update shell prompt
 var PromptUpdateCallback func()
 
+// blockContinuationPrompt is shown while the lines of an open block are being entered.
+const blockContinuationPrompt = "... "
+
+// pendingBlock holds the lines of a multi-line block typed in the shell until its \end or \enddef.
+var pendingBlock []string
+
 // ProcessInput handles user input from the interactive shell and executes commands.
 func ProcessInput(c *ishell.Context) {
 	if len(c.RawArgs) == 0 {
@@ -32,8 +40,24 @@ func ProcessInput(c *ishell.Context) {
 	rawInput := strings.Join(c.RawArgs, " ")
 	rawInput = strings.TrimSpace(rawInput)
 
-	// Skip comment lines (same logic as script service)
-	if strings.HasPrefix(rawInput, "%%") {
+	// Skip comment lines (same logic as script service), except inside a block, where they
+	// may be the help text of a \def
+	if strings.HasPrefix(rawInput, "%%") && len(pendingBlock) == 0 {
+		return
+	}
+
+	// Collect the lines of a block (\if[...] with no command, \else, \end, \def … \enddef) until it is closed
+	if len(pendingBlock) > 0 || statemachine.IsBlockLine(rawInput) {
+		pendingBlock = append(pendingBlock, rawInput)
+		if statemachine.BlockDepth(pendingBlock) > 0 {
+			if c.Actions != nil {
+				c.SetPrompt(blockContinuationPrompt)
+			}
+			return
+		}
+		lines := pendingBlock
+		pendingBlock = nil
+		executeBlock(c, lines)
 		return
 	}
 
@@ -117,8 +141,44 @@ func InitializeServices(testMode bool) error {
 		return err
 	}
 
-	// Use mock LLM service in test mode, new LLM service in production
-	if testMode {
+	// Register ToolService
+	if err := services.GetGlobalRegistry().RegisterService(services.NewToolService()); err != nil {
+		return err
+	}
+
+	// Register DefinitionService
+	if err := services.GetGlobalRegistry().RegisterService(services.NewDefinitionService()); err != nil {
+		return err
+	}
+
+	// Register ScriptLibraryService
+	if err := services.GetGlobalRegistry().RegisterService(services.NewScriptLibraryService()); err != nil {
+		return err
+	}
+
+	// Register SessionSearchService
+	if err := services.GetGlobalRegistry().RegisterService(services.NewSessionSearchService()); err != nil {
+		return err
+	}
+
+	// Register UsageService
+	if err := services.GetGlobalRegistry().RegisterService(services.NewUsageService()); err != nil {
+		return err
+	}
+
+	// Register BudgetService
+	if err := services.GetGlobalRegistry().RegisterService(services.NewBudgetService()); err != nil {
+		return err
+	}
+
+	// Register ContextWindowService
+	if err := services.GetGlobalRegistry().RegisterService(services.NewContextWindowService()); err != nil {
+		return err
+	}
+
+	// Use mock LLM service in test mode, new LLM service in production.
+	// A cassette supplies recorded provider traffic, so it keeps the real LLM service even in test mode.
+	if testMode && !cassetteConfigured() {
 		if err := services.GetGlobalRegistry().RegisterService(services.NewMockLLMService()); err != nil {
 			return err
 		}
@@ -181,8 +241,31 @@ func InitializeServices(testMode bool) error {
 	return nil
 }
 
+// cassetteConfigured reports whether LLM traffic is recorded to or replayed from a cassette.
+func cassetteConfigured() bool {
+	debugTransportService, err := services.GetGlobalDebugTransportService()
+	if err != nil {
+		return false
+	}
+	return debugTransportService.HasCassette()
+}
+
 func executeCommand(c *ishell.Context, rawInput string) {
+	runStateMachine(c, rawInput, func(stateMachine *statemachine.StateMachine) error {
+		// Execute through state machine (handles complete pipeline)
+		return stateMachine.Execute(rawInput)
+	})
+}
+
+// executeBlock executes the lines of a multi-line block entered in the shell.
+func executeBlock(c *ishell.Context, lines []string) {
+	runStateMachine(c, strings.Join(lines, "\n"), func(stateMachine *statemachine.StateMachine) error {
+		return stateMachine.ExecuteLines(lines)
+	})
+}
 
+// runStateMachine runs input through a new state machine, reports errors and updates the prompt.
+func runStateMachine(c *ishell.Context, rawInput string, run func(*statemachine.StateMachine) error) {
 	// Get the global context singleton
 	globalCtx := GetGlobalContext()
 
@@ -192,8 +275,7 @@ func executeCommand(c *ishell.Context, rawInput string) {
 	// Create state machine with default configuration
 	stateMachine := statemachine.NewStateMachineWithDefaults(globalCtx)
 
-	// Execute through state machine (handles complete pipeline)
-	err := stateMachine.Execute(rawInput)
+	err := run(stateMachine)
 
 	if err != nil {
 		logger.Error("Command failed", "command", rawInput, "error", err)
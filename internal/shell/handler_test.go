@@ -1571,3 +1571,56 @@ func TestExecuteCommand_DirectCoverage(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessInput_MultiLineBlock(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	defer func() { pendingBlock = nil }()
+
+	lines := [][]string{
+		{"\\if[condition=${flag}]"},
+		{"\\set[branch=then]"},
+		{"\\else"},
+		{"\\set[branch=else]"},
+		{"\\end"},
+	}
+	for i, rawArgs := range lines {
+		ctx := &ishell.Context{}
+		ctx.RawArgs = rawArgs
+		ProcessInput(ctx)
+
+		if i < len(lines)-1 {
+			// Nothing runs until the block is closed
+			assert.Len(t, pendingBlock, i+1)
+			branch, _ := GetGlobalContext().GetVariable("branch")
+			assert.Empty(t, branch)
+		}
+	}
+
+	assert.Empty(t, pendingBlock)
+	branch, err := GetGlobalContext().GetVariable("branch")
+	require.NoError(t, err)
+	assert.Equal(t, "else", branch)
+}
+
+func TestProcessInput_DefBlockKeepsComments(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	defer func() { pendingBlock = nil }()
+	require.NoError(t, commands.GetGlobalRegistry().Register(&builtin.DefCommand{}))
+
+	for _, line := range []string{"%% ignored outside a block", "\\def mark", "%% Mark the message", "\\set[marked=${_1}]", "\\enddef", "\\mark done"} {
+		ctx := &ishell.Context{}
+		ctx.RawArgs = []string{line}
+		ProcessInput(ctx)
+	}
+
+	assert.Empty(t, pendingBlock)
+	marked, err := GetGlobalContext().GetVariable("marked")
+	require.NoError(t, err)
+	assert.Equal(t, "done", marked)
+
+	helpInfo, exists := GetGlobalContext().GetCommandHelpInfo("mark")
+	require.True(t, exists)
+	assert.Equal(t, "Mark the message", helpInfo.Description)
+}
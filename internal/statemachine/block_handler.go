@@ -0,0 +1,123 @@
+// Package statemachine implements block boundary management for the stack-based execution engine.
+// The BlockHandler runs the markers produced by CompileBlocks: conditional branches and loop bodies.
+package statemachine
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"neuroshell/internal/context"
+	"neuroshell/internal/logger"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/stringprocessing"
+
+	"github.com/charmbracelet/log"
+)
+
+// BlockHandler manages block markers on the stack.
+// IF_BLOCK_START evaluates the block's condition and skips past IF_BLOCK_ELSE when it does not
+// hold; reaching IF_BLOCK_ELSE after the first branch skips past IF_BLOCK_END. A BLOCK_BODY
+// entry is replaced by the lines it holds.
+type BlockHandler struct {
+	// Interpolator for block conditions
+	interpolator *CoreInterpolator
+	// Services
+	stackService    *services.StackService
+	variableService *services.VariableService
+	// Logger
+	logger *log.Logger
+}
+
+// NewBlockHandler creates a new block handler with the required services.
+func NewBlockHandler(ctx *context.NeuroContext) *BlockHandler {
+	bh := &BlockHandler{
+		interpolator: NewCoreInterpolator(ctx),
+		logger:       logger.NewStyledLogger("BlockHandler"),
+	}
+
+	// Initialize services
+	var err error
+	bh.stackService, err = services.GetGlobalStackService()
+	if err != nil {
+		bh.logger.Error("Failed to get stack service", "error", err)
+	}
+
+	bh.variableService, err = services.GetGlobalVariableService()
+	if err != nil {
+		bh.logger.Error("Failed to get variable service", "error", err)
+	}
+
+	return bh
+}
+
+// IsBlockMarker checks if a command is a block marker or block body.
+func (bh *BlockHandler) IsBlockMarker(command string) bool {
+	return strings.HasPrefix(command, "IF_BLOCK_START:") ||
+		strings.HasPrefix(command, "IF_BLOCK_ELSE:") ||
+		strings.HasPrefix(command, "IF_BLOCK_END:") ||
+		strings.HasPrefix(command, "BLOCK_BODY:")
+}
+
+// HandleMarker runs a block marker or expands a block body.
+func (bh *BlockHandler) HandleMarker(command string) error {
+	if bh.stackService == nil {
+		return nil
+	}
+
+	switch {
+	case strings.HasPrefix(command, "BLOCK_BODY:"):
+		var lines []string
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(command, "BLOCK_BODY:")), &lines); err != nil {
+			return fmt.Errorf("invalid block body: %w", err)
+		}
+		// Push block lines in reverse order (LIFO execution)
+		for i := len(lines) - 1; i >= 0; i-- {
+			bh.stackService.PushCommand(lines[i])
+		}
+	case strings.HasPrefix(command, "IF_BLOCK_START:"):
+		// IF_BLOCK_START:<id>:<if|if-not>:<condition>
+		parts := strings.SplitN(strings.TrimPrefix(command, "IF_BLOCK_START:"), ":", 3)
+		if len(parts) != 3 {
+			return fmt.Errorf("invalid block marker: %s", command)
+		}
+		blockID, kind, condition := parts[0], parts[1], parts[2]
+
+		expanded, _, err := bh.interpolator.InterpolateCommandLine(condition)
+		if err != nil {
+			return fmt.Errorf("variable expansion failed: %w", err)
+		}
+		result := stringprocessing.IsTruthy(strings.TrimSpace(expanded))
+		if bh.variableService != nil {
+			_ = bh.variableService.SetSystemVariable("#"+strings.ReplaceAll(kind, "-", "_")+"_result", strconv.FormatBool(result))
+		}
+
+		run := result
+		if kind == "if-not" {
+			run = !result
+		}
+		bh.logger.Debug("Evaluated block condition", "blockID", blockID, "kind", kind, "condition", expanded, "run", run)
+		if !run {
+			bh.skipPast("IF_BLOCK_ELSE:" + blockID)
+		}
+	case strings.HasPrefix(command, "IF_BLOCK_ELSE:"):
+		// The first branch ran, so the else branch is skipped
+		bh.skipPast("IF_BLOCK_END:" + strings.TrimPrefix(command, "IF_BLOCK_ELSE:"))
+	}
+	return nil
+}
+
+// skipPast pops commands up to and including the given marker.
+func (bh *BlockHandler) skipPast(marker string) {
+	skipCount := 0
+	for !bh.stackService.IsEmpty() {
+		command, hasCommand := bh.stackService.PopCommand()
+		if !hasCommand || command == marker {
+			bh.logger.Debug("Skipped block branch", "marker", marker, "skipCount", skipCount)
+			return
+		}
+		skipCount++
+	}
+	bh.logger.Error("Never found matching block marker", "marker", marker, "totalSkipped", skipCount)
+}
@@ -0,0 +1,248 @@
+// Package statemachine implements multi-line block compilation for the stack-based execution engine.
+// Blocks let one condition or loop guard several lines: \if[...] … \else … \end and \for-each[...] … \end.
+// A \def name … \enddef block defines a command whose body runs like a script.
+package statemachine
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"neuroshell/internal/parser"
+)
+
+// Global counter for generating unique block IDs
+var blockCounter int64
+
+// blockCommands are the commands that open a block when written without a command after them.
+var blockCommands = map[string]bool{
+	"if":       true,
+	"if-not":   true,
+	"for-each": true,
+	"repeat":   true,
+	"while":    true,
+}
+
+// ScriptLine is a line of a script together with its 1-based line number, used in block errors.
+type ScriptLine struct {
+	Number int
+	Text   string
+}
+
+// CompileBlocks turns script lines into stack entries, in execution order.
+// Lines outside blocks are kept as they are. An \if or \if-not block becomes IF_BLOCK_START,
+// IF_BLOCK_ELSE and IF_BLOCK_END markers around its branches, and a loop block becomes its
+// header command with the compiled body attached as a BLOCK_BODY entry. A \def block becomes a
+// \def command carrying its uncompiled body, and %% comments outside \def bodies are dropped.
+// Unbalanced \else and \end lines are reported with their line numbers.
+func CompileBlocks(lines []ScriptLine) ([]string, error) {
+	bc := &blockCompiler{lines: lines}
+	entries, stop, err := bc.compile()
+	if err != nil {
+		return nil, err
+	}
+	switch stop.Text {
+	case "else":
+		return nil, fmt.Errorf("line %d: \\else without a matching \\if", stop.Number)
+	case "end":
+		return nil, fmt.Errorf("line %d: \\end without a matching block", stop.Number)
+	}
+	return entries, nil
+}
+
+// IsBlockLine reports whether a line opens a block or a \def, or is an \else, \end or \enddef line.
+func IsBlockLine(line string) bool {
+	kind, _ := blockLineKind(line)
+	return kind != ""
+}
+
+// BlockDepth returns how many blocks are still open at the end of the given lines.
+// It is zero or negative once every block is closed, or when an \end or \enddef has no matching block.
+func BlockDepth(lines []string) int {
+	depth := 0
+	for _, line := range lines {
+		switch kind, _ := blockLineKind(line); kind {
+		case "open", "def":
+			depth++
+		case "end", "enddef":
+			depth--
+			if depth < 0 {
+				return depth
+			}
+		}
+	}
+	return depth
+}
+
+// blockLineKind classifies a line as "open", "else", "end", "def", "enddef" or "" for an ordinary line.
+// For "open" and "def" it also returns the parsed header.
+func blockLineKind(line string) (string, *parser.Command) {
+	trimmed := strings.TrimSpace(line)
+	switch trimmed {
+	case "\\else":
+		return "else", nil
+	case "\\end":
+		return "end", nil
+	case "\\enddef":
+		return "enddef", nil
+	}
+	if isDefCommand(trimmed) {
+		return "def", parser.ParseInput(trimmed)
+	}
+	if !strings.HasPrefix(trimmed, "\\") || !strings.Contains(trimmed, "[") {
+		return "", nil
+	}
+
+	cmd := parser.ParseInput(trimmed)
+	if !blockCommands[cmd.Name] || cmd.Message != "" {
+		return "", nil
+	}
+	return "open", cmd
+}
+
+// blockCompiler compiles script lines recursively, one block level per compile call.
+type blockCompiler struct {
+	lines []ScriptLine
+	pos   int
+}
+
+// compile compiles lines until the end of the script or an \else or \end line, which is returned
+// as the stop line with Text set to "else" or "end".
+func (bc *blockCompiler) compile() ([]string, ScriptLine, error) {
+	var entries []string
+	for bc.pos < len(bc.lines) {
+		line := bc.lines[bc.pos]
+		bc.pos++
+
+		kind, header := blockLineKind(line.Text)
+		switch kind {
+		case "else", "end":
+			return entries, ScriptLine{Number: line.Number, Text: kind}, nil
+		case "open":
+			block, err := bc.compileBlock(line, header)
+			if err != nil {
+				return nil, ScriptLine{}, err
+			}
+			entries = append(entries, block...)
+		case "def":
+			definition, err := bc.compileDef(line, header)
+			if err != nil {
+				return nil, ScriptLine{}, err
+			}
+			entries = append(entries, definition)
+		case "enddef":
+			return nil, ScriptLine{}, fmt.Errorf("line %d: \\enddef without a matching \\def", line.Number)
+		default:
+			// Comments are only kept inside \def bodies, where they are the command's help
+			if !strings.HasPrefix(strings.TrimSpace(line.Text), "%%") {
+				entries = append(entries, line.Text)
+			}
+		}
+	}
+	return entries, ScriptLine{}, nil
+}
+
+// compileBlock compiles the block opened by the header line, up to and including its \end.
+func (bc *blockCompiler) compileBlock(line ScriptLine, header *parser.Command) ([]string, error) {
+	body, stop, err := bc.compile()
+	if err != nil {
+		return nil, err
+	}
+
+	if header.Name != "if" && header.Name != "if-not" {
+		switch stop.Text {
+		case "":
+			return nil, fmt.Errorf("line %d: \\%s block is not closed with \\end", line.Number, header.Name)
+		case "else":
+			return nil, fmt.Errorf("line %d: \\else is only allowed in \\if and \\if-not blocks", stop.Number)
+		}
+		encoded, err := encodeBlockBody(body)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line.Number, err)
+		}
+		return []string{strings.TrimSpace(line.Text) + " " + encoded}, nil
+	}
+
+	condition, exists := header.Options["condition"]
+	if !exists {
+		return nil, fmt.Errorf("line %d: \\%s block requires a condition", line.Number, header.Name)
+	}
+
+	var elseBody []string
+	if stop.Text == "else" {
+		elseLine := stop.Number
+		elseBody, stop, err = bc.compile()
+		if err != nil {
+			return nil, err
+		}
+		if stop.Text == "else" {
+			return nil, fmt.Errorf("line %d: \\%s block already has an \\else on line %d", stop.Number, header.Name, elseLine)
+		}
+	}
+	if stop.Text == "" {
+		return nil, fmt.Errorf("line %d: \\%s block is not closed with \\end", line.Number, header.Name)
+	}
+
+	blockID := fmt.Sprintf("block_id_%d", atomic.AddInt64(&blockCounter, 1))
+	entries := []string{fmt.Sprintf("IF_BLOCK_START:%s:%s:%s", blockID, header.Name, condition)}
+	entries = append(entries, body...)
+	entries = append(entries, "IF_BLOCK_ELSE:"+blockID)
+	entries = append(entries, elseBody...)
+	entries = append(entries, "IF_BLOCK_END:"+blockID)
+	return entries, nil
+}
+
+// compileDef collects the body of the \def opened by the header line, up to and including its \enddef.
+// The body is kept as written, comments included, and compiled again each time the command runs;
+// its blocks are checked here so mistakes are reported with the line numbers of the definition.
+func (bc *blockCompiler) compileDef(line ScriptLine, header *parser.Command) (string, error) {
+	name := strings.TrimSpace(header.Message)
+	if name == "" || len(header.Options) > 0 || strings.ContainsAny(name, " \t") {
+		return "", fmt.Errorf("line %d: \\def requires a single command name, as in \\def name", line.Number)
+	}
+
+	var body []ScriptLine
+	for bc.pos < len(bc.lines) {
+		bodyLine := bc.lines[bc.pos]
+		bc.pos++
+
+		switch kind, _ := blockLineKind(bodyLine.Text); kind {
+		case "enddef":
+			if _, err := CompileBlocks(body); err != nil {
+				return "", err
+			}
+			texts := make([]string, 0, len(body))
+			for _, l := range body {
+				texts = append(texts, l.Text)
+			}
+			encoded, err := encodeBlockBody(texts)
+			if err != nil {
+				return "", fmt.Errorf("line %d: %w", line.Number, err)
+			}
+			return "\\def " + name + " " + encoded, nil
+		case "def":
+			return "", fmt.Errorf("line %d: \\def blocks cannot be nested", bodyLine.Number)
+		}
+		body = append(body, bodyLine)
+	}
+	return "", fmt.Errorf("line %d: \\def %s is not closed with \\enddef", line.Number, name)
+}
+
+// isDefCommand reports whether a raw command line invokes \def.
+func isDefCommand(rawCommand string) bool {
+	cmd := strings.TrimSpace(rawCommand)
+	return cmd == "\\def" || strings.HasPrefix(cmd, "\\def ") || strings.HasPrefix(cmd, "\\def[")
+}
+
+// encodeBlockBody encodes compiled lines as a single BLOCK_BODY stack entry.
+func encodeBlockBody(entries []string) (string, error) {
+	if entries == nil {
+		entries = []string{}
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode block body: %w", err)
+	}
+	return "BLOCK_BODY:" + string(data), nil
+}
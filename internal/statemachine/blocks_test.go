@@ -0,0 +1,230 @@
+package statemachine
+
+import (
+	"testing"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/commands/builtin"
+	"neuroshell/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptLines numbers lines from 1 like a script file.
+func scriptLines(lines ...string) []ScriptLine {
+	result := make([]ScriptLine, 0, len(lines))
+	for i, line := range lines {
+		result = append(result, ScriptLine{Number: i + 1, Text: line})
+	}
+	return result
+}
+
+func TestCompileBlocks_PlainLines(t *testing.T) {
+	entries, err := CompileBlocks(scriptLines("\\echo a", "\\if[condition=true] \\echo b"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"\\echo a", "\\if[condition=true] \\echo b"}, entries)
+}
+
+func TestCompileBlocks_IfElse(t *testing.T) {
+	entries, err := CompileBlocks(scriptLines(
+		"\\if[condition=${x}]",
+		"\\echo then",
+		"\\else",
+		"\\echo else",
+		"\\end",
+		"\\echo after",
+	))
+	require.NoError(t, err)
+	require.Len(t, entries, 6)
+
+	isMarker, _, _ := (&LoopHandler{}).IsLoopBoundaryMarker(entries[0])
+	assert.False(t, isMarker)
+	assert.Regexp(t, `^IF_BLOCK_START:block_id_\d+:if:\$\{x\}$`, entries[0])
+	blockID := entries[0][len("IF_BLOCK_START:") : len(entries[0])-len(":if:${x}")]
+	assert.Equal(t, []string{
+		"\\echo then",
+		"IF_BLOCK_ELSE:" + blockID,
+		"\\echo else",
+		"IF_BLOCK_END:" + blockID,
+		"\\echo after",
+	}, entries[1:])
+}
+
+func TestCompileBlocks_LoopBody(t *testing.T) {
+	entries, err := CompileBlocks(scriptLines(
+		"\\for-each[var=x, in=\"a,b\"]",
+		"\\echo ${x}",
+		"\\repeat[times=2]",
+		"\\echo inner",
+		"\\end",
+		"\\end",
+	))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`\for-each[var=x, in="a,b"] BLOCK_BODY:["\\echo ${x}","\\repeat[times=2] BLOCK_BODY:[\"\\\\echo inner\"]"]`,
+	}, entries)
+}
+
+func TestCompileBlocks_Def(t *testing.T) {
+	entries, err := CompileBlocks(scriptLines(
+		"%% dropped comment",
+		"\\def greet",
+		"%% Greet someone",
+		"\\if[condition=${loud}]",
+		"\\echo HELLO ${_1}",
+		"\\end",
+		"\\enddef",
+		"\\greet world",
+	))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`\def greet BLOCK_BODY:["%% Greet someone","\\if[condition=${loud}]","\\echo HELLO ${_1}","\\end"]`,
+		"\\greet world",
+	}, entries)
+}
+
+func TestCompileBlocks_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		err   string
+	}{
+		{"unclosed if", []string{"\\echo a", "\\if[condition=true]", "\\echo b"}, "line 2: \\if block is not closed with \\end"},
+		{"unclosed loop", []string{"\\while[condition=true]", "\\if[condition=true]", "\\end"}, "line 1: \\while block is not closed with \\end"},
+		{"stray end", []string{"\\echo a", "\\end"}, "line 2: \\end without a matching block"},
+		{"stray else", []string{"\\else"}, "line 1: \\else without a matching \\if"},
+		{"else in loop", []string{"\\repeat[times=2]", "\\else", "\\end"}, "line 2: \\else is only allowed in \\if and \\if-not blocks"},
+		{"second else", []string{"\\if-not[condition=x]", "\\else", "\\else", "\\end"}, "line 3: \\if-not block already has an \\else on line 2"},
+		{"missing condition", []string{"\\if[cond=x]", "\\end"}, "line 1: \\if block requires a condition"},
+		{"unclosed def", []string{"\\def greet", "\\echo hi"}, "line 1: \\def greet is not closed with \\enddef"},
+		{"stray enddef", []string{"\\if[condition=true]", "\\enddef", "\\end"}, "line 2: \\enddef without a matching \\def"},
+		{"nested def", []string{"\\def a", "\\def b", "\\enddef"}, "line 2: \\def blocks cannot be nested"},
+		{"def without name", []string{"\\def", "\\enddef"}, "line 1: \\def requires a single command name, as in \\def name"},
+		{"def body block", []string{"\\echo a", "\\def a", "\\end", "\\enddef"}, "line 3: \\end without a matching block"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CompileBlocks(scriptLines(tt.lines...))
+			require.Error(t, err)
+			assert.Equal(t, tt.err, err.Error())
+		})
+	}
+}
+
+func TestIsBlockLine(t *testing.T) {
+	assert.True(t, IsBlockLine("\\if[condition=${x}]"))
+	assert.True(t, IsBlockLine("  \\for-each[in=\"a,b\"]  "))
+	assert.True(t, IsBlockLine("\\else"))
+	assert.True(t, IsBlockLine("\\end"))
+	assert.False(t, IsBlockLine("\\if[condition=true] \\echo hi"))
+	assert.False(t, IsBlockLine("\\set[x=1]"))
+	assert.False(t, IsBlockLine("\\echo \\end"))
+	assert.True(t, IsBlockLine("\\def greet"))
+	assert.True(t, IsBlockLine("\\enddef"))
+	assert.False(t, IsBlockLine("\\define greet"))
+}
+
+func TestBlockDepth(t *testing.T) {
+	assert.Equal(t, 1, BlockDepth([]string{"\\if[condition=x]", "\\echo a"}))
+	assert.Equal(t, 2, BlockDepth([]string{"\\repeat[times=2]", "\\if[condition=x]", "\\else"}))
+	assert.Equal(t, 0, BlockDepth([]string{"\\repeat[times=2]", "\\if[condition=x]", "\\end", "\\end"}))
+	assert.Equal(t, -1, BlockDepth([]string{"\\end", "\\if[condition=x]"}))
+	assert.Equal(t, 2, BlockDepth([]string{"\\def greet", "\\if[condition=x]"}))
+	assert.Equal(t, 0, BlockDepth([]string{"\\def greet", "\\if[condition=x]", "\\end", "\\enddef"}))
+}
+
+func TestStackMachine_ExecuteLines_IfBlock(t *testing.T) {
+	ctx, sm := setupLoopTestEnvironment(t)
+
+	require.NoError(t, sm.ExecuteLines([]string{
+		"\\set[branch=]",
+		"\\if[condition=${flag}]",
+		"\\set[branch=then]",
+		"\\else",
+		"\\set[branch=else]",
+		"\\end",
+	}))
+	assert.Equal(t, "else", variable(t, ctx, "branch"))
+	assert.Equal(t, "false", variable(t, ctx, "#if_result"))
+
+	require.NoError(t, sm.ExecuteLines([]string{
+		"\\set[flag=on]",
+		"\\if-not[condition=${flag}]",
+		"\\set[branch=not]",
+		"\\else",
+		"\\set[branch=flag ${flag}]",
+		"\\end",
+	}))
+	assert.Equal(t, "flag on", variable(t, ctx, "branch"))
+	assert.Equal(t, 0, ctx.GetStackSize())
+}
+
+func TestStackMachine_ExecuteLines_LoopBlock(t *testing.T) {
+	ctx, sm := setupLoopTestEnvironment(t)
+
+	require.NoError(t, sm.ExecuteLines([]string{
+		"\\set[seen=]",
+		"\\for-each[var=x, in=\"a,b,c\"]",
+		"  \\if[condition=${#loop_index}]",
+		"    \\set[seen=${seen}${x}]",
+		"  \\end",
+		"  \\set[seen=${seen}.]",
+		"\\end",
+	}))
+	assert.Equal(t, "a.b.c.", variable(t, ctx, "seen"))
+	assert.False(t, ctx.IsInLoopBlock())
+	assert.Equal(t, 0, ctx.GetStackSize())
+}
+
+func TestStackMachine_ExecuteLines_UnbalancedRunsNothing(t *testing.T) {
+	ctx, sm := setupLoopTestEnvironment(t)
+
+	err := sm.ExecuteLines([]string{"\\set[ran=yes]", "\\if[condition=true]"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+
+	ran, _ := ctx.GetVariable("ran")
+	assert.Empty(t, ran)
+	assert.Equal(t, 0, ctx.GetStackSize())
+}
+
+func TestStackMachine_ExecuteLines_Def(t *testing.T) {
+	ctx, sm := setupLoopTestEnvironment(t)
+	require.NoError(t, commands.GetGlobalRegistry().Register(&builtin.DefCommand{}))
+	definitionService := services.NewDefinitionService()
+	require.NoError(t, definitionService.Initialize())
+	require.NoError(t, services.GetGlobalRegistry().RegisterService(definitionService))
+
+	require.NoError(t, sm.ExecuteLines([]string{
+		"\\def tag",
+		"  %% Tag a value",
+		"  %% Usage: \\tag[label=name] value",
+		"  \\if[condition=${label}]",
+		"    \\set[tagged=${label}:${_1}]",
+		"  \\else",
+		"    \\set[tagged=${_1}]",
+		"  \\end",
+		"\\enddef",
+	}))
+	// Defining does not run the body
+	tagged, _ := ctx.GetVariable("tagged")
+	assert.Empty(t, tagged)
+
+	definition, exists := definitionService.Get("tag")
+	require.True(t, exists)
+	assert.Equal(t, "Tag a value", definition.Help.Description)
+	assert.Equal(t, "\\tag[label=name] value", definition.Help.Usage)
+
+	require.NoError(t, sm.ExecuteLines([]string{"\\tag[label=x] one"}))
+	assert.Equal(t, "x:one", variable(t, ctx, "tagged"))
+
+	helpInfo, exists := ctx.GetCommandHelpInfo("tag")
+	require.True(t, exists)
+	assert.Equal(t, "Tag a value", helpInfo.Description)
+
+	err := sm.ExecuteLines([]string{"\\def set", "\\echo shadow", "\\enddef"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot redefine builtin command \\set")
+}
@@ -7,6 +7,8 @@ import (
 
 	"neuroshell/internal/commands"
 	"neuroshell/internal/commands/builtin"
+	"neuroshell/internal/context"
+	"neuroshell/internal/services"
 	"neuroshell/pkg/neurotypes"
 
 	"github.com/stretchr/testify/assert"
@@ -124,6 +126,63 @@ func TestCommandResolver_ResolveCommand_UserScripts_NeuroRC(t *testing.T) {
 	assert.Contains(t, result.ScriptPath, "init.neurorc")
 }
 
+func TestCommandResolver_ResolveCommand_DefinedCommands(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	definitionService := services.NewDefinitionService()
+	require.NoError(t, definitionService.Initialize())
+	require.NoError(t, services.GetGlobalRegistry().RegisterService(definitionService))
+	_, err := definitionService.Define("greet", []string{"%% Say hello", "\\echo Hello ${_1}"})
+	require.NoError(t, err)
+
+	resolver := NewCommandResolver()
+	result, err := resolver.ResolveCommand("greet")
+	require.NoError(t, err)
+	assert.Equal(t, "greet", result.Name)
+	assert.Equal(t, neurotypes.CommandTypeUser, result.Type)
+	assert.Equal(t, "%% Say hello\n\\echo Hello ${_1}", result.ScriptContent)
+	assert.Empty(t, result.ScriptPath)
+
+	// Builtins are resolved before definitions
+	_, err = definitionService.Define("get", []string{"\\echo shadowed"})
+	require.NoError(t, err)
+	result, err = resolver.ResolveCommand("get")
+	require.NoError(t, err)
+	assert.Equal(t, neurotypes.CommandTypeBuiltin, result.Type)
+}
+
+func TestCommandResolver_ResolveCommand_LibraryScripts(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	first, second := t.TempDir(), t.TempDir()
+	context.GetGlobalContext().(*context.NeuroContext).SetTestEnvOverride(services.NeuroPathEnv, first+string(os.PathListSeparator)+second)
+	firstPath := filepath.Join(first, "review-pr.neuro")
+	require.NoError(t, os.WriteFile(firstPath, []byte("%% Review a pull request\n\\echo first"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(second, "review-pr.neuro"), []byte("\\echo second"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(first, "get.neuro"), []byte("\\echo shadowed"), 0644))
+	require.NoError(t, services.GetGlobalRegistry().RegisterService(services.NewScriptLibraryService()))
+
+	resolver := NewCommandResolver()
+	result, err := resolver.ResolveCommand("review-pr")
+	require.NoError(t, err)
+	assert.Equal(t, "review-pr", result.Name)
+	assert.Equal(t, neurotypes.CommandTypeUser, result.Type)
+	assert.Equal(t, "%% Review a pull request\n\\echo first", result.ScriptContent)
+	assert.Equal(t, firstPath, result.ScriptPath)
+
+	// Resolving registers the script's help
+	helpInfo, exists := context.GetGlobalContext().(*context.NeuroContext).GetCommandHelpInfo("review-pr")
+	require.True(t, exists)
+	assert.Equal(t, "Review a pull request", helpInfo.Description)
+
+	// Builtins are resolved before library scripts
+	result, err = resolver.ResolveCommand("get")
+	require.NoError(t, err)
+	assert.Equal(t, neurotypes.CommandTypeBuiltin, result.Type)
+}
+
 func TestCommandResolver_ResolveCommand_UnknownCommand(t *testing.T) {
 	resolver := NewCommandResolver()
 
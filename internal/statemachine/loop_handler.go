@@ -0,0 +1,178 @@
+// Package statemachine implements loop boundary management for the stack-based execution engine.
+// The LoopHandler runs the passes of \for-each, \repeat and \while loops between loop boundary markers.
+package statemachine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"neuroshell/internal/context"
+	"neuroshell/internal/logger"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/stringprocessing"
+
+	"github.com/charmbracelet/log"
+)
+
+// loopCommands are the commands whose body and condition are interpolated on each pass
+// instead of once when the loop command itself runs.
+var loopCommands = map[string]bool{
+	"for-each": true,
+	"repeat":   true,
+	"while":    true,
+}
+
+// LoopHandler manages loop boundaries and the passes of each loop.
+// A loop command registers its loop and pushes LOOP_BOUNDARY_START; each start marker decides
+// whether another pass runs and, if so, pushes the body followed by LOOP_BOUNDARY_END, which
+// in turn pushes the next start marker.
+type LoopHandler struct {
+	// Interpolator for while conditions
+	interpolator *CoreInterpolator
+	// Services
+	stackService    *services.StackService
+	variableService *services.VariableService
+	// Logger
+	logger *log.Logger
+}
+
+// NewLoopHandler creates a new loop handler with the required services.
+func NewLoopHandler(ctx *context.NeuroContext) *LoopHandler {
+	lh := &LoopHandler{
+		interpolator: NewCoreInterpolator(ctx),
+		logger:       logger.NewStyledLogger("LoopHandler"),
+	}
+
+	// Initialize services
+	var err error
+	lh.stackService, err = services.GetGlobalStackService()
+	if err != nil {
+		lh.logger.Error("Failed to get stack service", "error", err)
+	}
+
+	lh.variableService, err = services.GetGlobalVariableService()
+	if err != nil {
+		lh.logger.Error("Failed to get variable service", "error", err)
+	}
+
+	return lh
+}
+
+// StartPass handles a LOOP_BOUNDARY_START marker. It runs the next pass of the loop if there
+// is one, and otherwise leaves the loop.
+func (lh *LoopHandler) StartPass(loopID string) error {
+	if lh.stackService == nil {
+		return nil
+	}
+
+	loop, ok := lh.stackService.NextLoopPass(loopID)
+	if !ok {
+		lh.logger.Debug("Loop is not the innermost loop, ignoring start marker", "loopID", loopID)
+		return nil
+	}
+
+	item, run := lh.nextItem(loop)
+	if !run {
+		lh.ExitLoop(loopID, loop.Pass-1)
+		return nil
+	}
+	if loop.Pass > loop.Max {
+		lh.ExitLoop(loopID, loop.Pass-1)
+		return fmt.Errorf("%s loop stopped after %d passes; raise max to allow more", loop.Kind, loop.Max)
+	}
+
+	lh.logger.Debug("Starting loop pass", "loopID", loopID, "pass", loop.Pass, "item", item)
+	if lh.variableService != nil {
+		if loop.Variable != "" {
+			if err := lh.variableService.Set(loop.Variable, item); err != nil {
+				lh.ExitLoop(loopID, loop.Pass-1)
+				return fmt.Errorf("failed to set loop variable '%s': %w", loop.Variable, err)
+			}
+		}
+		_ = lh.variableService.SetSystemVariable("#loop_index", strconv.Itoa(loop.Pass))
+	}
+
+	// Push the end marker below the body (reverse order for LIFO)
+	lh.stackService.PushCommand("LOOP_BOUNDARY_END:" + loopID)
+	lh.stackService.PushCommand(loop.Body)
+	return nil
+}
+
+// EndPass handles a LOOP_BOUNDARY_END marker by scheduling the next pass check.
+func (lh *LoopHandler) EndPass(loopID string) {
+	if lh.stackService == nil {
+		return
+	}
+	lh.stackService.PushCommand("LOOP_BOUNDARY_START:" + loopID)
+}
+
+// ExitLoop leaves the loop with the given ID and records how many passes ran.
+func (lh *LoopHandler) ExitLoop(loopID string, passes int) {
+	lh.logger.Debug("Exiting loop", "loopID", loopID, "passes", passes)
+
+	if lh.variableService != nil {
+		_ = lh.variableService.SetSystemVariable("#loop_count", strconv.Itoa(passes))
+	}
+	if lh.stackService != nil && lh.stackService.GetCurrentLoopID() == loopID {
+		lh.stackService.PopLoopBoundary()
+	}
+}
+
+// nextItem reports whether the loop runs another pass and the value of its variable for that pass.
+func (lh *LoopHandler) nextItem(loop context.LoopBlockContext) (string, bool) {
+	switch loop.Kind {
+	case "for-each":
+		if loop.Pass > len(loop.Items) {
+			return "", false
+		}
+		return loop.Items[loop.Pass-1], true
+	case "repeat":
+		return strconv.Itoa(loop.Pass), loop.Pass <= loop.Times
+	case "while":
+		condition, _, err := lh.interpolator.InterpolateCommandLine(loop.Condition)
+		if err != nil {
+			lh.logger.Debug("Failed to interpolate loop condition", "condition", loop.Condition, "error", err)
+			return "", false
+		}
+		return strconv.Itoa(loop.Pass), stringprocessing.IsTruthy(strings.TrimSpace(condition))
+	default:
+		return "", false
+	}
+}
+
+// IsInLoopBlock returns true if currently inside a loop.
+func (lh *LoopHandler) IsInLoopBlock() bool {
+	if lh.stackService == nil {
+		return false
+	}
+	return lh.stackService.IsInLoopBlock()
+}
+
+// IsLoopBoundaryMarker checks if a command is a loop boundary marker.
+func (lh *LoopHandler) IsLoopBoundaryMarker(command string) (bool, string, bool) {
+	if strings.HasPrefix(command, "LOOP_BOUNDARY_START:") {
+		loopID := strings.TrimPrefix(command, "LOOP_BOUNDARY_START:")
+		return true, loopID, true // isStart = true
+	}
+	if strings.HasPrefix(command, "LOOP_BOUNDARY_END:") {
+		loopID := strings.TrimPrefix(command, "LOOP_BOUNDARY_END:")
+		return true, loopID, false // isStart = false
+	}
+	return false, "", false
+}
+
+// isLoopCommand reports whether a raw command line invokes \for-each, \repeat or \while.
+func isLoopCommand(rawCommand string) bool {
+	cmd := strings.TrimSpace(rawCommand)
+	if !strings.HasPrefix(cmd, "\\") {
+		return false
+	}
+
+	// Extract command name (everything after \ until first [ or space)
+	cmdName := cmd[1:]
+	if idx := strings.IndexAny(cmdName, "[ "); idx != -1 {
+		cmdName = cmdName[:idx]
+	}
+	return loopCommands[cmdName]
+}
@@ -0,0 +1,147 @@
+package statemachine
+
+import (
+	"testing"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/commands/builtin"
+	"neuroshell/internal/context"
+	"neuroshell/pkg/neurotypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupLoopTestEnvironment extends the stack test environment with the loop commands.
+func setupLoopTestEnvironment(t *testing.T) (*context.NeuroContext, *StackMachine) {
+	ctx, err := setupStackTestEnvironment()
+	require.NoError(t, err)
+
+	for _, cmd := range []neurotypes.Command{&builtin.ForEachCommand{}, &builtin.RepeatCommand{}, &builtin.WhileCommand{}} {
+		require.NoError(t, commands.GetGlobalRegistry().Register(cmd))
+	}
+
+	return ctx, NewStackMachine(ctx, neurotypes.DefaultStateMachineConfig())
+}
+
+func variable(t *testing.T, ctx *context.NeuroContext, name string) string {
+	value, err := ctx.GetVariable(name)
+	require.NoError(t, err)
+	return value
+}
+
+func TestLoopHandler_IsLoopBoundaryMarker(t *testing.T) {
+	lh := &LoopHandler{}
+
+	isMarker, loopID, isStart := lh.IsLoopBoundaryMarker("LOOP_BOUNDARY_START:loop_id_1")
+	assert.True(t, isMarker)
+	assert.Equal(t, "loop_id_1", loopID)
+	assert.True(t, isStart)
+
+	isMarker, loopID, isStart = lh.IsLoopBoundaryMarker("LOOP_BOUNDARY_END:loop_id_2")
+	assert.True(t, isMarker)
+	assert.Equal(t, "loop_id_2", loopID)
+	assert.False(t, isStart)
+
+	isMarker, _, _ = lh.IsLoopBoundaryMarker("\\echo LOOP_BOUNDARY_START:loop_id_1")
+	assert.False(t, isMarker)
+}
+
+func TestIsLoopCommand(t *testing.T) {
+	assert.True(t, isLoopCommand("\\for-each[in=a] \\echo ${item}"))
+	assert.True(t, isLoopCommand("  \\repeat[times=2] \\echo hi"))
+	assert.True(t, isLoopCommand("\\while[condition=${x}] \\set[x=]"))
+	assert.False(t, isLoopCommand("\\echo \\repeat[times=2]"))
+	assert.False(t, isLoopCommand("\\for-each-item"))
+	assert.False(t, isLoopCommand("repeat[times=2]"))
+}
+
+func TestStackMachine_ForEachLoop(t *testing.T) {
+	ctx, sm := setupLoopTestEnvironment(t)
+
+	require.NoError(t, sm.Execute("\\set[seen=]"))
+	require.NoError(t, sm.Execute("\\for-each[var=x, in=\"a,b,c\"] \\set[seen=${seen}${x}${#loop_index}]"))
+
+	assert.Equal(t, "a1b2c3", variable(t, ctx, "seen"))
+	assert.Equal(t, "c", variable(t, ctx, "x"))
+	assert.Equal(t, "3", variable(t, ctx, "#loop_count"))
+	assert.False(t, ctx.IsInLoopBlock())
+	assert.Equal(t, 0, ctx.GetStackSize())
+}
+
+func TestStackMachine_ForEachLoop_LinesInterpolatedOnce(t *testing.T) {
+	ctx, sm := setupLoopTestEnvironment(t)
+
+	require.NoError(t, ctx.SetVariable("list", "one, two\nthree"))
+	require.NoError(t, sm.Execute("\\set[seen=]"))
+	require.NoError(t, sm.Execute("\\for-each[var=line, lines=${list}] \\set[seen=\"${seen}|${line}\"]"))
+
+	assert.Equal(t, "|one, two|three", variable(t, ctx, "seen"))
+}
+
+func TestStackMachine_NestedLoops(t *testing.T) {
+	ctx, sm := setupLoopTestEnvironment(t)
+
+	require.NoError(t, sm.Execute("\\set[seen=]"))
+	require.NoError(t, sm.Execute("\\for-each[var=o, in=\"1,2\"] \\repeat[times=2, var=n] \\set[seen=${seen}${o}${n}]"))
+
+	assert.Equal(t, "11122122", variable(t, ctx, "seen"))
+	assert.Equal(t, "2", variable(t, ctx, "#loop_count"))
+	assert.False(t, ctx.IsInLoopBlock())
+}
+
+func TestStackMachine_WhileLoop_ReevaluatesCondition(t *testing.T) {
+	ctx, sm := setupLoopTestEnvironment(t)
+
+	require.NoError(t, sm.Execute("\\set[left=yes]"))
+	require.NoError(t, sm.Execute("\\while[condition=${left}] \\set[left=]"))
+
+	assert.Equal(t, "1", variable(t, ctx, "#loop_count"))
+	assert.False(t, ctx.IsInLoopBlock())
+}
+
+func TestStackMachine_WhileLoop_SafetyCap(t *testing.T) {
+	ctx, sm := setupLoopTestEnvironment(t)
+
+	require.NoError(t, sm.Execute("\\set[spin=]"))
+	err := sm.Execute("\\while[condition=true, max=3] \\set[spin=${spin}x]")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "while loop stopped after 3 passes")
+
+	assert.Equal(t, "xxx", variable(t, ctx, "spin"))
+	assert.False(t, ctx.IsInLoopBlock())
+}
+
+func TestStackMachine_LongLoopReachesMax(t *testing.T) {
+	ctx, sm := setupLoopTestEnvironment(t)
+
+	// Loop passes do not count toward the stack machine's runaway guard
+	require.NoError(t, sm.Execute("\\repeat[times=4000, max=5000] \\set[x=${#loop_index}]"))
+	assert.Equal(t, "4000", variable(t, ctx, "x"))
+	assert.Equal(t, "4000", variable(t, ctx, "#loop_count"))
+
+	require.NoError(t, sm.ExecuteLines([]string{
+		"\\repeat[times=1000]",
+		"  \\set[a=1]",
+		"  \\set[b=2]",
+		"  \\set[c=3]",
+		"  \\set[d=${#loop_index}]",
+		"\\end",
+	}))
+	assert.Equal(t, "1000", variable(t, ctx, "d"))
+	assert.Equal(t, 0, ctx.GetStackSize())
+}
+
+func TestStackMachine_TryAbandonsLoop(t *testing.T) {
+	ctx, sm := setupLoopTestEnvironment(t)
+
+	// A failing pass skips the rest of the try block, including the loop's markers
+	ctx.PushCommand("ERROR_BOUNDARY_END:test_id")
+	ctx.PushCommand("\\for-each[var=x, in=\"a,b\"] \\no-such-command")
+	ctx.PushCommand("ERROR_BOUNDARY_START:test_id")
+
+	require.NoError(t, sm.processStack())
+	assert.False(t, ctx.IsInLoopBlock())
+	assert.False(t, ctx.IsInTryBlock())
+	assert.Equal(t, 0, ctx.GetStackSize())
+}
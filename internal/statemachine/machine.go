@@ -47,6 +47,13 @@ func (sm *StateMachine) Execute(input string) error {
 	return sm.stackMachine.Execute(input)
 }
 
+// ExecuteLines executes several lines entered together, such as a multi-line block.
+// It delegates to the StackMachine, which compiles blocks before running the lines.
+func (sm *StateMachine) ExecuteLines(lines []string) error {
+	sm.logger.Debug("StateMachine ExecuteLines", "lines", len(lines))
+	return sm.stackMachine.ExecuteLines(lines)
+}
+
 // ExecuteInternal executes a command without resetting the global execution state.
 // This is used for nested execution (e.g., by try commands, script lines).
 func (sm *StateMachine) ExecuteInternal(input string) error {
@@ -9,6 +9,7 @@ import (
 	"neuroshell/internal/commands"
 	"neuroshell/internal/data/embedded"
 	"neuroshell/internal/logger"
+	"neuroshell/internal/services"
 	"neuroshell/pkg/neurotypes"
 
 	"github.com/charmbracelet/log"
@@ -29,7 +30,7 @@ func NewCommandResolver() *CommandResolver {
 }
 
 // ResolveCommand attempts to resolve a command name to a builtin command or script.
-// Priority: builtin → stdlib → user scripts
+// Priority: builtin → stdlib → commands defined with \def → library scripts in NEURO_PATH → user scripts
 func (r *CommandResolver) ResolveCommand(commandName string) (*neurotypes.StateMachineResolvedCommand, error) {
 	// Priority 1: Try builtin commands (highest priority)
 	if builtinCmd, exists := commands.GetGlobalRegistry().Get(commandName); exists {
@@ -55,7 +56,35 @@ func (r *CommandResolver) ResolveCommand(commandName string) (*neurotypes.StateM
 		}
 	}
 
-	// Priority 3: Try user scripts (lowest priority)
+	// Priority 3: Try commands defined with \def
+	if definitionService, err := services.GetGlobalDefinitionService(); err == nil {
+		if definition, exists := definitionService.Get(commandName); exists {
+			return &neurotypes.StateMachineResolvedCommand{
+				Name:          commandName,
+				Type:          neurotypes.CommandTypeUser,
+				ScriptContent: definition.Script,
+			}, nil
+		}
+	}
+
+	// Priority 4: Try library scripts from the script command search path
+	if libraryService, err := services.GetGlobalScriptLibraryService(); err == nil {
+		if scriptPath, found := libraryService.Find(commandName); found {
+			content, err := os.ReadFile(scriptPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read script file: %w", err)
+			}
+			libraryService.RegisterHelp(commandName, scriptPath, string(content))
+			return &neurotypes.StateMachineResolvedCommand{
+				Name:          commandName,
+				Type:          neurotypes.CommandTypeUser,
+				ScriptContent: string(content),
+				ScriptPath:    scriptPath,
+			}, nil
+		}
+	}
+
+	// Priority 5: Try user scripts (lowest priority)
 	if strings.HasSuffix(commandName, ".neuro") || strings.HasSuffix(commandName, ".neurorc") {
 		r.logger.Debug("Detected file path command", "command", commandName)
 		return r.resolveUserFilePath(commandName)
@@ -0,0 +1,101 @@
+// Package statemachine implements script variable frame management for the stack-based execution engine.
+// The ScriptFrameHandler gives each script invocation its own variable frame, ended by a frame marker.
+package statemachine
+
+import (
+	"strings"
+
+	"neuroshell/internal/logger"
+	"neuroshell/internal/services"
+
+	"github.com/charmbracelet/log"
+)
+
+// ScriptFrameHandler manages the variable frames of script command invocations.
+// Running a script pushes its frame, then SCRIPT_FRAME_END followed by the script's lines, so the
+// frame ends when the last line has run. Parameters and \local variables live in the frame.
+type ScriptFrameHandler struct {
+	// Services
+	stackService    *services.StackService
+	variableService *services.VariableService
+	// Logger
+	logger *log.Logger
+}
+
+// NewScriptFrameHandler creates a new script frame handler with the required services.
+func NewScriptFrameHandler() *ScriptFrameHandler {
+	fh := &ScriptFrameHandler{
+		logger: logger.NewStyledLogger("ScriptFrameHandler"),
+	}
+
+	// Initialize services
+	var err error
+	fh.stackService, err = services.GetGlobalStackService()
+	if err != nil {
+		fh.logger.Error("Failed to get stack service", "error", err)
+	}
+
+	fh.variableService, err = services.GetGlobalVariableService()
+	if err != nil {
+		fh.logger.Error("Failed to get variable service", "error", err)
+	}
+
+	return fh
+}
+
+// EnterScript pushes the frame of a script invocation and schedules its lines, ending the frame
+// after the last one.
+func (fh *ScriptFrameHandler) EnterScript(name string, parameters, options map[string]string, entries []string) error {
+	if fh.variableService == nil || fh.stackService == nil {
+		return nil
+	}
+
+	frameID, err := fh.variableService.PushScriptFrame(name, parameters, options)
+	if err != nil {
+		return err
+	}
+	fh.logger.Debug("Entering script frame", "frameID", frameID, "script", name)
+
+	// Push the end marker first and the lines in reverse order (LIFO execution)
+	fh.stackService.PushCommand("SCRIPT_FRAME_END:" + frameID)
+	for i := len(entries) - 1; i >= 0; i-- {
+		fh.stackService.PushCommand(entries[i])
+	}
+	return nil
+}
+
+// ExitScript ends the frame with the given ID, restoring the caller's parameter variables.
+func (fh *ScriptFrameHandler) ExitScript(frameID string) {
+	if fh.variableService == nil {
+		return
+	}
+	if !fh.variableService.PopScriptFrame(frameID) {
+		fh.logger.Debug("Ignoring end marker of a frame that is not running", "frameID", frameID)
+		return
+	}
+	fh.logger.Debug("Exited script frame", "frameID", frameID)
+}
+
+// GetFrameDepth returns the number of running script invocations.
+func (fh *ScriptFrameHandler) GetFrameDepth() int {
+	if fh.variableService == nil {
+		return 0
+	}
+	return fh.variableService.GetScriptFrameDepth()
+}
+
+// UnwindFrames ends the frames of scripts stopped by an error, leaving depth frames.
+func (fh *ScriptFrameHandler) UnwindFrames(depth int) {
+	if fh.variableService == nil {
+		return
+	}
+	fh.variableService.UnwindScriptFrames(depth)
+}
+
+// IsScriptFrameMarker checks if a command is a script frame end marker.
+func (fh *ScriptFrameHandler) IsScriptFrameMarker(command string) (bool, string) {
+	if frameID, found := strings.CutPrefix(command, "SCRIPT_FRAME_END:"); found {
+		return true, frameID
+	}
+	return false, ""
+}
@@ -0,0 +1,84 @@
+package statemachine
+
+import (
+	"testing"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/commands/builtin"
+	"neuroshell/internal/context"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupScriptFrameTestEnvironment(t *testing.T) (*context.NeuroContext, *StackMachine) {
+	ctx, sm := setupLoopTestEnvironment(t)
+	for _, cmd := range []neurotypes.Command{&builtin.DefCommand{}, &builtin.LocalCommand{}, &builtin.ExportCommand{}} {
+		require.NoError(t, commands.GetGlobalRegistry().Register(cmd))
+	}
+	definitionService := services.NewDefinitionService()
+	require.NoError(t, definitionService.Initialize())
+	require.NoError(t, services.GetGlobalRegistry().RegisterService(definitionService))
+	return ctx, sm
+}
+
+func TestScriptFrameHandler_IsScriptFrameMarker(t *testing.T) {
+	sfh := &ScriptFrameHandler{}
+
+	isMarker, frameID := sfh.IsScriptFrameMarker("SCRIPT_FRAME_END:script_frame_3")
+	assert.True(t, isMarker)
+	assert.Equal(t, "script_frame_3", frameID)
+
+	isMarker, _ = sfh.IsScriptFrameMarker("\\echo SCRIPT_FRAME_END:script_frame_3")
+	assert.False(t, isMarker)
+}
+
+func TestStackMachine_ScriptFrames_ParametersDoNotLeak(t *testing.T) {
+	ctx, sm := setupScriptFrameTestEnvironment(t)
+
+	require.NoError(t, sm.ExecuteLines([]string{
+		"\\def inner",
+		"  \\set[seen=${seen} inner:${_1}]",
+		"\\enddef",
+		"\\def outer",
+		"  \\local[scratch=temp]",
+		"  \\inner[name=nested] second",
+		"  \\set[seen=${seen} outer:${_1}:${name}]",
+		"  \\export[result=${name} done]",
+		"\\enddef",
+		"\\set[name=global, seen=]",
+		"\\outer[name=param] first",
+	}))
+
+	assert.Equal(t, "inner:second outer:first:param", variable(t, ctx, "seen"))
+	assert.Equal(t, "global", variable(t, ctx, "name"))
+	assert.Equal(t, "param done", variable(t, ctx, "result"))
+	assert.Empty(t, variable(t, ctx, "scratch"))
+	assert.Equal(t, 0, ctx.GetScriptFrameDepth())
+	assert.Equal(t, 0, ctx.GetStackSize())
+}
+
+func TestStackMachine_ScriptFrames_UnwoundOnError(t *testing.T) {
+	ctx, sm := setupScriptFrameTestEnvironment(t)
+
+	require.NoError(t, sm.ExecuteLines([]string{
+		"\\def broken",
+		"  \\local[scratch=temp]",
+		"  \\no-such-command",
+		"\\enddef",
+	}))
+
+	err := sm.Execute("\\broken[name=param] message")
+	require.Error(t, err)
+	assert.Equal(t, 0, ctx.GetScriptFrameDepth())
+	assert.Empty(t, variable(t, ctx, "name"))
+	assert.Empty(t, variable(t, ctx, "scratch"))
+	_, exists := ctx.GetAllVariables()["_1"]
+	assert.False(t, exists)
+
+	require.NoError(t, sm.Execute("\\try \\broken[name=param] message"))
+	assert.Equal(t, 0, ctx.GetScriptFrameDepth())
+	assert.Empty(t, variable(t, ctx, "name"))
+}
@@ -8,9 +8,11 @@ import (
 	"neuroshell/internal/commands"
 	"neuroshell/internal/context"
 	"neuroshell/internal/logger"
+	"neuroshell/internal/parser"
 	"neuroshell/internal/services"
 	"neuroshell/internal/stringprocessing"
 	"neuroshell/pkg/neurotypes"
+	pkgstringprocessing "neuroshell/pkg/stringprocessing"
 	"strings"
 
 	"github.com/charmbracelet/log"
@@ -18,7 +20,7 @@ import (
 
 // StackMachine implements the stack-based execution engine for NeuroShell.
 // It processes commands from a stack in LIFO order, handling try blocks,
-// error boundaries, and command execution through a unified pipeline.
+// error boundaries, loops, blocks, and command execution through a unified pipeline.
 type StackMachine struct {
 	// Context for state management
 	context *context.NeuroContext
@@ -28,6 +30,14 @@ type StackMachine struct {
 	tryHandler *TryHandler
 	// Silent handler for output suppression management
 	silentHandler *SilentHandler
+	// Loop handler for loop pass management
+	loopHandler *LoopHandler
+	// Block handler for multi-line conditional and loop blocks
+	blockHandler *BlockHandler
+	// Script frame handler for script-local variables
+	scriptFrameHandler *ScriptFrameHandler
+	// Tool loop handler for releasing tool loops
+	toolLoopHandler *ToolLoopHandler
 	// Configuration options
 	config neurotypes.StateMachineConfig
 	// Custom styled logger
@@ -45,8 +55,13 @@ func NewStackMachine(ctx *context.NeuroContext, config neurotypes.StateMachineCo
 		stateProcessor: NewStateProcessor(ctx, config),
 		tryHandler:     NewTryHandler(),
 		silentHandler:  NewSilentHandler(),
+		loopHandler:    NewLoopHandler(ctx),
+		blockHandler:   NewBlockHandler(ctx),
 		config:         config,
 		logger:         logger.NewStyledLogger("StackMachine"),
+
+		scriptFrameHandler: NewScriptFrameHandler(),
+		toolLoopHandler:    NewToolLoopHandler(),
 	}
 
 	// Initialize services
@@ -105,12 +120,56 @@ func (sm *StackMachine) ExecuteInternal(input string) error {
 	return sm.processStack()
 }
 
+// ExecuteLines executes several lines entered together, such as a multi-line block typed in the shell.
+// Blocks are compiled first, so unbalanced blocks are reported before any line runs.
+func (sm *StackMachine) ExecuteLines(lines []string) error {
+	// Check if required services are available
+	if sm.stackService == nil {
+		return fmt.Errorf("stack service not available")
+	}
+
+	scriptLines := make([]ScriptLine, 0, len(lines))
+	for i, line := range lines {
+		scriptLines = append(scriptLines, ScriptLine{Number: i + 1, Text: strings.TrimSpace(line)})
+	}
+	entries, err := CompileBlocks(scriptLines)
+	if err != nil {
+		return err
+	}
+
+	// Update echo configuration
+	sm.updateEchoConfig()
+
+	// Push entries in reverse order (LIFO execution)
+	for i := len(entries) - 1; i >= 0; i-- {
+		sm.stackService.PushCommand(entries[i])
+	}
+
+	return sm.processStack()
+}
+
 // processStack is the main stack processing loop.
 // It pops commands from the stack and processes them until the stack is empty.
 func (sm *StackMachine) processStack() error {
+	// Frames of scripts started here are ended if an error stops them
+	frameDepth := sm.scriptFrameHandler.GetFrameDepth()
+	// Tool loops started here are released if an error abandons their commands
+	toolLoopMark := sm.toolLoopHandler.GetLoopMark()
+
 	iterationCount := 0
 	for !sm.stackService.IsEmpty() {
-		iterationCount++
+		rawCommand, hasCommand := sm.stackService.PopCommand()
+		if !hasCommand {
+			break // Stack is empty
+		}
+
+		// Passes of \for-each, \repeat and \while are capped by the loop's own max,
+		// so the guard below only counts commands since the last loop boundary
+		if isMarker, _, _ := sm.loopHandler.IsLoopBoundaryMarker(rawCommand); isMarker {
+			iterationCount = 0
+		} else {
+			iterationCount++
+		}
 
 		// Debug: Check for potential infinite loops
 		if iterationCount > 10000 {
@@ -120,11 +179,6 @@ func (sm *StackMachine) processStack() error {
 			return fmt.Errorf("infinite loop detected in stack processing")
 		}
 
-		rawCommand, hasCommand := sm.stackService.PopCommand()
-		if !hasCommand {
-			break // Stack is empty
-		}
-
 		sm.logger.Debug("Processing stack command", "iteration", iterationCount, "command", rawCommand, "stackSize", sm.stackService.GetStackSize())
 
 		// Process individual command through state pipeline
@@ -142,6 +196,8 @@ func (sm *StackMachine) processStack() error {
 				continue // Continue processing after try block
 			}
 			// Normal error propagation
+			sm.scriptFrameHandler.UnwindFrames(frameDepth)
+			sm.toolLoopHandler.UnwindLoops(toolLoopMark)
 			return err
 		}
 
@@ -176,6 +232,32 @@ func (sm *StackMachine) processCommand(rawCommand string) error {
 		return nil
 	}
 
+	// Check for loop boundary markers using LoopHandler
+	if isMarker, loopID, isStart := sm.loopHandler.IsLoopBoundaryMarker(rawCommand); isMarker {
+		if isStart {
+			return sm.loopHandler.StartPass(loopID)
+		}
+		sm.loopHandler.EndPass(loopID)
+		return nil
+	}
+
+	// Check for script frame markers using ScriptFrameHandler
+	if isMarker, frameID := sm.scriptFrameHandler.IsScriptFrameMarker(rawCommand); isMarker {
+		sm.scriptFrameHandler.ExitScript(frameID)
+		return nil
+	}
+
+	// Check for tool loop markers using ToolLoopHandler
+	if isMarker, loopID := sm.toolLoopHandler.IsToolLoopMarker(rawCommand); isMarker {
+		sm.toolLoopHandler.EndLoop(loopID)
+		return nil
+	}
+
+	// Check for block markers using BlockHandler
+	if sm.blockHandler.IsBlockMarker(rawCommand) {
+		return sm.blockHandler.HandleMarker(rawCommand)
+	}
+
 	// Reset error state before processing command (moves current to last, resets current to success)
 	// But only for commands that can change system state - not for read-only commands like \get
 	shouldReset := sm.shouldResetErrorState(rawCommand)
@@ -312,6 +394,23 @@ func (sm *StackMachine) shouldResetErrorState(rawCommand string) bool {
 	return !sm.context.IsCommandReadOnly(command)
 }
 
+// isStreamingEnabled reports whether an \llm-call streams its response: its stream option if given,
+// otherwise the _stream variable.
+func (sm *StackMachine) isStreamingEnabled(rawCommand string) bool {
+	if streamValue, exists := parser.ParseInput(rawCommand).Options["stream"]; exists {
+		return pkgstringprocessing.IsTruthy(streamValue)
+	}
+	if sm.variableService == nil {
+		return false
+	}
+
+	streamVar, err := sm.variableService.Get("_stream")
+	if err != nil {
+		return false
+	}
+	return pkgstringprocessing.IsTruthy(streamVar)
+}
+
 // shouldSkipOutputCapture determines if output capture should be skipped for a command.
 // Some commands like \editor need direct access to stdout/stdin and cannot work with output capture.
 func (sm *StackMachine) shouldSkipOutputCapture(rawCommand string) bool {
@@ -332,6 +431,9 @@ func (sm *StackMachine) shouldSkipOutputCapture(rawCommand string) bool {
 	case "editor":
 		// The editor command needs direct stdout/stdin access for external editors
 		return true
+	case "llm-call":
+		// Streaming calls render deltas live, which output capture would hold back until completion
+		return sm.isStreamingEnabled(cmd)
 	default:
 		return false
 	}
@@ -461,6 +461,24 @@ func TestStackMachine_shouldResetErrorState_ReadOnlyCommands(t *testing.T) {
 	}
 }
 
+func TestStackMachine_shouldSkipOutputCapture_Streaming(t *testing.T) {
+	ctx, err := setupStackTestEnvironment()
+	require.NoError(t, err)
+	sm := NewStackMachine(ctx, neurotypes.DefaultStateMachineConfig())
+
+	assert.True(t, sm.shouldSkipOutputCapture("\\editor"))
+	assert.True(t, sm.shouldSkipOutputCapture("\\llm-call[stream=true]"))
+	assert.True(t, sm.shouldSkipOutputCapture("\\llm-call[session_id=s, stream=1]"))
+	assert.False(t, sm.shouldSkipOutputCapture("\\llm-call[stream=false]"))
+	assert.False(t, sm.shouldSkipOutputCapture("\\llm-call"))
+	assert.False(t, sm.shouldSkipOutputCapture("\\echo \\llm-call[stream=true]"))
+
+	// Without a stream option the _stream variable decides; an explicit option wins
+	require.NoError(t, ctx.SetVariable("_stream", "yes"))
+	assert.True(t, sm.shouldSkipOutputCapture("\\llm-call"))
+	assert.False(t, sm.shouldSkipOutputCapture("\\llm-call[stream=off]"))
+}
+
 func TestStackMachine_shouldResetErrorState_WithOverrides(t *testing.T) {
 	ctx, err := setupStackTestEnvironment()
 	require.NoError(t, err)
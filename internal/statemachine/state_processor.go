@@ -58,6 +58,12 @@ func NewStateProcessor(ctx *context.NeuroContext, config neurotypes.StateMachine
 func (sp *StateProcessor) ProcessCommand(rawCommand string) error {
 	sp.logger.Debug("Processing command through pipeline", "command", rawCommand)
 
+	// Loop bodies and conditions are interpolated on each pass, not when the loop starts,
+	// and \def bodies each time the defined command runs
+	if isLoopCommand(rawCommand) || isDefCommand(rawCommand) {
+		return sp.processBodyCommand(rawCommand)
+	}
+
 	// 1. Variable Interpolation (StateInterpolating equivalent)
 	interpolated, err := sp.interpolateVariables(rawCommand)
 	if err != nil {
@@ -80,6 +86,33 @@ func (sp *StateProcessor) ProcessCommand(rawCommand string) error {
 	return sp.executeCommand(resolved, parsed, interpolated)
 }
 
+// processBodyCommand processes \for-each, \repeat, \while and \def commands.
+// Options are interpolated individually, except the while condition; the body is passed on uninterpolated.
+func (sp *StateProcessor) processBodyCommand(rawCommand string) error {
+	parsed, err := sp.parseCommand(strings.TrimSpace(rawCommand))
+	if err != nil {
+		return fmt.Errorf("command parsing failed: %w", err)
+	}
+
+	for key, value := range parsed.Options {
+		if key == "condition" {
+			continue
+		}
+		interpolated, err := sp.interpolateVariables(value)
+		if err != nil {
+			return fmt.Errorf("variable expansion failed: %w", err)
+		}
+		parsed.Options[key] = interpolated
+	}
+
+	resolved, err := sp.resolveCommand(parsed)
+	if err != nil {
+		return fmt.Errorf("command resolution failed: %w", err)
+	}
+
+	return sp.executeCommand(resolved, parsed, rawCommand)
+}
+
 // interpolateVariables handles variable and macro expansion.
 // This uses the existing CoreInterpolator which already handles recursion limits properly.
 func (sp *StateProcessor) interpolateVariables(input string) (string, error) {
@@ -178,50 +211,40 @@ func (sp *StateProcessor) executeBuiltinCommand(resolved *neurotypes.StateMachin
 // executeScriptCommand handles execution of script commands (stdlib and user).
 // This preserves the existing script execution logic.
 func (sp *StateProcessor) executeScriptCommand(resolved *neurotypes.StateMachineResolvedCommand, parsedCmd *parser.Command) error {
-	// Set up parameter variables before script execution (even for empty scripts)
-	variableService, err := services.GetGlobalVariableService()
-	if err != nil {
-		sp.logger.Error("Failed to get variable service", "error", err)
-	} else {
-		// Special parameter variables (using SetSystemVariable for system-level variables)
-		_ = variableService.SetSystemVariable("_0", resolved.Name)                         // Command name
-		_ = variableService.SetSystemVariable("_1", parsedCmd.Message)                     // Message/first positional arg
-		_ = variableService.SetSystemVariable("_*", parsedCmd.Message)                     // All positional args (same as _1)
-		_ = variableService.SetSystemVariable("_@", sp.formatNamedArgs(parsedCmd.Options)) // Named args
-
-		// Individual named parameters (these are user-level variables)
-		for key, value := range parsedCmd.Options {
-			_ = variableService.Set(key, value)
-		}
-	}
-
 	// Parse script lines (handle empty content gracefully)
-	var scriptLines []string
+	var scriptLines []ScriptLine
 	if resolved.ScriptContent != "" {
 		lines := strings.Split(resolved.ScriptContent, "\n")
-		scriptLines = make([]string, 0)
+		scriptLines = make([]ScriptLine, 0)
 
-		for _, line := range lines {
+		for i, line := range lines {
 			trimmed := strings.TrimSpace(line)
-			// Skip empty lines and comments (%% for neuro-style comments)
-			if trimmed != "" && !strings.HasPrefix(trimmed, "%%") {
-				scriptLines = append(scriptLines, trimmed)
+			// Skip empty lines; comments are dropped by CompileBlocks, except inside \def bodies
+			if trimmed != "" {
+				scriptLines = append(scriptLines, ScriptLine{Number: i + 1, Text: trimmed})
 			}
 		}
 	}
 
-	if len(scriptLines) == 0 {
-		return nil // Empty script is successful
+	// Compile multi-line blocks; unbalanced blocks stop the script before any line runs
+	entries, err := CompileBlocks(scriptLines)
+	if err != nil {
+		return fmt.Errorf("%s: %w", resolved.Name, err)
 	}
 
-	// Push script lines to stack in reverse order (LIFO execution)
-	if sp.stackService != nil {
-		for i := len(scriptLines) - 1; i >= 0; i-- {
-			sp.stackService.PushCommand(scriptLines[i])
-		}
+	if len(entries) == 0 {
+		return nil // Empty script is successful
 	}
 
-	return nil
+	// Run the script in its own variable frame: named options are local to it, and the
+	// caller's parameter variables are restored when it returns
+	parameters := map[string]string{
+		"_0": resolved.Name,                         // Command name
+		"_1": parsedCmd.Message,                     // Message/first positional arg
+		"_*": parsedCmd.Message,                     // All positional args (same as _1)
+		"_@": sp.formatNamedArgs(parsedCmd.Options), // Named args
+	}
+	return NewScriptFrameHandler().EnterScript(resolved.Name, parameters, parsedCmd.Options, entries)
 }
 
 // formatNamedArgs formats named arguments as a comma-separated string.
@@ -313,9 +313,10 @@ func TestStateProcessor_executeScriptCommand(t *testing.T) {
 		t.Logf("Variable param1 not available via service: %v", err)
 	}
 
-	// Verify commands were pushed to stack (should be 3 commands)
+	// Verify commands were pushed to stack (3 commands and the end of the script's variable frame)
 	stackSize := stackService.GetStackSize()
-	assert.Equal(t, 3, stackSize)
+	assert.Equal(t, 4, stackSize)
+	assert.Equal(t, "SCRIPT_FRAME_END:"+concreteCtx.GetCurrentScriptFrameID(), stackService.PeekStack()[3])
 }
 
 func TestStateProcessor_executeScriptCommand_EmptyScript(t *testing.T) {
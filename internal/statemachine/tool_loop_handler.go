@@ -0,0 +1,70 @@
+// Package statemachine implements tool loop cleanup for the stack-based execution engine.
+// The ToolLoopHandler releases tool loops whose remaining commands will not run.
+package statemachine
+
+import (
+	"strings"
+
+	"neuroshell/internal/logger"
+	"neuroshell/internal/services"
+
+	"github.com/charmbracelet/log"
+)
+
+// ToolLoopHandler ends tool loops on every exit path.
+// \llm-call pushes TOOL_LOOP_END below the commands of a new tool loop, so the loop is released
+// when its commands have run, when a try block skips them, or when an error stops execution.
+type ToolLoopHandler struct {
+	// Logger
+	logger *log.Logger
+}
+
+// NewToolLoopHandler creates a new tool loop handler.
+// The tool service is looked up when needed, since not every shell registers it.
+func NewToolLoopHandler() *ToolLoopHandler {
+	return &ToolLoopHandler{
+		logger: logger.NewStyledLogger("ToolLoopHandler"),
+	}
+}
+
+// EndLoop releases the tool loop with the given ID. Loops that already ended are ignored.
+func (th *ToolLoopHandler) EndLoop(loopID string) {
+	toolService, err := services.GetGlobalToolService()
+	if err != nil {
+		return
+	}
+	th.logger.Debug("Ending tool loop", "loopID", loopID)
+	toolService.EndLoop(loopID)
+
+	if variableService, err := services.GetGlobalVariableService(); err == nil {
+		if current, _ := variableService.Get("#llm_tool_loop"); current == loopID {
+			_ = variableService.SetSystemVariable("#llm_tool_loop", "")
+		}
+	}
+}
+
+// GetLoopMark returns a mark of the tool loops started so far.
+func (th *ToolLoopHandler) GetLoopMark() int {
+	toolService, err := services.GetGlobalToolService()
+	if err != nil {
+		return 0
+	}
+	return toolService.LoopMark()
+}
+
+// UnwindLoops releases the tool loops started after mark, whose commands an error abandoned.
+func (th *ToolLoopHandler) UnwindLoops(mark int) {
+	toolService, err := services.GetGlobalToolService()
+	if err != nil {
+		return
+	}
+	toolService.EndLoopsSince(mark)
+}
+
+// IsToolLoopMarker checks if a command is a tool loop end marker.
+func (th *ToolLoopHandler) IsToolLoopMarker(command string) (bool, string) {
+	if loopID, found := strings.CutPrefix(command, "TOOL_LOOP_END:"); found {
+		return true, loopID
+	}
+	return false, ""
+}
@@ -0,0 +1,103 @@
+package statemachine
+
+import (
+	"testing"
+
+	"neuroshell/internal/commands"
+	"neuroshell/internal/context"
+	"neuroshell/internal/services"
+	"neuroshell/pkg/neurotypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupToolLoopTestEnvironment(t *testing.T) (*context.NeuroContext, *StackMachine, *services.ToolService) {
+	ctx, sm := setupLoopTestEnvironment(t)
+	toolService := services.NewToolService()
+	require.NoError(t, toolService.Initialize())
+	require.NoError(t, services.GetGlobalRegistry().RegisterService(toolService))
+	return ctx, sm, toolService
+}
+
+func TestToolLoopHandler_IsToolLoopMarker(t *testing.T) {
+	th := &ToolLoopHandler{}
+
+	isMarker, loopID := th.IsToolLoopMarker("TOOL_LOOP_END:tool_loop_1")
+	assert.True(t, isMarker)
+	assert.Equal(t, "tool_loop_1", loopID)
+
+	isMarker, _ = th.IsToolLoopMarker("\\echo TOOL_LOOP_END:tool_loop_1")
+	assert.False(t, isMarker)
+}
+
+func TestStackMachine_ToolLoop_EndedByMarker(t *testing.T) {
+	ctx, sm, toolService := setupToolLoopTestEnvironment(t)
+	loop := toolService.StartLoop("session")
+	require.NoError(t, ctx.SetSystemVariable("#llm_tool_loop", loop.ID))
+
+	ctx.PushCommand("TOOL_LOOP_END:" + loop.ID)
+	require.NoError(t, sm.Execute("\\set[x=1]"))
+
+	_, err := toolService.GetLoop(loop.ID)
+	assert.Error(t, err)
+	assert.Empty(t, variable(t, ctx, "#llm_tool_loop"))
+}
+
+func TestStackMachine_ToolLoop_EndedWhenTrySkipsIt(t *testing.T) {
+	ctx, sm, toolService := setupToolLoopTestEnvironment(t)
+	loop := toolService.StartLoop("session")
+
+	// Same layout as \try around a command that starts a tool loop
+	ctx.PushCommand("ERROR_BOUNDARY_END:try_1")
+	ctx.PushCommand("TOOL_LOOP_END:" + loop.ID)
+	ctx.PushCommand("\\no-such-command")
+	ctx.PushCommand("ERROR_BOUNDARY_START:try_1")
+	require.NoError(t, sm.Execute("\\set[x=1]"))
+
+	_, err := toolService.GetLoop(loop.ID)
+	assert.Error(t, err)
+	assert.Equal(t, 0, ctx.GetStackSize())
+}
+
+func TestStackMachine_ToolLoop_UnwoundOnError(t *testing.T) {
+	_, sm, toolService := setupToolLoopTestEnvironment(t)
+	require.NoError(t, commands.GetGlobalRegistry().Register(&startToolLoopCommand{}))
+	earlier := toolService.StartLoop("session")
+
+	err := sm.Execute("\\start-tool-loop")
+	require.Error(t, err)
+
+	assert.Equal(t, 2, toolService.LoopMark())
+	_, err = toolService.GetLoop("tool_loop_2")
+	assert.Error(t, err, "loop started by the failed command should be released")
+	_, err = toolService.GetLoop(earlier.ID)
+	assert.NoError(t, err, "loops started before the command are left alone")
+}
+
+// startToolLoopCommand starts a tool loop the way \llm-call does and queues a failing tool command.
+type startToolLoopCommand struct{}
+
+func (c *startToolLoopCommand) Name() string                    { return "start-tool-loop" }
+func (c *startToolLoopCommand) ParseMode() neurotypes.ParseMode { return neurotypes.ParseModeKeyValue }
+func (c *startToolLoopCommand) Description() string             { return "Start a tool loop" }
+func (c *startToolLoopCommand) Usage() string                   { return "\\start-tool-loop" }
+func (c *startToolLoopCommand) HelpInfo() neurotypes.HelpInfo {
+	return neurotypes.HelpInfo{Command: c.Name(), Description: c.Description(), Usage: c.Usage()}
+}
+func (c *startToolLoopCommand) IsReadOnly() bool { return false }
+
+func (c *startToolLoopCommand) Execute(_ map[string]string, _ string) error {
+	toolService, err := services.GetGlobalToolService()
+	if err != nil {
+		return err
+	}
+	stackService, err := services.GetGlobalStackService()
+	if err != nil {
+		return err
+	}
+	loop := toolService.StartLoop("session")
+	stackService.PushCommand("TOOL_LOOP_END:" + loop.ID)
+	stackService.PushCommand("\\no-such-command")
+	return nil
+}
@@ -19,6 +19,8 @@ type TryHandler struct {
 	stackService    *services.StackService
 	variableService *services.VariableService
 	errorService    *services.ErrorManagementService
+	// Tool loops abandoned while skipping
+	toolLoopHandler *ToolLoopHandler
 	// Logger
 	logger *log.Logger
 }
@@ -26,7 +28,8 @@ type TryHandler struct {
 // NewTryHandler creates a new try handler with the required services.
 func NewTryHandler() *TryHandler {
 	th := &TryHandler{
-		logger: logger.NewStyledLogger("TryHandler"),
+		toolLoopHandler: NewToolLoopHandler(),
+		logger:          logger.NewStyledLogger("TryHandler"),
 	}
 
 	// Initialize services
@@ -149,6 +152,27 @@ func (th *TryHandler) SkipToTryBlockEnd() {
 			silentID := strings.TrimPrefix(command, "SILENT_BOUNDARY_END:")
 			th.logger.Debug("Processing silent boundary end while skipping", "silentID", silentID)
 			th.stackService.PopSilentBoundary()
+		case strings.HasPrefix(command, "LOOP_BOUNDARY_START:"), strings.HasPrefix(command, "LOOP_BOUNDARY_END:"):
+			// A loop inside the try block is abandoned along with the rest of the block
+			loopID := command[strings.Index(command, ":")+1:]
+			th.logger.Debug("Abandoning loop while skipping", "loopID", loopID)
+			if th.stackService.GetCurrentLoopID() == loopID {
+				th.stackService.PopLoopBoundary()
+			}
+		case strings.HasPrefix(command, "SCRIPT_FRAME_END:"):
+			// A script called inside the try block returns along with the rest of the block
+			frameID := strings.TrimPrefix(command, "SCRIPT_FRAME_END:")
+			th.logger.Debug("Ending script frame while skipping", "frameID", frameID)
+			if th.variableService != nil {
+				th.variableService.PopScriptFrame(frameID)
+			}
+		case strings.HasPrefix(command, "TOOL_LOOP_END:"):
+			// A tool loop started inside the try block is abandoned along with the rest of the block
+			loopID := strings.TrimPrefix(command, "TOOL_LOOP_END:")
+			th.logger.Debug("Ending tool loop while skipping", "loopID", loopID)
+			if th.toolLoopHandler != nil {
+				th.toolLoopHandler.EndLoop(loopID)
+			}
 		case command == "ERROR_BOUNDARY_END:"+currentTryID:
 			th.logger.Debug("Found matching try block end", "tryID", currentTryID, "totalSkipped", skipCount)
 			th.ExitTryBlock(currentTryID)
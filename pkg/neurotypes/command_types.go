@@ -57,3 +57,10 @@ type HelpStoredVariable struct {
 	Type        string `json:"type"`              // Variable type category (e.g., "command_output", "system_metadata")
 	Example     string `json:"example,omitempty"` // Optional example value
 }
+
+// CommandDefinition describes a script command defined at runtime with \def name … \enddef.
+type CommandDefinition struct {
+	Name   string   `json:"name"`   // Command name, invoked as \name
+	Help   HelpInfo `json:"help"`   // Help parsed from the %% comments at the top of the body
+	Script string   `json:"script"` // Body lines, executed like a script file
+}
@@ -2,33 +2,59 @@
 // This file contains types for LLM client abstraction, streaming, and service interfaces.
 package neurotypes
 
-import "net/http"
+import (
+	"net/http"
+	"time"
+)
 
 // StructuredLLMResponse represents a structured response from an LLM provider.
 // It separates clean text content from thinking/reasoning blocks for proper rendering control.
 type StructuredLLMResponse struct {
 	TextContent    string                 // Clean main response content (user-facing)
 	ThinkingBlocks []ThinkingBlock        // Extracted thinking/reasoning content
+	ToolCalls      []ToolCall             // Tool calls requested by the model, if any
 	Error          *LLMError              // Captured error if any
 	Metadata       map[string]interface{} // Additional metadata from provider
 }
 
+// Metadata keys used to carry response details in StructuredLLMResponse.Metadata.
+const (
+	ResponseStopReasonKey = "stop_reason" // Why the provider stopped generating (end_turn, max_tokens, STOP, ...)
+	ResponseRequestIDKey  = "request_id"  // Provider-assigned ID of the response, for audits and support requests
+	ResponseLatencyMsKey  = "latency_ms"  // Wall-clock duration of the request including retries, in milliseconds
+)
+
 // LLMError represents an error that occurred during LLM processing.
 // This captures provider-specific error information for proper handling.
 type LLMError struct {
-	Code    string `json:"code"`    // Error code from provider
-	Message string `json:"message"` // Human-readable error message
-	Type    string `json:"type"`    // Error type (rate_limit, invalid_request, server_error, etc.)
+	Code       string        `json:"code"`                  // Error code from provider
+	Message    string        `json:"message"`               // Human-readable error message
+	Type       string        `json:"type"`                  // Error type (rate_limit, invalid_request, server_error, etc.)
+	RetryAfter time.Duration `json:"retry_after,omitempty"` // Wait requested by the provider's Retry-After header, if any
 }
 
 // ThinkingBlock represents a block of thinking/reasoning content from an LLM.
 // This unified structure handles thinking content from all providers (Anthropic, Gemini, OpenAI).
+// Signature and Data are opaque provider values that must be sent back unchanged when the block is replayed.
 type ThinkingBlock struct {
-	Content  string `json:"content"`  // The actual thinking/reasoning text content
-	Provider string `json:"provider"` // Source provider: "anthropic", "gemini", "openai"
-	Type     string `json:"type"`     // Block type: "thinking", "redacted_thinking", "reasoning"
+	Content   string `json:"content"`             // The actual thinking/reasoning text content
+	Provider  string `json:"provider"`            // Source provider: "anthropic", "gemini", "openai"
+	Type      string `json:"type"`                // Block type: "thinking", "redacted_thinking", "reasoning"
+	Signature string `json:"signature,omitempty"` // Anthropic signature verifying a "thinking" block
+	Data      string `json:"data,omitempty"`      // Encrypted content of an Anthropic "redacted_thinking" block
+}
+
+// StreamChunk represents an incremental piece of a streamed LLM response.
+// Chunks are delivered in arrival order while the provider is still generating.
+type StreamChunk struct {
+	Type     string // Chunk type: "text" for answer content, "thinking" for thinking/reasoning content
+	Content  string // The delta content carried by this chunk
+	Provider string // Source provider: "anthropic", "gemini", "openai"
 }
 
+// StreamHandler receives stream chunks as they arrive from the provider.
+type StreamHandler func(chunk StreamChunk)
+
 // LLMClient defines the interface for LLM provider implementations.
 // This interface abstracts different LLM providers (OpenAI, Anthropic, etc.)
 // and provides a common way to interact with them.
@@ -55,6 +81,19 @@ type LLMClient interface {
 	SetDebugTransport(transport http.RoundTripper)
 }
 
+// StreamingLLMClient is an optional extension of LLMClient for providers that support streaming.
+// Callers should type-assert an LLMClient to this interface and fall back to
+// SendStructuredCompletion when streaming is not available.
+type StreamingLLMClient interface {
+	LLMClient
+
+	// StreamStructuredCompletion sends a chat completion request and delivers text and thinking
+	// deltas to onChunk as they arrive. The fully accumulated response is returned at the end,
+	// with the same shape SendStructuredCompletion would have produced.
+	// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+	StreamStructuredCompletion(session *ChatSession, model *ModelConfig, onChunk StreamHandler) *StructuredLLMResponse
+}
+
 // ClientFactory manages the creation and caching of LLM clients.
 // It provides a centralized way to get clients based on API keys and supports
 // lazy initialization to avoid creating clients until they're actually needed.
@@ -82,4 +121,21 @@ type LLMService interface {
 	// Debug transport capture happens transparently via the client's debug transport.
 	// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
 	SendStructuredCompletion(client LLMClient, session *ChatSession, model *ModelConfig) *StructuredLLMResponse
+
+	// StreamStructuredCompletion sends a chat completion request and delivers deltas to onChunk as they arrive.
+	// Clients that do not implement StreamingLLMClient fall back to a blocking call whose
+	// result is delivered as whole chunks once it is available.
+	// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+	StreamStructuredCompletion(client LLMClient, session *ChatSession, model *ModelConfig, onChunk StreamHandler) *StructuredLLMResponse
+
+	// SendStructuredCompletionWithTools sends a chat completion request advertising the given tools.
+	// Clients that do not implement ToolCallingLLMClient receive a plain structured request without tools.
+	// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+	SendStructuredCompletionWithTools(client LLMClient, session *ChatSession, model *ModelConfig, tools []ToolDefinition) *StructuredLLMResponse
+
+	// SendStructuredCompletionWithSchema sends a chat completion request constrained to a JSON schema
+	// and validates the returned JSON document against the schema. Clients that do not implement
+	// StructuredOutputLLMClient and responses that do not match the schema are reported as errors.
+	// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+	SendStructuredCompletionWithSchema(client LLMClient, session *ChatSession, model *ModelConfig, schema *ResponseSchema) *StructuredLLMResponse
 }
@@ -102,8 +102,9 @@ type ModelProviderInfo struct {
 	ParameterConstraints map[string]string `json:"parameter_constraints"`
 }
 
-// ModelCatalogEntry represents a model entry in the embedded model catalog.
+// ModelCatalogEntry represents a model entry in the model catalog.
 // This contains basic information about available LLM models from various providers.
+// Entries come from the embedded catalog or from user and project catalog directories.
 type ModelCatalogEntry struct {
 	// Name is the provider's model identifier (e.g., "gpt-4", "claude-3-sonnet-20240229")
 	Name string `yaml:"name" json:"name"`
@@ -171,6 +172,9 @@ type ModelCatalogEntry struct {
 	// Parameters defines the configurable parameters supported by this model
 	// Each parameter includes type information, constraints, and validation rules
 	Parameters []ParameterDefinition `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+
+	// Source records where the entry was loaded from (embedded, user or project catalog)
+	Source CatalogSource `yaml:"-" json:"source"`
 }
 
 // ModelPricing contains pricing information for a model.
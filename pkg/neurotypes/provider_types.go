@@ -30,7 +30,8 @@ type ProviderCatalogEntry struct {
 	Headers map[string]string `yaml:"headers" json:"headers"`
 
 	// ClientType indicates which client implementation to use for this provider
-	// Supported types: "openai", "openai-compatible", "anthropic"
+	// Supported types: "openai", "openai_reasoning", "anthropic", "gemini", "openai-compatible"
+	// Entries with "openai-compatible" are served by a generic client built from BaseURL and Headers
 	ClientType string `yaml:"client_type" json:"client_type"`
 
 	// Description provides a brief description of the provider endpoint's capabilities
@@ -39,6 +40,51 @@ type ProviderCatalogEntry struct {
 	// ImplementationNotes provides information about how the provider connection is handled
 	// (e.g., "Natively supported by NeuroShell", "Uses OpenAI-compatible API")
 	ImplementationNotes string `yaml:"implementation_notes" json:"implementation_notes"`
+
+	// Source records where the entry was loaded from (embedded, user or project catalog)
+	Source CatalogSource `yaml:"-" json:"source"`
+}
+
+// IsOpenAICompatible reports whether the provider is served by the generic OpenAI-compatible client.
+func (p ProviderCatalogEntry) IsOpenAICompatible() bool {
+	return p.ClientType == "openai-compatible"
+}
+
+// Catalog source kinds, in increasing order of precedence.
+const (
+	CatalogSourceEmbedded = "embedded" // Shipped with NeuroShell
+	CatalogSourceUser     = "user"     // YAML files in the user config directory (~/.config/neuroshell)
+	CatalogSourceProject  = "project"  // YAML files in the project-local .neuro directory
+)
+
+// CatalogSource describes where a model or provider catalog entry was loaded from.
+// User and project entries replace embedded entries with the same ID (case-insensitive).
+type CatalogSource struct {
+	// Kind is one of CatalogSourceEmbedded, CatalogSourceUser or CatalogSourceProject
+	Kind string `json:"kind"`
+
+	// Path is the YAML file the entry was read from (empty for embedded entries)
+	Path string `json:"path,omitempty"`
+
+	// Overrides is the kind of the lower-precedence source whose entry this one replaced, if any
+	Overrides string `json:"overrides,omitempty"`
+}
+
+// Describe returns a short description of a user or project source,
+// e.g. "user (/home/me/.config/neuroshell/models/my-model.yaml), overrides embedded".
+// It returns an empty string for embedded entries.
+func (s CatalogSource) Describe() string {
+	if s.Kind == "" || s.Kind == CatalogSourceEmbedded {
+		return ""
+	}
+	text := s.Kind
+	if s.Path != "" {
+		text += " (" + s.Path + ")"
+	}
+	if s.Overrides != "" {
+		text += ", overrides " + s.Overrides
+	}
+	return text
 }
 
 // ProviderCatalogFile represents the structure of a provider catalog YAML file.
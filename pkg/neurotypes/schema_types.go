@@ -0,0 +1,24 @@
+// Package neurotypes defines structured output types for NeuroShell.
+// This file contains the types used to request JSON-schema-constrained responses from LLM providers.
+package neurotypes
+
+// ResponseSchema describes the JSON schema a structured output response must conform to.
+// Providers receive it through their native structured output feature, and the response
+// is validated locally against it before it is accepted.
+type ResponseSchema struct {
+	Name   string                 `json:"name"`   // Schema name sent to providers that require one (letters, digits, _ and -)
+	Schema map[string]interface{} `json:"schema"` // JSON schema document
+	Path   string                 `json:"path"`   // File the schema was loaded from, if any
+}
+
+// StructuredOutputLLMClient is an optional extension of LLMClient for providers that can constrain
+// responses to a JSON schema. Callers should type-assert an LLMClient to this interface and report
+// an error when structured output is requested from a client that does not implement it.
+type StructuredOutputLLMClient interface {
+	LLMClient
+
+	// SendStructuredCompletionWithSchema sends a chat completion request whose answer must be a
+	// JSON document conforming to the schema. The JSON document is returned in TextContent.
+	// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+	SendStructuredCompletionWithSchema(session *ChatSession, model *ModelConfig, schema *ResponseSchema) *StructuredLLMResponse
+}
@@ -7,11 +7,37 @@ import "time"
 
 // Message represents a single message in the conversation history.
 // Messages track the role (user/assistant), content, and timestamp for each interaction.
+// During tool calling, assistant messages may carry ToolCalls and "tool" role messages
+// carry the result for the call identified by ToolCallID.
+// Regenerated assistant messages keep every generated response in Alternatives;
+// Content is the one at the 1-based SelectedAlternative.
+// Metadata describes how an assistant message was produced; AlternativeMetadata holds it per alternative.
 type Message struct {
-	ID        string    `json:"id"`
-	Role      string    `json:"role"`
-	Content   string    `json:"content"`
-	Timestamp time.Time `json:"timestamp"`
+	ID                  string             `json:"id"`
+	Role                string             `json:"role"`
+	Content             string             `json:"content"`
+	Timestamp           time.Time          `json:"timestamp"`
+	ToolCalls           []ToolCall         `json:"tool_calls,omitempty"`
+	ToolCallID          string             `json:"tool_call_id,omitempty"`
+	Alternatives        []string           `json:"alternatives,omitempty"`
+	SelectedAlternative int                `json:"selected_alternative,omitempty"`
+	Metadata            *MessageMetadata   `json:"metadata,omitempty"`
+	AlternativeMetadata []*MessageMetadata `json:"alternative_metadata,omitempty"`
+}
+
+// MessageMetadata records how an assistant message was produced.
+// It is filled from the last \llm-call when the response is added to a session,
+// so mixed-model sessions can be audited message by message. Every field is optional.
+type MessageMetadata struct {
+	Model          string          `json:"model,omitempty"`           // Model configuration name
+	CatalogID      string          `json:"catalog_id,omitempty"`      // Catalog ID the model was created from
+	BaseModel      string          `json:"base_model,omitempty"`      // Provider model identifier
+	Provider       string          `json:"provider,omitempty"`        // Provider that answered
+	Usage          *TokenUsage     `json:"usage,omitempty"`           // Token usage reported for the call
+	LatencyMs      int64           `json:"latency_ms,omitempty"`      // Wall-clock duration of the call
+	StopReason     string          `json:"stop_reason,omitempty"`     // Why the provider stopped generating
+	RequestID      string          `json:"request_id,omitempty"`      // Provider-assigned response ID
+	ThinkingBlocks []ThinkingBlock `json:"thinking_blocks,omitempty"` // Thinking/reasoning returned with the answer
 }
 
 // SessionState represents the complete state of a NeuroShell session.
@@ -35,4 +61,41 @@ type ChatSession struct {
 	CreatedAt    time.Time `json:"created_at"`    // Session creation timestamp
 	UpdatedAt    time.Time `json:"updated_at"`    // Last modification timestamp
 	IsActive     bool      `json:"is_active"`     // Whether this is the current active session
+
+	// ContextPolicy controls what is sent when the conversation outgrows the model's context window
+	// (error, truncate-oldest, keep-system-and-last-N or summarize-oldest). Empty uses the model's policy.
+	ContextPolicy string `json:"context_policy,omitempty"`
+
+	// MessageTree holds every message of a branched conversation once, linked to the message before it.
+	// Branches name paths through the tree by their last message. Messages is always the path of
+	// ActiveBranch and is recorded in the tree when branches are created, switched or listed.
+	// Unbranched sessions have neither.
+	MessageTree  []MessageNode   `json:"message_tree,omitempty"`
+	Branches     []SessionBranch `json:"branches,omitempty"`
+	ActiveBranch string          `json:"active_branch,omitempty"`
+
+	// PendingMetadata describes the last \llm-call made for this session. It is attached to the next
+	// assistant message added to the session and is never saved.
+	PendingMetadata *MessageMetadata `json:"-"`
+
+	// UnloadedFrom is the saved session file of a session that was indexed lazily at startup.
+	// Only ID and Name are set until the session is first looked up and its file is read.
+	UnloadedFrom string `json:"-"`
+}
+
+// SessionBranch is one path through a branched conversation.
+// A branch starts at the message ForkPoint of its parent branch and shares the messages before it.
+type SessionBranch struct {
+	Name      string    `json:"name"`             // Unique branch name within the session
+	Parent    string    `json:"parent,omitempty"` // Branch this one was forked from
+	ForkPoint int       `json:"fork_point"`       // Number of parent messages the branch started with
+	Head      string    `json:"head,omitempty"`   // ID of the branch's last message (empty when it has none)
+	CreatedAt time.Time `json:"created_at"`       // Branch creation timestamp
+}
+
+// MessageNode is one message in the tree of a branched conversation.
+// The node is identified by the ID of its message.
+type MessageNode struct {
+	Parent  string  `json:"parent,omitempty"` // ID of the message before this one (empty for the first)
+	Message Message `json:"message"`
 }
@@ -0,0 +1,41 @@
+// Package neurotypes defines tool calling types for NeuroShell.
+// This file contains the types used to advertise .neuro script tools to LLM providers
+// and to carry tool calls and their results through a conversation.
+package neurotypes
+
+// ToolDefinition describes a tool backed by a .neuro script.
+// The parameter schema is advertised to the model so it can request calls with structured arguments.
+type ToolDefinition struct {
+	Name        string                 `json:"name"`        // Tool name advertised to the model
+	Description string                 `json:"description"` // What the tool does, shown to the model
+	Parameters  map[string]interface{} `json:"parameters"`  // JSON schema describing the tool arguments
+	ScriptPath  string                 `json:"script_path"` // Path of the .neuro script executed for each call
+}
+
+// ToolCall represents a single tool invocation requested by the model.
+type ToolCall struct {
+	ID        string `json:"id"`        // Provider-assigned call identifier used to match results
+	Name      string `json:"name"`      // Name of the requested tool
+	Arguments string `json:"arguments"` // Arguments as a JSON object string
+}
+
+// ToolResult represents the outcome of running a tool script for a tool call.
+type ToolResult struct {
+	CallID  string `json:"call_id"`  // Identifier of the tool call this result answers
+	Name    string `json:"name"`     // Name of the tool that produced the result
+	Content string `json:"content"`  // Output of the tool script
+	IsError bool   `json:"is_error"` // Whether the tool script failed
+}
+
+// ToolCallingLLMClient is an optional extension of LLMClient for providers that support tool calling.
+// Callers should type-assert an LLMClient to this interface and only advertise tools when it is available.
+type ToolCallingLLMClient interface {
+	LLMClient
+
+	// SendStructuredCompletionWithTools sends a chat completion request advertising the given tools.
+	// Tool calls requested by the model are returned in StructuredLLMResponse.ToolCalls.
+	// Messages with ToolCalls and role "tool" messages in the session are sent back to the provider
+	// so the model can continue from the tool results.
+	// All errors are encoded in the StructuredLLMResponse.Error field - no Go errors are returned.
+	SendStructuredCompletionWithTools(session *ChatSession, model *ModelConfig, tools []ToolDefinition) *StructuredLLMResponse
+}
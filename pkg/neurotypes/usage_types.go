@@ -0,0 +1,45 @@
+// Package neurotypes defines token usage and cost accounting types for NeuroShell.
+// This file contains the normalized token counts reported by LLM providers and
+// the running totals accumulated per session and per model.
+package neurotypes
+
+// Metadata keys used to carry token usage in StructuredLLMResponse.Metadata.
+const (
+	UsageInputTokensKey    = "input_tokens"
+	UsageOutputTokensKey   = "output_tokens"
+	UsageThinkingTokensKey = "thinking_tokens"
+	UsageCachedTokensKey   = "cached_tokens"
+)
+
+// TokenUsage holds the token counts reported by a provider for a single request.
+// Counts are normalized across providers: InputTokens includes cached input tokens and
+// OutputTokens includes thinking tokens, so cost can always be computed from those two.
+type TokenUsage struct {
+	InputTokens    int64 `json:"input_tokens"`    // All prompt tokens, including cached ones
+	OutputTokens   int64 `json:"output_tokens"`   // All generated tokens, including thinking
+	ThinkingTokens int64 `json:"thinking_tokens"` // Portion of OutputTokens spent on thinking/reasoning
+	CachedTokens   int64 `json:"cached_tokens"`   // Portion of InputTokens served from the provider cache
+}
+
+// UsageTotals accumulates token usage and cost across multiple LLM requests.
+// Requests without pricing information are counted in UnpricedRequests and
+// contribute tokens but no cost.
+type UsageTotals struct {
+	Requests         int        `json:"requests"`          // Number of requests that reported usage
+	UnpricedRequests int        `json:"unpriced_requests"` // Requests whose model has no catalog pricing
+	Tokens           TokenUsage `json:"tokens"`            // Summed token counts
+	CostUSD          float64    `json:"cost_usd"`          // Summed cost in USD for priced requests
+}
+
+// Add accumulates the usage and cost of a single request.
+func (t *UsageTotals) Add(usage TokenUsage, cost float64, priced bool) {
+	t.Requests++
+	if !priced {
+		t.UnpricedRequests++
+	}
+	t.Tokens.InputTokens += usage.InputTokens
+	t.Tokens.OutputTokens += usage.OutputTokens
+	t.Tokens.ThinkingTokens += usage.ThinkingTokens
+	t.Tokens.CachedTokens += usage.CachedTokens
+	t.CostUSD += cost
+}